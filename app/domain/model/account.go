@@ -1,5 +1,7 @@
 package model
 
+import "fmt"
+
 type Account struct {
 	ID int
 
@@ -7,6 +9,45 @@ type Account struct {
 
 	Name string
 	Bank string
+	// Number is the account number at the bank, empty for accounts
+	// recorded before numbers were
+	Number string
+	// IBAN is optional, banks of Vietnam do not issue them
+	IBAN string
+}
+
+// ValidateNumbers checks the number of acc against the validator of its
+// bank and its IBAN, when acc has them
+func (acc Account) ValidateNumbers() error {
+	if acc.Number != "" {
+		if err := ValidateAccountNumber(acc.Bank, acc.Number); err != nil {
+			return err
+		}
+	}
+
+	if acc.IBAN != "" {
+		if err := ValidateIBAN(acc.IBAN); err != nil {
+			return fmt.Errorf("IBAN %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ResolveNumber returns the number of acc. given is the number a request
+// names acc by, it is required for the accounts without a number and has to
+// be the number of the others.
+func (acc Account) ResolveNumber(given string) (string, error) {
+	switch {
+	case acc.Number == "" && given == "":
+		return "", fmt.Errorf("is required, account[%v] has no number: %w", acc.ID, ErrInvalid)
+	case acc.Number == "":
+		return given, nil
+	case given != "" && given != acc.Number:
+		return "", fmt.Errorf("is not the number of account[%v]: %w", acc.ID, ErrInvalid)
+	}
+
+	return acc.Number, nil
 }
 
 type Accounts []Account
@@ -20,3 +61,14 @@ func (s Accounts) ByID(id int) (Account, bool) {
 
 	return Account{}, false
 }
+
+// ByNumber returns the account whose number or IBAN is number
+func (s Accounts) ByNumber(number string) (Account, bool) {
+	for _, acc := range s {
+		if number != "" && (acc.Number == number || acc.IBAN == number) {
+			return acc, true
+		}
+	}
+
+	return Account{}, false
+}
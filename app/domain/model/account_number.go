@@ -0,0 +1,127 @@
+package model
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+)
+
+// AccountNumberValidator checks the format of the account numbers of a bank
+type AccountNumberValidator interface {
+	ValidateAccountNumber(number string) error
+}
+
+// AccountNumberFormat accepts numbers of MinLength to MaxLength digits.
+// CheckDigit, when set, has to accept them too.
+type AccountNumberFormat struct {
+	MinLength  int
+	MaxLength  int
+	CheckDigit func(number string) bool
+}
+
+var digits = regexp.MustCompile(`^[0-9]+$`)
+
+func (f AccountNumberFormat) ValidateAccountNumber(number string) error {
+	if len(number) < f.MinLength || len(number) > f.MaxLength || !digits.MatchString(number) {
+		if f.MinLength == f.MaxLength {
+			return fmt.Errorf("%q is not %d digits: %w", number, f.MinLength, ErrInvalid)
+		}
+
+		return fmt.Errorf("%q is not %d to %d digits: %w", number, f.MinLength, f.MaxLength, ErrInvalid)
+	}
+
+	if f.CheckDigit != nil && !f.CheckDigit(number) {
+		return fmt.Errorf("%q has a wrong check digit: %w", number, ErrInvalid)
+	}
+
+	return nil
+}
+
+var (
+	// accountNumberValidators are the validators by bank code. The banks do
+	// not publish how their check digits are computed, only the length is
+	// checked until one is registered.
+	accountNumberValidators = map[string]AccountNumberValidator{
+		"VCB": AccountNumberFormat{MinLength: 13, MaxLength: 13},
+		"ACB": AccountNumberFormat{MinLength: 6, MaxLength: 14},
+		"VIB": AccountNumberFormat{MinLength: 12, MaxLength: 16},
+	}
+
+	// anyAccountNumber is the format of the banks without a validator, the
+	// longest account number of a VietQR code
+	anyAccountNumber = AccountNumberFormat{MinLength: 1, MaxLength: 19}
+
+	iban = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[0-9A-Z]{11,30}$`)
+)
+
+// RegisterAccountNumberValidator replaces the validator of the account
+// numbers of bank. Validators are not guarded by a lock, register them
+// before serving requests.
+func RegisterAccountNumberValidator(bank string, v AccountNumberValidator) {
+	accountNumberValidators[bank] = v
+}
+
+// ValidateAccountNumber checks number against the validator of bank
+func ValidateAccountNumber(bank, number string) error {
+	if err := ValidateBank(bank); err != nil {
+		return err
+	}
+
+	v, ok := accountNumberValidators[bank]
+	if !ok {
+		v = anyAccountNumber
+	}
+
+	if err := v.ValidateAccountNumber(number); err != nil {
+		return fmt.Errorf("%s account number %w", bank, err)
+	}
+
+	return nil
+}
+
+// Luhn reports whether the last digit of number is its Luhn (mod 10) check
+// digit, it is a CheckDigit for the banks using it
+func Luhn(number string) bool {
+	if !digits.MatchString(number) {
+		return false
+	}
+
+	sum := 0
+	for i := 0; i < len(number); i++ {
+		d := int(number[len(number)-1-i] - '0')
+		if i%2 == 1 {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+	}
+
+	return sum%10 == 0
+}
+
+// ValidateIBAN checks the ISO 13616 IBAN in its electronic format, upper
+// case without spaces, and its mod 97 check digits
+func ValidateIBAN(s string) error {
+	if !iban.MatchString(s) {
+		return fmt.Errorf("%q is not a country code, 2 check digits and up to 30 letters and digits: %w", s, ErrInvalid)
+	}
+
+	// the country code and check digits move to the end, then letters are
+	// numbers from A=10 to Z=35
+	n := ""
+	for _, c := range s[4:] + s[:4] {
+		if c >= 'A' {
+			n += fmt.Sprint(c - 'A' + 10)
+		} else {
+			n += string(c)
+		}
+	}
+
+	i, _ := new(big.Int).SetString(n, 10)
+	if new(big.Int).Mod(i, big.NewInt(97)).Int64() != 1 {
+		return fmt.Errorf("%q has wrong check digits: %w", s, ErrInvalid)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAccountNumber(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		assert.NoError(t, ValidateAccountNumber("VCB", "0071000123456"))
+		assert.NoError(t, ValidateAccountNumber("ACB", "190123"))
+		assert.NoError(t, ValidateAccountNumber("VIB", "601704060012345"))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := ValidateAccountNumber("VCB", "007100012345")
+		assert.True(t, errors.Is(err, ErrInvalid), err)
+		assert.EqualError(t, err, `VCB account number "007100012345" is not 13 digits: invalid`)
+
+		err = ValidateAccountNumber("ACB", "19012A")
+		assert.EqualError(t, err, `ACB account number "19012A" is not 6 to 14 digits: invalid`)
+
+		err = ValidateAccountNumber("XYZ", "190123")
+		assert.True(t, errors.Is(err, ErrInvalidBank), err)
+	})
+}
+
+func TestRegisterAccountNumberValidator(t *testing.T) {
+	// replaces a validator of the registry shared by the other tests
+	defer RegisterAccountNumberValidator("ACB", accountNumberValidators["ACB"])
+
+	RegisterAccountNumberValidator("ACB", AccountNumberFormat{MinLength: 6, MaxLength: 6, CheckDigit: Luhn})
+
+	assert.NoError(t, ValidateAccountNumber("ACB", "190124"))
+	assert.EqualError(t, ValidateAccountNumber("ACB", "190123"), `ACB account number "190123" has a wrong check digit: invalid`)
+}
+
+func TestLuhn(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, Luhn("79927398713"))
+	assert.False(t, Luhn("79927398710"))
+	assert.False(t, Luhn("7992739871a"))
+}
+
+func TestValidateIBAN(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, ValidateIBAN("GB82WEST12345698765432"))
+	assert.NoError(t, ValidateIBAN("DE89370400440532013000"))
+
+	for _, s := range []string{
+		"GB83WEST12345698765432",
+		"GB82 WEST 1234 5698 7654 32",
+		"gb82west12345698765432",
+		"GB82",
+		"",
+	} {
+		assert.True(t, errors.Is(ValidateIBAN(s), ErrInvalid), s)
+	}
+}
+
+func TestAccount_ValidateNumbers(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, Account{Bank: "VCB"}.ValidateNumbers())
+	assert.NoError(t, Account{Bank: "VCB", Number: "0071000123456", IBAN: "GB82WEST12345698765432"}.ValidateNumbers())
+	assert.EqualError(t, Account{Bank: "VCB", Number: "0071000123456", IBAN: "GB83WEST12345698765432"}.ValidateNumbers(),
+		`IBAN "GB83WEST12345698765432" has wrong check digits: invalid`)
+}
@@ -9,6 +9,21 @@ import (
 var (
 	Banks = []string{"VCB", "ACB", "VIB"}
 
+	// BankBICs are the SWIFT codes identifying the banks in payment files
+	BankBICs = map[string]string{
+		"VCB": "BFTVVNVX",
+		"ACB": "ASCBVNVX",
+		"VIB": "VNIBVNVX",
+	}
+
+	// BankBINs are the NAPAS bank identification numbers of the banks in
+	// VietQR codes
+	BankBINs = map[string]string{
+		"VCB": "970436",
+		"ACB": "970416",
+		"VIB": "970441",
+	}
+
 	ErrInvalidBank = fmt.Errorf("invalid bank")
 )
 
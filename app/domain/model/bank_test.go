@@ -29,3 +29,15 @@ func TestValidateBank(t *testing.T) {
 		assert.EqualError(t, err, "abc: invalid bank")
 	})
 }
+
+func TestBankRegistry(t *testing.T) {
+	t.Parallel()
+
+	for _, bank := range Banks {
+		assert.Len(t, BankBICs[bank], 8, "BIC of %s", bank)
+		assert.Len(t, BankBINs[bank], 6, "BIN of %s", bank)
+	}
+
+	assert.Len(t, BankBICs, len(Banks))
+	assert.Len(t, BankBINs, len(Banks))
+}
@@ -1,11 +1,109 @@
 package model
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 )
 
 var (
 	ErrNotFound      = fmt.Errorf("not found")
 	ErrInvalidAmount = fmt.Errorf("invalid amount")
 	ErrInvalid       = fmt.Errorf("invalid")
+	ErrUnauthorized  = fmt.Errorf("unauthorized")
+
+	ErrPayloadTooLarge = fmt.Errorf("payload too large")
+	// ErrInsufficientFunds is a withdrawal larger than the balance of its
+	// account
+	ErrInsufficientFunds = fmt.Errorf("insufficient funds")
+	// ErrRateLimited is a request over the rate limit of its client
+	ErrRateLimited = fmt.Errorf("rate limited")
+	// ErrMethodNotAllowed is a request to a route with a method it doesn't
+	// serve
+	ErrMethodNotAllowed = fmt.Errorf("method not allowed")
+	// ErrConflict is a write clashing with existing data, e.g. a taken
+	// user name or account number
+	ErrConflict = fmt.Errorf("conflict")
+)
+
+// ErrorCode is the stable, machine readable name of a domain error. Clients
+// rely on it, so existing codes must never change.
+type ErrorCode string
+
+var (
+	CodeConflict               ErrorCode = "conflict"
+	CodeInternal               ErrorCode = "internal"
+	CodeInvalid                ErrorCode = "invalid"
+	CodeInvalidAmount          ErrorCode = "invalid_amount"
+	CodeInvalidBank            ErrorCode = "invalid_bank"
+	CodeInvalidTransactionType ErrorCode = "invalid_transaction_type"
+	CodeInsufficientFunds      ErrorCode = "insufficient_funds"
+	CodeMethodNotAllowed       ErrorCode = "method_not_allowed"
+	CodeNotFound               ErrorCode = "not_found"
+	CodePayloadTooLarge        ErrorCode = "payload_too_large"
+	CodeRateLimited            ErrorCode = "rate_limited"
+	CodeUnauthorized           ErrorCode = "unauthorized"
 )
+
+// errorCodes is checked in order, so more specific errors come first
+var errorCodes = []struct {
+	err  error
+	code ErrorCode
+}{
+	{ErrInvalidAmount, CodeInvalidAmount},
+	{ErrInvalidBank, CodeInvalidBank},
+	{ErrTransactionTypeInvalid, CodeInvalidTransactionType},
+	{ErrInvalid, CodeInvalid},
+	{ErrNotFound, CodeNotFound},
+	{ErrUnauthorized, CodeUnauthorized},
+	{ErrPayloadTooLarge, CodePayloadTooLarge},
+	{ErrInsufficientFunds, CodeInsufficientFunds},
+	{ErrRateLimited, CodeRateLimited},
+	{ErrMethodNotAllowed, CodeMethodNotAllowed},
+	{ErrConflict, CodeConflict},
+}
+
+// ErrorCodeOf returns the code of the domain error wrapped by err, or
+// CodeInternal when err is not a domain error.
+func ErrorCodeOf(err error) ErrorCode {
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+
+	return CodeInternal
+}
+
+// FieldError is an invalid input field. Field is a JSON pointer for body
+// fields, e.g. "/amount", a path of elements for XML files, e.g.
+// "Stmt[1]/Ntry[2]/Amt", or the plain name of a path or query parameter.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// FieldErrors reports every invalid field of an input at once. It always
+// matches ErrInvalid.
+type FieldErrors []*FieldError
+
+func (s FieldErrors) Error() string {
+	msgs := make([]string, len(s))
+	for i := range s {
+		msgs[i] = s[i].Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (s FieldErrors) Is(target error) bool {
+	return target == ErrInvalid
+}
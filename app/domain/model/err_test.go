@@ -0,0 +1,36 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCodeOf(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, CodeNotFound, ErrorCodeOf(fmt.Errorf("find user[1] %w", ErrNotFound)))
+	assert.Equal(t, CodeInvalid, ErrorCodeOf(fmt.Errorf("amount[0]: %w", ErrInvalid)))
+	assert.Equal(t, CodeInvalidBank, ErrorCodeOf(ValidateBank("XYZ")))
+	assert.Equal(t, CodeInvalidTransactionType, ErrorCodeOf(ValidateTransactionType("refund")))
+	assert.Equal(t, CodeInvalid, ErrorCodeOf(FieldErrors{{Field: "/amount", Err: ErrInvalidAmount}}))
+	assert.Equal(t, CodeInternal, ErrorCodeOf(errors.New("connection refused")))
+}
+
+func TestFieldErrors(t *testing.T) {
+	t.Parallel()
+
+	err := error(FieldErrors{
+		{Field: "/account_id", Err: fmt.Errorf("is required: %w", ErrInvalid)},
+		{Field: "/amount", Err: ErrInvalidAmount},
+	})
+
+	assert.True(t, errors.Is(err, ErrInvalid))
+	assert.EqualError(t, err, "/account_id: is required: invalid; /amount: invalid amount")
+
+	var fieldErrs FieldErrors
+	assert.True(t, errors.As(fmt.Errorf("create transaction: %w", err), &fieldErrs))
+	assert.Len(t, fieldErrs, 2)
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+type Severity string
+
+var (
+	// SeverityCritical breaks references between entities
+	SeverityCritical Severity = "critical"
+	// SeverityError breaks a business rule
+	SeverityError Severity = "error"
+	// SeverityWarning is a data quality issue that business rules tolerate
+	SeverityWarning Severity = "warning"
+)
+
+type Rule string
+
+var (
+	RuleAccountUnknownBank          Rule = "account.unknown_bank"
+	RuleAccountInvalidNumber        Rule = "account.invalid_number"
+	RuleTransactionUnknownAccount   Rule = "transaction.unknown_account"
+	RuleTransactionOwnerMismatch    Rule = "transaction.owner_mismatch"
+	RuleTransactionInvalidType      Rule = "transaction.invalid_type"
+	RuleTransactionInvalidAmount    Rule = "transaction.invalid_amount"
+	RuleTransactionInvalidCreatedAt Rule = "transaction.invalid_created_at"
+)
+
+// Violation is a stored entity that breaks one of the domain invariants.
+type Violation struct {
+	Rule     Rule
+	Severity Severity
+	Entity   string
+	EntityID int
+	Message  string
+	// Fixable tells whether the violation can be repaired without a human
+	Fixable bool
+}
+
+// CheckAccount returns the invariants broken by acc.
+func CheckAccount(acc Account) []Violation {
+	var out []Violation
+
+	if err := ValidateBank(acc.Bank); err != nil {
+		out = append(out, Violation{
+			Rule:     RuleAccountUnknownBank,
+			Severity: SeverityError,
+			Entity:   "account",
+			EntityID: acc.ID,
+			Message:  err.Error(),
+		})
+	} else if err := acc.ValidateNumbers(); err != nil {
+		// numbers recorded before they were validated are kept, the bank
+		// knows the account by them
+		out = append(out, Violation{
+			Rule:     RuleAccountInvalidNumber,
+			Severity: SeverityWarning,
+			Entity:   "account",
+			EntityID: acc.ID,
+			Message:  err.Error(),
+		})
+	}
+
+	return out
+}
+
+// CheckTransaction returns the invariants broken by t. accounts must hold
+// every stored account.
+func CheckTransaction(t Transaction, accounts map[int]Account) []Violation {
+	var out []Violation
+
+	violation := func(rule Rule, severity Severity, fixable bool, format string, args ...interface{}) {
+		out = append(out, Violation{
+			Rule:     rule,
+			Severity: severity,
+			Entity:   "transaction",
+			EntityID: t.ID,
+			Message:  fmt.Sprintf(format, args...),
+			Fixable:  fixable,
+		})
+	}
+
+	acc, ok := accounts[t.AccountID]
+	if !ok {
+		violation(RuleTransactionUnknownAccount, SeverityCritical, false, "account[%v] %v", t.AccountID, ErrNotFound)
+	}
+
+	if ok && acc.UserID != t.UserID {
+		violation(RuleTransactionOwnerMismatch, SeverityError, true, "user[%v] does not own account[%v], owner is user[%v]", t.UserID, t.AccountID, acc.UserID)
+	}
+
+	if err := ValidateTransactionType(t.TransactionType); err != nil {
+		violation(RuleTransactionInvalidType, SeverityError, false, "%v", err)
+	}
+
+	if t.Amount.LessThanOrEqual(decimal.Zero) {
+		violation(RuleTransactionInvalidAmount, SeverityError, false, "amount[%v]: %v", t.Amount.String(), ErrInvalidAmount)
+	}
+
+	if _, err := ParseCreatedAt(t.CreatedAt); err != nil {
+		violation(RuleTransactionInvalidCreatedAt, SeverityWarning, false, "created_at[%v]: %v", t.CreatedAt, ErrInvalid)
+	}
+
+	return out
+}
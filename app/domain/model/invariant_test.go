@@ -0,0 +1,105 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckAccount(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, CheckAccount(Account{ID: 1, UserID: 1, Bank: "VCB"}))
+
+	assert.Equal(t, []Violation{
+		{
+			Rule:     RuleAccountUnknownBank,
+			Severity: SeverityError,
+			Entity:   "account",
+			EntityID: 2,
+			Message:  "XYZ: invalid bank",
+		},
+	}, CheckAccount(Account{ID: 2, UserID: 1, Bank: "XYZ"}))
+
+	assert.Equal(t, []Violation{
+		{
+			Rule:     RuleAccountInvalidNumber,
+			Severity: SeverityWarning,
+			Entity:   "account",
+			EntityID: 3,
+			Message:  `VCB account number "123" is not 13 digits: invalid`,
+		},
+	}, CheckAccount(Account{ID: 3, UserID: 1, Bank: "VCB", Number: "123"}))
+}
+
+func TestCheckTransaction(t *testing.T) {
+	t.Parallel()
+
+	accounts := map[int]Account{
+		1: {ID: 1, UserID: 1, Bank: "VCB"},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		assert.Empty(t, CheckTransaction(Transaction{
+			ID:              1,
+			UserID:          1,
+			AccountID:       1,
+			Amount:          decimal.NewFromInt(100),
+			TransactionType: TransactionTypeDeposit,
+			CreatedAt:       "2020-02-10 20:00:00 +0700",
+		}, accounts))
+
+		assert.Empty(t, CheckTransaction(Transaction{
+			ID:              1,
+			UserID:          1,
+			AccountID:       1,
+			Amount:          decimal.NewFromInt(100),
+			TransactionType: TransactionTypeWithdraw,
+			CreatedAt:       "2020-02-10 13:00:00.123456789 +0000 UTC",
+		}, accounts))
+	})
+
+	t.Run("owner mismatch", func(t *testing.T) {
+		assert.Equal(t, []Violation{
+			{
+				Rule:     RuleTransactionOwnerMismatch,
+				Severity: SeverityError,
+				Entity:   "transaction",
+				EntityID: 2,
+				Message:  "user[2] does not own account[1], owner is user[1]",
+				Fixable:  true,
+			},
+		}, CheckTransaction(Transaction{
+			ID:              2,
+			UserID:          2,
+			AccountID:       1,
+			Amount:          decimal.NewFromInt(100),
+			TransactionType: TransactionTypeDeposit,
+			CreatedAt:       "2020-02-10 20:00:00 +0700",
+		}, accounts))
+	})
+
+	t.Run("every field broken", func(t *testing.T) {
+		violations := CheckTransaction(Transaction{
+			ID:              3,
+			UserID:          1,
+			AccountID:       9,
+			Amount:          decimal.NewFromInt(-1),
+			TransactionType: "refund",
+			CreatedAt:       "yesterday",
+		}, accounts)
+
+		rules := []Rule{}
+		for _, v := range violations {
+			rules = append(rules, v.Rule)
+		}
+
+		assert.Equal(t, []Rule{
+			RuleTransactionUnknownAccount,
+			RuleTransactionInvalidType,
+			RuleTransactionInvalidAmount,
+			RuleTransactionInvalidCreatedAt,
+		}, rules)
+	})
+}
@@ -0,0 +1,118 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/shopspring/decimal"
+)
+
+type PaymentStatus string
+
+var (
+	// PaymentStatusPending payments are sent to the bank but not seen on a
+	// statement yet
+	PaymentStatusPending PaymentStatus = "pending"
+	// PaymentStatusConfirmed payments were matched on a statement or
+	// confirmed by hand
+	PaymentStatusConfirmed PaymentStatus = "confirmed"
+
+	ErrPaymentStatusInvalid = fmt.Errorf("invalid payment status")
+)
+
+var (
+	// MaxBatchPayments limits the payments of a batch
+	MaxBatchPayments = 100
+	// MaxPaymentAmount limits the amount of each payment, it is the
+	// interbank transfer limit of the banks
+	MaxPaymentAmount = decimal.New(500000000, 0)
+)
+
+// ExecutionDateLayout is the layout of PaymentBatch.ExecutionDate
+const ExecutionDateLayout = "2006-01-02"
+
+// PaymentBatch groups withdrawals of one account paid by the bank from a
+// single payment initiation file
+type PaymentBatch struct {
+	ID int
+
+	UserID    int
+	AccountID int
+
+	// DebtorAccount is the number of the account at its bank
+	DebtorAccount string
+	ExecutionDate string
+	CreatedAt     string
+
+	Payments []Payment
+}
+
+// MessageID identifies the payment initiation file of b at the bank
+func (b PaymentBatch) MessageID() string {
+	return fmt.Sprintf("PB%08d", b.ID)
+}
+
+// Pay sets the TransactionID of the payments of b without one to the ids of
+// withdrawals, in order. There has to be one withdrawal for each of them.
+func (b *PaymentBatch) Pay(withdrawals []*Transaction) error {
+	unpaid := []int{}
+	for i, p := range b.Payments {
+		if p.TransactionID == 0 {
+			unpaid = append(unpaid, i)
+		}
+	}
+
+	if len(unpaid) != len(withdrawals) {
+		return fmt.Errorf("%d payments without transaction, %d withdrawals: %w", len(unpaid), len(withdrawals), ErrInvalid)
+	}
+
+	for i, t := range withdrawals {
+		b.Payments[unpaid[i]].TransactionID = t.ID
+	}
+
+	return nil
+}
+
+// CheckFunds tells whether the balance of the account covers withdrawals,
+// else it is ErrInsufficientFunds
+func CheckFunds(accountID int, balance decimal.Decimal, withdrawals []*Transaction) error {
+	total := decimal.Zero
+	for _, t := range withdrawals {
+		total = total.Add(t.Amount)
+	}
+
+	if total.GreaterThan(balance) {
+		return fmt.Errorf("payments of %v, account[%v] has %v: %w", total, accountID, balance, ErrInsufficientFunds)
+	}
+
+	return nil
+}
+
+// Payment is a withdrawal of a batch and the creditor it is paid to
+type Payment struct {
+	TransactionID int
+	BatchID       int
+
+	CreditorName    string
+	CreditorAccount string
+	CreditorBank    string
+	Remittance      string
+
+	Status      PaymentStatus
+	ConfirmedAt string
+}
+
+// EndToEndID identifies p from the payment file to the statement of the
+// creditor
+func (p Payment) EndToEndID() string {
+	return fmt.Sprintf("TX%d", p.TransactionID)
+}
+
+func ValidatePaymentStatus(s PaymentStatus) error {
+	switch s {
+	case PaymentStatusPending, PaymentStatusConfirmed:
+		return nil
+
+	default:
+		return fmt.Errorf("%s: %w", s, ErrPaymentStatusInvalid)
+	}
+}
@@ -0,0 +1,51 @@
+package model
+
+import "fmt"
+
+type ReconciliationStatus string
+
+var (
+	// ReconciliationStatusMatched marks a transaction found on a bank
+	// statement
+	ReconciliationStatusMatched ReconciliationStatus = "matched"
+	// ReconciliationStatusCreated marks a transaction created from a
+	// statement line missing in the ledger
+	ReconciliationStatusCreated ReconciliationStatus = "created"
+
+	ErrReconciliationStatusInvalid = fmt.Errorf("invalid reconciliation status")
+)
+
+// StatementDateLayout is the layout of Reconciliation.StatementDate and
+// ValueDate
+const StatementDateLayout = "2006-01-02"
+
+// Reconciliation records the bank statement line a transaction was
+// reconciled with. StatementDate is the booking date of the line, ValueDate
+// is empty for statements without value dates.
+type Reconciliation struct {
+	TransactionID int
+	AccountID     int
+
+	Status        ReconciliationStatus
+	Reference     string
+	StatementDate string
+	ValueDate     string
+	ReconciledAt  string
+}
+
+// ReconciledTransaction is a transaction created from a statement line and
+// its reconciliation
+type ReconciledTransaction struct {
+	Transaction    *Transaction
+	Reconciliation *Reconciliation
+}
+
+func ValidateReconciliationStatus(s ReconciliationStatus) error {
+	switch s {
+	case ReconciliationStatusMatched, ReconciliationStatusCreated:
+		return nil
+
+	default:
+		return fmt.Errorf("%s: %w", s, ErrReconciliationStatusInvalid)
+	}
+}
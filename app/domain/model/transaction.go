@@ -2,12 +2,19 @@ package model
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/shopspring/decimal"
 )
 
 type TransactionType string
 
+// CreatedAtLayout is the time layout of Transaction.CreatedAt
+const CreatedAtLayout = "2006-01-02 15:04:05 -0700"
+
+// legacyCreatedAtLayout is time.Time.String(), used by the first releases
+const legacyCreatedAtLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
 var (
 	TransactionTypeWithdraw TransactionType = "withdraw"
 	TransactionTypeDeposit  TransactionType = "deposit"
@@ -47,3 +54,23 @@ func NewTransaction(userID, accountID int, amount decimal.Decimal, t Transaction
 		TransactionType: t,
 	}
 }
+
+// SignedAmount is the change t makes to the balance of its account:
+// deposits add their amount, withdrawals subtract it
+func SignedAmount(t TransactionType, amount decimal.Decimal) decimal.Decimal {
+	if t == TransactionTypeWithdraw {
+		return amount.Neg()
+	}
+
+	return amount
+}
+
+// ParseCreatedAt parses a Transaction.CreatedAt value.
+func ParseCreatedAt(s string) (time.Time, error) {
+	t, err := time.Parse(CreatedAtLayout, s)
+	if err == nil {
+		return t, nil
+	}
+
+	return time.Parse(legacyCreatedAtLayout, s)
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -24,3 +25,11 @@ func TestValidateTransactionType(t *testing.T) {
 		errors.Is(err, ErrTransactionTypeInvalid)
 	})
 }
+
+func TestSignedAmount(t *testing.T) {
+	t.Parallel()
+
+	amount := decimal.RequireFromString("10.50")
+	assert.Equal(t, "10.5", SignedAmount(TransactionTypeDeposit, amount).String())
+	assert.Equal(t, "-10.5", SignedAmount(TransactionTypeWithdraw, amount).String())
+}
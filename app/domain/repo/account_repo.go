@@ -5,4 +5,12 @@ import "go-prj-skeleton/app/domain/model"
 type AccountRepo interface {
 	FindByUser(userID int) ([]model.Account, error)
 	FindByID(id int) (model.Account, error)
+	// FindByNumber returns the account numbered number at bank, of any user
+	FindByNumber(bank, number string) (model.Account, error)
+	// Create inserts acc and sets its ID, a number already used at its bank
+	// is model.ErrConflict
+	Create(acc *model.Account) error
+	// Update saves the name, number and IBAN of acc, its user and bank never
+	// change. A missing account is model.ErrNotFound.
+	Update(acc *model.Account) error
 }
@@ -0,0 +1,21 @@
+package repo
+
+import "context"
+
+// Contextual is implemented by the repos which can run their queries in the
+// context of a request, so that they are traced within its span
+type Contextual interface {
+	// WithContext returns a copy of the repo, of the same interface, running
+	// its queries in ctx
+	WithContext(ctx context.Context) interface{}
+}
+
+// WithContext returns r running its queries in ctx when it is Contextual,
+// else r itself
+func WithContext(ctx context.Context, r interface{}) interface{} {
+	if c, ok := r.(Contextual); ok {
+		return c.WithContext(ctx)
+	}
+
+	return r
+}
@@ -0,0 +1,10 @@
+package repo
+
+import "go-prj-skeleton/app/domain/model"
+
+// IntegrityRepo scans every stored entity, regardless of its owner.
+type IntegrityRepo interface {
+	EachAccount(fn func(model.Account) error) error
+	EachTransaction(fn func(model.Transaction) error) error
+	SetTransactionUser(tranID, userID int) error
+}
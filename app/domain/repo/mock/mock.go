@@ -1,4 +1,4 @@
-// generated by "charlatan -dir=/home/congphan/Golang/src/github.com/congphan/go-prj-skeleton/app/domain/repo -output=/home/congphan/Golang/src/github.com/congphan/go-prj-skeleton/app/domain/repo/mock/mock.go -package=mock UserRepo AccountRepo TransactionRepo".  DO NOT EDIT.
+// generated by "charlatan -dir=/home/congphan/Golang/src/github.com/congphan/go-prj-skeleton/app/domain/repo -output=/home/congphan/Golang/src/github.com/congphan/go-prj-skeleton/app/domain/repo/mock/mock.go -package=mock UserRepo AccountRepo TransactionRepo ReconciliationRepo PaymentRepo".  DO NOT EDIT.
 
 package mock
 
@@ -28,6 +28,48 @@ func NewUserRepoFindByIDInvocation(id int, ident1 model.User, ident2 error) *Use
 	return invocation
 }
 
+// UserRepoCreateInvocation represents a single call of FakeUserRepo.Create
+type UserRepoCreateInvocation struct {
+	Parameters struct {
+		Ident1 *model.User
+	}
+	Results struct {
+		Ident2 error
+	}
+}
+
+// NewUserRepoCreateInvocation creates a new instance of UserRepoCreateInvocation
+func NewUserRepoCreateInvocation(ident1 *model.User, ident2 error) *UserRepoCreateInvocation {
+	invocation := new(UserRepoCreateInvocation)
+
+	invocation.Parameters.Ident1 = ident1
+
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+// UserRepoUpdateInvocation represents a single call of FakeUserRepo.Update
+type UserRepoUpdateInvocation struct {
+	Parameters struct {
+		Ident1 *model.User
+	}
+	Results struct {
+		Ident2 error
+	}
+}
+
+// NewUserRepoUpdateInvocation creates a new instance of UserRepoUpdateInvocation
+func NewUserRepoUpdateInvocation(ident1 *model.User, ident2 error) *UserRepoUpdateInvocation {
+	invocation := new(UserRepoUpdateInvocation)
+
+	invocation.Parameters.Ident1 = ident1
+
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
 // UserRepoTestingT represents the methods of "testing".T used by charlatan Fakes.  It avoids importing the testing package.
 type UserRepoTestingT interface {
 	Error(...interface{})
@@ -62,8 +104,12 @@ unexpected calls are made to FakeFindByID.
 */
 type FakeUserRepo struct {
 	FindByIDHook func(int) (model.User, error)
+	CreateHook   func(*model.User) error
+	UpdateHook   func(*model.User) error
 
 	FindByIDCalls []*UserRepoFindByIDInvocation
+	CreateCalls   []*UserRepoCreateInvocation
+	UpdateCalls   []*UserRepoUpdateInvocation
 }
 
 // NewFakeUserRepoDefaultPanic returns an instance of FakeUserRepo with all hooks configured to panic
@@ -72,6 +118,12 @@ func NewFakeUserRepoDefaultPanic() *FakeUserRepo {
 		FindByIDHook: func(int) (ident1 model.User, ident2 error) {
 			panic("Unexpected call to UserRepo.FindByID")
 		},
+		CreateHook: func(*model.User) (ident2 error) {
+			panic("Unexpected call to UserRepo.Create")
+		},
+		UpdateHook: func(*model.User) (ident2 error) {
+			panic("Unexpected call to UserRepo.Update")
+		},
 	}
 }
 
@@ -82,6 +134,14 @@ func NewFakeUserRepoDefaultFatal(t_sym1 UserRepoTestingT) *FakeUserRepo {
 			t_sym1.Fatal("Unexpected call to UserRepo.FindByID")
 			return
 		},
+		CreateHook: func(*model.User) (ident2 error) {
+			t_sym1.Fatal("Unexpected call to UserRepo.Create")
+			return
+		},
+		UpdateHook: func(*model.User) (ident2 error) {
+			t_sym1.Fatal("Unexpected call to UserRepo.Update")
+			return
+		},
 	}
 }
 
@@ -92,11 +152,21 @@ func NewFakeUserRepoDefaultError(t_sym2 UserRepoTestingT) *FakeUserRepo {
 			t_sym2.Error("Unexpected call to UserRepo.FindByID")
 			return
 		},
+		CreateHook: func(*model.User) (ident2 error) {
+			t_sym2.Error("Unexpected call to UserRepo.Create")
+			return
+		},
+		UpdateHook: func(*model.User) (ident2 error) {
+			t_sym2.Error("Unexpected call to UserRepo.Update")
+			return
+		},
 	}
 }
 
 func (f *FakeUserRepo) Reset() {
 	f.FindByIDCalls = []*UserRepoFindByIDInvocation{}
+	f.CreateCalls = []*UserRepoCreateInvocation{}
+	f.UpdateCalls = []*UserRepoUpdateInvocation{}
 }
 
 func (f_sym3 *FakeUserRepo) FindByID(id int) (ident1 model.User, ident2 error) {
@@ -261,235 +331,261 @@ func (f_sym10 *FakeUserRepo) FindByIDResultsForCall(id int) (ident1 model.User,
 	return
 }
 
-// AccountRepoFindByUserInvocation represents a single call of FakeAccountRepo.FindByUser
-type AccountRepoFindByUserInvocation struct {
-	Parameters struct {
-		UserID int
-	}
-	Results struct {
-		Ident1 []model.Account
-		Ident2 error
+func (f_sym437 *FakeUserRepo) Create(ident1 *model.User) (ident2 error) {
+	if f_sym437.CreateHook == nil {
+		panic("UserRepo.Create() called but FakeUserRepo.CreateHook is nil")
 	}
-}
 
-// NewAccountRepoFindByUserInvocation creates a new instance of AccountRepoFindByUserInvocation
-func NewAccountRepoFindByUserInvocation(userID int, ident1 []model.Account, ident2 error) *AccountRepoFindByUserInvocation {
-	invocation := new(AccountRepoFindByUserInvocation)
+	invocation_sym437 := new(UserRepoCreateInvocation)
+	f_sym437.CreateCalls = append(f_sym437.CreateCalls, invocation_sym437)
 
-	invocation.Parameters.UserID = userID
+	invocation_sym437.Parameters.Ident1 = ident1
 
-	invocation.Results.Ident1 = ident1
-	invocation.Results.Ident2 = ident2
+	ident2 = f_sym437.CreateHook(ident1)
 
-	return invocation
+	invocation_sym437.Results.Ident2 = ident2
+
+	return
 }
 
-// AccountRepoFindByIDInvocation represents a single call of FakeAccountRepo.FindByID
-type AccountRepoFindByIDInvocation struct {
-	Parameters struct {
-		Id int
-	}
-	Results struct {
-		Ident1 model.Account
-		Ident2 error
+// SetCreateStub configures UserRepo.Create to always return the given values
+func (f_sym438 *FakeUserRepo) SetCreateStub(ident2 error) {
+	f_sym438.CreateHook = func(*model.User) error {
+		return ident2
 	}
 }
 
-// NewAccountRepoFindByIDInvocation creates a new instance of AccountRepoFindByIDInvocation
-func NewAccountRepoFindByIDInvocation(id int, ident1 model.Account, ident2 error) *AccountRepoFindByIDInvocation {
-	invocation := new(AccountRepoFindByIDInvocation)
+// SetCreateInvocation configures UserRepo.Create to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym439 *FakeUserRepo) SetCreateInvocation(calls_sym439 []*UserRepoCreateInvocation, fallback_sym439 func() error) {
+	f_sym439.CreateHook = func(ident1 *model.User) (ident2 error) {
+		for _, call_sym439 := range calls_sym439 {
+			if reflect.DeepEqual(call_sym439.Parameters.Ident1, ident1) {
+				ident2 = call_sym439.Results.Ident2
 
-	invocation.Parameters.Id = id
+				return
+			}
+		}
 
-	invocation.Results.Ident1 = ident1
-	invocation.Results.Ident2 = ident2
+		return fallback_sym439()
+	}
+}
 
-	return invocation
+// CreateCalled returns true if FakeUserRepo.Create was called
+func (f *FakeUserRepo) CreateCalled() bool {
+	return len(f.CreateCalls) != 0
 }
 
-// AccountRepoTestingT represents the methods of "testing".T used by charlatan Fakes.  It avoids importing the testing package.
-type AccountRepoTestingT interface {
-	Error(...interface{})
-	Errorf(string, ...interface{})
-	Fatal(...interface{})
-	Helper()
+// AssertCreateCalled calls t.Error if FakeUserRepo.Create was not called
+func (f *FakeUserRepo) AssertCreateCalled(t UserRepoTestingT) {
+	t.Helper()
+	if len(f.CreateCalls) == 0 {
+		t.Error("FakeUserRepo.Create not called, expected at least one")
+	}
 }
 
-/*
-FakeAccountRepo is a mock implementation of AccountRepo for testing.
-Use it in your tests as in this example:
+// CreateNotCalled returns true if FakeUserRepo.Create was not called
+func (f *FakeUserRepo) CreateNotCalled() bool {
+	return len(f.CreateCalls) == 0
+}
 
-	package example
+// AssertCreateNotCalled calls t.Error if FakeUserRepo.Create was called
+func (f *FakeUserRepo) AssertCreateNotCalled(t UserRepoTestingT) {
+	t.Helper()
+	if len(f.CreateCalls) != 0 {
+		t.Error("FakeUserRepo.Create called, expected none")
+	}
+}
 
-	func TestWithAccountRepo(t *testing.T) {
-		f := &mock.FakeAccountRepo{
-			FindByUserHook: func(userID int) (ident1 []model.Account, ident2 error) {
-				// ensure parameters meet expections, signal errors using t, etc
-				return
-			},
-		}
+// CreateCalledOnce returns true if FakeUserRepo.Create was called exactly once
+func (f *FakeUserRepo) CreateCalledOnce() bool {
+	return len(f.CreateCalls) == 1
+}
 
-		// test code goes here ...
+// AssertCreateCalledOnce calls t.Error if FakeUserRepo.Create was not called exactly once
+func (f *FakeUserRepo) AssertCreateCalledOnce(t UserRepoTestingT) {
+	t.Helper()
+	if len(f.CreateCalls) != 1 {
+		t.Errorf("FakeUserRepo.Create called %d times, expected 1", len(f.CreateCalls))
+	}
+}
 
-		// assert state of FakeFindByUser ...
-		f.AssertFindByUserCalledOnce(t)
+// CreateCalledN returns true if FakeUserRepo.Create was called at least n times
+func (f *FakeUserRepo) CreateCalledN(n int) bool {
+	return len(f.CreateCalls) >= n
+}
+
+// AssertCreateCalledN calls t.Error if FakeUserRepo.Create was called less than n times
+func (f *FakeUserRepo) AssertCreateCalledN(t UserRepoTestingT, n int) {
+	t.Helper()
+	if len(f.CreateCalls) < n {
+		t.Errorf("FakeUserRepo.Create called %d times, expected >= %d", len(f.CreateCalls), n)
 	}
+}
 
-Create anonymous function implementations for only those interface methods that
-should be called in the code under test.  This will force a panic if any
-unexpected calls are made to FakeFindByUser.
-*/
-type FakeAccountRepo struct {
-	FindByUserHook func(int) ([]model.Account, error)
-	FindByIDHook   func(int) (model.Account, error)
+// CreateCalledWith returns true if FakeUserRepo.Create was called with the given values
+func (f_sym440 *FakeUserRepo) CreateCalledWith(ident1 *model.User) bool {
+	for _, call_sym440 := range f_sym440.CreateCalls {
+		if reflect.DeepEqual(call_sym440.Parameters.Ident1, ident1) {
+			return true
+		}
+	}
 
-	FindByUserCalls []*AccountRepoFindByUserInvocation
-	FindByIDCalls   []*AccountRepoFindByIDInvocation
+	return false
 }
 
-// NewFakeAccountRepoDefaultPanic returns an instance of FakeAccountRepo with all hooks configured to panic
-func NewFakeAccountRepoDefaultPanic() *FakeAccountRepo {
-	return &FakeAccountRepo{
-		FindByUserHook: func(int) (ident1 []model.Account, ident2 error) {
-			panic("Unexpected call to AccountRepo.FindByUser")
-		},
-		FindByIDHook: func(int) (ident1 model.Account, ident2 error) {
-			panic("Unexpected call to AccountRepo.FindByID")
-		},
+// AssertCreateCalledWith calls t.Error if FakeUserRepo.Create was not called with the given values
+func (f_sym441 *FakeUserRepo) AssertCreateCalledWith(t UserRepoTestingT, ident1 *model.User) {
+	t.Helper()
+	var found_sym441 bool
+	for _, call_sym441 := range f_sym441.CreateCalls {
+		if reflect.DeepEqual(call_sym441.Parameters.Ident1, ident1) {
+			found_sym441 = true
+			break
+		}
+	}
+
+	if !found_sym441 {
+		t.Error("FakeUserRepo.Create not called with expected parameters")
 	}
 }
 
-// NewFakeAccountRepoDefaultFatal returns an instance of FakeAccountRepo with all hooks configured to call t.Fatal
-func NewFakeAccountRepoDefaultFatal(t_sym11 AccountRepoTestingT) *FakeAccountRepo {
-	return &FakeAccountRepo{
-		FindByUserHook: func(int) (ident1 []model.Account, ident2 error) {
-			t_sym11.Fatal("Unexpected call to AccountRepo.FindByUser")
-			return
-		},
-		FindByIDHook: func(int) (ident1 model.Account, ident2 error) {
-			t_sym11.Fatal("Unexpected call to AccountRepo.FindByID")
-			return
-		},
+// CreateCalledOnceWith returns true if FakeUserRepo.Create was called exactly once with the given values
+func (f_sym442 *FakeUserRepo) CreateCalledOnceWith(ident1 *model.User) bool {
+	var count_sym442 int
+	for _, call_sym442 := range f_sym442.CreateCalls {
+		if reflect.DeepEqual(call_sym442.Parameters.Ident1, ident1) {
+			count_sym442++
+		}
 	}
+
+	return count_sym442 == 1
 }
 
-// NewFakeAccountRepoDefaultError returns an instance of FakeAccountRepo with all hooks configured to call t.Error
-func NewFakeAccountRepoDefaultError(t_sym12 AccountRepoTestingT) *FakeAccountRepo {
-	return &FakeAccountRepo{
-		FindByUserHook: func(int) (ident1 []model.Account, ident2 error) {
-			t_sym12.Error("Unexpected call to AccountRepo.FindByUser")
-			return
-		},
-		FindByIDHook: func(int) (ident1 model.Account, ident2 error) {
-			t_sym12.Error("Unexpected call to AccountRepo.FindByID")
-			return
-		},
+// AssertCreateCalledOnceWith calls t.Error if FakeUserRepo.Create was not called exactly once with the given values
+func (f_sym443 *FakeUserRepo) AssertCreateCalledOnceWith(t UserRepoTestingT, ident1 *model.User) {
+	t.Helper()
+	var count_sym443 int
+	for _, call_sym443 := range f_sym443.CreateCalls {
+		if reflect.DeepEqual(call_sym443.Parameters.Ident1, ident1) {
+			count_sym443++
+		}
+	}
+
+	if count_sym443 != 1 {
+		t.Errorf("FakeUserRepo.Create called %d times with expected parameters, expected one", count_sym443)
 	}
 }
 
-func (f *FakeAccountRepo) Reset() {
-	f.FindByUserCalls = []*AccountRepoFindByUserInvocation{}
-	f.FindByIDCalls = []*AccountRepoFindByIDInvocation{}
+// CreateResultsForCall returns the result values for the first call to FakeUserRepo.Create with the given values
+func (f_sym444 *FakeUserRepo) CreateResultsForCall(ident1 *model.User) (ident2 error, found_sym444 bool) {
+	for _, call_sym444 := range f_sym444.CreateCalls {
+		if reflect.DeepEqual(call_sym444.Parameters.Ident1, ident1) {
+			ident2 = call_sym444.Results.Ident2
+			found_sym444 = true
+			break
+		}
+	}
+
+	return
 }
 
-func (f_sym13 *FakeAccountRepo) FindByUser(userID int) (ident1 []model.Account, ident2 error) {
-	if f_sym13.FindByUserHook == nil {
-		panic("AccountRepo.FindByUser() called but FakeAccountRepo.FindByUserHook is nil")
+func (f_sym445 *FakeUserRepo) Update(ident1 *model.User) (ident2 error) {
+	if f_sym445.UpdateHook == nil {
+		panic("UserRepo.Update() called but FakeUserRepo.UpdateHook is nil")
 	}
 
-	invocation_sym13 := new(AccountRepoFindByUserInvocation)
-	f_sym13.FindByUserCalls = append(f_sym13.FindByUserCalls, invocation_sym13)
+	invocation_sym445 := new(UserRepoUpdateInvocation)
+	f_sym445.UpdateCalls = append(f_sym445.UpdateCalls, invocation_sym445)
 
-	invocation_sym13.Parameters.UserID = userID
+	invocation_sym445.Parameters.Ident1 = ident1
 
-	ident1, ident2 = f_sym13.FindByUserHook(userID)
+	ident2 = f_sym445.UpdateHook(ident1)
 
-	invocation_sym13.Results.Ident1 = ident1
-	invocation_sym13.Results.Ident2 = ident2
+	invocation_sym445.Results.Ident2 = ident2
 
 	return
 }
 
-// SetFindByUserStub configures AccountRepo.FindByUser to always return the given values
-func (f_sym14 *FakeAccountRepo) SetFindByUserStub(ident1 []model.Account, ident2 error) {
-	f_sym14.FindByUserHook = func(int) ([]model.Account, error) {
-		return ident1, ident2
+// SetUpdateStub configures UserRepo.Update to always return the given values
+func (f_sym446 *FakeUserRepo) SetUpdateStub(ident2 error) {
+	f_sym446.UpdateHook = func(*model.User) error {
+		return ident2
 	}
 }
 
-// SetFindByUserInvocation configures AccountRepo.FindByUser to return the given results when called with the given parameters
+// SetUpdateInvocation configures UserRepo.Update to return the given results when called with the given parameters
 // If no match is found for an invocation the result(s) of the fallback function are returned
-func (f_sym15 *FakeAccountRepo) SetFindByUserInvocation(calls_sym15 []*AccountRepoFindByUserInvocation, fallback_sym15 func() ([]model.Account, error)) {
-	f_sym15.FindByUserHook = func(userID int) (ident1 []model.Account, ident2 error) {
-		for _, call_sym15 := range calls_sym15 {
-			if reflect.DeepEqual(call_sym15.Parameters.UserID, userID) {
-				ident1 = call_sym15.Results.Ident1
-				ident2 = call_sym15.Results.Ident2
+func (f_sym447 *FakeUserRepo) SetUpdateInvocation(calls_sym447 []*UserRepoUpdateInvocation, fallback_sym447 func() error) {
+	f_sym447.UpdateHook = func(ident1 *model.User) (ident2 error) {
+		for _, call_sym447 := range calls_sym447 {
+			if reflect.DeepEqual(call_sym447.Parameters.Ident1, ident1) {
+				ident2 = call_sym447.Results.Ident2
 
 				return
 			}
 		}
 
-		return fallback_sym15()
+		return fallback_sym447()
 	}
 }
 
-// FindByUserCalled returns true if FakeAccountRepo.FindByUser was called
-func (f *FakeAccountRepo) FindByUserCalled() bool {
-	return len(f.FindByUserCalls) != 0
+// UpdateCalled returns true if FakeUserRepo.Update was called
+func (f *FakeUserRepo) UpdateCalled() bool {
+	return len(f.UpdateCalls) != 0
 }
 
-// AssertFindByUserCalled calls t.Error if FakeAccountRepo.FindByUser was not called
-func (f *FakeAccountRepo) AssertFindByUserCalled(t AccountRepoTestingT) {
+// AssertUpdateCalled calls t.Error if FakeUserRepo.Update was not called
+func (f *FakeUserRepo) AssertUpdateCalled(t UserRepoTestingT) {
 	t.Helper()
-	if len(f.FindByUserCalls) == 0 {
-		t.Error("FakeAccountRepo.FindByUser not called, expected at least one")
+	if len(f.UpdateCalls) == 0 {
+		t.Error("FakeUserRepo.Update not called, expected at least one")
 	}
 }
 
-// FindByUserNotCalled returns true if FakeAccountRepo.FindByUser was not called
-func (f *FakeAccountRepo) FindByUserNotCalled() bool {
-	return len(f.FindByUserCalls) == 0
+// UpdateNotCalled returns true if FakeUserRepo.Update was not called
+func (f *FakeUserRepo) UpdateNotCalled() bool {
+	return len(f.UpdateCalls) == 0
 }
 
-// AssertFindByUserNotCalled calls t.Error if FakeAccountRepo.FindByUser was called
-func (f *FakeAccountRepo) AssertFindByUserNotCalled(t AccountRepoTestingT) {
+// AssertUpdateNotCalled calls t.Error if FakeUserRepo.Update was called
+func (f *FakeUserRepo) AssertUpdateNotCalled(t UserRepoTestingT) {
 	t.Helper()
-	if len(f.FindByUserCalls) != 0 {
-		t.Error("FakeAccountRepo.FindByUser called, expected none")
+	if len(f.UpdateCalls) != 0 {
+		t.Error("FakeUserRepo.Update called, expected none")
 	}
 }
 
-// FindByUserCalledOnce returns true if FakeAccountRepo.FindByUser was called exactly once
-func (f *FakeAccountRepo) FindByUserCalledOnce() bool {
-	return len(f.FindByUserCalls) == 1
+// UpdateCalledOnce returns true if FakeUserRepo.Update was called exactly once
+func (f *FakeUserRepo) UpdateCalledOnce() bool {
+	return len(f.UpdateCalls) == 1
 }
 
-// AssertFindByUserCalledOnce calls t.Error if FakeAccountRepo.FindByUser was not called exactly once
-func (f *FakeAccountRepo) AssertFindByUserCalledOnce(t AccountRepoTestingT) {
+// AssertUpdateCalledOnce calls t.Error if FakeUserRepo.Update was not called exactly once
+func (f *FakeUserRepo) AssertUpdateCalledOnce(t UserRepoTestingT) {
 	t.Helper()
-	if len(f.FindByUserCalls) != 1 {
-		t.Errorf("FakeAccountRepo.FindByUser called %d times, expected 1", len(f.FindByUserCalls))
+	if len(f.UpdateCalls) != 1 {
+		t.Errorf("FakeUserRepo.Update called %d times, expected 1", len(f.UpdateCalls))
 	}
 }
 
-// FindByUserCalledN returns true if FakeAccountRepo.FindByUser was called at least n times
-func (f *FakeAccountRepo) FindByUserCalledN(n int) bool {
-	return len(f.FindByUserCalls) >= n
+// UpdateCalledN returns true if FakeUserRepo.Update was called at least n times
+func (f *FakeUserRepo) UpdateCalledN(n int) bool {
+	return len(f.UpdateCalls) >= n
 }
 
-// AssertFindByUserCalledN calls t.Error if FakeAccountRepo.FindByUser was called less than n times
-func (f *FakeAccountRepo) AssertFindByUserCalledN(t AccountRepoTestingT, n int) {
+// AssertUpdateCalledN calls t.Error if FakeUserRepo.Update was called less than n times
+func (f *FakeUserRepo) AssertUpdateCalledN(t UserRepoTestingT, n int) {
 	t.Helper()
-	if len(f.FindByUserCalls) < n {
-		t.Errorf("FakeAccountRepo.FindByUser called %d times, expected >= %d", len(f.FindByUserCalls), n)
+	if len(f.UpdateCalls) < n {
+		t.Errorf("FakeUserRepo.Update called %d times, expected >= %d", len(f.UpdateCalls), n)
 	}
 }
 
-// FindByUserCalledWith returns true if FakeAccountRepo.FindByUser was called with the given values
-func (f_sym16 *FakeAccountRepo) FindByUserCalledWith(userID int) bool {
-	for _, call_sym16 := range f_sym16.FindByUserCalls {
-		if reflect.DeepEqual(call_sym16.Parameters.UserID, userID) {
+// UpdateCalledWith returns true if FakeUserRepo.Update was called with the given values
+func (f_sym448 *FakeUserRepo) UpdateCalledWith(ident1 *model.User) bool {
+	for _, call_sym448 := range f_sym448.UpdateCalls {
+		if reflect.DeepEqual(call_sym448.Parameters.Ident1, ident1) {
 			return true
 		}
 	}
@@ -497,56 +593,2424 @@ func (f_sym16 *FakeAccountRepo) FindByUserCalledWith(userID int) bool {
 	return false
 }
 
-// AssertFindByUserCalledWith calls t.Error if FakeAccountRepo.FindByUser was not called with the given values
-func (f_sym17 *FakeAccountRepo) AssertFindByUserCalledWith(t AccountRepoTestingT, userID int) {
+// AssertUpdateCalledWith calls t.Error if FakeUserRepo.Update was not called with the given values
+func (f_sym449 *FakeUserRepo) AssertUpdateCalledWith(t UserRepoTestingT, ident1 *model.User) {
 	t.Helper()
-	var found_sym17 bool
-	for _, call_sym17 := range f_sym17.FindByUserCalls {
-		if reflect.DeepEqual(call_sym17.Parameters.UserID, userID) {
-			found_sym17 = true
+	var found_sym449 bool
+	for _, call_sym449 := range f_sym449.UpdateCalls {
+		if reflect.DeepEqual(call_sym449.Parameters.Ident1, ident1) {
+			found_sym449 = true
 			break
 		}
 	}
 
-	if !found_sym17 {
-		t.Error("FakeAccountRepo.FindByUser not called with expected parameters")
+	if !found_sym449 {
+		t.Error("FakeUserRepo.Update not called with expected parameters")
 	}
 }
 
-// FindByUserCalledOnceWith returns true if FakeAccountRepo.FindByUser was called exactly once with the given values
-func (f_sym18 *FakeAccountRepo) FindByUserCalledOnceWith(userID int) bool {
-	var count_sym18 int
-	for _, call_sym18 := range f_sym18.FindByUserCalls {
-		if reflect.DeepEqual(call_sym18.Parameters.UserID, userID) {
+// UpdateCalledOnceWith returns true if FakeUserRepo.Update was called exactly once with the given values
+func (f_sym450 *FakeUserRepo) UpdateCalledOnceWith(ident1 *model.User) bool {
+	var count_sym450 int
+	for _, call_sym450 := range f_sym450.UpdateCalls {
+		if reflect.DeepEqual(call_sym450.Parameters.Ident1, ident1) {
+			count_sym450++
+		}
+	}
+
+	return count_sym450 == 1
+}
+
+// AssertUpdateCalledOnceWith calls t.Error if FakeUserRepo.Update was not called exactly once with the given values
+func (f_sym451 *FakeUserRepo) AssertUpdateCalledOnceWith(t UserRepoTestingT, ident1 *model.User) {
+	t.Helper()
+	var count_sym451 int
+	for _, call_sym451 := range f_sym451.UpdateCalls {
+		if reflect.DeepEqual(call_sym451.Parameters.Ident1, ident1) {
+			count_sym451++
+		}
+	}
+
+	if count_sym451 != 1 {
+		t.Errorf("FakeUserRepo.Update called %d times with expected parameters, expected one", count_sym451)
+	}
+}
+
+// UpdateResultsForCall returns the result values for the first call to FakeUserRepo.Update with the given values
+func (f_sym452 *FakeUserRepo) UpdateResultsForCall(ident1 *model.User) (ident2 error, found_sym452 bool) {
+	for _, call_sym452 := range f_sym452.UpdateCalls {
+		if reflect.DeepEqual(call_sym452.Parameters.Ident1, ident1) {
+			ident2 = call_sym452.Results.Ident2
+			found_sym452 = true
+			break
+		}
+	}
+
+	return
+}
+
+// AccountRepoFindByUserInvocation represents a single call of FakeAccountRepo.FindByUser
+type AccountRepoFindByUserInvocation struct {
+	Parameters struct {
+		UserID int
+	}
+	Results struct {
+		Ident1 []model.Account
+		Ident2 error
+	}
+}
+
+// NewAccountRepoFindByUserInvocation creates a new instance of AccountRepoFindByUserInvocation
+func NewAccountRepoFindByUserInvocation(userID int, ident1 []model.Account, ident2 error) *AccountRepoFindByUserInvocation {
+	invocation := new(AccountRepoFindByUserInvocation)
+
+	invocation.Parameters.UserID = userID
+
+	invocation.Results.Ident1 = ident1
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+// AccountRepoFindByIDInvocation represents a single call of FakeAccountRepo.FindByID
+type AccountRepoFindByIDInvocation struct {
+	Parameters struct {
+		Id int
+	}
+	Results struct {
+		Ident1 model.Account
+		Ident2 error
+	}
+}
+
+// NewAccountRepoFindByIDInvocation creates a new instance of AccountRepoFindByIDInvocation
+func NewAccountRepoFindByIDInvocation(id int, ident1 model.Account, ident2 error) *AccountRepoFindByIDInvocation {
+	invocation := new(AccountRepoFindByIDInvocation)
+
+	invocation.Parameters.Id = id
+
+	invocation.Results.Ident1 = ident1
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+// AccountRepoFindByNumberInvocation represents a single call of FakeAccountRepo.FindByNumber
+type AccountRepoFindByNumberInvocation struct {
+	Parameters struct {
+		Bank   string
+		Number string
+	}
+	Results struct {
+		Ident1 model.Account
+		Ident2 error
+	}
+}
+
+// NewAccountRepoFindByNumberInvocation creates a new instance of AccountRepoFindByNumberInvocation
+func NewAccountRepoFindByNumberInvocation(bank string, number string, ident1 model.Account, ident2 error) *AccountRepoFindByNumberInvocation {
+	invocation := new(AccountRepoFindByNumberInvocation)
+
+	invocation.Parameters.Bank = bank
+	invocation.Parameters.Number = number
+
+	invocation.Results.Ident1 = ident1
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+// AccountRepoCreateInvocation represents a single call of FakeAccountRepo.Create
+type AccountRepoCreateInvocation struct {
+	Parameters struct {
+		Ident1 *model.Account
+	}
+	Results struct {
+		Ident2 error
+	}
+}
+
+// NewAccountRepoCreateInvocation creates a new instance of AccountRepoCreateInvocation
+func NewAccountRepoCreateInvocation(ident1 *model.Account, ident2 error) *AccountRepoCreateInvocation {
+	invocation := new(AccountRepoCreateInvocation)
+
+	invocation.Parameters.Ident1 = ident1
+
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+// AccountRepoUpdateInvocation represents a single call of FakeAccountRepo.Update
+type AccountRepoUpdateInvocation struct {
+	Parameters struct {
+		Ident1 *model.Account
+	}
+	Results struct {
+		Ident2 error
+	}
+}
+
+// NewAccountRepoUpdateInvocation creates a new instance of AccountRepoUpdateInvocation
+func NewAccountRepoUpdateInvocation(ident1 *model.Account, ident2 error) *AccountRepoUpdateInvocation {
+	invocation := new(AccountRepoUpdateInvocation)
+
+	invocation.Parameters.Ident1 = ident1
+
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+// AccountRepoTestingT represents the methods of "testing".T used by charlatan Fakes.  It avoids importing the testing package.
+type AccountRepoTestingT interface {
+	Error(...interface{})
+	Errorf(string, ...interface{})
+	Fatal(...interface{})
+	Helper()
+}
+
+/*
+FakeAccountRepo is a mock implementation of AccountRepo for testing.
+Use it in your tests as in this example:
+
+	package example
+
+	func TestWithAccountRepo(t *testing.T) {
+		f := &mock.FakeAccountRepo{
+			FindByUserHook: func(userID int) (ident1 []model.Account, ident2 error) {
+				// ensure parameters meet expections, signal errors using t, etc
+				return
+			},
+		}
+
+		// test code goes here ...
+
+		// assert state of FakeFindByUser ...
+		f.AssertFindByUserCalledOnce(t)
+	}
+
+Create anonymous function implementations for only those interface methods that
+should be called in the code under test.  This will force a panic if any
+unexpected calls are made to FakeFindByUser.
+*/
+type FakeAccountRepo struct {
+	FindByUserHook   func(int) ([]model.Account, error)
+	FindByIDHook     func(int) (model.Account, error)
+	FindByNumberHook func(string, string) (model.Account, error)
+	CreateHook       func(*model.Account) error
+	UpdateHook       func(*model.Account) error
+
+	FindByUserCalls   []*AccountRepoFindByUserInvocation
+	FindByIDCalls     []*AccountRepoFindByIDInvocation
+	FindByNumberCalls []*AccountRepoFindByNumberInvocation
+	CreateCalls       []*AccountRepoCreateInvocation
+	UpdateCalls       []*AccountRepoUpdateInvocation
+}
+
+// NewFakeAccountRepoDefaultPanic returns an instance of FakeAccountRepo with all hooks configured to panic
+func NewFakeAccountRepoDefaultPanic() *FakeAccountRepo {
+	return &FakeAccountRepo{
+		FindByUserHook: func(int) (ident1 []model.Account, ident2 error) {
+			panic("Unexpected call to AccountRepo.FindByUser")
+		},
+		FindByIDHook: func(int) (ident1 model.Account, ident2 error) {
+			panic("Unexpected call to AccountRepo.FindByID")
+		},
+		FindByNumberHook: func(string, string) (ident1 model.Account, ident2 error) {
+			panic("Unexpected call to AccountRepo.FindByNumber")
+		},
+		CreateHook: func(*model.Account) (ident2 error) {
+			panic("Unexpected call to AccountRepo.Create")
+		},
+		UpdateHook: func(*model.Account) (ident2 error) {
+			panic("Unexpected call to AccountRepo.Update")
+		},
+	}
+}
+
+// NewFakeAccountRepoDefaultFatal returns an instance of FakeAccountRepo with all hooks configured to call t.Fatal
+func NewFakeAccountRepoDefaultFatal(t_sym11 AccountRepoTestingT) *FakeAccountRepo {
+	return &FakeAccountRepo{
+		FindByUserHook: func(int) (ident1 []model.Account, ident2 error) {
+			t_sym11.Fatal("Unexpected call to AccountRepo.FindByUser")
+			return
+		},
+		FindByIDHook: func(int) (ident1 model.Account, ident2 error) {
+			t_sym11.Fatal("Unexpected call to AccountRepo.FindByID")
+			return
+		},
+		FindByNumberHook: func(string, string) (ident1 model.Account, ident2 error) {
+			t_sym11.Fatal("Unexpected call to AccountRepo.FindByNumber")
+			return
+		},
+		CreateHook: func(*model.Account) (ident2 error) {
+			t_sym11.Fatal("Unexpected call to AccountRepo.Create")
+			return
+		},
+		UpdateHook: func(*model.Account) (ident2 error) {
+			t_sym11.Fatal("Unexpected call to AccountRepo.Update")
+			return
+		},
+	}
+}
+
+// NewFakeAccountRepoDefaultError returns an instance of FakeAccountRepo with all hooks configured to call t.Error
+func NewFakeAccountRepoDefaultError(t_sym12 AccountRepoTestingT) *FakeAccountRepo {
+	return &FakeAccountRepo{
+		FindByUserHook: func(int) (ident1 []model.Account, ident2 error) {
+			t_sym12.Error("Unexpected call to AccountRepo.FindByUser")
+			return
+		},
+		FindByIDHook: func(int) (ident1 model.Account, ident2 error) {
+			t_sym12.Error("Unexpected call to AccountRepo.FindByID")
+			return
+		},
+		FindByNumberHook: func(string, string) (ident1 model.Account, ident2 error) {
+			t_sym12.Error("Unexpected call to AccountRepo.FindByNumber")
+			return
+		},
+		CreateHook: func(*model.Account) (ident2 error) {
+			t_sym12.Error("Unexpected call to AccountRepo.Create")
+			return
+		},
+		UpdateHook: func(*model.Account) (ident2 error) {
+			t_sym12.Error("Unexpected call to AccountRepo.Update")
+			return
+		},
+	}
+}
+
+func (f *FakeAccountRepo) Reset() {
+	f.FindByUserCalls = []*AccountRepoFindByUserInvocation{}
+	f.FindByIDCalls = []*AccountRepoFindByIDInvocation{}
+	f.FindByNumberCalls = []*AccountRepoFindByNumberInvocation{}
+	f.CreateCalls = []*AccountRepoCreateInvocation{}
+	f.UpdateCalls = []*AccountRepoUpdateInvocation{}
+}
+
+func (f_sym13 *FakeAccountRepo) FindByUser(userID int) (ident1 []model.Account, ident2 error) {
+	if f_sym13.FindByUserHook == nil {
+		panic("AccountRepo.FindByUser() called but FakeAccountRepo.FindByUserHook is nil")
+	}
+
+	invocation_sym13 := new(AccountRepoFindByUserInvocation)
+	f_sym13.FindByUserCalls = append(f_sym13.FindByUserCalls, invocation_sym13)
+
+	invocation_sym13.Parameters.UserID = userID
+
+	ident1, ident2 = f_sym13.FindByUserHook(userID)
+
+	invocation_sym13.Results.Ident1 = ident1
+	invocation_sym13.Results.Ident2 = ident2
+
+	return
+}
+
+// SetFindByUserStub configures AccountRepo.FindByUser to always return the given values
+func (f_sym14 *FakeAccountRepo) SetFindByUserStub(ident1 []model.Account, ident2 error) {
+	f_sym14.FindByUserHook = func(int) ([]model.Account, error) {
+		return ident1, ident2
+	}
+}
+
+// SetFindByUserInvocation configures AccountRepo.FindByUser to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym15 *FakeAccountRepo) SetFindByUserInvocation(calls_sym15 []*AccountRepoFindByUserInvocation, fallback_sym15 func() ([]model.Account, error)) {
+	f_sym15.FindByUserHook = func(userID int) (ident1 []model.Account, ident2 error) {
+		for _, call_sym15 := range calls_sym15 {
+			if reflect.DeepEqual(call_sym15.Parameters.UserID, userID) {
+				ident1 = call_sym15.Results.Ident1
+				ident2 = call_sym15.Results.Ident2
+
+				return
+			}
+		}
+
+		return fallback_sym15()
+	}
+}
+
+// FindByUserCalled returns true if FakeAccountRepo.FindByUser was called
+func (f *FakeAccountRepo) FindByUserCalled() bool {
+	return len(f.FindByUserCalls) != 0
+}
+
+// AssertFindByUserCalled calls t.Error if FakeAccountRepo.FindByUser was not called
+func (f *FakeAccountRepo) AssertFindByUserCalled(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.FindByUserCalls) == 0 {
+		t.Error("FakeAccountRepo.FindByUser not called, expected at least one")
+	}
+}
+
+// FindByUserNotCalled returns true if FakeAccountRepo.FindByUser was not called
+func (f *FakeAccountRepo) FindByUserNotCalled() bool {
+	return len(f.FindByUserCalls) == 0
+}
+
+// AssertFindByUserNotCalled calls t.Error if FakeAccountRepo.FindByUser was called
+func (f *FakeAccountRepo) AssertFindByUserNotCalled(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.FindByUserCalls) != 0 {
+		t.Error("FakeAccountRepo.FindByUser called, expected none")
+	}
+}
+
+// FindByUserCalledOnce returns true if FakeAccountRepo.FindByUser was called exactly once
+func (f *FakeAccountRepo) FindByUserCalledOnce() bool {
+	return len(f.FindByUserCalls) == 1
+}
+
+// AssertFindByUserCalledOnce calls t.Error if FakeAccountRepo.FindByUser was not called exactly once
+func (f *FakeAccountRepo) AssertFindByUserCalledOnce(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.FindByUserCalls) != 1 {
+		t.Errorf("FakeAccountRepo.FindByUser called %d times, expected 1", len(f.FindByUserCalls))
+	}
+}
+
+// FindByUserCalledN returns true if FakeAccountRepo.FindByUser was called at least n times
+func (f *FakeAccountRepo) FindByUserCalledN(n int) bool {
+	return len(f.FindByUserCalls) >= n
+}
+
+// AssertFindByUserCalledN calls t.Error if FakeAccountRepo.FindByUser was called less than n times
+func (f *FakeAccountRepo) AssertFindByUserCalledN(t AccountRepoTestingT, n int) {
+	t.Helper()
+	if len(f.FindByUserCalls) < n {
+		t.Errorf("FakeAccountRepo.FindByUser called %d times, expected >= %d", len(f.FindByUserCalls), n)
+	}
+}
+
+// FindByUserCalledWith returns true if FakeAccountRepo.FindByUser was called with the given values
+func (f_sym16 *FakeAccountRepo) FindByUserCalledWith(userID int) bool {
+	for _, call_sym16 := range f_sym16.FindByUserCalls {
+		if reflect.DeepEqual(call_sym16.Parameters.UserID, userID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertFindByUserCalledWith calls t.Error if FakeAccountRepo.FindByUser was not called with the given values
+func (f_sym17 *FakeAccountRepo) AssertFindByUserCalledWith(t AccountRepoTestingT, userID int) {
+	t.Helper()
+	var found_sym17 bool
+	for _, call_sym17 := range f_sym17.FindByUserCalls {
+		if reflect.DeepEqual(call_sym17.Parameters.UserID, userID) {
+			found_sym17 = true
+			break
+		}
+	}
+
+	if !found_sym17 {
+		t.Error("FakeAccountRepo.FindByUser not called with expected parameters")
+	}
+}
+
+// FindByUserCalledOnceWith returns true if FakeAccountRepo.FindByUser was called exactly once with the given values
+func (f_sym18 *FakeAccountRepo) FindByUserCalledOnceWith(userID int) bool {
+	var count_sym18 int
+	for _, call_sym18 := range f_sym18.FindByUserCalls {
+		if reflect.DeepEqual(call_sym18.Parameters.UserID, userID) {
 			count_sym18++
 		}
 	}
 
-	return count_sym18 == 1
+	return count_sym18 == 1
+}
+
+// AssertFindByUserCalledOnceWith calls t.Error if FakeAccountRepo.FindByUser was not called exactly once with the given values
+func (f_sym19 *FakeAccountRepo) AssertFindByUserCalledOnceWith(t AccountRepoTestingT, userID int) {
+	t.Helper()
+	var count_sym19 int
+	for _, call_sym19 := range f_sym19.FindByUserCalls {
+		if reflect.DeepEqual(call_sym19.Parameters.UserID, userID) {
+			count_sym19++
+		}
+	}
+
+	if count_sym19 != 1 {
+		t.Errorf("FakeAccountRepo.FindByUser called %d times with expected parameters, expected one", count_sym19)
+	}
+}
+
+// FindByUserResultsForCall returns the result values for the first call to FakeAccountRepo.FindByUser with the given values
+func (f_sym20 *FakeAccountRepo) FindByUserResultsForCall(userID int) (ident1 []model.Account, ident2 error, found_sym20 bool) {
+	for _, call_sym20 := range f_sym20.FindByUserCalls {
+		if reflect.DeepEqual(call_sym20.Parameters.UserID, userID) {
+			ident1 = call_sym20.Results.Ident1
+			ident2 = call_sym20.Results.Ident2
+			found_sym20 = true
+			break
+		}
+	}
+
+	return
+}
+
+func (f_sym21 *FakeAccountRepo) FindByID(id int) (ident1 model.Account, ident2 error) {
+	if f_sym21.FindByIDHook == nil {
+		panic("AccountRepo.FindByID() called but FakeAccountRepo.FindByIDHook is nil")
+	}
+
+	invocation_sym21 := new(AccountRepoFindByIDInvocation)
+	f_sym21.FindByIDCalls = append(f_sym21.FindByIDCalls, invocation_sym21)
+
+	invocation_sym21.Parameters.Id = id
+
+	ident1, ident2 = f_sym21.FindByIDHook(id)
+
+	invocation_sym21.Results.Ident1 = ident1
+	invocation_sym21.Results.Ident2 = ident2
+
+	return
+}
+
+// SetFindByIDStub configures AccountRepo.FindByID to always return the given values
+func (f_sym22 *FakeAccountRepo) SetFindByIDStub(ident1 model.Account, ident2 error) {
+	f_sym22.FindByIDHook = func(int) (model.Account, error) {
+		return ident1, ident2
+	}
+}
+
+// SetFindByIDInvocation configures AccountRepo.FindByID to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym23 *FakeAccountRepo) SetFindByIDInvocation(calls_sym23 []*AccountRepoFindByIDInvocation, fallback_sym23 func() (model.Account, error)) {
+	f_sym23.FindByIDHook = func(id int) (ident1 model.Account, ident2 error) {
+		for _, call_sym23 := range calls_sym23 {
+			if reflect.DeepEqual(call_sym23.Parameters.Id, id) {
+				ident1 = call_sym23.Results.Ident1
+				ident2 = call_sym23.Results.Ident2
+
+				return
+			}
+		}
+
+		return fallback_sym23()
+	}
+}
+
+// FindByIDCalled returns true if FakeAccountRepo.FindByID was called
+func (f *FakeAccountRepo) FindByIDCalled() bool {
+	return len(f.FindByIDCalls) != 0
+}
+
+// AssertFindByIDCalled calls t.Error if FakeAccountRepo.FindByID was not called
+func (f *FakeAccountRepo) AssertFindByIDCalled(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.FindByIDCalls) == 0 {
+		t.Error("FakeAccountRepo.FindByID not called, expected at least one")
+	}
+}
+
+// FindByIDNotCalled returns true if FakeAccountRepo.FindByID was not called
+func (f *FakeAccountRepo) FindByIDNotCalled() bool {
+	return len(f.FindByIDCalls) == 0
+}
+
+// AssertFindByIDNotCalled calls t.Error if FakeAccountRepo.FindByID was called
+func (f *FakeAccountRepo) AssertFindByIDNotCalled(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.FindByIDCalls) != 0 {
+		t.Error("FakeAccountRepo.FindByID called, expected none")
+	}
+}
+
+// FindByIDCalledOnce returns true if FakeAccountRepo.FindByID was called exactly once
+func (f *FakeAccountRepo) FindByIDCalledOnce() bool {
+	return len(f.FindByIDCalls) == 1
+}
+
+// AssertFindByIDCalledOnce calls t.Error if FakeAccountRepo.FindByID was not called exactly once
+func (f *FakeAccountRepo) AssertFindByIDCalledOnce(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.FindByIDCalls) != 1 {
+		t.Errorf("FakeAccountRepo.FindByID called %d times, expected 1", len(f.FindByIDCalls))
+	}
+}
+
+// FindByIDCalledN returns true if FakeAccountRepo.FindByID was called at least n times
+func (f *FakeAccountRepo) FindByIDCalledN(n int) bool {
+	return len(f.FindByIDCalls) >= n
+}
+
+// AssertFindByIDCalledN calls t.Error if FakeAccountRepo.FindByID was called less than n times
+func (f *FakeAccountRepo) AssertFindByIDCalledN(t AccountRepoTestingT, n int) {
+	t.Helper()
+	if len(f.FindByIDCalls) < n {
+		t.Errorf("FakeAccountRepo.FindByID called %d times, expected >= %d", len(f.FindByIDCalls), n)
+	}
+}
+
+// FindByIDCalledWith returns true if FakeAccountRepo.FindByID was called with the given values
+func (f_sym24 *FakeAccountRepo) FindByIDCalledWith(id int) bool {
+	for _, call_sym24 := range f_sym24.FindByIDCalls {
+		if reflect.DeepEqual(call_sym24.Parameters.Id, id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertFindByIDCalledWith calls t.Error if FakeAccountRepo.FindByID was not called with the given values
+func (f_sym25 *FakeAccountRepo) AssertFindByIDCalledWith(t AccountRepoTestingT, id int) {
+	t.Helper()
+	var found_sym25 bool
+	for _, call_sym25 := range f_sym25.FindByIDCalls {
+		if reflect.DeepEqual(call_sym25.Parameters.Id, id) {
+			found_sym25 = true
+			break
+		}
+	}
+
+	if !found_sym25 {
+		t.Error("FakeAccountRepo.FindByID not called with expected parameters")
+	}
+}
+
+// FindByIDCalledOnceWith returns true if FakeAccountRepo.FindByID was called exactly once with the given values
+func (f_sym26 *FakeAccountRepo) FindByIDCalledOnceWith(id int) bool {
+	var count_sym26 int
+	for _, call_sym26 := range f_sym26.FindByIDCalls {
+		if reflect.DeepEqual(call_sym26.Parameters.Id, id) {
+			count_sym26++
+		}
+	}
+
+	return count_sym26 == 1
+}
+
+// AssertFindByIDCalledOnceWith calls t.Error if FakeAccountRepo.FindByID was not called exactly once with the given values
+func (f_sym27 *FakeAccountRepo) AssertFindByIDCalledOnceWith(t AccountRepoTestingT, id int) {
+	t.Helper()
+	var count_sym27 int
+	for _, call_sym27 := range f_sym27.FindByIDCalls {
+		if reflect.DeepEqual(call_sym27.Parameters.Id, id) {
+			count_sym27++
+		}
+	}
+
+	if count_sym27 != 1 {
+		t.Errorf("FakeAccountRepo.FindByID called %d times with expected parameters, expected one", count_sym27)
+	}
+}
+
+// FindByIDResultsForCall returns the result values for the first call to FakeAccountRepo.FindByID with the given values
+func (f_sym28 *FakeAccountRepo) FindByIDResultsForCall(id int) (ident1 model.Account, ident2 error, found_sym28 bool) {
+	for _, call_sym28 := range f_sym28.FindByIDCalls {
+		if reflect.DeepEqual(call_sym28.Parameters.Id, id) {
+			ident1 = call_sym28.Results.Ident1
+			ident2 = call_sym28.Results.Ident2
+			found_sym28 = true
+			break
+		}
+	}
+
+	return
+}
+
+func (f_sym421 *FakeAccountRepo) FindByNumber(bank string, number string) (ident1 model.Account, ident2 error) {
+	if f_sym421.FindByNumberHook == nil {
+		panic("AccountRepo.FindByNumber() called but FakeAccountRepo.FindByNumberHook is nil")
+	}
+
+	invocation_sym421 := new(AccountRepoFindByNumberInvocation)
+	f_sym421.FindByNumberCalls = append(f_sym421.FindByNumberCalls, invocation_sym421)
+
+	invocation_sym421.Parameters.Bank = bank
+	invocation_sym421.Parameters.Number = number
+
+	ident1, ident2 = f_sym421.FindByNumberHook(bank, number)
+
+	invocation_sym421.Results.Ident1 = ident1
+	invocation_sym421.Results.Ident2 = ident2
+
+	return
+}
+
+// SetFindByNumberStub configures AccountRepo.FindByNumber to always return the given values
+func (f_sym422 *FakeAccountRepo) SetFindByNumberStub(ident1 model.Account, ident2 error) {
+	f_sym422.FindByNumberHook = func(string, string) (model.Account, error) {
+		return ident1, ident2
+	}
+}
+
+// SetFindByNumberInvocation configures AccountRepo.FindByNumber to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym423 *FakeAccountRepo) SetFindByNumberInvocation(calls_sym423 []*AccountRepoFindByNumberInvocation, fallback_sym423 func() (model.Account, error)) {
+	f_sym423.FindByNumberHook = func(bank string, number string) (ident1 model.Account, ident2 error) {
+		for _, call_sym423 := range calls_sym423 {
+			if reflect.DeepEqual(call_sym423.Parameters.Bank, bank) && reflect.DeepEqual(call_sym423.Parameters.Number, number) {
+				ident1 = call_sym423.Results.Ident1
+				ident2 = call_sym423.Results.Ident2
+
+				return
+			}
+		}
+
+		return fallback_sym423()
+	}
+}
+
+// FindByNumberCalled returns true if FakeAccountRepo.FindByNumber was called
+func (f *FakeAccountRepo) FindByNumberCalled() bool {
+	return len(f.FindByNumberCalls) != 0
+}
+
+// AssertFindByNumberCalled calls t.Error if FakeAccountRepo.FindByNumber was not called
+func (f *FakeAccountRepo) AssertFindByNumberCalled(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.FindByNumberCalls) == 0 {
+		t.Error("FakeAccountRepo.FindByNumber not called, expected at least one")
+	}
+}
+
+// FindByNumberNotCalled returns true if FakeAccountRepo.FindByNumber was not called
+func (f *FakeAccountRepo) FindByNumberNotCalled() bool {
+	return len(f.FindByNumberCalls) == 0
+}
+
+// AssertFindByNumberNotCalled calls t.Error if FakeAccountRepo.FindByNumber was called
+func (f *FakeAccountRepo) AssertFindByNumberNotCalled(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.FindByNumberCalls) != 0 {
+		t.Error("FakeAccountRepo.FindByNumber called, expected none")
+	}
+}
+
+// FindByNumberCalledOnce returns true if FakeAccountRepo.FindByNumber was called exactly once
+func (f *FakeAccountRepo) FindByNumberCalledOnce() bool {
+	return len(f.FindByNumberCalls) == 1
+}
+
+// AssertFindByNumberCalledOnce calls t.Error if FakeAccountRepo.FindByNumber was not called exactly once
+func (f *FakeAccountRepo) AssertFindByNumberCalledOnce(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.FindByNumberCalls) != 1 {
+		t.Errorf("FakeAccountRepo.FindByNumber called %d times, expected 1", len(f.FindByNumberCalls))
+	}
+}
+
+// FindByNumberCalledN returns true if FakeAccountRepo.FindByNumber was called at least n times
+func (f *FakeAccountRepo) FindByNumberCalledN(n int) bool {
+	return len(f.FindByNumberCalls) >= n
+}
+
+// AssertFindByNumberCalledN calls t.Error if FakeAccountRepo.FindByNumber was called less than n times
+func (f *FakeAccountRepo) AssertFindByNumberCalledN(t AccountRepoTestingT, n int) {
+	t.Helper()
+	if len(f.FindByNumberCalls) < n {
+		t.Errorf("FakeAccountRepo.FindByNumber called %d times, expected >= %d", len(f.FindByNumberCalls), n)
+	}
+}
+
+// FindByNumberCalledWith returns true if FakeAccountRepo.FindByNumber was called with the given values
+func (f_sym424 *FakeAccountRepo) FindByNumberCalledWith(bank string, number string) bool {
+	for _, call_sym424 := range f_sym424.FindByNumberCalls {
+		if reflect.DeepEqual(call_sym424.Parameters.Bank, bank) && reflect.DeepEqual(call_sym424.Parameters.Number, number) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertFindByNumberCalledWith calls t.Error if FakeAccountRepo.FindByNumber was not called with the given values
+func (f_sym425 *FakeAccountRepo) AssertFindByNumberCalledWith(t AccountRepoTestingT, bank string, number string) {
+	t.Helper()
+	var found_sym425 bool
+	for _, call_sym425 := range f_sym425.FindByNumberCalls {
+		if reflect.DeepEqual(call_sym425.Parameters.Bank, bank) && reflect.DeepEqual(call_sym425.Parameters.Number, number) {
+			found_sym425 = true
+			break
+		}
+	}
+
+	if !found_sym425 {
+		t.Error("FakeAccountRepo.FindByNumber not called with expected parameters")
+	}
+}
+
+// FindByNumberCalledOnceWith returns true if FakeAccountRepo.FindByNumber was called exactly once with the given values
+func (f_sym426 *FakeAccountRepo) FindByNumberCalledOnceWith(bank string, number string) bool {
+	var count_sym426 int
+	for _, call_sym426 := range f_sym426.FindByNumberCalls {
+		if reflect.DeepEqual(call_sym426.Parameters.Bank, bank) && reflect.DeepEqual(call_sym426.Parameters.Number, number) {
+			count_sym426++
+		}
+	}
+
+	return count_sym426 == 1
+}
+
+// AssertFindByNumberCalledOnceWith calls t.Error if FakeAccountRepo.FindByNumber was not called exactly once with the given values
+func (f_sym427 *FakeAccountRepo) AssertFindByNumberCalledOnceWith(t AccountRepoTestingT, bank string, number string) {
+	t.Helper()
+	var count_sym427 int
+	for _, call_sym427 := range f_sym427.FindByNumberCalls {
+		if reflect.DeepEqual(call_sym427.Parameters.Bank, bank) && reflect.DeepEqual(call_sym427.Parameters.Number, number) {
+			count_sym427++
+		}
+	}
+
+	if count_sym427 != 1 {
+		t.Errorf("FakeAccountRepo.FindByNumber called %d times with expected parameters, expected one", count_sym427)
+	}
+}
+
+// FindByNumberResultsForCall returns the result values for the first call to FakeAccountRepo.FindByNumber with the given values
+func (f_sym428 *FakeAccountRepo) FindByNumberResultsForCall(bank string, number string) (ident1 model.Account, ident2 error, found_sym428 bool) {
+	for _, call_sym428 := range f_sym428.FindByNumberCalls {
+		if reflect.DeepEqual(call_sym428.Parameters.Bank, bank) && reflect.DeepEqual(call_sym428.Parameters.Number, number) {
+			ident1 = call_sym428.Results.Ident1
+			ident2 = call_sym428.Results.Ident2
+			found_sym428 = true
+			break
+		}
+	}
+
+	return
+}
+
+func (f_sym453 *FakeAccountRepo) Create(ident1 *model.Account) (ident2 error) {
+	if f_sym453.CreateHook == nil {
+		panic("AccountRepo.Create() called but FakeAccountRepo.CreateHook is nil")
+	}
+
+	invocation_sym453 := new(AccountRepoCreateInvocation)
+	f_sym453.CreateCalls = append(f_sym453.CreateCalls, invocation_sym453)
+
+	invocation_sym453.Parameters.Ident1 = ident1
+
+	ident2 = f_sym453.CreateHook(ident1)
+
+	invocation_sym453.Results.Ident2 = ident2
+
+	return
+}
+
+// SetCreateStub configures AccountRepo.Create to always return the given values
+func (f_sym454 *FakeAccountRepo) SetCreateStub(ident2 error) {
+	f_sym454.CreateHook = func(*model.Account) error {
+		return ident2
+	}
+}
+
+// SetCreateInvocation configures AccountRepo.Create to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym455 *FakeAccountRepo) SetCreateInvocation(calls_sym455 []*AccountRepoCreateInvocation, fallback_sym455 func() error) {
+	f_sym455.CreateHook = func(ident1 *model.Account) (ident2 error) {
+		for _, call_sym455 := range calls_sym455 {
+			if reflect.DeepEqual(call_sym455.Parameters.Ident1, ident1) {
+				ident2 = call_sym455.Results.Ident2
+
+				return
+			}
+		}
+
+		return fallback_sym455()
+	}
+}
+
+// CreateCalled returns true if FakeAccountRepo.Create was called
+func (f *FakeAccountRepo) CreateCalled() bool {
+	return len(f.CreateCalls) != 0
+}
+
+// AssertCreateCalled calls t.Error if FakeAccountRepo.Create was not called
+func (f *FakeAccountRepo) AssertCreateCalled(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.CreateCalls) == 0 {
+		t.Error("FakeAccountRepo.Create not called, expected at least one")
+	}
+}
+
+// CreateNotCalled returns true if FakeAccountRepo.Create was not called
+func (f *FakeAccountRepo) CreateNotCalled() bool {
+	return len(f.CreateCalls) == 0
+}
+
+// AssertCreateNotCalled calls t.Error if FakeAccountRepo.Create was called
+func (f *FakeAccountRepo) AssertCreateNotCalled(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.CreateCalls) != 0 {
+		t.Error("FakeAccountRepo.Create called, expected none")
+	}
+}
+
+// CreateCalledOnce returns true if FakeAccountRepo.Create was called exactly once
+func (f *FakeAccountRepo) CreateCalledOnce() bool {
+	return len(f.CreateCalls) == 1
+}
+
+// AssertCreateCalledOnce calls t.Error if FakeAccountRepo.Create was not called exactly once
+func (f *FakeAccountRepo) AssertCreateCalledOnce(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.CreateCalls) != 1 {
+		t.Errorf("FakeAccountRepo.Create called %d times, expected 1", len(f.CreateCalls))
+	}
+}
+
+// CreateCalledN returns true if FakeAccountRepo.Create was called at least n times
+func (f *FakeAccountRepo) CreateCalledN(n int) bool {
+	return len(f.CreateCalls) >= n
+}
+
+// AssertCreateCalledN calls t.Error if FakeAccountRepo.Create was called less than n times
+func (f *FakeAccountRepo) AssertCreateCalledN(t AccountRepoTestingT, n int) {
+	t.Helper()
+	if len(f.CreateCalls) < n {
+		t.Errorf("FakeAccountRepo.Create called %d times, expected >= %d", len(f.CreateCalls), n)
+	}
+}
+
+// CreateCalledWith returns true if FakeAccountRepo.Create was called with the given values
+func (f_sym456 *FakeAccountRepo) CreateCalledWith(ident1 *model.Account) bool {
+	for _, call_sym456 := range f_sym456.CreateCalls {
+		if reflect.DeepEqual(call_sym456.Parameters.Ident1, ident1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertCreateCalledWith calls t.Error if FakeAccountRepo.Create was not called with the given values
+func (f_sym457 *FakeAccountRepo) AssertCreateCalledWith(t AccountRepoTestingT, ident1 *model.Account) {
+	t.Helper()
+	var found_sym457 bool
+	for _, call_sym457 := range f_sym457.CreateCalls {
+		if reflect.DeepEqual(call_sym457.Parameters.Ident1, ident1) {
+			found_sym457 = true
+			break
+		}
+	}
+
+	if !found_sym457 {
+		t.Error("FakeAccountRepo.Create not called with expected parameters")
+	}
+}
+
+// CreateCalledOnceWith returns true if FakeAccountRepo.Create was called exactly once with the given values
+func (f_sym458 *FakeAccountRepo) CreateCalledOnceWith(ident1 *model.Account) bool {
+	var count_sym458 int
+	for _, call_sym458 := range f_sym458.CreateCalls {
+		if reflect.DeepEqual(call_sym458.Parameters.Ident1, ident1) {
+			count_sym458++
+		}
+	}
+
+	return count_sym458 == 1
+}
+
+// AssertCreateCalledOnceWith calls t.Error if FakeAccountRepo.Create was not called exactly once with the given values
+func (f_sym459 *FakeAccountRepo) AssertCreateCalledOnceWith(t AccountRepoTestingT, ident1 *model.Account) {
+	t.Helper()
+	var count_sym459 int
+	for _, call_sym459 := range f_sym459.CreateCalls {
+		if reflect.DeepEqual(call_sym459.Parameters.Ident1, ident1) {
+			count_sym459++
+		}
+	}
+
+	if count_sym459 != 1 {
+		t.Errorf("FakeAccountRepo.Create called %d times with expected parameters, expected one", count_sym459)
+	}
+}
+
+// CreateResultsForCall returns the result values for the first call to FakeAccountRepo.Create with the given values
+func (f_sym460 *FakeAccountRepo) CreateResultsForCall(ident1 *model.Account) (ident2 error, found_sym460 bool) {
+	for _, call_sym460 := range f_sym460.CreateCalls {
+		if reflect.DeepEqual(call_sym460.Parameters.Ident1, ident1) {
+			ident2 = call_sym460.Results.Ident2
+			found_sym460 = true
+			break
+		}
+	}
+
+	return
+}
+
+func (f_sym461 *FakeAccountRepo) Update(ident1 *model.Account) (ident2 error) {
+	if f_sym461.UpdateHook == nil {
+		panic("AccountRepo.Update() called but FakeAccountRepo.UpdateHook is nil")
+	}
+
+	invocation_sym461 := new(AccountRepoUpdateInvocation)
+	f_sym461.UpdateCalls = append(f_sym461.UpdateCalls, invocation_sym461)
+
+	invocation_sym461.Parameters.Ident1 = ident1
+
+	ident2 = f_sym461.UpdateHook(ident1)
+
+	invocation_sym461.Results.Ident2 = ident2
+
+	return
+}
+
+// SetUpdateStub configures AccountRepo.Update to always return the given values
+func (f_sym462 *FakeAccountRepo) SetUpdateStub(ident2 error) {
+	f_sym462.UpdateHook = func(*model.Account) error {
+		return ident2
+	}
+}
+
+// SetUpdateInvocation configures AccountRepo.Update to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym463 *FakeAccountRepo) SetUpdateInvocation(calls_sym463 []*AccountRepoUpdateInvocation, fallback_sym463 func() error) {
+	f_sym463.UpdateHook = func(ident1 *model.Account) (ident2 error) {
+		for _, call_sym463 := range calls_sym463 {
+			if reflect.DeepEqual(call_sym463.Parameters.Ident1, ident1) {
+				ident2 = call_sym463.Results.Ident2
+
+				return
+			}
+		}
+
+		return fallback_sym463()
+	}
+}
+
+// UpdateCalled returns true if FakeAccountRepo.Update was called
+func (f *FakeAccountRepo) UpdateCalled() bool {
+	return len(f.UpdateCalls) != 0
+}
+
+// AssertUpdateCalled calls t.Error if FakeAccountRepo.Update was not called
+func (f *FakeAccountRepo) AssertUpdateCalled(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.UpdateCalls) == 0 {
+		t.Error("FakeAccountRepo.Update not called, expected at least one")
+	}
+}
+
+// UpdateNotCalled returns true if FakeAccountRepo.Update was not called
+func (f *FakeAccountRepo) UpdateNotCalled() bool {
+	return len(f.UpdateCalls) == 0
+}
+
+// AssertUpdateNotCalled calls t.Error if FakeAccountRepo.Update was called
+func (f *FakeAccountRepo) AssertUpdateNotCalled(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.UpdateCalls) != 0 {
+		t.Error("FakeAccountRepo.Update called, expected none")
+	}
+}
+
+// UpdateCalledOnce returns true if FakeAccountRepo.Update was called exactly once
+func (f *FakeAccountRepo) UpdateCalledOnce() bool {
+	return len(f.UpdateCalls) == 1
+}
+
+// AssertUpdateCalledOnce calls t.Error if FakeAccountRepo.Update was not called exactly once
+func (f *FakeAccountRepo) AssertUpdateCalledOnce(t AccountRepoTestingT) {
+	t.Helper()
+	if len(f.UpdateCalls) != 1 {
+		t.Errorf("FakeAccountRepo.Update called %d times, expected 1", len(f.UpdateCalls))
+	}
+}
+
+// UpdateCalledN returns true if FakeAccountRepo.Update was called at least n times
+func (f *FakeAccountRepo) UpdateCalledN(n int) bool {
+	return len(f.UpdateCalls) >= n
+}
+
+// AssertUpdateCalledN calls t.Error if FakeAccountRepo.Update was called less than n times
+func (f *FakeAccountRepo) AssertUpdateCalledN(t AccountRepoTestingT, n int) {
+	t.Helper()
+	if len(f.UpdateCalls) < n {
+		t.Errorf("FakeAccountRepo.Update called %d times, expected >= %d", len(f.UpdateCalls), n)
+	}
+}
+
+// UpdateCalledWith returns true if FakeAccountRepo.Update was called with the given values
+func (f_sym464 *FakeAccountRepo) UpdateCalledWith(ident1 *model.Account) bool {
+	for _, call_sym464 := range f_sym464.UpdateCalls {
+		if reflect.DeepEqual(call_sym464.Parameters.Ident1, ident1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertUpdateCalledWith calls t.Error if FakeAccountRepo.Update was not called with the given values
+func (f_sym465 *FakeAccountRepo) AssertUpdateCalledWith(t AccountRepoTestingT, ident1 *model.Account) {
+	t.Helper()
+	var found_sym465 bool
+	for _, call_sym465 := range f_sym465.UpdateCalls {
+		if reflect.DeepEqual(call_sym465.Parameters.Ident1, ident1) {
+			found_sym465 = true
+			break
+		}
+	}
+
+	if !found_sym465 {
+		t.Error("FakeAccountRepo.Update not called with expected parameters")
+	}
+}
+
+// UpdateCalledOnceWith returns true if FakeAccountRepo.Update was called exactly once with the given values
+func (f_sym466 *FakeAccountRepo) UpdateCalledOnceWith(ident1 *model.Account) bool {
+	var count_sym466 int
+	for _, call_sym466 := range f_sym466.UpdateCalls {
+		if reflect.DeepEqual(call_sym466.Parameters.Ident1, ident1) {
+			count_sym466++
+		}
+	}
+
+	return count_sym466 == 1
+}
+
+// AssertUpdateCalledOnceWith calls t.Error if FakeAccountRepo.Update was not called exactly once with the given values
+func (f_sym467 *FakeAccountRepo) AssertUpdateCalledOnceWith(t AccountRepoTestingT, ident1 *model.Account) {
+	t.Helper()
+	var count_sym467 int
+	for _, call_sym467 := range f_sym467.UpdateCalls {
+		if reflect.DeepEqual(call_sym467.Parameters.Ident1, ident1) {
+			count_sym467++
+		}
+	}
+
+	if count_sym467 != 1 {
+		t.Errorf("FakeAccountRepo.Update called %d times with expected parameters, expected one", count_sym467)
+	}
+}
+
+// UpdateResultsForCall returns the result values for the first call to FakeAccountRepo.Update with the given values
+func (f_sym468 *FakeAccountRepo) UpdateResultsForCall(ident1 *model.Account) (ident2 error, found_sym468 bool) {
+	for _, call_sym468 := range f_sym468.UpdateCalls {
+		if reflect.DeepEqual(call_sym468.Parameters.Ident1, ident1) {
+			ident2 = call_sym468.Results.Ident2
+			found_sym468 = true
+			break
+		}
+	}
+
+	return
+}
+
+// TransactionRepoFindByIDInvocation represents a single call of FakeTransactionRepo.FindByID
+type TransactionRepoFindByIDInvocation struct {
+	Parameters struct {
+		Id int
+	}
+	Results struct {
+		Ident1 model.Transaction
+		Ident2 error
+	}
+}
+
+// NewTransactionRepoFindByIDInvocation creates a new instance of TransactionRepoFindByIDInvocation
+func NewTransactionRepoFindByIDInvocation(id int, ident1 model.Transaction, ident2 error) *TransactionRepoFindByIDInvocation {
+	invocation := new(TransactionRepoFindByIDInvocation)
+
+	invocation.Parameters.Id = id
+
+	invocation.Results.Ident1 = ident1
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+// TransactionRepoFindByUserInvocation represents a single call of FakeTransactionRepo.FindByUser
+type TransactionRepoFindByUserInvocation struct {
+	Parameters struct {
+		UserID int
+	}
+	Results struct {
+		Ident1 []model.Transaction
+		Ident2 error
+	}
+}
+
+// NewTransactionRepoFindByUserInvocation creates a new instance of TransactionRepoFindByUserInvocation
+func NewTransactionRepoFindByUserInvocation(userID int, ident1 []model.Transaction, ident2 error) *TransactionRepoFindByUserInvocation {
+	invocation := new(TransactionRepoFindByUserInvocation)
+
+	invocation.Parameters.UserID = userID
+
+	invocation.Results.Ident1 = ident1
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+// TransactionRepoFindByUserAccountInvocation represents a single call of FakeTransactionRepo.FindByUserAccount
+type TransactionRepoFindByUserAccountInvocation struct {
+	Parameters struct {
+		UserID    int
+		AccountID int
+	}
+	Results struct {
+		Ident1 []model.Transaction
+		Ident2 error
+	}
+}
+
+// NewTransactionRepoFindByUserAccountInvocation creates a new instance of TransactionRepoFindByUserAccountInvocation
+func NewTransactionRepoFindByUserAccountInvocation(userID int, accountID int, ident1 []model.Transaction, ident2 error) *TransactionRepoFindByUserAccountInvocation {
+	invocation := new(TransactionRepoFindByUserAccountInvocation)
+
+	invocation.Parameters.UserID = userID
+	invocation.Parameters.AccountID = accountID
+
+	invocation.Results.Ident1 = ident1
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+// TransactionRepoCreateInvocation represents a single call of FakeTransactionRepo.Create
+type TransactionRepoCreateInvocation struct {
+	Parameters struct {
+		Ident1 *model.Transaction
+	}
+	Results struct {
+		Ident2 error
+	}
+}
+
+// NewTransactionRepoCreateInvocation creates a new instance of TransactionRepoCreateInvocation
+func NewTransactionRepoCreateInvocation(ident1 *model.Transaction, ident2 error) *TransactionRepoCreateInvocation {
+	invocation := new(TransactionRepoCreateInvocation)
+
+	invocation.Parameters.Ident1 = ident1
+
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+// TransactionRepoUpdateInvocation represents a single call of FakeTransactionRepo.Update
+type TransactionRepoUpdateInvocation struct {
+	Parameters struct {
+		Ident1 *model.Transaction
+	}
+	Results struct {
+		Ident2 error
+	}
+}
+
+// NewTransactionRepoUpdateInvocation creates a new instance of TransactionRepoUpdateInvocation
+func NewTransactionRepoUpdateInvocation(ident1 *model.Transaction, ident2 error) *TransactionRepoUpdateInvocation {
+	invocation := new(TransactionRepoUpdateInvocation)
+
+	invocation.Parameters.Ident1 = ident1
+
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+// TransactionRepoDeleteInvocation represents a single call of FakeTransactionRepo.Delete
+type TransactionRepoDeleteInvocation struct {
+	Parameters struct {
+		UserID int
+		TranID int
+	}
+	Results struct {
+		Ident1 error
+	}
+}
+
+// NewTransactionRepoDeleteInvocation creates a new instance of TransactionRepoDeleteInvocation
+func NewTransactionRepoDeleteInvocation(userID int, tranID int, ident1 error) *TransactionRepoDeleteInvocation {
+	invocation := new(TransactionRepoDeleteInvocation)
+
+	invocation.Parameters.UserID = userID
+	invocation.Parameters.TranID = tranID
+
+	invocation.Results.Ident1 = ident1
+
+	return invocation
+}
+
+// TransactionRepoEachByUserInvocation represents a single call of FakeTransactionRepo.EachByUser
+type TransactionRepoEachByUserInvocation struct {
+	Parameters struct {
+		UserID    int
+		AccountID *int
+		Fn        func(model.Transaction) error
+	}
+	Results struct {
+		Ident1 error
+	}
+}
+
+// NewTransactionRepoEachByUserInvocation creates a new instance of TransactionRepoEachByUserInvocation
+func NewTransactionRepoEachByUserInvocation(userID int, accountID *int, fn func(model.Transaction) error, ident1 error) *TransactionRepoEachByUserInvocation {
+	invocation := new(TransactionRepoEachByUserInvocation)
+
+	invocation.Parameters.UserID = userID
+	invocation.Parameters.AccountID = accountID
+	invocation.Parameters.Fn = fn
+
+	invocation.Results.Ident1 = ident1
+
+	return invocation
+}
+
+// TransactionRepoFindPageInvocation represents a single call of FakeTransactionRepo.FindPage
+type TransactionRepoFindPageInvocation struct {
+	Parameters struct {
+		UserID    int
+		AccountID *int
+		After     int
+		Limit     int
+	}
+	Results struct {
+		Ident1 []model.Transaction
+		Ident2 error
+	}
+}
+
+// NewTransactionRepoFindPageInvocation creates a new instance of TransactionRepoFindPageInvocation
+func NewTransactionRepoFindPageInvocation(userID int, accountID *int, after int, limit int, ident1 []model.Transaction, ident2 error) *TransactionRepoFindPageInvocation {
+	invocation := new(TransactionRepoFindPageInvocation)
+
+	invocation.Parameters.UserID = userID
+	invocation.Parameters.AccountID = accountID
+	invocation.Parameters.After = after
+	invocation.Parameters.Limit = limit
+
+	invocation.Results.Ident1 = ident1
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+// TransactionRepoTestingT represents the methods of "testing".T used by charlatan Fakes.  It avoids importing the testing package.
+type TransactionRepoTestingT interface {
+	Error(...interface{})
+	Errorf(string, ...interface{})
+	Fatal(...interface{})
+	Helper()
+}
+
+/*
+FakeTransactionRepo is a mock implementation of TransactionRepo for testing.
+Use it in your tests as in this example:
+
+	package example
+
+	func TestWithTransactionRepo(t *testing.T) {
+		f := &mock.FakeTransactionRepo{
+			FindByIDHook: func(id int) (ident1 model.Transaction, ident2 error) {
+				// ensure parameters meet expections, signal errors using t, etc
+				return
+			},
+		}
+
+		// test code goes here ...
+
+		// assert state of FakeFindByID ...
+		f.AssertFindByIDCalledOnce(t)
+	}
+
+Create anonymous function implementations for only those interface methods that
+should be called in the code under test.  This will force a panic if any
+unexpected calls are made to FakeFindByID.
+*/
+type FakeTransactionRepo struct {
+	FindByIDHook          func(int) (model.Transaction, error)
+	FindByUserHook        func(int) ([]model.Transaction, error)
+	FindByUserAccountHook func(int, int) ([]model.Transaction, error)
+	CreateHook            func(*model.Transaction) error
+	UpdateHook            func(*model.Transaction) error
+	DeleteHook            func(int, int) error
+	EachByUserHook        func(int, *int, func(model.Transaction) error) error
+	FindPageHook          func(int, *int, int, int) ([]model.Transaction, error)
+
+	FindByIDCalls          []*TransactionRepoFindByIDInvocation
+	FindByUserCalls        []*TransactionRepoFindByUserInvocation
+	FindByUserAccountCalls []*TransactionRepoFindByUserAccountInvocation
+	CreateCalls            []*TransactionRepoCreateInvocation
+	UpdateCalls            []*TransactionRepoUpdateInvocation
+	DeleteCalls            []*TransactionRepoDeleteInvocation
+	EachByUserCalls        []*TransactionRepoEachByUserInvocation
+	FindPageCalls          []*TransactionRepoFindPageInvocation
+}
+
+// NewFakeTransactionRepoDefaultPanic returns an instance of FakeTransactionRepo with all hooks configured to panic
+func NewFakeTransactionRepoDefaultPanic() *FakeTransactionRepo {
+	return &FakeTransactionRepo{
+		FindByIDHook: func(int) (ident1 model.Transaction, ident2 error) {
+			panic("Unexpected call to TransactionRepo.FindByID")
+		},
+		FindByUserHook: func(int) (ident1 []model.Transaction, ident2 error) {
+			panic("Unexpected call to TransactionRepo.FindByUser")
+		},
+		FindByUserAccountHook: func(int, int) (ident1 []model.Transaction, ident2 error) {
+			panic("Unexpected call to TransactionRepo.FindByUserAccount")
+		},
+		CreateHook: func(*model.Transaction) (ident2 error) {
+			panic("Unexpected call to TransactionRepo.Create")
+		},
+		UpdateHook: func(*model.Transaction) (ident2 error) {
+			panic("Unexpected call to TransactionRepo.Update")
+		},
+		DeleteHook: func(int, int) (ident1 error) {
+			panic("Unexpected call to TransactionRepo.Delete")
+		},
+		EachByUserHook: func(int, *int, func(model.Transaction) error) (ident1 error) {
+			panic("Unexpected call to TransactionRepo.EachByUser")
+		},
+		FindPageHook: func(int, *int, int, int) (ident1 []model.Transaction, ident2 error) {
+			panic("Unexpected call to TransactionRepo.FindPage")
+		},
+	}
+}
+
+// NewFakeTransactionRepoDefaultFatal returns an instance of FakeTransactionRepo with all hooks configured to call t.Fatal
+func NewFakeTransactionRepoDefaultFatal(t_sym29 TransactionRepoTestingT) *FakeTransactionRepo {
+	return &FakeTransactionRepo{
+		FindByIDHook: func(int) (ident1 model.Transaction, ident2 error) {
+			t_sym29.Fatal("Unexpected call to TransactionRepo.FindByID")
+			return
+		},
+		FindByUserHook: func(int) (ident1 []model.Transaction, ident2 error) {
+			t_sym29.Fatal("Unexpected call to TransactionRepo.FindByUser")
+			return
+		},
+		FindByUserAccountHook: func(int, int) (ident1 []model.Transaction, ident2 error) {
+			t_sym29.Fatal("Unexpected call to TransactionRepo.FindByUserAccount")
+			return
+		},
+		CreateHook: func(*model.Transaction) (ident2 error) {
+			t_sym29.Fatal("Unexpected call to TransactionRepo.Create")
+			return
+		},
+		UpdateHook: func(*model.Transaction) (ident2 error) {
+			t_sym29.Fatal("Unexpected call to TransactionRepo.Update")
+			return
+		},
+		DeleteHook: func(int, int) (ident1 error) {
+			t_sym29.Fatal("Unexpected call to TransactionRepo.Delete")
+			return
+		},
+		EachByUserHook: func(int, *int, func(model.Transaction) error) (ident1 error) {
+			t_sym29.Fatal("Unexpected call to TransactionRepo.EachByUser")
+			return
+		},
+		FindPageHook: func(int, *int, int, int) (ident1 []model.Transaction, ident2 error) {
+			t_sym29.Fatal("Unexpected call to TransactionRepo.FindPage")
+			return
+		},
+	}
+}
+
+// NewFakeTransactionRepoDefaultError returns an instance of FakeTransactionRepo with all hooks configured to call t.Error
+func NewFakeTransactionRepoDefaultError(t_sym30 TransactionRepoTestingT) *FakeTransactionRepo {
+	return &FakeTransactionRepo{
+		FindByIDHook: func(int) (ident1 model.Transaction, ident2 error) {
+			t_sym30.Error("Unexpected call to TransactionRepo.FindByID")
+			return
+		},
+		FindByUserHook: func(int) (ident1 []model.Transaction, ident2 error) {
+			t_sym30.Error("Unexpected call to TransactionRepo.FindByUser")
+			return
+		},
+		FindByUserAccountHook: func(int, int) (ident1 []model.Transaction, ident2 error) {
+			t_sym30.Error("Unexpected call to TransactionRepo.FindByUserAccount")
+			return
+		},
+		CreateHook: func(*model.Transaction) (ident2 error) {
+			t_sym30.Error("Unexpected call to TransactionRepo.Create")
+			return
+		},
+		UpdateHook: func(*model.Transaction) (ident2 error) {
+			t_sym30.Error("Unexpected call to TransactionRepo.Update")
+			return
+		},
+		DeleteHook: func(int, int) (ident1 error) {
+			t_sym30.Error("Unexpected call to TransactionRepo.Delete")
+			return
+		},
+		EachByUserHook: func(int, *int, func(model.Transaction) error) (ident1 error) {
+			t_sym30.Error("Unexpected call to TransactionRepo.EachByUser")
+			return
+		},
+		FindPageHook: func(int, *int, int, int) (ident1 []model.Transaction, ident2 error) {
+			t_sym30.Error("Unexpected call to TransactionRepo.FindPage")
+			return
+		},
+	}
+}
+
+func (f *FakeTransactionRepo) Reset() {
+	f.FindByIDCalls = []*TransactionRepoFindByIDInvocation{}
+	f.FindByUserCalls = []*TransactionRepoFindByUserInvocation{}
+	f.FindByUserAccountCalls = []*TransactionRepoFindByUserAccountInvocation{}
+	f.CreateCalls = []*TransactionRepoCreateInvocation{}
+	f.UpdateCalls = []*TransactionRepoUpdateInvocation{}
+	f.DeleteCalls = []*TransactionRepoDeleteInvocation{}
+	f.EachByUserCalls = []*TransactionRepoEachByUserInvocation{}
+	f.FindPageCalls = []*TransactionRepoFindPageInvocation{}
+}
+
+func (f_sym31 *FakeTransactionRepo) FindByID(id int) (ident1 model.Transaction, ident2 error) {
+	if f_sym31.FindByIDHook == nil {
+		panic("TransactionRepo.FindByID() called but FakeTransactionRepo.FindByIDHook is nil")
+	}
+
+	invocation_sym31 := new(TransactionRepoFindByIDInvocation)
+	f_sym31.FindByIDCalls = append(f_sym31.FindByIDCalls, invocation_sym31)
+
+	invocation_sym31.Parameters.Id = id
+
+	ident1, ident2 = f_sym31.FindByIDHook(id)
+
+	invocation_sym31.Results.Ident1 = ident1
+	invocation_sym31.Results.Ident2 = ident2
+
+	return
+}
+
+// SetFindByIDStub configures TransactionRepo.FindByID to always return the given values
+func (f_sym32 *FakeTransactionRepo) SetFindByIDStub(ident1 model.Transaction, ident2 error) {
+	f_sym32.FindByIDHook = func(int) (model.Transaction, error) {
+		return ident1, ident2
+	}
+}
+
+// SetFindByIDInvocation configures TransactionRepo.FindByID to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym33 *FakeTransactionRepo) SetFindByIDInvocation(calls_sym33 []*TransactionRepoFindByIDInvocation, fallback_sym33 func() (model.Transaction, error)) {
+	f_sym33.FindByIDHook = func(id int) (ident1 model.Transaction, ident2 error) {
+		for _, call_sym33 := range calls_sym33 {
+			if reflect.DeepEqual(call_sym33.Parameters.Id, id) {
+				ident1 = call_sym33.Results.Ident1
+				ident2 = call_sym33.Results.Ident2
+
+				return
+			}
+		}
+
+		return fallback_sym33()
+	}
+}
+
+// FindByIDCalled returns true if FakeTransactionRepo.FindByID was called
+func (f *FakeTransactionRepo) FindByIDCalled() bool {
+	return len(f.FindByIDCalls) != 0
+}
+
+// AssertFindByIDCalled calls t.Error if FakeTransactionRepo.FindByID was not called
+func (f *FakeTransactionRepo) AssertFindByIDCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.FindByIDCalls) == 0 {
+		t.Error("FakeTransactionRepo.FindByID not called, expected at least one")
+	}
+}
+
+// FindByIDNotCalled returns true if FakeTransactionRepo.FindByID was not called
+func (f *FakeTransactionRepo) FindByIDNotCalled() bool {
+	return len(f.FindByIDCalls) == 0
+}
+
+// AssertFindByIDNotCalled calls t.Error if FakeTransactionRepo.FindByID was called
+func (f *FakeTransactionRepo) AssertFindByIDNotCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.FindByIDCalls) != 0 {
+		t.Error("FakeTransactionRepo.FindByID called, expected none")
+	}
+}
+
+// FindByIDCalledOnce returns true if FakeTransactionRepo.FindByID was called exactly once
+func (f *FakeTransactionRepo) FindByIDCalledOnce() bool {
+	return len(f.FindByIDCalls) == 1
+}
+
+// AssertFindByIDCalledOnce calls t.Error if FakeTransactionRepo.FindByID was not called exactly once
+func (f *FakeTransactionRepo) AssertFindByIDCalledOnce(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.FindByIDCalls) != 1 {
+		t.Errorf("FakeTransactionRepo.FindByID called %d times, expected 1", len(f.FindByIDCalls))
+	}
+}
+
+// FindByIDCalledN returns true if FakeTransactionRepo.FindByID was called at least n times
+func (f *FakeTransactionRepo) FindByIDCalledN(n int) bool {
+	return len(f.FindByIDCalls) >= n
+}
+
+// AssertFindByIDCalledN calls t.Error if FakeTransactionRepo.FindByID was called less than n times
+func (f *FakeTransactionRepo) AssertFindByIDCalledN(t TransactionRepoTestingT, n int) {
+	t.Helper()
+	if len(f.FindByIDCalls) < n {
+		t.Errorf("FakeTransactionRepo.FindByID called %d times, expected >= %d", len(f.FindByIDCalls), n)
+	}
+}
+
+// FindByIDCalledWith returns true if FakeTransactionRepo.FindByID was called with the given values
+func (f_sym34 *FakeTransactionRepo) FindByIDCalledWith(id int) bool {
+	for _, call_sym34 := range f_sym34.FindByIDCalls {
+		if reflect.DeepEqual(call_sym34.Parameters.Id, id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertFindByIDCalledWith calls t.Error if FakeTransactionRepo.FindByID was not called with the given values
+func (f_sym35 *FakeTransactionRepo) AssertFindByIDCalledWith(t TransactionRepoTestingT, id int) {
+	t.Helper()
+	var found_sym35 bool
+	for _, call_sym35 := range f_sym35.FindByIDCalls {
+		if reflect.DeepEqual(call_sym35.Parameters.Id, id) {
+			found_sym35 = true
+			break
+		}
+	}
+
+	if !found_sym35 {
+		t.Error("FakeTransactionRepo.FindByID not called with expected parameters")
+	}
+}
+
+// FindByIDCalledOnceWith returns true if FakeTransactionRepo.FindByID was called exactly once with the given values
+func (f_sym36 *FakeTransactionRepo) FindByIDCalledOnceWith(id int) bool {
+	var count_sym36 int
+	for _, call_sym36 := range f_sym36.FindByIDCalls {
+		if reflect.DeepEqual(call_sym36.Parameters.Id, id) {
+			count_sym36++
+		}
+	}
+
+	return count_sym36 == 1
+}
+
+// AssertFindByIDCalledOnceWith calls t.Error if FakeTransactionRepo.FindByID was not called exactly once with the given values
+func (f_sym37 *FakeTransactionRepo) AssertFindByIDCalledOnceWith(t TransactionRepoTestingT, id int) {
+	t.Helper()
+	var count_sym37 int
+	for _, call_sym37 := range f_sym37.FindByIDCalls {
+		if reflect.DeepEqual(call_sym37.Parameters.Id, id) {
+			count_sym37++
+		}
+	}
+
+	if count_sym37 != 1 {
+		t.Errorf("FakeTransactionRepo.FindByID called %d times with expected parameters, expected one", count_sym37)
+	}
+}
+
+// FindByIDResultsForCall returns the result values for the first call to FakeTransactionRepo.FindByID with the given values
+func (f_sym38 *FakeTransactionRepo) FindByIDResultsForCall(id int) (ident1 model.Transaction, ident2 error, found_sym38 bool) {
+	for _, call_sym38 := range f_sym38.FindByIDCalls {
+		if reflect.DeepEqual(call_sym38.Parameters.Id, id) {
+			ident1 = call_sym38.Results.Ident1
+			ident2 = call_sym38.Results.Ident2
+			found_sym38 = true
+			break
+		}
+	}
+
+	return
+}
+
+func (f_sym39 *FakeTransactionRepo) FindByUser(userID int) (ident1 []model.Transaction, ident2 error) {
+	if f_sym39.FindByUserHook == nil {
+		panic("TransactionRepo.FindByUser() called but FakeTransactionRepo.FindByUserHook is nil")
+	}
+
+	invocation_sym39 := new(TransactionRepoFindByUserInvocation)
+	f_sym39.FindByUserCalls = append(f_sym39.FindByUserCalls, invocation_sym39)
+
+	invocation_sym39.Parameters.UserID = userID
+
+	ident1, ident2 = f_sym39.FindByUserHook(userID)
+
+	invocation_sym39.Results.Ident1 = ident1
+	invocation_sym39.Results.Ident2 = ident2
+
+	return
+}
+
+// SetFindByUserStub configures TransactionRepo.FindByUser to always return the given values
+func (f_sym40 *FakeTransactionRepo) SetFindByUserStub(ident1 []model.Transaction, ident2 error) {
+	f_sym40.FindByUserHook = func(int) ([]model.Transaction, error) {
+		return ident1, ident2
+	}
+}
+
+// SetFindByUserInvocation configures TransactionRepo.FindByUser to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym41 *FakeTransactionRepo) SetFindByUserInvocation(calls_sym41 []*TransactionRepoFindByUserInvocation, fallback_sym41 func() ([]model.Transaction, error)) {
+	f_sym41.FindByUserHook = func(userID int) (ident1 []model.Transaction, ident2 error) {
+		for _, call_sym41 := range calls_sym41 {
+			if reflect.DeepEqual(call_sym41.Parameters.UserID, userID) {
+				ident1 = call_sym41.Results.Ident1
+				ident2 = call_sym41.Results.Ident2
+
+				return
+			}
+		}
+
+		return fallback_sym41()
+	}
+}
+
+// FindByUserCalled returns true if FakeTransactionRepo.FindByUser was called
+func (f *FakeTransactionRepo) FindByUserCalled() bool {
+	return len(f.FindByUserCalls) != 0
+}
+
+// AssertFindByUserCalled calls t.Error if FakeTransactionRepo.FindByUser was not called
+func (f *FakeTransactionRepo) AssertFindByUserCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.FindByUserCalls) == 0 {
+		t.Error("FakeTransactionRepo.FindByUser not called, expected at least one")
+	}
+}
+
+// FindByUserNotCalled returns true if FakeTransactionRepo.FindByUser was not called
+func (f *FakeTransactionRepo) FindByUserNotCalled() bool {
+	return len(f.FindByUserCalls) == 0
+}
+
+// AssertFindByUserNotCalled calls t.Error if FakeTransactionRepo.FindByUser was called
+func (f *FakeTransactionRepo) AssertFindByUserNotCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.FindByUserCalls) != 0 {
+		t.Error("FakeTransactionRepo.FindByUser called, expected none")
+	}
+}
+
+// FindByUserCalledOnce returns true if FakeTransactionRepo.FindByUser was called exactly once
+func (f *FakeTransactionRepo) FindByUserCalledOnce() bool {
+	return len(f.FindByUserCalls) == 1
+}
+
+// AssertFindByUserCalledOnce calls t.Error if FakeTransactionRepo.FindByUser was not called exactly once
+func (f *FakeTransactionRepo) AssertFindByUserCalledOnce(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.FindByUserCalls) != 1 {
+		t.Errorf("FakeTransactionRepo.FindByUser called %d times, expected 1", len(f.FindByUserCalls))
+	}
+}
+
+// FindByUserCalledN returns true if FakeTransactionRepo.FindByUser was called at least n times
+func (f *FakeTransactionRepo) FindByUserCalledN(n int) bool {
+	return len(f.FindByUserCalls) >= n
+}
+
+// AssertFindByUserCalledN calls t.Error if FakeTransactionRepo.FindByUser was called less than n times
+func (f *FakeTransactionRepo) AssertFindByUserCalledN(t TransactionRepoTestingT, n int) {
+	t.Helper()
+	if len(f.FindByUserCalls) < n {
+		t.Errorf("FakeTransactionRepo.FindByUser called %d times, expected >= %d", len(f.FindByUserCalls), n)
+	}
+}
+
+// FindByUserCalledWith returns true if FakeTransactionRepo.FindByUser was called with the given values
+func (f_sym42 *FakeTransactionRepo) FindByUserCalledWith(userID int) bool {
+	for _, call_sym42 := range f_sym42.FindByUserCalls {
+		if reflect.DeepEqual(call_sym42.Parameters.UserID, userID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertFindByUserCalledWith calls t.Error if FakeTransactionRepo.FindByUser was not called with the given values
+func (f_sym43 *FakeTransactionRepo) AssertFindByUserCalledWith(t TransactionRepoTestingT, userID int) {
+	t.Helper()
+	var found_sym43 bool
+	for _, call_sym43 := range f_sym43.FindByUserCalls {
+		if reflect.DeepEqual(call_sym43.Parameters.UserID, userID) {
+			found_sym43 = true
+			break
+		}
+	}
+
+	if !found_sym43 {
+		t.Error("FakeTransactionRepo.FindByUser not called with expected parameters")
+	}
+}
+
+// FindByUserCalledOnceWith returns true if FakeTransactionRepo.FindByUser was called exactly once with the given values
+func (f_sym44 *FakeTransactionRepo) FindByUserCalledOnceWith(userID int) bool {
+	var count_sym44 int
+	for _, call_sym44 := range f_sym44.FindByUserCalls {
+		if reflect.DeepEqual(call_sym44.Parameters.UserID, userID) {
+			count_sym44++
+		}
+	}
+
+	return count_sym44 == 1
+}
+
+// AssertFindByUserCalledOnceWith calls t.Error if FakeTransactionRepo.FindByUser was not called exactly once with the given values
+func (f_sym45 *FakeTransactionRepo) AssertFindByUserCalledOnceWith(t TransactionRepoTestingT, userID int) {
+	t.Helper()
+	var count_sym45 int
+	for _, call_sym45 := range f_sym45.FindByUserCalls {
+		if reflect.DeepEqual(call_sym45.Parameters.UserID, userID) {
+			count_sym45++
+		}
+	}
+
+	if count_sym45 != 1 {
+		t.Errorf("FakeTransactionRepo.FindByUser called %d times with expected parameters, expected one", count_sym45)
+	}
+}
+
+// FindByUserResultsForCall returns the result values for the first call to FakeTransactionRepo.FindByUser with the given values
+func (f_sym46 *FakeTransactionRepo) FindByUserResultsForCall(userID int) (ident1 []model.Transaction, ident2 error, found_sym46 bool) {
+	for _, call_sym46 := range f_sym46.FindByUserCalls {
+		if reflect.DeepEqual(call_sym46.Parameters.UserID, userID) {
+			ident1 = call_sym46.Results.Ident1
+			ident2 = call_sym46.Results.Ident2
+			found_sym46 = true
+			break
+		}
+	}
+
+	return
+}
+
+func (f_sym47 *FakeTransactionRepo) FindByUserAccount(userID int, accountID int) (ident1 []model.Transaction, ident2 error) {
+	if f_sym47.FindByUserAccountHook == nil {
+		panic("TransactionRepo.FindByUserAccount() called but FakeTransactionRepo.FindByUserAccountHook is nil")
+	}
+
+	invocation_sym47 := new(TransactionRepoFindByUserAccountInvocation)
+	f_sym47.FindByUserAccountCalls = append(f_sym47.FindByUserAccountCalls, invocation_sym47)
+
+	invocation_sym47.Parameters.UserID = userID
+	invocation_sym47.Parameters.AccountID = accountID
+
+	ident1, ident2 = f_sym47.FindByUserAccountHook(userID, accountID)
+
+	invocation_sym47.Results.Ident1 = ident1
+	invocation_sym47.Results.Ident2 = ident2
+
+	return
+}
+
+// SetFindByUserAccountStub configures TransactionRepo.FindByUserAccount to always return the given values
+func (f_sym48 *FakeTransactionRepo) SetFindByUserAccountStub(ident1 []model.Transaction, ident2 error) {
+	f_sym48.FindByUserAccountHook = func(int, int) ([]model.Transaction, error) {
+		return ident1, ident2
+	}
+}
+
+// SetFindByUserAccountInvocation configures TransactionRepo.FindByUserAccount to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym49 *FakeTransactionRepo) SetFindByUserAccountInvocation(calls_sym49 []*TransactionRepoFindByUserAccountInvocation, fallback_sym49 func() ([]model.Transaction, error)) {
+	f_sym49.FindByUserAccountHook = func(userID int, accountID int) (ident1 []model.Transaction, ident2 error) {
+		for _, call_sym49 := range calls_sym49 {
+			if reflect.DeepEqual(call_sym49.Parameters.UserID, userID) && reflect.DeepEqual(call_sym49.Parameters.AccountID, accountID) {
+				ident1 = call_sym49.Results.Ident1
+				ident2 = call_sym49.Results.Ident2
+
+				return
+			}
+		}
+
+		return fallback_sym49()
+	}
+}
+
+// FindByUserAccountCalled returns true if FakeTransactionRepo.FindByUserAccount was called
+func (f *FakeTransactionRepo) FindByUserAccountCalled() bool {
+	return len(f.FindByUserAccountCalls) != 0
+}
+
+// AssertFindByUserAccountCalled calls t.Error if FakeTransactionRepo.FindByUserAccount was not called
+func (f *FakeTransactionRepo) AssertFindByUserAccountCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.FindByUserAccountCalls) == 0 {
+		t.Error("FakeTransactionRepo.FindByUserAccount not called, expected at least one")
+	}
+}
+
+// FindByUserAccountNotCalled returns true if FakeTransactionRepo.FindByUserAccount was not called
+func (f *FakeTransactionRepo) FindByUserAccountNotCalled() bool {
+	return len(f.FindByUserAccountCalls) == 0
+}
+
+// AssertFindByUserAccountNotCalled calls t.Error if FakeTransactionRepo.FindByUserAccount was called
+func (f *FakeTransactionRepo) AssertFindByUserAccountNotCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.FindByUserAccountCalls) != 0 {
+		t.Error("FakeTransactionRepo.FindByUserAccount called, expected none")
+	}
+}
+
+// FindByUserAccountCalledOnce returns true if FakeTransactionRepo.FindByUserAccount was called exactly once
+func (f *FakeTransactionRepo) FindByUserAccountCalledOnce() bool {
+	return len(f.FindByUserAccountCalls) == 1
+}
+
+// AssertFindByUserAccountCalledOnce calls t.Error if FakeTransactionRepo.FindByUserAccount was not called exactly once
+func (f *FakeTransactionRepo) AssertFindByUserAccountCalledOnce(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.FindByUserAccountCalls) != 1 {
+		t.Errorf("FakeTransactionRepo.FindByUserAccount called %d times, expected 1", len(f.FindByUserAccountCalls))
+	}
+}
+
+// FindByUserAccountCalledN returns true if FakeTransactionRepo.FindByUserAccount was called at least n times
+func (f *FakeTransactionRepo) FindByUserAccountCalledN(n int) bool {
+	return len(f.FindByUserAccountCalls) >= n
+}
+
+// AssertFindByUserAccountCalledN calls t.Error if FakeTransactionRepo.FindByUserAccount was called less than n times
+func (f *FakeTransactionRepo) AssertFindByUserAccountCalledN(t TransactionRepoTestingT, n int) {
+	t.Helper()
+	if len(f.FindByUserAccountCalls) < n {
+		t.Errorf("FakeTransactionRepo.FindByUserAccount called %d times, expected >= %d", len(f.FindByUserAccountCalls), n)
+	}
+}
+
+// FindByUserAccountCalledWith returns true if FakeTransactionRepo.FindByUserAccount was called with the given values
+func (f_sym50 *FakeTransactionRepo) FindByUserAccountCalledWith(userID int, accountID int) bool {
+	for _, call_sym50 := range f_sym50.FindByUserAccountCalls {
+		if reflect.DeepEqual(call_sym50.Parameters.UserID, userID) && reflect.DeepEqual(call_sym50.Parameters.AccountID, accountID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertFindByUserAccountCalledWith calls t.Error if FakeTransactionRepo.FindByUserAccount was not called with the given values
+func (f_sym51 *FakeTransactionRepo) AssertFindByUserAccountCalledWith(t TransactionRepoTestingT, userID int, accountID int) {
+	t.Helper()
+	var found_sym51 bool
+	for _, call_sym51 := range f_sym51.FindByUserAccountCalls {
+		if reflect.DeepEqual(call_sym51.Parameters.UserID, userID) && reflect.DeepEqual(call_sym51.Parameters.AccountID, accountID) {
+			found_sym51 = true
+			break
+		}
+	}
+
+	if !found_sym51 {
+		t.Error("FakeTransactionRepo.FindByUserAccount not called with expected parameters")
+	}
+}
+
+// FindByUserAccountCalledOnceWith returns true if FakeTransactionRepo.FindByUserAccount was called exactly once with the given values
+func (f_sym52 *FakeTransactionRepo) FindByUserAccountCalledOnceWith(userID int, accountID int) bool {
+	var count_sym52 int
+	for _, call_sym52 := range f_sym52.FindByUserAccountCalls {
+		if reflect.DeepEqual(call_sym52.Parameters.UserID, userID) && reflect.DeepEqual(call_sym52.Parameters.AccountID, accountID) {
+			count_sym52++
+		}
+	}
+
+	return count_sym52 == 1
+}
+
+// AssertFindByUserAccountCalledOnceWith calls t.Error if FakeTransactionRepo.FindByUserAccount was not called exactly once with the given values
+func (f_sym53 *FakeTransactionRepo) AssertFindByUserAccountCalledOnceWith(t TransactionRepoTestingT, userID int, accountID int) {
+	t.Helper()
+	var count_sym53 int
+	for _, call_sym53 := range f_sym53.FindByUserAccountCalls {
+		if reflect.DeepEqual(call_sym53.Parameters.UserID, userID) && reflect.DeepEqual(call_sym53.Parameters.AccountID, accountID) {
+			count_sym53++
+		}
+	}
+
+	if count_sym53 != 1 {
+		t.Errorf("FakeTransactionRepo.FindByUserAccount called %d times with expected parameters, expected one", count_sym53)
+	}
+}
+
+// FindByUserAccountResultsForCall returns the result values for the first call to FakeTransactionRepo.FindByUserAccount with the given values
+func (f_sym54 *FakeTransactionRepo) FindByUserAccountResultsForCall(userID int, accountID int) (ident1 []model.Transaction, ident2 error, found_sym54 bool) {
+	for _, call_sym54 := range f_sym54.FindByUserAccountCalls {
+		if reflect.DeepEqual(call_sym54.Parameters.UserID, userID) && reflect.DeepEqual(call_sym54.Parameters.AccountID, accountID) {
+			ident1 = call_sym54.Results.Ident1
+			ident2 = call_sym54.Results.Ident2
+			found_sym54 = true
+			break
+		}
+	}
+
+	return
+}
+
+func (f_sym55 *FakeTransactionRepo) Create(ident1 *model.Transaction) (ident2 error) {
+	if f_sym55.CreateHook == nil {
+		panic("TransactionRepo.Create() called but FakeTransactionRepo.CreateHook is nil")
+	}
+
+	invocation_sym55 := new(TransactionRepoCreateInvocation)
+	f_sym55.CreateCalls = append(f_sym55.CreateCalls, invocation_sym55)
+
+	invocation_sym55.Parameters.Ident1 = ident1
+
+	ident2 = f_sym55.CreateHook(ident1)
+
+	invocation_sym55.Results.Ident2 = ident2
+
+	return
+}
+
+// SetCreateStub configures TransactionRepo.Create to always return the given values
+func (f_sym56 *FakeTransactionRepo) SetCreateStub(ident2 error) {
+	f_sym56.CreateHook = func(*model.Transaction) error {
+		return ident2
+	}
+}
+
+// SetCreateInvocation configures TransactionRepo.Create to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym57 *FakeTransactionRepo) SetCreateInvocation(calls_sym57 []*TransactionRepoCreateInvocation, fallback_sym57 func() error) {
+	f_sym57.CreateHook = func(ident1 *model.Transaction) (ident2 error) {
+		for _, call_sym57 := range calls_sym57 {
+			if reflect.DeepEqual(call_sym57.Parameters.Ident1, ident1) {
+				ident2 = call_sym57.Results.Ident2
+
+				return
+			}
+		}
+
+		return fallback_sym57()
+	}
+}
+
+// CreateCalled returns true if FakeTransactionRepo.Create was called
+func (f *FakeTransactionRepo) CreateCalled() bool {
+	return len(f.CreateCalls) != 0
+}
+
+// AssertCreateCalled calls t.Error if FakeTransactionRepo.Create was not called
+func (f *FakeTransactionRepo) AssertCreateCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.CreateCalls) == 0 {
+		t.Error("FakeTransactionRepo.Create not called, expected at least one")
+	}
+}
+
+// CreateNotCalled returns true if FakeTransactionRepo.Create was not called
+func (f *FakeTransactionRepo) CreateNotCalled() bool {
+	return len(f.CreateCalls) == 0
+}
+
+// AssertCreateNotCalled calls t.Error if FakeTransactionRepo.Create was called
+func (f *FakeTransactionRepo) AssertCreateNotCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.CreateCalls) != 0 {
+		t.Error("FakeTransactionRepo.Create called, expected none")
+	}
+}
+
+// CreateCalledOnce returns true if FakeTransactionRepo.Create was called exactly once
+func (f *FakeTransactionRepo) CreateCalledOnce() bool {
+	return len(f.CreateCalls) == 1
+}
+
+// AssertCreateCalledOnce calls t.Error if FakeTransactionRepo.Create was not called exactly once
+func (f *FakeTransactionRepo) AssertCreateCalledOnce(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.CreateCalls) != 1 {
+		t.Errorf("FakeTransactionRepo.Create called %d times, expected 1", len(f.CreateCalls))
+	}
+}
+
+// CreateCalledN returns true if FakeTransactionRepo.Create was called at least n times
+func (f *FakeTransactionRepo) CreateCalledN(n int) bool {
+	return len(f.CreateCalls) >= n
+}
+
+// AssertCreateCalledN calls t.Error if FakeTransactionRepo.Create was called less than n times
+func (f *FakeTransactionRepo) AssertCreateCalledN(t TransactionRepoTestingT, n int) {
+	t.Helper()
+	if len(f.CreateCalls) < n {
+		t.Errorf("FakeTransactionRepo.Create called %d times, expected >= %d", len(f.CreateCalls), n)
+	}
+}
+
+// CreateCalledWith returns true if FakeTransactionRepo.Create was called with the given values
+func (f_sym58 *FakeTransactionRepo) CreateCalledWith(ident1 *model.Transaction) bool {
+	for _, call_sym58 := range f_sym58.CreateCalls {
+		if reflect.DeepEqual(call_sym58.Parameters.Ident1, ident1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertCreateCalledWith calls t.Error if FakeTransactionRepo.Create was not called with the given values
+func (f_sym59 *FakeTransactionRepo) AssertCreateCalledWith(t TransactionRepoTestingT, ident1 *model.Transaction) {
+	t.Helper()
+	var found_sym59 bool
+	for _, call_sym59 := range f_sym59.CreateCalls {
+		if reflect.DeepEqual(call_sym59.Parameters.Ident1, ident1) {
+			found_sym59 = true
+			break
+		}
+	}
+
+	if !found_sym59 {
+		t.Error("FakeTransactionRepo.Create not called with expected parameters")
+	}
+}
+
+// CreateCalledOnceWith returns true if FakeTransactionRepo.Create was called exactly once with the given values
+func (f_sym60 *FakeTransactionRepo) CreateCalledOnceWith(ident1 *model.Transaction) bool {
+	var count_sym60 int
+	for _, call_sym60 := range f_sym60.CreateCalls {
+		if reflect.DeepEqual(call_sym60.Parameters.Ident1, ident1) {
+			count_sym60++
+		}
+	}
+
+	return count_sym60 == 1
+}
+
+// AssertCreateCalledOnceWith calls t.Error if FakeTransactionRepo.Create was not called exactly once with the given values
+func (f_sym61 *FakeTransactionRepo) AssertCreateCalledOnceWith(t TransactionRepoTestingT, ident1 *model.Transaction) {
+	t.Helper()
+	var count_sym61 int
+	for _, call_sym61 := range f_sym61.CreateCalls {
+		if reflect.DeepEqual(call_sym61.Parameters.Ident1, ident1) {
+			count_sym61++
+		}
+	}
+
+	if count_sym61 != 1 {
+		t.Errorf("FakeTransactionRepo.Create called %d times with expected parameters, expected one", count_sym61)
+	}
+}
+
+// CreateResultsForCall returns the result values for the first call to FakeTransactionRepo.Create with the given values
+func (f_sym62 *FakeTransactionRepo) CreateResultsForCall(ident1 *model.Transaction) (ident2 error, found_sym62 bool) {
+	for _, call_sym62 := range f_sym62.CreateCalls {
+		if reflect.DeepEqual(call_sym62.Parameters.Ident1, ident1) {
+			ident2 = call_sym62.Results.Ident2
+			found_sym62 = true
+			break
+		}
+	}
+
+	return
+}
+
+func (f_sym63 *FakeTransactionRepo) Update(ident1 *model.Transaction) (ident2 error) {
+	if f_sym63.UpdateHook == nil {
+		panic("TransactionRepo.Update() called but FakeTransactionRepo.UpdateHook is nil")
+	}
+
+	invocation_sym63 := new(TransactionRepoUpdateInvocation)
+	f_sym63.UpdateCalls = append(f_sym63.UpdateCalls, invocation_sym63)
+
+	invocation_sym63.Parameters.Ident1 = ident1
+
+	ident2 = f_sym63.UpdateHook(ident1)
+
+	invocation_sym63.Results.Ident2 = ident2
+
+	return
+}
+
+// SetUpdateStub configures TransactionRepo.Update to always return the given values
+func (f_sym64 *FakeTransactionRepo) SetUpdateStub(ident2 error) {
+	f_sym64.UpdateHook = func(*model.Transaction) error {
+		return ident2
+	}
+}
+
+// SetUpdateInvocation configures TransactionRepo.Update to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym65 *FakeTransactionRepo) SetUpdateInvocation(calls_sym65 []*TransactionRepoUpdateInvocation, fallback_sym65 func() error) {
+	f_sym65.UpdateHook = func(ident1 *model.Transaction) (ident2 error) {
+		for _, call_sym65 := range calls_sym65 {
+			if reflect.DeepEqual(call_sym65.Parameters.Ident1, ident1) {
+				ident2 = call_sym65.Results.Ident2
+
+				return
+			}
+		}
+
+		return fallback_sym65()
+	}
+}
+
+// UpdateCalled returns true if FakeTransactionRepo.Update was called
+func (f *FakeTransactionRepo) UpdateCalled() bool {
+	return len(f.UpdateCalls) != 0
+}
+
+// AssertUpdateCalled calls t.Error if FakeTransactionRepo.Update was not called
+func (f *FakeTransactionRepo) AssertUpdateCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.UpdateCalls) == 0 {
+		t.Error("FakeTransactionRepo.Update not called, expected at least one")
+	}
+}
+
+// UpdateNotCalled returns true if FakeTransactionRepo.Update was not called
+func (f *FakeTransactionRepo) UpdateNotCalled() bool {
+	return len(f.UpdateCalls) == 0
+}
+
+// AssertUpdateNotCalled calls t.Error if FakeTransactionRepo.Update was called
+func (f *FakeTransactionRepo) AssertUpdateNotCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.UpdateCalls) != 0 {
+		t.Error("FakeTransactionRepo.Update called, expected none")
+	}
+}
+
+// UpdateCalledOnce returns true if FakeTransactionRepo.Update was called exactly once
+func (f *FakeTransactionRepo) UpdateCalledOnce() bool {
+	return len(f.UpdateCalls) == 1
+}
+
+// AssertUpdateCalledOnce calls t.Error if FakeTransactionRepo.Update was not called exactly once
+func (f *FakeTransactionRepo) AssertUpdateCalledOnce(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.UpdateCalls) != 1 {
+		t.Errorf("FakeTransactionRepo.Update called %d times, expected 1", len(f.UpdateCalls))
+	}
+}
+
+// UpdateCalledN returns true if FakeTransactionRepo.Update was called at least n times
+func (f *FakeTransactionRepo) UpdateCalledN(n int) bool {
+	return len(f.UpdateCalls) >= n
+}
+
+// AssertUpdateCalledN calls t.Error if FakeTransactionRepo.Update was called less than n times
+func (f *FakeTransactionRepo) AssertUpdateCalledN(t TransactionRepoTestingT, n int) {
+	t.Helper()
+	if len(f.UpdateCalls) < n {
+		t.Errorf("FakeTransactionRepo.Update called %d times, expected >= %d", len(f.UpdateCalls), n)
+	}
+}
+
+// UpdateCalledWith returns true if FakeTransactionRepo.Update was called with the given values
+func (f_sym66 *FakeTransactionRepo) UpdateCalledWith(ident1 *model.Transaction) bool {
+	for _, call_sym66 := range f_sym66.UpdateCalls {
+		if reflect.DeepEqual(call_sym66.Parameters.Ident1, ident1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertUpdateCalledWith calls t.Error if FakeTransactionRepo.Update was not called with the given values
+func (f_sym67 *FakeTransactionRepo) AssertUpdateCalledWith(t TransactionRepoTestingT, ident1 *model.Transaction) {
+	t.Helper()
+	var found_sym67 bool
+	for _, call_sym67 := range f_sym67.UpdateCalls {
+		if reflect.DeepEqual(call_sym67.Parameters.Ident1, ident1) {
+			found_sym67 = true
+			break
+		}
+	}
+
+	if !found_sym67 {
+		t.Error("FakeTransactionRepo.Update not called with expected parameters")
+	}
+}
+
+// UpdateCalledOnceWith returns true if FakeTransactionRepo.Update was called exactly once with the given values
+func (f_sym68 *FakeTransactionRepo) UpdateCalledOnceWith(ident1 *model.Transaction) bool {
+	var count_sym68 int
+	for _, call_sym68 := range f_sym68.UpdateCalls {
+		if reflect.DeepEqual(call_sym68.Parameters.Ident1, ident1) {
+			count_sym68++
+		}
+	}
+
+	return count_sym68 == 1
+}
+
+// AssertUpdateCalledOnceWith calls t.Error if FakeTransactionRepo.Update was not called exactly once with the given values
+func (f_sym69 *FakeTransactionRepo) AssertUpdateCalledOnceWith(t TransactionRepoTestingT, ident1 *model.Transaction) {
+	t.Helper()
+	var count_sym69 int
+	for _, call_sym69 := range f_sym69.UpdateCalls {
+		if reflect.DeepEqual(call_sym69.Parameters.Ident1, ident1) {
+			count_sym69++
+		}
+	}
+
+	if count_sym69 != 1 {
+		t.Errorf("FakeTransactionRepo.Update called %d times with expected parameters, expected one", count_sym69)
+	}
+}
+
+// UpdateResultsForCall returns the result values for the first call to FakeTransactionRepo.Update with the given values
+func (f_sym70 *FakeTransactionRepo) UpdateResultsForCall(ident1 *model.Transaction) (ident2 error, found_sym70 bool) {
+	for _, call_sym70 := range f_sym70.UpdateCalls {
+		if reflect.DeepEqual(call_sym70.Parameters.Ident1, ident1) {
+			ident2 = call_sym70.Results.Ident2
+			found_sym70 = true
+			break
+		}
+	}
+
+	return
+}
+
+func (f_sym71 *FakeTransactionRepo) Delete(userID int, tranID int) (ident1 error) {
+	if f_sym71.DeleteHook == nil {
+		panic("TransactionRepo.Delete() called but FakeTransactionRepo.DeleteHook is nil")
+	}
+
+	invocation_sym71 := new(TransactionRepoDeleteInvocation)
+	f_sym71.DeleteCalls = append(f_sym71.DeleteCalls, invocation_sym71)
+
+	invocation_sym71.Parameters.UserID = userID
+	invocation_sym71.Parameters.TranID = tranID
+
+	ident1 = f_sym71.DeleteHook(userID, tranID)
+
+	invocation_sym71.Results.Ident1 = ident1
+
+	return
+}
+
+// SetDeleteStub configures TransactionRepo.Delete to always return the given values
+func (f_sym72 *FakeTransactionRepo) SetDeleteStub(ident1 error) {
+	f_sym72.DeleteHook = func(int, int) error {
+		return ident1
+	}
+}
+
+// SetDeleteInvocation configures TransactionRepo.Delete to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym73 *FakeTransactionRepo) SetDeleteInvocation(calls_sym73 []*TransactionRepoDeleteInvocation, fallback_sym73 func() error) {
+	f_sym73.DeleteHook = func(userID int, tranID int) (ident1 error) {
+		for _, call_sym73 := range calls_sym73 {
+			if reflect.DeepEqual(call_sym73.Parameters.UserID, userID) && reflect.DeepEqual(call_sym73.Parameters.TranID, tranID) {
+				ident1 = call_sym73.Results.Ident1
+
+				return
+			}
+		}
+
+		return fallback_sym73()
+	}
+}
+
+// DeleteCalled returns true if FakeTransactionRepo.Delete was called
+func (f *FakeTransactionRepo) DeleteCalled() bool {
+	return len(f.DeleteCalls) != 0
+}
+
+// AssertDeleteCalled calls t.Error if FakeTransactionRepo.Delete was not called
+func (f *FakeTransactionRepo) AssertDeleteCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.DeleteCalls) == 0 {
+		t.Error("FakeTransactionRepo.Delete not called, expected at least one")
+	}
+}
+
+// DeleteNotCalled returns true if FakeTransactionRepo.Delete was not called
+func (f *FakeTransactionRepo) DeleteNotCalled() bool {
+	return len(f.DeleteCalls) == 0
+}
+
+// AssertDeleteNotCalled calls t.Error if FakeTransactionRepo.Delete was called
+func (f *FakeTransactionRepo) AssertDeleteNotCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.DeleteCalls) != 0 {
+		t.Error("FakeTransactionRepo.Delete called, expected none")
+	}
+}
+
+// DeleteCalledOnce returns true if FakeTransactionRepo.Delete was called exactly once
+func (f *FakeTransactionRepo) DeleteCalledOnce() bool {
+	return len(f.DeleteCalls) == 1
+}
+
+// AssertDeleteCalledOnce calls t.Error if FakeTransactionRepo.Delete was not called exactly once
+func (f *FakeTransactionRepo) AssertDeleteCalledOnce(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.DeleteCalls) != 1 {
+		t.Errorf("FakeTransactionRepo.Delete called %d times, expected 1", len(f.DeleteCalls))
+	}
+}
+
+// DeleteCalledN returns true if FakeTransactionRepo.Delete was called at least n times
+func (f *FakeTransactionRepo) DeleteCalledN(n int) bool {
+	return len(f.DeleteCalls) >= n
+}
+
+// AssertDeleteCalledN calls t.Error if FakeTransactionRepo.Delete was called less than n times
+func (f *FakeTransactionRepo) AssertDeleteCalledN(t TransactionRepoTestingT, n int) {
+	t.Helper()
+	if len(f.DeleteCalls) < n {
+		t.Errorf("FakeTransactionRepo.Delete called %d times, expected >= %d", len(f.DeleteCalls), n)
+	}
+}
+
+// DeleteCalledWith returns true if FakeTransactionRepo.Delete was called with the given values
+func (f_sym74 *FakeTransactionRepo) DeleteCalledWith(userID int, tranID int) bool {
+	for _, call_sym74 := range f_sym74.DeleteCalls {
+		if reflect.DeepEqual(call_sym74.Parameters.UserID, userID) && reflect.DeepEqual(call_sym74.Parameters.TranID, tranID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertDeleteCalledWith calls t.Error if FakeTransactionRepo.Delete was not called with the given values
+func (f_sym75 *FakeTransactionRepo) AssertDeleteCalledWith(t TransactionRepoTestingT, userID int, tranID int) {
+	t.Helper()
+	var found_sym75 bool
+	for _, call_sym75 := range f_sym75.DeleteCalls {
+		if reflect.DeepEqual(call_sym75.Parameters.UserID, userID) && reflect.DeepEqual(call_sym75.Parameters.TranID, tranID) {
+			found_sym75 = true
+			break
+		}
+	}
+
+	if !found_sym75 {
+		t.Error("FakeTransactionRepo.Delete not called with expected parameters")
+	}
+}
+
+// DeleteCalledOnceWith returns true if FakeTransactionRepo.Delete was called exactly once with the given values
+func (f_sym76 *FakeTransactionRepo) DeleteCalledOnceWith(userID int, tranID int) bool {
+	var count_sym76 int
+	for _, call_sym76 := range f_sym76.DeleteCalls {
+		if reflect.DeepEqual(call_sym76.Parameters.UserID, userID) && reflect.DeepEqual(call_sym76.Parameters.TranID, tranID) {
+			count_sym76++
+		}
+	}
+
+	return count_sym76 == 1
 }
 
-// AssertFindByUserCalledOnceWith calls t.Error if FakeAccountRepo.FindByUser was not called exactly once with the given values
-func (f_sym19 *FakeAccountRepo) AssertFindByUserCalledOnceWith(t AccountRepoTestingT, userID int) {
+// AssertDeleteCalledOnceWith calls t.Error if FakeTransactionRepo.Delete was not called exactly once with the given values
+func (f_sym77 *FakeTransactionRepo) AssertDeleteCalledOnceWith(t TransactionRepoTestingT, userID int, tranID int) {
 	t.Helper()
-	var count_sym19 int
-	for _, call_sym19 := range f_sym19.FindByUserCalls {
-		if reflect.DeepEqual(call_sym19.Parameters.UserID, userID) {
-			count_sym19++
+	var count_sym77 int
+	for _, call_sym77 := range f_sym77.DeleteCalls {
+		if reflect.DeepEqual(call_sym77.Parameters.UserID, userID) && reflect.DeepEqual(call_sym77.Parameters.TranID, tranID) {
+			count_sym77++
 		}
 	}
 
-	if count_sym19 != 1 {
-		t.Errorf("FakeAccountRepo.FindByUser called %d times with expected parameters, expected one", count_sym19)
+	if count_sym77 != 1 {
+		t.Errorf("FakeTransactionRepo.Delete called %d times with expected parameters, expected one", count_sym77)
 	}
 }
 
-// FindByUserResultsForCall returns the result values for the first call to FakeAccountRepo.FindByUser with the given values
-func (f_sym20 *FakeAccountRepo) FindByUserResultsForCall(userID int) (ident1 []model.Account, ident2 error, found_sym20 bool) {
-	for _, call_sym20 := range f_sym20.FindByUserCalls {
-		if reflect.DeepEqual(call_sym20.Parameters.UserID, userID) {
-			ident1 = call_sym20.Results.Ident1
-			ident2 = call_sym20.Results.Ident2
-			found_sym20 = true
+// DeleteResultsForCall returns the result values for the first call to FakeTransactionRepo.Delete with the given values
+func (f_sym78 *FakeTransactionRepo) DeleteResultsForCall(userID int, tranID int) (ident1 error, found_sym78 bool) {
+	for _, call_sym78 := range f_sym78.DeleteCalls {
+		if reflect.DeepEqual(call_sym78.Parameters.UserID, userID) && reflect.DeepEqual(call_sym78.Parameters.TranID, tranID) {
+			ident1 = call_sym78.Results.Ident1
+			found_sym78 = true
 			break
 		}
 	}
@@ -554,104 +3018,104 @@ func (f_sym20 *FakeAccountRepo) FindByUserResultsForCall(userID int) (ident1 []m
 	return
 }
 
-func (f_sym21 *FakeAccountRepo) FindByID(id int) (ident1 model.Account, ident2 error) {
-	if f_sym21.FindByIDHook == nil {
-		panic("AccountRepo.FindByID() called but FakeAccountRepo.FindByIDHook is nil")
+func (f_sym79 *FakeTransactionRepo) EachByUser(userID int, accountID *int, fn func(model.Transaction) error) (ident1 error) {
+	if f_sym79.EachByUserHook == nil {
+		panic("TransactionRepo.EachByUser() called but FakeTransactionRepo.EachByUserHook is nil")
 	}
 
-	invocation_sym21 := new(AccountRepoFindByIDInvocation)
-	f_sym21.FindByIDCalls = append(f_sym21.FindByIDCalls, invocation_sym21)
+	invocation_sym79 := new(TransactionRepoEachByUserInvocation)
+	f_sym79.EachByUserCalls = append(f_sym79.EachByUserCalls, invocation_sym79)
 
-	invocation_sym21.Parameters.Id = id
+	invocation_sym79.Parameters.UserID = userID
+	invocation_sym79.Parameters.AccountID = accountID
+	invocation_sym79.Parameters.Fn = fn
 
-	ident1, ident2 = f_sym21.FindByIDHook(id)
+	ident1 = f_sym79.EachByUserHook(userID, accountID, fn)
 
-	invocation_sym21.Results.Ident1 = ident1
-	invocation_sym21.Results.Ident2 = ident2
+	invocation_sym79.Results.Ident1 = ident1
 
 	return
 }
 
-// SetFindByIDStub configures AccountRepo.FindByID to always return the given values
-func (f_sym22 *FakeAccountRepo) SetFindByIDStub(ident1 model.Account, ident2 error) {
-	f_sym22.FindByIDHook = func(int) (model.Account, error) {
-		return ident1, ident2
+// SetEachByUserStub configures TransactionRepo.EachByUser to always return the given values
+func (f_sym80 *FakeTransactionRepo) SetEachByUserStub(ident1 error) {
+	f_sym80.EachByUserHook = func(int, *int, func(model.Transaction) error) error {
+		return ident1
 	}
 }
 
-// SetFindByIDInvocation configures AccountRepo.FindByID to return the given results when called with the given parameters
+// SetEachByUserInvocation configures TransactionRepo.EachByUser to return the given results when called with the given parameters
 // If no match is found for an invocation the result(s) of the fallback function are returned
-func (f_sym23 *FakeAccountRepo) SetFindByIDInvocation(calls_sym23 []*AccountRepoFindByIDInvocation, fallback_sym23 func() (model.Account, error)) {
-	f_sym23.FindByIDHook = func(id int) (ident1 model.Account, ident2 error) {
-		for _, call_sym23 := range calls_sym23 {
-			if reflect.DeepEqual(call_sym23.Parameters.Id, id) {
-				ident1 = call_sym23.Results.Ident1
-				ident2 = call_sym23.Results.Ident2
+func (f_sym81 *FakeTransactionRepo) SetEachByUserInvocation(calls_sym81 []*TransactionRepoEachByUserInvocation, fallback_sym81 func() error) {
+	f_sym81.EachByUserHook = func(userID int, accountID *int, fn func(model.Transaction) error) (ident1 error) {
+		for _, call_sym81 := range calls_sym81 {
+			if reflect.DeepEqual(call_sym81.Parameters.UserID, userID) && reflect.DeepEqual(call_sym81.Parameters.AccountID, accountID) && reflect.DeepEqual(call_sym81.Parameters.Fn, fn) {
+				ident1 = call_sym81.Results.Ident1
 
 				return
 			}
 		}
 
-		return fallback_sym23()
+		return fallback_sym81()
 	}
 }
 
-// FindByIDCalled returns true if FakeAccountRepo.FindByID was called
-func (f *FakeAccountRepo) FindByIDCalled() bool {
-	return len(f.FindByIDCalls) != 0
+// EachByUserCalled returns true if FakeTransactionRepo.EachByUser was called
+func (f *FakeTransactionRepo) EachByUserCalled() bool {
+	return len(f.EachByUserCalls) != 0
 }
 
-// AssertFindByIDCalled calls t.Error if FakeAccountRepo.FindByID was not called
-func (f *FakeAccountRepo) AssertFindByIDCalled(t AccountRepoTestingT) {
+// AssertEachByUserCalled calls t.Error if FakeTransactionRepo.EachByUser was not called
+func (f *FakeTransactionRepo) AssertEachByUserCalled(t TransactionRepoTestingT) {
 	t.Helper()
-	if len(f.FindByIDCalls) == 0 {
-		t.Error("FakeAccountRepo.FindByID not called, expected at least one")
+	if len(f.EachByUserCalls) == 0 {
+		t.Error("FakeTransactionRepo.EachByUser not called, expected at least one")
 	}
 }
 
-// FindByIDNotCalled returns true if FakeAccountRepo.FindByID was not called
-func (f *FakeAccountRepo) FindByIDNotCalled() bool {
-	return len(f.FindByIDCalls) == 0
+// EachByUserNotCalled returns true if FakeTransactionRepo.EachByUser was not called
+func (f *FakeTransactionRepo) EachByUserNotCalled() bool {
+	return len(f.EachByUserCalls) == 0
 }
 
-// AssertFindByIDNotCalled calls t.Error if FakeAccountRepo.FindByID was called
-func (f *FakeAccountRepo) AssertFindByIDNotCalled(t AccountRepoTestingT) {
+// AssertEachByUserNotCalled calls t.Error if FakeTransactionRepo.EachByUser was called
+func (f *FakeTransactionRepo) AssertEachByUserNotCalled(t TransactionRepoTestingT) {
 	t.Helper()
-	if len(f.FindByIDCalls) != 0 {
-		t.Error("FakeAccountRepo.FindByID called, expected none")
+	if len(f.EachByUserCalls) != 0 {
+		t.Error("FakeTransactionRepo.EachByUser called, expected none")
 	}
 }
 
-// FindByIDCalledOnce returns true if FakeAccountRepo.FindByID was called exactly once
-func (f *FakeAccountRepo) FindByIDCalledOnce() bool {
-	return len(f.FindByIDCalls) == 1
+// EachByUserCalledOnce returns true if FakeTransactionRepo.EachByUser was called exactly once
+func (f *FakeTransactionRepo) EachByUserCalledOnce() bool {
+	return len(f.EachByUserCalls) == 1
 }
 
-// AssertFindByIDCalledOnce calls t.Error if FakeAccountRepo.FindByID was not called exactly once
-func (f *FakeAccountRepo) AssertFindByIDCalledOnce(t AccountRepoTestingT) {
+// AssertEachByUserCalledOnce calls t.Error if FakeTransactionRepo.EachByUser was not called exactly once
+func (f *FakeTransactionRepo) AssertEachByUserCalledOnce(t TransactionRepoTestingT) {
 	t.Helper()
-	if len(f.FindByIDCalls) != 1 {
-		t.Errorf("FakeAccountRepo.FindByID called %d times, expected 1", len(f.FindByIDCalls))
+	if len(f.EachByUserCalls) != 1 {
+		t.Errorf("FakeTransactionRepo.EachByUser called %d times, expected 1", len(f.EachByUserCalls))
 	}
 }
 
-// FindByIDCalledN returns true if FakeAccountRepo.FindByID was called at least n times
-func (f *FakeAccountRepo) FindByIDCalledN(n int) bool {
-	return len(f.FindByIDCalls) >= n
+// EachByUserCalledN returns true if FakeTransactionRepo.EachByUser was called at least n times
+func (f *FakeTransactionRepo) EachByUserCalledN(n int) bool {
+	return len(f.EachByUserCalls) >= n
 }
 
-// AssertFindByIDCalledN calls t.Error if FakeAccountRepo.FindByID was called less than n times
-func (f *FakeAccountRepo) AssertFindByIDCalledN(t AccountRepoTestingT, n int) {
+// AssertEachByUserCalledN calls t.Error if FakeTransactionRepo.EachByUser was called less than n times
+func (f *FakeTransactionRepo) AssertEachByUserCalledN(t TransactionRepoTestingT, n int) {
 	t.Helper()
-	if len(f.FindByIDCalls) < n {
-		t.Errorf("FakeAccountRepo.FindByID called %d times, expected >= %d", len(f.FindByIDCalls), n)
+	if len(f.EachByUserCalls) < n {
+		t.Errorf("FakeTransactionRepo.EachByUser called %d times, expected >= %d", len(f.EachByUserCalls), n)
 	}
 }
 
-// FindByIDCalledWith returns true if FakeAccountRepo.FindByID was called with the given values
-func (f_sym24 *FakeAccountRepo) FindByIDCalledWith(id int) bool {
-	for _, call_sym24 := range f_sym24.FindByIDCalls {
-		if reflect.DeepEqual(call_sym24.Parameters.Id, id) {
+// EachByUserCalledWith returns true if FakeTransactionRepo.EachByUser was called with the given values
+func (f_sym82 *FakeTransactionRepo) EachByUserCalledWith(userID int, accountID *int, fn func(model.Transaction) error) bool {
+	for _, call_sym82 := range f_sym82.EachByUserCalls {
+		if reflect.DeepEqual(call_sym82.Parameters.UserID, userID) && reflect.DeepEqual(call_sym82.Parameters.AccountID, accountID) && reflect.DeepEqual(call_sym82.Parameters.Fn, fn) {
 			return true
 		}
 	}
@@ -659,56 +3123,55 @@ func (f_sym24 *FakeAccountRepo) FindByIDCalledWith(id int) bool {
 	return false
 }
 
-// AssertFindByIDCalledWith calls t.Error if FakeAccountRepo.FindByID was not called with the given values
-func (f_sym25 *FakeAccountRepo) AssertFindByIDCalledWith(t AccountRepoTestingT, id int) {
+// AssertEachByUserCalledWith calls t.Error if FakeTransactionRepo.EachByUser was not called with the given values
+func (f_sym83 *FakeTransactionRepo) AssertEachByUserCalledWith(t TransactionRepoTestingT, userID int, accountID *int, fn func(model.Transaction) error) {
 	t.Helper()
-	var found_sym25 bool
-	for _, call_sym25 := range f_sym25.FindByIDCalls {
-		if reflect.DeepEqual(call_sym25.Parameters.Id, id) {
-			found_sym25 = true
+	var found_sym83 bool
+	for _, call_sym83 := range f_sym83.EachByUserCalls {
+		if reflect.DeepEqual(call_sym83.Parameters.UserID, userID) && reflect.DeepEqual(call_sym83.Parameters.AccountID, accountID) && reflect.DeepEqual(call_sym83.Parameters.Fn, fn) {
+			found_sym83 = true
 			break
 		}
 	}
 
-	if !found_sym25 {
-		t.Error("FakeAccountRepo.FindByID not called with expected parameters")
+	if !found_sym83 {
+		t.Error("FakeTransactionRepo.EachByUser not called with expected parameters")
 	}
 }
 
-// FindByIDCalledOnceWith returns true if FakeAccountRepo.FindByID was called exactly once with the given values
-func (f_sym26 *FakeAccountRepo) FindByIDCalledOnceWith(id int) bool {
-	var count_sym26 int
-	for _, call_sym26 := range f_sym26.FindByIDCalls {
-		if reflect.DeepEqual(call_sym26.Parameters.Id, id) {
-			count_sym26++
+// EachByUserCalledOnceWith returns true if FakeTransactionRepo.EachByUser was called exactly once with the given values
+func (f_sym84 *FakeTransactionRepo) EachByUserCalledOnceWith(userID int, accountID *int, fn func(model.Transaction) error) bool {
+	var count_sym84 int
+	for _, call_sym84 := range f_sym84.EachByUserCalls {
+		if reflect.DeepEqual(call_sym84.Parameters.UserID, userID) && reflect.DeepEqual(call_sym84.Parameters.AccountID, accountID) && reflect.DeepEqual(call_sym84.Parameters.Fn, fn) {
+			count_sym84++
 		}
 	}
 
-	return count_sym26 == 1
+	return count_sym84 == 1
 }
 
-// AssertFindByIDCalledOnceWith calls t.Error if FakeAccountRepo.FindByID was not called exactly once with the given values
-func (f_sym27 *FakeAccountRepo) AssertFindByIDCalledOnceWith(t AccountRepoTestingT, id int) {
+// AssertEachByUserCalledOnceWith calls t.Error if FakeTransactionRepo.EachByUser was not called exactly once with the given values
+func (f_sym85 *FakeTransactionRepo) AssertEachByUserCalledOnceWith(t TransactionRepoTestingT, userID int, accountID *int, fn func(model.Transaction) error) {
 	t.Helper()
-	var count_sym27 int
-	for _, call_sym27 := range f_sym27.FindByIDCalls {
-		if reflect.DeepEqual(call_sym27.Parameters.Id, id) {
-			count_sym27++
+	var count_sym85 int
+	for _, call_sym85 := range f_sym85.EachByUserCalls {
+		if reflect.DeepEqual(call_sym85.Parameters.UserID, userID) && reflect.DeepEqual(call_sym85.Parameters.AccountID, accountID) && reflect.DeepEqual(call_sym85.Parameters.Fn, fn) {
+			count_sym85++
 		}
 	}
 
-	if count_sym27 != 1 {
-		t.Errorf("FakeAccountRepo.FindByID called %d times with expected parameters, expected one", count_sym27)
+	if count_sym85 != 1 {
+		t.Errorf("FakeTransactionRepo.EachByUser called %d times with expected parameters, expected one", count_sym85)
 	}
 }
 
-// FindByIDResultsForCall returns the result values for the first call to FakeAccountRepo.FindByID with the given values
-func (f_sym28 *FakeAccountRepo) FindByIDResultsForCall(id int) (ident1 model.Account, ident2 error, found_sym28 bool) {
-	for _, call_sym28 := range f_sym28.FindByIDCalls {
-		if reflect.DeepEqual(call_sym28.Parameters.Id, id) {
-			ident1 = call_sym28.Results.Ident1
-			ident2 = call_sym28.Results.Ident2
-			found_sym28 = true
+// EachByUserResultsForCall returns the result values for the first call to FakeTransactionRepo.EachByUser with the given values
+func (f_sym86 *FakeTransactionRepo) EachByUserResultsForCall(userID int, accountID *int, fn func(model.Transaction) error) (ident1 error, found_sym86 bool) {
+	for _, call_sym86 := range f_sym86.EachByUserCalls {
+		if reflect.DeepEqual(call_sym86.Parameters.UserID, userID) && reflect.DeepEqual(call_sym86.Parameters.AccountID, accountID) && reflect.DeepEqual(call_sym86.Parameters.Fn, fn) {
+			ident1 = call_sym86.Results.Ident1
+			found_sym86 = true
 			break
 		}
 	}
@@ -716,111 +3179,207 @@ func (f_sym28 *FakeAccountRepo) FindByIDResultsForCall(id int) (ident1 model.Acc
 	return
 }
 
-// TransactionRepoFindByIDInvocation represents a single call of FakeTransactionRepo.FindByID
-type TransactionRepoFindByIDInvocation struct {
-	Parameters struct {
-		Id int
+func (f_sym429 *FakeTransactionRepo) FindPage(userID int, accountID *int, after int, limit int) (ident1 []model.Transaction, ident2 error) {
+	if f_sym429.FindPageHook == nil {
+		panic("TransactionRepo.FindPage() called but FakeTransactionRepo.FindPageHook is nil")
 	}
-	Results struct {
-		Ident1 model.Transaction
-		Ident2 error
+
+	invocation_sym429 := new(TransactionRepoFindPageInvocation)
+	f_sym429.FindPageCalls = append(f_sym429.FindPageCalls, invocation_sym429)
+
+	invocation_sym429.Parameters.UserID = userID
+	invocation_sym429.Parameters.AccountID = accountID
+	invocation_sym429.Parameters.After = after
+	invocation_sym429.Parameters.Limit = limit
+
+	ident1, ident2 = f_sym429.FindPageHook(userID, accountID, after, limit)
+
+	invocation_sym429.Results.Ident1 = ident1
+	invocation_sym429.Results.Ident2 = ident2
+
+	return
+}
+
+// SetFindPageStub configures TransactionRepo.FindPage to always return the given values
+func (f_sym430 *FakeTransactionRepo) SetFindPageStub(ident1 []model.Transaction, ident2 error) {
+	f_sym430.FindPageHook = func(int, *int, int, int) ([]model.Transaction, error) {
+		return ident1, ident2
 	}
 }
 
-// NewTransactionRepoFindByIDInvocation creates a new instance of TransactionRepoFindByIDInvocation
-func NewTransactionRepoFindByIDInvocation(id int, ident1 model.Transaction, ident2 error) *TransactionRepoFindByIDInvocation {
-	invocation := new(TransactionRepoFindByIDInvocation)
+// SetFindPageInvocation configures TransactionRepo.FindPage to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym431 *FakeTransactionRepo) SetFindPageInvocation(calls_sym431 []*TransactionRepoFindPageInvocation, fallback_sym431 func() ([]model.Transaction, error)) {
+	f_sym431.FindPageHook = func(userID int, accountID *int, after int, limit int) (ident1 []model.Transaction, ident2 error) {
+		for _, call_sym431 := range calls_sym431 {
+			if reflect.DeepEqual(call_sym431.Parameters.UserID, userID) && reflect.DeepEqual(call_sym431.Parameters.AccountID, accountID) && reflect.DeepEqual(call_sym431.Parameters.After, after) && reflect.DeepEqual(call_sym431.Parameters.Limit, limit) {
+				ident1 = call_sym431.Results.Ident1
+				ident2 = call_sym431.Results.Ident2
 
-	invocation.Parameters.Id = id
+				return
+			}
+		}
 
-	invocation.Results.Ident1 = ident1
-	invocation.Results.Ident2 = ident2
+		return fallback_sym431()
+	}
+}
 
-	return invocation
+// FindPageCalled returns true if FakeTransactionRepo.FindPage was called
+func (f *FakeTransactionRepo) FindPageCalled() bool {
+	return len(f.FindPageCalls) != 0
 }
 
-// TransactionRepoFindByUserInvocation represents a single call of FakeTransactionRepo.FindByUser
-type TransactionRepoFindByUserInvocation struct {
-	Parameters struct {
-		UserID int
+// AssertFindPageCalled calls t.Error if FakeTransactionRepo.FindPage was not called
+func (f *FakeTransactionRepo) AssertFindPageCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.FindPageCalls) == 0 {
+		t.Error("FakeTransactionRepo.FindPage not called, expected at least one")
 	}
-	Results struct {
-		Ident1 []model.Transaction
-		Ident2 error
+}
+
+// FindPageNotCalled returns true if FakeTransactionRepo.FindPage was not called
+func (f *FakeTransactionRepo) FindPageNotCalled() bool {
+	return len(f.FindPageCalls) == 0
+}
+
+// AssertFindPageNotCalled calls t.Error if FakeTransactionRepo.FindPage was called
+func (f *FakeTransactionRepo) AssertFindPageNotCalled(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.FindPageCalls) != 0 {
+		t.Error("FakeTransactionRepo.FindPage called, expected none")
 	}
 }
 
-// NewTransactionRepoFindByUserInvocation creates a new instance of TransactionRepoFindByUserInvocation
-func NewTransactionRepoFindByUserInvocation(userID int, ident1 []model.Transaction, ident2 error) *TransactionRepoFindByUserInvocation {
-	invocation := new(TransactionRepoFindByUserInvocation)
+// FindPageCalledOnce returns true if FakeTransactionRepo.FindPage was called exactly once
+func (f *FakeTransactionRepo) FindPageCalledOnce() bool {
+	return len(f.FindPageCalls) == 1
+}
 
-	invocation.Parameters.UserID = userID
+// AssertFindPageCalledOnce calls t.Error if FakeTransactionRepo.FindPage was not called exactly once
+func (f *FakeTransactionRepo) AssertFindPageCalledOnce(t TransactionRepoTestingT) {
+	t.Helper()
+	if len(f.FindPageCalls) != 1 {
+		t.Errorf("FakeTransactionRepo.FindPage called %d times, expected 1", len(f.FindPageCalls))
+	}
+}
 
-	invocation.Results.Ident1 = ident1
-	invocation.Results.Ident2 = ident2
+// FindPageCalledN returns true if FakeTransactionRepo.FindPage was called at least n times
+func (f *FakeTransactionRepo) FindPageCalledN(n int) bool {
+	return len(f.FindPageCalls) >= n
+}
 
-	return invocation
+// AssertFindPageCalledN calls t.Error if FakeTransactionRepo.FindPage was called less than n times
+func (f *FakeTransactionRepo) AssertFindPageCalledN(t TransactionRepoTestingT, n int) {
+	t.Helper()
+	if len(f.FindPageCalls) < n {
+		t.Errorf("FakeTransactionRepo.FindPage called %d times, expected >= %d", len(f.FindPageCalls), n)
+	}
 }
 
-// TransactionRepoFindByUserAccountInvocation represents a single call of FakeTransactionRepo.FindByUserAccount
-type TransactionRepoFindByUserAccountInvocation struct {
-	Parameters struct {
-		UserID    int
-		AccountID int
+// FindPageCalledWith returns true if FakeTransactionRepo.FindPage was called with the given values
+func (f_sym432 *FakeTransactionRepo) FindPageCalledWith(userID int, accountID *int, after int, limit int) bool {
+	for _, call_sym432 := range f_sym432.FindPageCalls {
+		if reflect.DeepEqual(call_sym432.Parameters.UserID, userID) && reflect.DeepEqual(call_sym432.Parameters.AccountID, accountID) && reflect.DeepEqual(call_sym432.Parameters.After, after) && reflect.DeepEqual(call_sym432.Parameters.Limit, limit) {
+			return true
+		}
 	}
-	Results struct {
-		Ident1 []model.Transaction
-		Ident2 error
+
+	return false
+}
+
+// AssertFindPageCalledWith calls t.Error if FakeTransactionRepo.FindPage was not called with the given values
+func (f_sym433 *FakeTransactionRepo) AssertFindPageCalledWith(t TransactionRepoTestingT, userID int, accountID *int, after int, limit int) {
+	t.Helper()
+	var found_sym433 bool
+	for _, call_sym433 := range f_sym433.FindPageCalls {
+		if reflect.DeepEqual(call_sym433.Parameters.UserID, userID) && reflect.DeepEqual(call_sym433.Parameters.AccountID, accountID) && reflect.DeepEqual(call_sym433.Parameters.After, after) && reflect.DeepEqual(call_sym433.Parameters.Limit, limit) {
+			found_sym433 = true
+			break
+		}
+	}
+
+	if !found_sym433 {
+		t.Error("FakeTransactionRepo.FindPage not called with expected parameters")
 	}
 }
 
-// NewTransactionRepoFindByUserAccountInvocation creates a new instance of TransactionRepoFindByUserAccountInvocation
-func NewTransactionRepoFindByUserAccountInvocation(userID int, accountID int, ident1 []model.Transaction, ident2 error) *TransactionRepoFindByUserAccountInvocation {
-	invocation := new(TransactionRepoFindByUserAccountInvocation)
+// FindPageCalledOnceWith returns true if FakeTransactionRepo.FindPage was called exactly once with the given values
+func (f_sym434 *FakeTransactionRepo) FindPageCalledOnceWith(userID int, accountID *int, after int, limit int) bool {
+	var count_sym434 int
+	for _, call_sym434 := range f_sym434.FindPageCalls {
+		if reflect.DeepEqual(call_sym434.Parameters.UserID, userID) && reflect.DeepEqual(call_sym434.Parameters.AccountID, accountID) && reflect.DeepEqual(call_sym434.Parameters.After, after) && reflect.DeepEqual(call_sym434.Parameters.Limit, limit) {
+			count_sym434++
+		}
+	}
 
-	invocation.Parameters.UserID = userID
-	invocation.Parameters.AccountID = accountID
+	return count_sym434 == 1
+}
 
-	invocation.Results.Ident1 = ident1
-	invocation.Results.Ident2 = ident2
+// AssertFindPageCalledOnceWith calls t.Error if FakeTransactionRepo.FindPage was not called exactly once with the given values
+func (f_sym435 *FakeTransactionRepo) AssertFindPageCalledOnceWith(t TransactionRepoTestingT, userID int, accountID *int, after int, limit int) {
+	t.Helper()
+	var count_sym435 int
+	for _, call_sym435 := range f_sym435.FindPageCalls {
+		if reflect.DeepEqual(call_sym435.Parameters.UserID, userID) && reflect.DeepEqual(call_sym435.Parameters.AccountID, accountID) && reflect.DeepEqual(call_sym435.Parameters.After, after) && reflect.DeepEqual(call_sym435.Parameters.Limit, limit) {
+			count_sym435++
+		}
+	}
 
-	return invocation
+	if count_sym435 != 1 {
+		t.Errorf("FakeTransactionRepo.FindPage called %d times with expected parameters, expected one", count_sym435)
+	}
 }
 
-// TransactionRepoCreateInvocation represents a single call of FakeTransactionRepo.Create
-type TransactionRepoCreateInvocation struct {
+// FindPageResultsForCall returns the result values for the first call to FakeTransactionRepo.FindPage with the given values
+func (f_sym436 *FakeTransactionRepo) FindPageResultsForCall(userID int, accountID *int, after int, limit int) (ident1 []model.Transaction, ident2 error, found_sym436 bool) {
+	for _, call_sym436 := range f_sym436.FindPageCalls {
+		if reflect.DeepEqual(call_sym436.Parameters.UserID, userID) && reflect.DeepEqual(call_sym436.Parameters.AccountID, accountID) && reflect.DeepEqual(call_sym436.Parameters.After, after) && reflect.DeepEqual(call_sym436.Parameters.Limit, limit) {
+			ident1 = call_sym436.Results.Ident1
+			ident2 = call_sym436.Results.Ident2
+			found_sym436 = true
+			break
+		}
+	}
+
+	return
+}
+
+// ReconciliationRepoFindByAccountInvocation represents a single call of FakeReconciliationRepo.FindByAccount
+type ReconciliationRepoFindByAccountInvocation struct {
 	Parameters struct {
-		Ident1 *model.Transaction
+		AccountID int
 	}
 	Results struct {
+		Ident1 []model.Reconciliation
 		Ident2 error
 	}
 }
 
-// NewTransactionRepoCreateInvocation creates a new instance of TransactionRepoCreateInvocation
-func NewTransactionRepoCreateInvocation(ident1 *model.Transaction, ident2 error) *TransactionRepoCreateInvocation {
-	invocation := new(TransactionRepoCreateInvocation)
+// NewReconciliationRepoFindByAccountInvocation creates a new instance of ReconciliationRepoFindByAccountInvocation
+func NewReconciliationRepoFindByAccountInvocation(accountID int, ident1 []model.Reconciliation, ident2 error) *ReconciliationRepoFindByAccountInvocation {
+	invocation := new(ReconciliationRepoFindByAccountInvocation)
 
-	invocation.Parameters.Ident1 = ident1
+	invocation.Parameters.AccountID = accountID
 
+	invocation.Results.Ident1 = ident1
 	invocation.Results.Ident2 = ident2
 
 	return invocation
 }
 
-// TransactionRepoUpdateInvocation represents a single call of FakeTransactionRepo.Update
-type TransactionRepoUpdateInvocation struct {
+// ReconciliationRepoSaveInvocation represents a single call of FakeReconciliationRepo.Save
+type ReconciliationRepoSaveInvocation struct {
 	Parameters struct {
-		Ident1 *model.Transaction
+		Ident1 *model.Reconciliation
 	}
 	Results struct {
 		Ident2 error
 	}
 }
 
-// NewTransactionRepoUpdateInvocation creates a new instance of TransactionRepoUpdateInvocation
-func NewTransactionRepoUpdateInvocation(ident1 *model.Transaction, ident2 error) *TransactionRepoUpdateInvocation {
-	invocation := new(TransactionRepoUpdateInvocation)
+// NewReconciliationRepoSaveInvocation creates a new instance of ReconciliationRepoSaveInvocation
+func NewReconciliationRepoSaveInvocation(ident1 *model.Reconciliation, ident2 error) *ReconciliationRepoSaveInvocation {
+	invocation := new(ReconciliationRepoSaveInvocation)
 
 	invocation.Parameters.Ident1 = ident1
 
@@ -829,31 +3388,31 @@ func NewTransactionRepoUpdateInvocation(ident1 *model.Transaction, ident2 error)
 	return invocation
 }
 
-// TransactionRepoDeleteInvocation represents a single call of FakeTransactionRepo.Delete
-type TransactionRepoDeleteInvocation struct {
+// ReconciliationRepoSaveStatementInvocation represents a single call of FakeReconciliationRepo.SaveStatement
+type ReconciliationRepoSaveStatementInvocation struct {
 	Parameters struct {
-		UserID int
-		TranID int
+		Saved   []*model.Reconciliation
+		Created []model.ReconciledTransaction
 	}
 	Results struct {
 		Ident1 error
 	}
 }
 
-// NewTransactionRepoDeleteInvocation creates a new instance of TransactionRepoDeleteInvocation
-func NewTransactionRepoDeleteInvocation(userID int, tranID int, ident1 error) *TransactionRepoDeleteInvocation {
-	invocation := new(TransactionRepoDeleteInvocation)
+// NewReconciliationRepoSaveStatementInvocation creates a new instance of ReconciliationRepoSaveStatementInvocation
+func NewReconciliationRepoSaveStatementInvocation(saved []*model.Reconciliation, created []model.ReconciledTransaction, ident1 error) *ReconciliationRepoSaveStatementInvocation {
+	invocation := new(ReconciliationRepoSaveStatementInvocation)
 
-	invocation.Parameters.UserID = userID
-	invocation.Parameters.TranID = tranID
+	invocation.Parameters.Saved = saved
+	invocation.Parameters.Created = created
 
 	invocation.Results.Ident1 = ident1
 
 	return invocation
 }
 
-// TransactionRepoTestingT represents the methods of "testing".T used by charlatan Fakes.  It avoids importing the testing package.
-type TransactionRepoTestingT interface {
+// ReconciliationRepoTestingT represents the methods of "testing".T used by charlatan Fakes.  It avoids importing the testing package.
+type ReconciliationRepoTestingT interface {
 	Error(...interface{})
 	Errorf(string, ...interface{})
 	Fatal(...interface{})
@@ -861,14 +3420,14 @@ type TransactionRepoTestingT interface {
 }
 
 /*
-FakeTransactionRepo is a mock implementation of TransactionRepo for testing.
+FakeReconciliationRepo is a mock implementation of ReconciliationRepo for testing.
 Use it in your tests as in this example:
 
 	package example
 
-	func TestWithTransactionRepo(t *testing.T) {
-		f := &mock.FakeTransactionRepo{
-			FindByIDHook: func(id int) (ident1 model.Transaction, ident2 error) {
+	func TestWithReconciliationRepo(t *testing.T) {
+		f := &mock.FakeReconciliationRepo{
+			FindByAccountHook: func(accountID int) (ident1 []model.Reconciliation, ident2 error) {
 				// ensure parameters meet expections, signal errors using t, etc
 				return
 			},
@@ -876,221 +3435,179 @@ Use it in your tests as in this example:
 
 		// test code goes here ...
 
-		// assert state of FakeFindByID ...
-		f.AssertFindByIDCalledOnce(t)
-	}
-
-Create anonymous function implementations for only those interface methods that
-should be called in the code under test.  This will force a panic if any
-unexpected calls are made to FakeFindByID.
-*/
-type FakeTransactionRepo struct {
-	FindByIDHook          func(int) (model.Transaction, error)
-	FindByUserHook        func(int) ([]model.Transaction, error)
-	FindByUserAccountHook func(int, int) ([]model.Transaction, error)
-	CreateHook            func(*model.Transaction) error
-	UpdateHook            func(*model.Transaction) error
-	DeleteHook            func(int, int) error
+		// assert state of FakeFindByAccount ...
+		f.AssertFindByAccountCalledOnce(t)
+	}
 
-	FindByIDCalls          []*TransactionRepoFindByIDInvocation
-	FindByUserCalls        []*TransactionRepoFindByUserInvocation
-	FindByUserAccountCalls []*TransactionRepoFindByUserAccountInvocation
-	CreateCalls            []*TransactionRepoCreateInvocation
-	UpdateCalls            []*TransactionRepoUpdateInvocation
-	DeleteCalls            []*TransactionRepoDeleteInvocation
+Create anonymous function implementations for only those interface methods that
+should be called in the code under test.  This will force a panic if any
+unexpected calls are made to FakeFindByAccount.
+*/
+type FakeReconciliationRepo struct {
+	FindByAccountHook func(int) ([]model.Reconciliation, error)
+	SaveHook          func(*model.Reconciliation) error
+	SaveStatementHook func([]*model.Reconciliation, []model.ReconciledTransaction) error
+
+	FindByAccountCalls []*ReconciliationRepoFindByAccountInvocation
+	SaveCalls          []*ReconciliationRepoSaveInvocation
+	SaveStatementCalls []*ReconciliationRepoSaveStatementInvocation
 }
 
-// NewFakeTransactionRepoDefaultPanic returns an instance of FakeTransactionRepo with all hooks configured to panic
-func NewFakeTransactionRepoDefaultPanic() *FakeTransactionRepo {
-	return &FakeTransactionRepo{
-		FindByIDHook: func(int) (ident1 model.Transaction, ident2 error) {
-			panic("Unexpected call to TransactionRepo.FindByID")
+// NewFakeReconciliationRepoDefaultPanic returns an instance of FakeReconciliationRepo with all hooks configured to panic
+func NewFakeReconciliationRepoDefaultPanic() *FakeReconciliationRepo {
+	return &FakeReconciliationRepo{
+		FindByAccountHook: func(int) (ident1 []model.Reconciliation, ident2 error) {
+			panic("Unexpected call to ReconciliationRepo.FindByAccount")
 		},
-		FindByUserHook: func(int) (ident1 []model.Transaction, ident2 error) {
-			panic("Unexpected call to TransactionRepo.FindByUser")
-		},
-		FindByUserAccountHook: func(int, int) (ident1 []model.Transaction, ident2 error) {
-			panic("Unexpected call to TransactionRepo.FindByUserAccount")
-		},
-		CreateHook: func(*model.Transaction) (ident2 error) {
-			panic("Unexpected call to TransactionRepo.Create")
+		SaveHook: func(*model.Reconciliation) (ident2 error) {
+			panic("Unexpected call to ReconciliationRepo.Save")
 		},
-		UpdateHook: func(*model.Transaction) (ident2 error) {
-			panic("Unexpected call to TransactionRepo.Update")
-		},
-		DeleteHook: func(int, int) (ident1 error) {
-			panic("Unexpected call to TransactionRepo.Delete")
+		SaveStatementHook: func([]*model.Reconciliation, []model.ReconciledTransaction) (ident1 error) {
+			panic("Unexpected call to ReconciliationRepo.SaveStatement")
 		},
 	}
 }
 
-// NewFakeTransactionRepoDefaultFatal returns an instance of FakeTransactionRepo with all hooks configured to call t.Fatal
-func NewFakeTransactionRepoDefaultFatal(t_sym29 TransactionRepoTestingT) *FakeTransactionRepo {
-	return &FakeTransactionRepo{
-		FindByIDHook: func(int) (ident1 model.Transaction, ident2 error) {
-			t_sym29.Fatal("Unexpected call to TransactionRepo.FindByID")
-			return
-		},
-		FindByUserHook: func(int) (ident1 []model.Transaction, ident2 error) {
-			t_sym29.Fatal("Unexpected call to TransactionRepo.FindByUser")
-			return
-		},
-		FindByUserAccountHook: func(int, int) (ident1 []model.Transaction, ident2 error) {
-			t_sym29.Fatal("Unexpected call to TransactionRepo.FindByUserAccount")
-			return
-		},
-		CreateHook: func(*model.Transaction) (ident2 error) {
-			t_sym29.Fatal("Unexpected call to TransactionRepo.Create")
+// NewFakeReconciliationRepoDefaultFatal returns an instance of FakeReconciliationRepo with all hooks configured to call t.Fatal
+func NewFakeReconciliationRepoDefaultFatal(t_sym198 ReconciliationRepoTestingT) *FakeReconciliationRepo {
+	return &FakeReconciliationRepo{
+		FindByAccountHook: func(int) (ident1 []model.Reconciliation, ident2 error) {
+			t_sym198.Fatal("Unexpected call to ReconciliationRepo.FindByAccount")
 			return
 		},
-		UpdateHook: func(*model.Transaction) (ident2 error) {
-			t_sym29.Fatal("Unexpected call to TransactionRepo.Update")
+		SaveHook: func(*model.Reconciliation) (ident2 error) {
+			t_sym198.Fatal("Unexpected call to ReconciliationRepo.Save")
 			return
 		},
-		DeleteHook: func(int, int) (ident1 error) {
-			t_sym29.Fatal("Unexpected call to TransactionRepo.Delete")
+		SaveStatementHook: func([]*model.Reconciliation, []model.ReconciledTransaction) (ident1 error) {
+			t_sym198.Fatal("Unexpected call to ReconciliationRepo.SaveStatement")
 			return
 		},
 	}
 }
 
-// NewFakeTransactionRepoDefaultError returns an instance of FakeTransactionRepo with all hooks configured to call t.Error
-func NewFakeTransactionRepoDefaultError(t_sym30 TransactionRepoTestingT) *FakeTransactionRepo {
-	return &FakeTransactionRepo{
-		FindByIDHook: func(int) (ident1 model.Transaction, ident2 error) {
-			t_sym30.Error("Unexpected call to TransactionRepo.FindByID")
-			return
-		},
-		FindByUserHook: func(int) (ident1 []model.Transaction, ident2 error) {
-			t_sym30.Error("Unexpected call to TransactionRepo.FindByUser")
-			return
-		},
-		FindByUserAccountHook: func(int, int) (ident1 []model.Transaction, ident2 error) {
-			t_sym30.Error("Unexpected call to TransactionRepo.FindByUserAccount")
-			return
-		},
-		CreateHook: func(*model.Transaction) (ident2 error) {
-			t_sym30.Error("Unexpected call to TransactionRepo.Create")
+// NewFakeReconciliationRepoDefaultError returns an instance of FakeReconciliationRepo with all hooks configured to call t.Error
+func NewFakeReconciliationRepoDefaultError(t_sym199 ReconciliationRepoTestingT) *FakeReconciliationRepo {
+	return &FakeReconciliationRepo{
+		FindByAccountHook: func(int) (ident1 []model.Reconciliation, ident2 error) {
+			t_sym199.Error("Unexpected call to ReconciliationRepo.FindByAccount")
 			return
 		},
-		UpdateHook: func(*model.Transaction) (ident2 error) {
-			t_sym30.Error("Unexpected call to TransactionRepo.Update")
+		SaveHook: func(*model.Reconciliation) (ident2 error) {
+			t_sym199.Error("Unexpected call to ReconciliationRepo.Save")
 			return
 		},
-		DeleteHook: func(int, int) (ident1 error) {
-			t_sym30.Error("Unexpected call to TransactionRepo.Delete")
+		SaveStatementHook: func([]*model.Reconciliation, []model.ReconciledTransaction) (ident1 error) {
+			t_sym199.Error("Unexpected call to ReconciliationRepo.SaveStatement")
 			return
 		},
 	}
 }
 
-func (f *FakeTransactionRepo) Reset() {
-	f.FindByIDCalls = []*TransactionRepoFindByIDInvocation{}
-	f.FindByUserCalls = []*TransactionRepoFindByUserInvocation{}
-	f.FindByUserAccountCalls = []*TransactionRepoFindByUserAccountInvocation{}
-	f.CreateCalls = []*TransactionRepoCreateInvocation{}
-	f.UpdateCalls = []*TransactionRepoUpdateInvocation{}
-	f.DeleteCalls = []*TransactionRepoDeleteInvocation{}
+func (f *FakeReconciliationRepo) Reset() {
+	f.FindByAccountCalls = []*ReconciliationRepoFindByAccountInvocation{}
+	f.SaveCalls = []*ReconciliationRepoSaveInvocation{}
+	f.SaveStatementCalls = []*ReconciliationRepoSaveStatementInvocation{}
 }
 
-func (f_sym31 *FakeTransactionRepo) FindByID(id int) (ident1 model.Transaction, ident2 error) {
-	if f_sym31.FindByIDHook == nil {
-		panic("TransactionRepo.FindByID() called but FakeTransactionRepo.FindByIDHook is nil")
+func (f_sym213 *FakeReconciliationRepo) FindByAccount(accountID int) (ident1 []model.Reconciliation, ident2 error) {
+	if f_sym213.FindByAccountHook == nil {
+		panic("ReconciliationRepo.FindByAccount() called but FakeReconciliationRepo.FindByAccountHook is nil")
 	}
 
-	invocation_sym31 := new(TransactionRepoFindByIDInvocation)
-	f_sym31.FindByIDCalls = append(f_sym31.FindByIDCalls, invocation_sym31)
+	invocation_sym213 := new(ReconciliationRepoFindByAccountInvocation)
+	f_sym213.FindByAccountCalls = append(f_sym213.FindByAccountCalls, invocation_sym213)
 
-	invocation_sym31.Parameters.Id = id
+	invocation_sym213.Parameters.AccountID = accountID
 
-	ident1, ident2 = f_sym31.FindByIDHook(id)
+	ident1, ident2 = f_sym213.FindByAccountHook(accountID)
 
-	invocation_sym31.Results.Ident1 = ident1
-	invocation_sym31.Results.Ident2 = ident2
+	invocation_sym213.Results.Ident1 = ident1
+	invocation_sym213.Results.Ident2 = ident2
 
 	return
 }
 
-// SetFindByIDStub configures TransactionRepo.FindByID to always return the given values
-func (f_sym32 *FakeTransactionRepo) SetFindByIDStub(ident1 model.Transaction, ident2 error) {
-	f_sym32.FindByIDHook = func(int) (model.Transaction, error) {
+// SetFindByAccountStub configures ReconciliationRepo.FindByAccount to always return the given values
+func (f_sym214 *FakeReconciliationRepo) SetFindByAccountStub(ident1 []model.Reconciliation, ident2 error) {
+	f_sym214.FindByAccountHook = func(int) ([]model.Reconciliation, error) {
 		return ident1, ident2
 	}
 }
 
-// SetFindByIDInvocation configures TransactionRepo.FindByID to return the given results when called with the given parameters
+// SetFindByAccountInvocation configures ReconciliationRepo.FindByAccount to return the given results when called with the given parameters
 // If no match is found for an invocation the result(s) of the fallback function are returned
-func (f_sym33 *FakeTransactionRepo) SetFindByIDInvocation(calls_sym33 []*TransactionRepoFindByIDInvocation, fallback_sym33 func() (model.Transaction, error)) {
-	f_sym33.FindByIDHook = func(id int) (ident1 model.Transaction, ident2 error) {
-		for _, call_sym33 := range calls_sym33 {
-			if reflect.DeepEqual(call_sym33.Parameters.Id, id) {
-				ident1 = call_sym33.Results.Ident1
-				ident2 = call_sym33.Results.Ident2
+func (f_sym215 *FakeReconciliationRepo) SetFindByAccountInvocation(calls_sym215 []*ReconciliationRepoFindByAccountInvocation, fallback_sym215 func() ([]model.Reconciliation, error)) {
+	f_sym215.FindByAccountHook = func(accountID int) (ident1 []model.Reconciliation, ident2 error) {
+		for _, call_sym215 := range calls_sym215 {
+			if reflect.DeepEqual(call_sym215.Parameters.AccountID, accountID) {
+				ident1 = call_sym215.Results.Ident1
+				ident2 = call_sym215.Results.Ident2
 
 				return
 			}
 		}
 
-		return fallback_sym33()
+		return fallback_sym215()
 	}
 }
 
-// FindByIDCalled returns true if FakeTransactionRepo.FindByID was called
-func (f *FakeTransactionRepo) FindByIDCalled() bool {
-	return len(f.FindByIDCalls) != 0
+// FindByAccountCalled returns true if FakeReconciliationRepo.FindByAccount was called
+func (f *FakeReconciliationRepo) FindByAccountCalled() bool {
+	return len(f.FindByAccountCalls) != 0
 }
 
-// AssertFindByIDCalled calls t.Error if FakeTransactionRepo.FindByID was not called
-func (f *FakeTransactionRepo) AssertFindByIDCalled(t TransactionRepoTestingT) {
+// AssertFindByAccountCalled calls t.Error if FakeReconciliationRepo.FindByAccount was not called
+func (f *FakeReconciliationRepo) AssertFindByAccountCalled(t ReconciliationRepoTestingT) {
 	t.Helper()
-	if len(f.FindByIDCalls) == 0 {
-		t.Error("FakeTransactionRepo.FindByID not called, expected at least one")
+	if len(f.FindByAccountCalls) == 0 {
+		t.Error("FakeReconciliationRepo.FindByAccount not called, expected at least one")
 	}
 }
 
-// FindByIDNotCalled returns true if FakeTransactionRepo.FindByID was not called
-func (f *FakeTransactionRepo) FindByIDNotCalled() bool {
-	return len(f.FindByIDCalls) == 0
+// FindByAccountNotCalled returns true if FakeReconciliationRepo.FindByAccount was not called
+func (f *FakeReconciliationRepo) FindByAccountNotCalled() bool {
+	return len(f.FindByAccountCalls) == 0
 }
 
-// AssertFindByIDNotCalled calls t.Error if FakeTransactionRepo.FindByID was called
-func (f *FakeTransactionRepo) AssertFindByIDNotCalled(t TransactionRepoTestingT) {
+// AssertFindByAccountNotCalled calls t.Error if FakeReconciliationRepo.FindByAccount was called
+func (f *FakeReconciliationRepo) AssertFindByAccountNotCalled(t ReconciliationRepoTestingT) {
 	t.Helper()
-	if len(f.FindByIDCalls) != 0 {
-		t.Error("FakeTransactionRepo.FindByID called, expected none")
+	if len(f.FindByAccountCalls) != 0 {
+		t.Error("FakeReconciliationRepo.FindByAccount called, expected none")
 	}
 }
 
-// FindByIDCalledOnce returns true if FakeTransactionRepo.FindByID was called exactly once
-func (f *FakeTransactionRepo) FindByIDCalledOnce() bool {
-	return len(f.FindByIDCalls) == 1
+// FindByAccountCalledOnce returns true if FakeReconciliationRepo.FindByAccount was called exactly once
+func (f *FakeReconciliationRepo) FindByAccountCalledOnce() bool {
+	return len(f.FindByAccountCalls) == 1
 }
 
-// AssertFindByIDCalledOnce calls t.Error if FakeTransactionRepo.FindByID was not called exactly once
-func (f *FakeTransactionRepo) AssertFindByIDCalledOnce(t TransactionRepoTestingT) {
+// AssertFindByAccountCalledOnce calls t.Error if FakeReconciliationRepo.FindByAccount was not called exactly once
+func (f *FakeReconciliationRepo) AssertFindByAccountCalledOnce(t ReconciliationRepoTestingT) {
 	t.Helper()
-	if len(f.FindByIDCalls) != 1 {
-		t.Errorf("FakeTransactionRepo.FindByID called %d times, expected 1", len(f.FindByIDCalls))
+	if len(f.FindByAccountCalls) != 1 {
+		t.Errorf("FakeReconciliationRepo.FindByAccount called %d times, expected 1", len(f.FindByAccountCalls))
 	}
 }
 
-// FindByIDCalledN returns true if FakeTransactionRepo.FindByID was called at least n times
-func (f *FakeTransactionRepo) FindByIDCalledN(n int) bool {
-	return len(f.FindByIDCalls) >= n
+// FindByAccountCalledN returns true if FakeReconciliationRepo.FindByAccount was called at least n times
+func (f *FakeReconciliationRepo) FindByAccountCalledN(n int) bool {
+	return len(f.FindByAccountCalls) >= n
 }
 
-// AssertFindByIDCalledN calls t.Error if FakeTransactionRepo.FindByID was called less than n times
-func (f *FakeTransactionRepo) AssertFindByIDCalledN(t TransactionRepoTestingT, n int) {
+// AssertFindByAccountCalledN calls t.Error if FakeReconciliationRepo.FindByAccount was called less than n times
+func (f *FakeReconciliationRepo) AssertFindByAccountCalledN(t ReconciliationRepoTestingT, n int) {
 	t.Helper()
-	if len(f.FindByIDCalls) < n {
-		t.Errorf("FakeTransactionRepo.FindByID called %d times, expected >= %d", len(f.FindByIDCalls), n)
+	if len(f.FindByAccountCalls) < n {
+		t.Errorf("FakeReconciliationRepo.FindByAccount called %d times, expected >= %d", len(f.FindByAccountCalls), n)
 	}
 }
 
-// FindByIDCalledWith returns true if FakeTransactionRepo.FindByID was called with the given values
-func (f_sym34 *FakeTransactionRepo) FindByIDCalledWith(id int) bool {
-	for _, call_sym34 := range f_sym34.FindByIDCalls {
-		if reflect.DeepEqual(call_sym34.Parameters.Id, id) {
+// FindByAccountCalledWith returns true if FakeReconciliationRepo.FindByAccount was called with the given values
+func (f_sym216 *FakeReconciliationRepo) FindByAccountCalledWith(accountID int) bool {
+	for _, call_sym216 := range f_sym216.FindByAccountCalls {
+		if reflect.DeepEqual(call_sym216.Parameters.AccountID, accountID) {
 			return true
 		}
 	}
@@ -1098,56 +3615,56 @@ func (f_sym34 *FakeTransactionRepo) FindByIDCalledWith(id int) bool {
 	return false
 }
 
-// AssertFindByIDCalledWith calls t.Error if FakeTransactionRepo.FindByID was not called with the given values
-func (f_sym35 *FakeTransactionRepo) AssertFindByIDCalledWith(t TransactionRepoTestingT, id int) {
+// AssertFindByAccountCalledWith calls t.Error if FakeReconciliationRepo.FindByAccount was not called with the given values
+func (f_sym217 *FakeReconciliationRepo) AssertFindByAccountCalledWith(t ReconciliationRepoTestingT, accountID int) {
 	t.Helper()
-	var found_sym35 bool
-	for _, call_sym35 := range f_sym35.FindByIDCalls {
-		if reflect.DeepEqual(call_sym35.Parameters.Id, id) {
-			found_sym35 = true
+	var found_sym217 bool
+	for _, call_sym217 := range f_sym217.FindByAccountCalls {
+		if reflect.DeepEqual(call_sym217.Parameters.AccountID, accountID) {
+			found_sym217 = true
 			break
 		}
 	}
 
-	if !found_sym35 {
-		t.Error("FakeTransactionRepo.FindByID not called with expected parameters")
+	if !found_sym217 {
+		t.Error("FakeReconciliationRepo.FindByAccount not called with expected parameters")
 	}
 }
 
-// FindByIDCalledOnceWith returns true if FakeTransactionRepo.FindByID was called exactly once with the given values
-func (f_sym36 *FakeTransactionRepo) FindByIDCalledOnceWith(id int) bool {
-	var count_sym36 int
-	for _, call_sym36 := range f_sym36.FindByIDCalls {
-		if reflect.DeepEqual(call_sym36.Parameters.Id, id) {
-			count_sym36++
+// FindByAccountCalledOnceWith returns true if FakeReconciliationRepo.FindByAccount was called exactly once with the given values
+func (f_sym218 *FakeReconciliationRepo) FindByAccountCalledOnceWith(accountID int) bool {
+	var count_sym218 int
+	for _, call_sym218 := range f_sym218.FindByAccountCalls {
+		if reflect.DeepEqual(call_sym218.Parameters.AccountID, accountID) {
+			count_sym218++
 		}
 	}
 
-	return count_sym36 == 1
+	return count_sym218 == 1
 }
 
-// AssertFindByIDCalledOnceWith calls t.Error if FakeTransactionRepo.FindByID was not called exactly once with the given values
-func (f_sym37 *FakeTransactionRepo) AssertFindByIDCalledOnceWith(t TransactionRepoTestingT, id int) {
+// AssertFindByAccountCalledOnceWith calls t.Error if FakeReconciliationRepo.FindByAccount was not called exactly once with the given values
+func (f_sym219 *FakeReconciliationRepo) AssertFindByAccountCalledOnceWith(t ReconciliationRepoTestingT, accountID int) {
 	t.Helper()
-	var count_sym37 int
-	for _, call_sym37 := range f_sym37.FindByIDCalls {
-		if reflect.DeepEqual(call_sym37.Parameters.Id, id) {
-			count_sym37++
+	var count_sym219 int
+	for _, call_sym219 := range f_sym219.FindByAccountCalls {
+		if reflect.DeepEqual(call_sym219.Parameters.AccountID, accountID) {
+			count_sym219++
 		}
 	}
 
-	if count_sym37 != 1 {
-		t.Errorf("FakeTransactionRepo.FindByID called %d times with expected parameters, expected one", count_sym37)
+	if count_sym219 != 1 {
+		t.Errorf("FakeReconciliationRepo.FindByAccount called %d times with expected parameters, expected one", count_sym219)
 	}
 }
 
-// FindByIDResultsForCall returns the result values for the first call to FakeTransactionRepo.FindByID with the given values
-func (f_sym38 *FakeTransactionRepo) FindByIDResultsForCall(id int) (ident1 model.Transaction, ident2 error, found_sym38 bool) {
-	for _, call_sym38 := range f_sym38.FindByIDCalls {
-		if reflect.DeepEqual(call_sym38.Parameters.Id, id) {
-			ident1 = call_sym38.Results.Ident1
-			ident2 = call_sym38.Results.Ident2
-			found_sym38 = true
+// FindByAccountResultsForCall returns the result values for the first call to FakeReconciliationRepo.FindByAccount with the given values
+func (f_sym220 *FakeReconciliationRepo) FindByAccountResultsForCall(accountID int) (ident1 []model.Reconciliation, ident2 error, found_sym220 bool) {
+	for _, call_sym220 := range f_sym220.FindByAccountCalls {
+		if reflect.DeepEqual(call_sym220.Parameters.AccountID, accountID) {
+			ident1 = call_sym220.Results.Ident1
+			ident2 = call_sym220.Results.Ident2
+			found_sym220 = true
 			break
 		}
 	}
@@ -1155,104 +3672,262 @@ func (f_sym38 *FakeTransactionRepo) FindByIDResultsForCall(id int) (ident1 model
 	return
 }
 
-func (f_sym39 *FakeTransactionRepo) FindByUser(userID int) (ident1 []model.Transaction, ident2 error) {
-	if f_sym39.FindByUserHook == nil {
-		panic("TransactionRepo.FindByUser() called but FakeTransactionRepo.FindByUserHook is nil")
+func (f_sym255 *FakeReconciliationRepo) Save(ident1 *model.Reconciliation) (ident2 error) {
+	if f_sym255.SaveHook == nil {
+		panic("ReconciliationRepo.Save() called but FakeReconciliationRepo.SaveHook is nil")
 	}
 
-	invocation_sym39 := new(TransactionRepoFindByUserInvocation)
-	f_sym39.FindByUserCalls = append(f_sym39.FindByUserCalls, invocation_sym39)
+	invocation_sym255 := new(ReconciliationRepoSaveInvocation)
+	f_sym255.SaveCalls = append(f_sym255.SaveCalls, invocation_sym255)
 
-	invocation_sym39.Parameters.UserID = userID
+	invocation_sym255.Parameters.Ident1 = ident1
 
-	ident1, ident2 = f_sym39.FindByUserHook(userID)
+	ident2 = f_sym255.SaveHook(ident1)
 
-	invocation_sym39.Results.Ident1 = ident1
-	invocation_sym39.Results.Ident2 = ident2
+	invocation_sym255.Results.Ident2 = ident2
 
 	return
 }
 
-// SetFindByUserStub configures TransactionRepo.FindByUser to always return the given values
-func (f_sym40 *FakeTransactionRepo) SetFindByUserStub(ident1 []model.Transaction, ident2 error) {
-	f_sym40.FindByUserHook = func(int) ([]model.Transaction, error) {
-		return ident1, ident2
+// SetSaveStub configures ReconciliationRepo.Save to always return the given values
+func (f_sym256 *FakeReconciliationRepo) SetSaveStub(ident2 error) {
+	f_sym256.SaveHook = func(*model.Reconciliation) error {
+		return ident2
 	}
 }
 
-// SetFindByUserInvocation configures TransactionRepo.FindByUser to return the given results when called with the given parameters
+// SetSaveInvocation configures ReconciliationRepo.Save to return the given results when called with the given parameters
 // If no match is found for an invocation the result(s) of the fallback function are returned
-func (f_sym41 *FakeTransactionRepo) SetFindByUserInvocation(calls_sym41 []*TransactionRepoFindByUserInvocation, fallback_sym41 func() ([]model.Transaction, error)) {
-	f_sym41.FindByUserHook = func(userID int) (ident1 []model.Transaction, ident2 error) {
-		for _, call_sym41 := range calls_sym41 {
-			if reflect.DeepEqual(call_sym41.Parameters.UserID, userID) {
-				ident1 = call_sym41.Results.Ident1
-				ident2 = call_sym41.Results.Ident2
+func (f_sym257 *FakeReconciliationRepo) SetSaveInvocation(calls_sym257 []*ReconciliationRepoSaveInvocation, fallback_sym257 func() error) {
+	f_sym257.SaveHook = func(ident1 *model.Reconciliation) (ident2 error) {
+		for _, call_sym257 := range calls_sym257 {
+			if reflect.DeepEqual(call_sym257.Parameters.Ident1, ident1) {
+				ident2 = call_sym257.Results.Ident2
+
+				return
+			}
+		}
+
+		return fallback_sym257()
+	}
+}
+
+// SaveCalled returns true if FakeReconciliationRepo.Save was called
+func (f *FakeReconciliationRepo) SaveCalled() bool {
+	return len(f.SaveCalls) != 0
+}
+
+// AssertSaveCalled calls t.Error if FakeReconciliationRepo.Save was not called
+func (f *FakeReconciliationRepo) AssertSaveCalled(t ReconciliationRepoTestingT) {
+	t.Helper()
+	if len(f.SaveCalls) == 0 {
+		t.Error("FakeReconciliationRepo.Save not called, expected at least one")
+	}
+}
+
+// SaveNotCalled returns true if FakeReconciliationRepo.Save was not called
+func (f *FakeReconciliationRepo) SaveNotCalled() bool {
+	return len(f.SaveCalls) == 0
+}
+
+// AssertSaveNotCalled calls t.Error if FakeReconciliationRepo.Save was called
+func (f *FakeReconciliationRepo) AssertSaveNotCalled(t ReconciliationRepoTestingT) {
+	t.Helper()
+	if len(f.SaveCalls) != 0 {
+		t.Error("FakeReconciliationRepo.Save called, expected none")
+	}
+}
+
+// SaveCalledOnce returns true if FakeReconciliationRepo.Save was called exactly once
+func (f *FakeReconciliationRepo) SaveCalledOnce() bool {
+	return len(f.SaveCalls) == 1
+}
+
+// AssertSaveCalledOnce calls t.Error if FakeReconciliationRepo.Save was not called exactly once
+func (f *FakeReconciliationRepo) AssertSaveCalledOnce(t ReconciliationRepoTestingT) {
+	t.Helper()
+	if len(f.SaveCalls) != 1 {
+		t.Errorf("FakeReconciliationRepo.Save called %d times, expected 1", len(f.SaveCalls))
+	}
+}
+
+// SaveCalledN returns true if FakeReconciliationRepo.Save was called at least n times
+func (f *FakeReconciliationRepo) SaveCalledN(n int) bool {
+	return len(f.SaveCalls) >= n
+}
+
+// AssertSaveCalledN calls t.Error if FakeReconciliationRepo.Save was called less than n times
+func (f *FakeReconciliationRepo) AssertSaveCalledN(t ReconciliationRepoTestingT, n int) {
+	t.Helper()
+	if len(f.SaveCalls) < n {
+		t.Errorf("FakeReconciliationRepo.Save called %d times, expected >= %d", len(f.SaveCalls), n)
+	}
+}
+
+// SaveCalledWith returns true if FakeReconciliationRepo.Save was called with the given values
+func (f_sym258 *FakeReconciliationRepo) SaveCalledWith(ident1 *model.Reconciliation) bool {
+	for _, call_sym258 := range f_sym258.SaveCalls {
+		if reflect.DeepEqual(call_sym258.Parameters.Ident1, ident1) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssertSaveCalledWith calls t.Error if FakeReconciliationRepo.Save was not called with the given values
+func (f_sym259 *FakeReconciliationRepo) AssertSaveCalledWith(t ReconciliationRepoTestingT, ident1 *model.Reconciliation) {
+	t.Helper()
+	var found_sym259 bool
+	for _, call_sym259 := range f_sym259.SaveCalls {
+		if reflect.DeepEqual(call_sym259.Parameters.Ident1, ident1) {
+			found_sym259 = true
+			break
+		}
+	}
+
+	if !found_sym259 {
+		t.Error("FakeReconciliationRepo.Save not called with expected parameters")
+	}
+}
+
+// SaveCalledOnceWith returns true if FakeReconciliationRepo.Save was called exactly once with the given values
+func (f_sym260 *FakeReconciliationRepo) SaveCalledOnceWith(ident1 *model.Reconciliation) bool {
+	var count_sym260 int
+	for _, call_sym260 := range f_sym260.SaveCalls {
+		if reflect.DeepEqual(call_sym260.Parameters.Ident1, ident1) {
+			count_sym260++
+		}
+	}
+
+	return count_sym260 == 1
+}
+
+// AssertSaveCalledOnceWith calls t.Error if FakeReconciliationRepo.Save was not called exactly once with the given values
+func (f_sym261 *FakeReconciliationRepo) AssertSaveCalledOnceWith(t ReconciliationRepoTestingT, ident1 *model.Reconciliation) {
+	t.Helper()
+	var count_sym261 int
+	for _, call_sym261 := range f_sym261.SaveCalls {
+		if reflect.DeepEqual(call_sym261.Parameters.Ident1, ident1) {
+			count_sym261++
+		}
+	}
+
+	if count_sym261 != 1 {
+		t.Errorf("FakeReconciliationRepo.Save called %d times with expected parameters, expected one", count_sym261)
+	}
+}
+
+// SaveResultsForCall returns the result values for the first call to FakeReconciliationRepo.Save with the given values
+func (f_sym262 *FakeReconciliationRepo) SaveResultsForCall(ident1 *model.Reconciliation) (ident2 error, found_sym262 bool) {
+	for _, call_sym262 := range f_sym262.SaveCalls {
+		if reflect.DeepEqual(call_sym262.Parameters.Ident1, ident1) {
+			ident2 = call_sym262.Results.Ident2
+			found_sym262 = true
+			break
+		}
+	}
+
+	return
+}
+
+func (f_sym469 *FakeReconciliationRepo) SaveStatement(saved []*model.Reconciliation, created []model.ReconciledTransaction) (ident1 error) {
+	if f_sym469.SaveStatementHook == nil {
+		panic("ReconciliationRepo.SaveStatement() called but FakeReconciliationRepo.SaveStatementHook is nil")
+	}
+
+	invocation_sym469 := new(ReconciliationRepoSaveStatementInvocation)
+	f_sym469.SaveStatementCalls = append(f_sym469.SaveStatementCalls, invocation_sym469)
+
+	invocation_sym469.Parameters.Saved = saved
+	invocation_sym469.Parameters.Created = created
+
+	ident1 = f_sym469.SaveStatementHook(saved, created)
+
+	invocation_sym469.Results.Ident1 = ident1
+
+	return
+}
+
+// SetSaveStatementStub configures ReconciliationRepo.SaveStatement to always return the given values
+func (f_sym470 *FakeReconciliationRepo) SetSaveStatementStub(ident1 error) {
+	f_sym470.SaveStatementHook = func([]*model.Reconciliation, []model.ReconciledTransaction) error {
+		return ident1
+	}
+}
+
+// SetSaveStatementInvocation configures ReconciliationRepo.SaveStatement to return the given results when called with the given parameters
+// If no match is found for an invocation the result(s) of the fallback function are returned
+func (f_sym471 *FakeReconciliationRepo) SetSaveStatementInvocation(calls_sym471 []*ReconciliationRepoSaveStatementInvocation, fallback_sym471 func() error) {
+	f_sym471.SaveStatementHook = func(saved []*model.Reconciliation, created []model.ReconciledTransaction) (ident1 error) {
+		for _, call_sym471 := range calls_sym471 {
+			if reflect.DeepEqual(call_sym471.Parameters.Saved, saved) && reflect.DeepEqual(call_sym471.Parameters.Created, created) {
+				ident1 = call_sym471.Results.Ident1
 
 				return
 			}
 		}
 
-		return fallback_sym41()
+		return fallback_sym471()
 	}
 }
 
-// FindByUserCalled returns true if FakeTransactionRepo.FindByUser was called
-func (f *FakeTransactionRepo) FindByUserCalled() bool {
-	return len(f.FindByUserCalls) != 0
+// SaveStatementCalled returns true if FakeReconciliationRepo.SaveStatement was called
+func (f *FakeReconciliationRepo) SaveStatementCalled() bool {
+	return len(f.SaveStatementCalls) != 0
 }
 
-// AssertFindByUserCalled calls t.Error if FakeTransactionRepo.FindByUser was not called
-func (f *FakeTransactionRepo) AssertFindByUserCalled(t TransactionRepoTestingT) {
+// AssertSaveStatementCalled calls t.Error if FakeReconciliationRepo.SaveStatement was not called
+func (f *FakeReconciliationRepo) AssertSaveStatementCalled(t ReconciliationRepoTestingT) {
 	t.Helper()
-	if len(f.FindByUserCalls) == 0 {
-		t.Error("FakeTransactionRepo.FindByUser not called, expected at least one")
+	if len(f.SaveStatementCalls) == 0 {
+		t.Error("FakeReconciliationRepo.SaveStatement not called, expected at least one")
 	}
 }
 
-// FindByUserNotCalled returns true if FakeTransactionRepo.FindByUser was not called
-func (f *FakeTransactionRepo) FindByUserNotCalled() bool {
-	return len(f.FindByUserCalls) == 0
+// SaveStatementNotCalled returns true if FakeReconciliationRepo.SaveStatement was not called
+func (f *FakeReconciliationRepo) SaveStatementNotCalled() bool {
+	return len(f.SaveStatementCalls) == 0
 }
 
-// AssertFindByUserNotCalled calls t.Error if FakeTransactionRepo.FindByUser was called
-func (f *FakeTransactionRepo) AssertFindByUserNotCalled(t TransactionRepoTestingT) {
+// AssertSaveStatementNotCalled calls t.Error if FakeReconciliationRepo.SaveStatement was called
+func (f *FakeReconciliationRepo) AssertSaveStatementNotCalled(t ReconciliationRepoTestingT) {
 	t.Helper()
-	if len(f.FindByUserCalls) != 0 {
-		t.Error("FakeTransactionRepo.FindByUser called, expected none")
+	if len(f.SaveStatementCalls) != 0 {
+		t.Error("FakeReconciliationRepo.SaveStatement called, expected none")
 	}
 }
 
-// FindByUserCalledOnce returns true if FakeTransactionRepo.FindByUser was called exactly once
-func (f *FakeTransactionRepo) FindByUserCalledOnce() bool {
-	return len(f.FindByUserCalls) == 1
+// SaveStatementCalledOnce returns true if FakeReconciliationRepo.SaveStatement was called exactly once
+func (f *FakeReconciliationRepo) SaveStatementCalledOnce() bool {
+	return len(f.SaveStatementCalls) == 1
 }
 
-// AssertFindByUserCalledOnce calls t.Error if FakeTransactionRepo.FindByUser was not called exactly once
-func (f *FakeTransactionRepo) AssertFindByUserCalledOnce(t TransactionRepoTestingT) {
+// AssertSaveStatementCalledOnce calls t.Error if FakeReconciliationRepo.SaveStatement was not called exactly once
+func (f *FakeReconciliationRepo) AssertSaveStatementCalledOnce(t ReconciliationRepoTestingT) {
 	t.Helper()
-	if len(f.FindByUserCalls) != 1 {
-		t.Errorf("FakeTransactionRepo.FindByUser called %d times, expected 1", len(f.FindByUserCalls))
+	if len(f.SaveStatementCalls) != 1 {
+		t.Errorf("FakeReconciliationRepo.SaveStatement called %d times, expected 1", len(f.SaveStatementCalls))
 	}
 }
 
-// FindByUserCalledN returns true if FakeTransactionRepo.FindByUser was called at least n times
-func (f *FakeTransactionRepo) FindByUserCalledN(n int) bool {
-	return len(f.FindByUserCalls) >= n
+// SaveStatementCalledN returns true if FakeReconciliationRepo.SaveStatement was called at least n times
+func (f *FakeReconciliationRepo) SaveStatementCalledN(n int) bool {
+	return len(f.SaveStatementCalls) >= n
 }
 
-// AssertFindByUserCalledN calls t.Error if FakeTransactionRepo.FindByUser was called less than n times
-func (f *FakeTransactionRepo) AssertFindByUserCalledN(t TransactionRepoTestingT, n int) {
+// AssertSaveStatementCalledN calls t.Error if FakeReconciliationRepo.SaveStatement was called less than n times
+func (f *FakeReconciliationRepo) AssertSaveStatementCalledN(t ReconciliationRepoTestingT, n int) {
 	t.Helper()
-	if len(f.FindByUserCalls) < n {
-		t.Errorf("FakeTransactionRepo.FindByUser called %d times, expected >= %d", len(f.FindByUserCalls), n)
+	if len(f.SaveStatementCalls) < n {
+		t.Errorf("FakeReconciliationRepo.SaveStatement called %d times, expected >= %d", len(f.SaveStatementCalls), n)
 	}
 }
 
-// FindByUserCalledWith returns true if FakeTransactionRepo.FindByUser was called with the given values
-func (f_sym42 *FakeTransactionRepo) FindByUserCalledWith(userID int) bool {
-	for _, call_sym42 := range f_sym42.FindByUserCalls {
-		if reflect.DeepEqual(call_sym42.Parameters.UserID, userID) {
+// SaveStatementCalledWith returns true if FakeReconciliationRepo.SaveStatement was called with the given values
+func (f_sym472 *FakeReconciliationRepo) SaveStatementCalledWith(saved []*model.Reconciliation, created []model.ReconciledTransaction) bool {
+	for _, call_sym472 := range f_sym472.SaveStatementCalls {
+		if reflect.DeepEqual(call_sym472.Parameters.Saved, saved) && reflect.DeepEqual(call_sym472.Parameters.Created, created) {
 			return true
 		}
 	}
@@ -1260,56 +3935,55 @@ func (f_sym42 *FakeTransactionRepo) FindByUserCalledWith(userID int) bool {
 	return false
 }
 
-// AssertFindByUserCalledWith calls t.Error if FakeTransactionRepo.FindByUser was not called with the given values
-func (f_sym43 *FakeTransactionRepo) AssertFindByUserCalledWith(t TransactionRepoTestingT, userID int) {
+// AssertSaveStatementCalledWith calls t.Error if FakeReconciliationRepo.SaveStatement was not called with the given values
+func (f_sym473 *FakeReconciliationRepo) AssertSaveStatementCalledWith(t ReconciliationRepoTestingT, saved []*model.Reconciliation, created []model.ReconciledTransaction) {
 	t.Helper()
-	var found_sym43 bool
-	for _, call_sym43 := range f_sym43.FindByUserCalls {
-		if reflect.DeepEqual(call_sym43.Parameters.UserID, userID) {
-			found_sym43 = true
+	var found_sym473 bool
+	for _, call_sym473 := range f_sym473.SaveStatementCalls {
+		if reflect.DeepEqual(call_sym473.Parameters.Saved, saved) && reflect.DeepEqual(call_sym473.Parameters.Created, created) {
+			found_sym473 = true
 			break
 		}
 	}
 
-	if !found_sym43 {
-		t.Error("FakeTransactionRepo.FindByUser not called with expected parameters")
+	if !found_sym473 {
+		t.Error("FakeReconciliationRepo.SaveStatement not called with expected parameters")
 	}
 }
 
-// FindByUserCalledOnceWith returns true if FakeTransactionRepo.FindByUser was called exactly once with the given values
-func (f_sym44 *FakeTransactionRepo) FindByUserCalledOnceWith(userID int) bool {
-	var count_sym44 int
-	for _, call_sym44 := range f_sym44.FindByUserCalls {
-		if reflect.DeepEqual(call_sym44.Parameters.UserID, userID) {
-			count_sym44++
+// SaveStatementCalledOnceWith returns true if FakeReconciliationRepo.SaveStatement was called exactly once with the given values
+func (f_sym474 *FakeReconciliationRepo) SaveStatementCalledOnceWith(saved []*model.Reconciliation, created []model.ReconciledTransaction) bool {
+	var count_sym474 int
+	for _, call_sym474 := range f_sym474.SaveStatementCalls {
+		if reflect.DeepEqual(call_sym474.Parameters.Saved, saved) && reflect.DeepEqual(call_sym474.Parameters.Created, created) {
+			count_sym474++
 		}
 	}
 
-	return count_sym44 == 1
+	return count_sym474 == 1
 }
 
-// AssertFindByUserCalledOnceWith calls t.Error if FakeTransactionRepo.FindByUser was not called exactly once with the given values
-func (f_sym45 *FakeTransactionRepo) AssertFindByUserCalledOnceWith(t TransactionRepoTestingT, userID int) {
+// AssertSaveStatementCalledOnceWith calls t.Error if FakeReconciliationRepo.SaveStatement was not called exactly once with the given values
+func (f_sym475 *FakeReconciliationRepo) AssertSaveStatementCalledOnceWith(t ReconciliationRepoTestingT, saved []*model.Reconciliation, created []model.ReconciledTransaction) {
 	t.Helper()
-	var count_sym45 int
-	for _, call_sym45 := range f_sym45.FindByUserCalls {
-		if reflect.DeepEqual(call_sym45.Parameters.UserID, userID) {
-			count_sym45++
+	var count_sym475 int
+	for _, call_sym475 := range f_sym475.SaveStatementCalls {
+		if reflect.DeepEqual(call_sym475.Parameters.Saved, saved) && reflect.DeepEqual(call_sym475.Parameters.Created, created) {
+			count_sym475++
 		}
 	}
 
-	if count_sym45 != 1 {
-		t.Errorf("FakeTransactionRepo.FindByUser called %d times with expected parameters, expected one", count_sym45)
+	if count_sym475 != 1 {
+		t.Errorf("FakeReconciliationRepo.SaveStatement called %d times with expected parameters, expected one", count_sym475)
 	}
 }
 
-// FindByUserResultsForCall returns the result values for the first call to FakeTransactionRepo.FindByUser with the given values
-func (f_sym46 *FakeTransactionRepo) FindByUserResultsForCall(userID int) (ident1 []model.Transaction, ident2 error, found_sym46 bool) {
-	for _, call_sym46 := range f_sym46.FindByUserCalls {
-		if reflect.DeepEqual(call_sym46.Parameters.UserID, userID) {
-			ident1 = call_sym46.Results.Ident1
-			ident2 = call_sym46.Results.Ident2
-			found_sym46 = true
+// SaveStatementResultsForCall returns the result values for the first call to FakeReconciliationRepo.SaveStatement with the given values
+func (f_sym476 *FakeReconciliationRepo) SaveStatementResultsForCall(saved []*model.Reconciliation, created []model.ReconciledTransaction) (ident1 error, found_sym476 bool) {
+	for _, call_sym476 := range f_sym476.SaveStatementCalls {
+		if reflect.DeepEqual(call_sym476.Parameters.Saved, saved) && reflect.DeepEqual(call_sym476.Parameters.Created, created) {
+			ident1 = call_sym476.Results.Ident1
+			found_sym476 = true
 			break
 		}
 	}
@@ -1317,105 +3991,309 @@ func (f_sym46 *FakeTransactionRepo) FindByUserResultsForCall(userID int) (ident1
 	return
 }
 
-func (f_sym47 *FakeTransactionRepo) FindByUserAccount(userID int, accountID int) (ident1 []model.Transaction, ident2 error) {
-	if f_sym47.FindByUserAccountHook == nil {
-		panic("TransactionRepo.FindByUserAccount() called but FakeTransactionRepo.FindByUserAccountHook is nil")
+// PaymentRepoFindBatchInvocation represents a single call of FakePaymentRepo.FindBatch
+type PaymentRepoFindBatchInvocation struct {
+	Parameters struct {
+		Id int
+	}
+	Results struct {
+		Ident1 model.PaymentBatch
+		Ident2 error
 	}
+}
 
-	invocation_sym47 := new(TransactionRepoFindByUserAccountInvocation)
-	f_sym47.FindByUserAccountCalls = append(f_sym47.FindByUserAccountCalls, invocation_sym47)
+// NewPaymentRepoFindBatchInvocation creates a new instance of PaymentRepoFindBatchInvocation
+func NewPaymentRepoFindBatchInvocation(id int, ident1 model.PaymentBatch, ident2 error) *PaymentRepoFindBatchInvocation {
+	invocation := new(PaymentRepoFindBatchInvocation)
 
-	invocation_sym47.Parameters.UserID = userID
-	invocation_sym47.Parameters.AccountID = accountID
+	invocation.Parameters.Id = id
 
-	ident1, ident2 = f_sym47.FindByUserAccountHook(userID, accountID)
+	invocation.Results.Ident1 = ident1
+	invocation.Results.Ident2 = ident2
 
-	invocation_sym47.Results.Ident1 = ident1
-	invocation_sym47.Results.Ident2 = ident2
+	return invocation
+}
+
+// PaymentRepoFindByAccountInvocation represents a single call of FakePaymentRepo.FindByAccount
+type PaymentRepoFindByAccountInvocation struct {
+	Parameters struct {
+		AccountID int
+	}
+	Results struct {
+		Ident1 []model.PaymentBatch
+		Ident2 error
+	}
+}
+
+// NewPaymentRepoFindByAccountInvocation creates a new instance of PaymentRepoFindByAccountInvocation
+func NewPaymentRepoFindByAccountInvocation(accountID int, ident1 []model.PaymentBatch, ident2 error) *PaymentRepoFindByAccountInvocation {
+	invocation := new(PaymentRepoFindByAccountInvocation)
+
+	invocation.Parameters.AccountID = accountID
+
+	invocation.Results.Ident1 = ident1
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+// PaymentRepoCreateBatchInvocation represents a single call of FakePaymentRepo.CreateBatch
+type PaymentRepoCreateBatchInvocation struct {
+	Parameters struct {
+		B           *model.PaymentBatch
+		Withdrawals []*model.Transaction
+	}
+	Results struct {
+		Ident1 error
+	}
+}
+
+// NewPaymentRepoCreateBatchInvocation creates a new instance of PaymentRepoCreateBatchInvocation
+func NewPaymentRepoCreateBatchInvocation(b *model.PaymentBatch, withdrawals []*model.Transaction, ident1 error) *PaymentRepoCreateBatchInvocation {
+	invocation := new(PaymentRepoCreateBatchInvocation)
+
+	invocation.Parameters.B = b
+	invocation.Parameters.Withdrawals = withdrawals
+
+	invocation.Results.Ident1 = ident1
+
+	return invocation
+}
+
+// PaymentRepoTestingT represents the methods of "testing".T used by charlatan Fakes.  It avoids importing the testing package.
+// PaymentRepoConfirmInvocation represents a single call of FakePaymentRepo.Confirm
+type PaymentRepoConfirmInvocation struct {
+	Parameters struct {
+		TransactionID int
+	}
+	Results struct {
+		Ident1 model.Payment
+		Ident2 error
+	}
+}
+
+// NewPaymentRepoConfirmInvocation creates a new instance of PaymentRepoConfirmInvocation
+func NewPaymentRepoConfirmInvocation(transactionID int, ident1 model.Payment, ident2 error) *PaymentRepoConfirmInvocation {
+	invocation := new(PaymentRepoConfirmInvocation)
+
+	invocation.Parameters.TransactionID = transactionID
+
+	invocation.Results.Ident1 = ident1
+	invocation.Results.Ident2 = ident2
+
+	return invocation
+}
+
+type PaymentRepoTestingT interface {
+	Error(...interface{})
+	Errorf(string, ...interface{})
+	Fatal(...interface{})
+	Helper()
+}
+
+/*
+FakePaymentRepo is a mock implementation of PaymentRepo for testing.
+Use it in your tests as in this example:
+
+	package example
+
+	func TestWithPaymentRepo(t *testing.T) {
+		f := &mock.FakePaymentRepo{
+			FindBatchHook: func(id int) (ident1 model.PaymentBatch, ident2 error) {
+				// ensure parameters meet expections, signal errors using t, etc
+				return
+			},
+		}
+
+		// test code goes here ...
+
+		// assert state of FakeFindBatch ...
+		f.AssertFindBatchCalledOnce(t)
+	}
+
+Create anonymous function implementations for only those interface methods that
+should be called in the code under test.  This will force a panic if any
+unexpected calls are made to FakeFindBatch.
+*/
+type FakePaymentRepo struct {
+	FindBatchHook     func(int) (model.PaymentBatch, error)
+	FindByAccountHook func(int) ([]model.PaymentBatch, error)
+	ConfirmHook       func(int) (model.Payment, error)
+	CreateBatchHook   func(*model.PaymentBatch, []*model.Transaction) error
+
+	FindBatchCalls     []*PaymentRepoFindBatchInvocation
+	FindByAccountCalls []*PaymentRepoFindByAccountInvocation
+	ConfirmCalls       []*PaymentRepoConfirmInvocation
+	CreateBatchCalls   []*PaymentRepoCreateBatchInvocation
+}
+
+// NewFakePaymentRepoDefaultPanic returns an instance of FakePaymentRepo with all hooks configured to panic
+func NewFakePaymentRepoDefaultPanic() *FakePaymentRepo {
+	return &FakePaymentRepo{
+		FindBatchHook: func(int) (ident1 model.PaymentBatch, ident2 error) {
+			panic("Unexpected call to PaymentRepo.FindBatch")
+		},
+		FindByAccountHook: func(int) (ident1 []model.PaymentBatch, ident2 error) {
+			panic("Unexpected call to PaymentRepo.FindByAccount")
+		},
+		ConfirmHook: func(int) (ident1 model.Payment, ident2 error) {
+			panic("Unexpected call to PaymentRepo.Confirm")
+		},
+		CreateBatchHook: func(*model.PaymentBatch, []*model.Transaction) (ident1 error) {
+			panic("Unexpected call to PaymentRepo.CreateBatch")
+		},
+	}
+}
+
+// NewFakePaymentRepoDefaultFatal returns an instance of FakePaymentRepo with all hooks configured to call t.Fatal
+func NewFakePaymentRepoDefaultFatal(t_sym298 PaymentRepoTestingT) *FakePaymentRepo {
+	return &FakePaymentRepo{
+		FindBatchHook: func(int) (ident1 model.PaymentBatch, ident2 error) {
+			t_sym298.Fatal("Unexpected call to PaymentRepo.FindBatch")
+			return
+		},
+		FindByAccountHook: func(int) (ident1 []model.PaymentBatch, ident2 error) {
+			t_sym298.Fatal("Unexpected call to PaymentRepo.FindByAccount")
+			return
+		},
+		ConfirmHook: func(int) (ident1 model.Payment, ident2 error) {
+			t_sym298.Fatal("Unexpected call to PaymentRepo.Confirm")
+			return
+		},
+		CreateBatchHook: func(*model.PaymentBatch, []*model.Transaction) (ident1 error) {
+			t_sym298.Fatal("Unexpected call to PaymentRepo.CreateBatch")
+			return
+		},
+	}
+}
+
+// NewFakePaymentRepoDefaultError returns an instance of FakePaymentRepo with all hooks configured to call t.Error
+func NewFakePaymentRepoDefaultError(t_sym299 PaymentRepoTestingT) *FakePaymentRepo {
+	return &FakePaymentRepo{
+		FindBatchHook: func(int) (ident1 model.PaymentBatch, ident2 error) {
+			t_sym299.Error("Unexpected call to PaymentRepo.FindBatch")
+			return
+		},
+		FindByAccountHook: func(int) (ident1 []model.PaymentBatch, ident2 error) {
+			t_sym299.Error("Unexpected call to PaymentRepo.FindByAccount")
+			return
+		},
+		ConfirmHook: func(int) (ident1 model.Payment, ident2 error) {
+			t_sym299.Error("Unexpected call to PaymentRepo.Confirm")
+			return
+		},
+		CreateBatchHook: func(*model.PaymentBatch, []*model.Transaction) (ident1 error) {
+			t_sym299.Error("Unexpected call to PaymentRepo.CreateBatch")
+			return
+		},
+	}
+}
+
+func (f *FakePaymentRepo) Reset() {
+	f.FindBatchCalls = []*PaymentRepoFindBatchInvocation{}
+	f.FindByAccountCalls = []*PaymentRepoFindByAccountInvocation{}
+	f.ConfirmCalls = []*PaymentRepoConfirmInvocation{}
+	f.CreateBatchCalls = []*PaymentRepoCreateBatchInvocation{}
+}
+
+func (f_sym313 *FakePaymentRepo) FindBatch(id int) (ident1 model.PaymentBatch, ident2 error) {
+	if f_sym313.FindBatchHook == nil {
+		panic("PaymentRepo.FindBatch() called but FakePaymentRepo.FindBatchHook is nil")
+	}
+
+	invocation_sym313 := new(PaymentRepoFindBatchInvocation)
+	f_sym313.FindBatchCalls = append(f_sym313.FindBatchCalls, invocation_sym313)
+
+	invocation_sym313.Parameters.Id = id
+
+	ident1, ident2 = f_sym313.FindBatchHook(id)
+
+	invocation_sym313.Results.Ident1 = ident1
+	invocation_sym313.Results.Ident2 = ident2
 
 	return
 }
 
-// SetFindByUserAccountStub configures TransactionRepo.FindByUserAccount to always return the given values
-func (f_sym48 *FakeTransactionRepo) SetFindByUserAccountStub(ident1 []model.Transaction, ident2 error) {
-	f_sym48.FindByUserAccountHook = func(int, int) ([]model.Transaction, error) {
+// SetFindBatchStub configures PaymentRepo.FindBatch to always return the given values
+func (f_sym314 *FakePaymentRepo) SetFindBatchStub(ident1 model.PaymentBatch, ident2 error) {
+	f_sym314.FindBatchHook = func(int) (model.PaymentBatch, error) {
 		return ident1, ident2
 	}
 }
 
-// SetFindByUserAccountInvocation configures TransactionRepo.FindByUserAccount to return the given results when called with the given parameters
+// SetFindBatchInvocation configures PaymentRepo.FindBatch to return the given results when called with the given parameters
 // If no match is found for an invocation the result(s) of the fallback function are returned
-func (f_sym49 *FakeTransactionRepo) SetFindByUserAccountInvocation(calls_sym49 []*TransactionRepoFindByUserAccountInvocation, fallback_sym49 func() ([]model.Transaction, error)) {
-	f_sym49.FindByUserAccountHook = func(userID int, accountID int) (ident1 []model.Transaction, ident2 error) {
-		for _, call_sym49 := range calls_sym49 {
-			if reflect.DeepEqual(call_sym49.Parameters.UserID, userID) && reflect.DeepEqual(call_sym49.Parameters.AccountID, accountID) {
-				ident1 = call_sym49.Results.Ident1
-				ident2 = call_sym49.Results.Ident2
+func (f_sym315 *FakePaymentRepo) SetFindBatchInvocation(calls_sym315 []*PaymentRepoFindBatchInvocation, fallback_sym315 func() (model.PaymentBatch, error)) {
+	f_sym315.FindBatchHook = func(id int) (ident1 model.PaymentBatch, ident2 error) {
+		for _, call_sym315 := range calls_sym315 {
+			if reflect.DeepEqual(call_sym315.Parameters.Id, id) {
+				ident1 = call_sym315.Results.Ident1
+				ident2 = call_sym315.Results.Ident2
 
 				return
 			}
 		}
 
-		return fallback_sym49()
+		return fallback_sym315()
 	}
 }
 
-// FindByUserAccountCalled returns true if FakeTransactionRepo.FindByUserAccount was called
-func (f *FakeTransactionRepo) FindByUserAccountCalled() bool {
-	return len(f.FindByUserAccountCalls) != 0
+// FindBatchCalled returns true if FakePaymentRepo.FindBatch was called
+func (f *FakePaymentRepo) FindBatchCalled() bool {
+	return len(f.FindBatchCalls) != 0
 }
 
-// AssertFindByUserAccountCalled calls t.Error if FakeTransactionRepo.FindByUserAccount was not called
-func (f *FakeTransactionRepo) AssertFindByUserAccountCalled(t TransactionRepoTestingT) {
+// AssertFindBatchCalled calls t.Error if FakePaymentRepo.FindBatch was not called
+func (f *FakePaymentRepo) AssertFindBatchCalled(t PaymentRepoTestingT) {
 	t.Helper()
-	if len(f.FindByUserAccountCalls) == 0 {
-		t.Error("FakeTransactionRepo.FindByUserAccount not called, expected at least one")
+	if len(f.FindBatchCalls) == 0 {
+		t.Error("FakePaymentRepo.FindBatch not called, expected at least one")
 	}
 }
 
-// FindByUserAccountNotCalled returns true if FakeTransactionRepo.FindByUserAccount was not called
-func (f *FakeTransactionRepo) FindByUserAccountNotCalled() bool {
-	return len(f.FindByUserAccountCalls) == 0
+// FindBatchNotCalled returns true if FakePaymentRepo.FindBatch was not called
+func (f *FakePaymentRepo) FindBatchNotCalled() bool {
+	return len(f.FindBatchCalls) == 0
 }
 
-// AssertFindByUserAccountNotCalled calls t.Error if FakeTransactionRepo.FindByUserAccount was called
-func (f *FakeTransactionRepo) AssertFindByUserAccountNotCalled(t TransactionRepoTestingT) {
+// AssertFindBatchNotCalled calls t.Error if FakePaymentRepo.FindBatch was called
+func (f *FakePaymentRepo) AssertFindBatchNotCalled(t PaymentRepoTestingT) {
 	t.Helper()
-	if len(f.FindByUserAccountCalls) != 0 {
-		t.Error("FakeTransactionRepo.FindByUserAccount called, expected none")
+	if len(f.FindBatchCalls) != 0 {
+		t.Error("FakePaymentRepo.FindBatch called, expected none")
 	}
 }
 
-// FindByUserAccountCalledOnce returns true if FakeTransactionRepo.FindByUserAccount was called exactly once
-func (f *FakeTransactionRepo) FindByUserAccountCalledOnce() bool {
-	return len(f.FindByUserAccountCalls) == 1
+// FindBatchCalledOnce returns true if FakePaymentRepo.FindBatch was called exactly once
+func (f *FakePaymentRepo) FindBatchCalledOnce() bool {
+	return len(f.FindBatchCalls) == 1
 }
 
-// AssertFindByUserAccountCalledOnce calls t.Error if FakeTransactionRepo.FindByUserAccount was not called exactly once
-func (f *FakeTransactionRepo) AssertFindByUserAccountCalledOnce(t TransactionRepoTestingT) {
+// AssertFindBatchCalledOnce calls t.Error if FakePaymentRepo.FindBatch was not called exactly once
+func (f *FakePaymentRepo) AssertFindBatchCalledOnce(t PaymentRepoTestingT) {
 	t.Helper()
-	if len(f.FindByUserAccountCalls) != 1 {
-		t.Errorf("FakeTransactionRepo.FindByUserAccount called %d times, expected 1", len(f.FindByUserAccountCalls))
+	if len(f.FindBatchCalls) != 1 {
+		t.Errorf("FakePaymentRepo.FindBatch called %d times, expected 1", len(f.FindBatchCalls))
 	}
 }
 
-// FindByUserAccountCalledN returns true if FakeTransactionRepo.FindByUserAccount was called at least n times
-func (f *FakeTransactionRepo) FindByUserAccountCalledN(n int) bool {
-	return len(f.FindByUserAccountCalls) >= n
+// FindBatchCalledN returns true if FakePaymentRepo.FindBatch was called at least n times
+func (f *FakePaymentRepo) FindBatchCalledN(n int) bool {
+	return len(f.FindBatchCalls) >= n
 }
 
-// AssertFindByUserAccountCalledN calls t.Error if FakeTransactionRepo.FindByUserAccount was called less than n times
-func (f *FakeTransactionRepo) AssertFindByUserAccountCalledN(t TransactionRepoTestingT, n int) {
+// AssertFindBatchCalledN calls t.Error if FakePaymentRepo.FindBatch was called less than n times
+func (f *FakePaymentRepo) AssertFindBatchCalledN(t PaymentRepoTestingT, n int) {
 	t.Helper()
-	if len(f.FindByUserAccountCalls) < n {
-		t.Errorf("FakeTransactionRepo.FindByUserAccount called %d times, expected >= %d", len(f.FindByUserAccountCalls), n)
+	if len(f.FindBatchCalls) < n {
+		t.Errorf("FakePaymentRepo.FindBatch called %d times, expected >= %d", len(f.FindBatchCalls), n)
 	}
 }
 
-// FindByUserAccountCalledWith returns true if FakeTransactionRepo.FindByUserAccount was called with the given values
-func (f_sym50 *FakeTransactionRepo) FindByUserAccountCalledWith(userID int, accountID int) bool {
-	for _, call_sym50 := range f_sym50.FindByUserAccountCalls {
-		if reflect.DeepEqual(call_sym50.Parameters.UserID, userID) && reflect.DeepEqual(call_sym50.Parameters.AccountID, accountID) {
+// FindBatchCalledWith returns true if FakePaymentRepo.FindBatch was called with the given values
+func (f_sym316 *FakePaymentRepo) FindBatchCalledWith(id int) bool {
+	for _, call_sym316 := range f_sym316.FindBatchCalls {
+		if reflect.DeepEqual(call_sym316.Parameters.Id, id) {
 			return true
 		}
 	}
@@ -1423,56 +4301,56 @@ func (f_sym50 *FakeTransactionRepo) FindByUserAccountCalledWith(userID int, acco
 	return false
 }
 
-// AssertFindByUserAccountCalledWith calls t.Error if FakeTransactionRepo.FindByUserAccount was not called with the given values
-func (f_sym51 *FakeTransactionRepo) AssertFindByUserAccountCalledWith(t TransactionRepoTestingT, userID int, accountID int) {
+// AssertFindBatchCalledWith calls t.Error if FakePaymentRepo.FindBatch was not called with the given values
+func (f_sym317 *FakePaymentRepo) AssertFindBatchCalledWith(t PaymentRepoTestingT, id int) {
 	t.Helper()
-	var found_sym51 bool
-	for _, call_sym51 := range f_sym51.FindByUserAccountCalls {
-		if reflect.DeepEqual(call_sym51.Parameters.UserID, userID) && reflect.DeepEqual(call_sym51.Parameters.AccountID, accountID) {
-			found_sym51 = true
+	var found_sym317 bool
+	for _, call_sym317 := range f_sym317.FindBatchCalls {
+		if reflect.DeepEqual(call_sym317.Parameters.Id, id) {
+			found_sym317 = true
 			break
 		}
 	}
 
-	if !found_sym51 {
-		t.Error("FakeTransactionRepo.FindByUserAccount not called with expected parameters")
+	if !found_sym317 {
+		t.Error("FakePaymentRepo.FindBatch not called with expected parameters")
 	}
 }
 
-// FindByUserAccountCalledOnceWith returns true if FakeTransactionRepo.FindByUserAccount was called exactly once with the given values
-func (f_sym52 *FakeTransactionRepo) FindByUserAccountCalledOnceWith(userID int, accountID int) bool {
-	var count_sym52 int
-	for _, call_sym52 := range f_sym52.FindByUserAccountCalls {
-		if reflect.DeepEqual(call_sym52.Parameters.UserID, userID) && reflect.DeepEqual(call_sym52.Parameters.AccountID, accountID) {
-			count_sym52++
+// FindBatchCalledOnceWith returns true if FakePaymentRepo.FindBatch was called exactly once with the given values
+func (f_sym318 *FakePaymentRepo) FindBatchCalledOnceWith(id int) bool {
+	var count_sym318 int
+	for _, call_sym318 := range f_sym318.FindBatchCalls {
+		if reflect.DeepEqual(call_sym318.Parameters.Id, id) {
+			count_sym318++
 		}
 	}
 
-	return count_sym52 == 1
+	return count_sym318 == 1
 }
-
-// AssertFindByUserAccountCalledOnceWith calls t.Error if FakeTransactionRepo.FindByUserAccount was not called exactly once with the given values
-func (f_sym53 *FakeTransactionRepo) AssertFindByUserAccountCalledOnceWith(t TransactionRepoTestingT, userID int, accountID int) {
+
+// AssertFindBatchCalledOnceWith calls t.Error if FakePaymentRepo.FindBatch was not called exactly once with the given values
+func (f_sym319 *FakePaymentRepo) AssertFindBatchCalledOnceWith(t PaymentRepoTestingT, id int) {
 	t.Helper()
-	var count_sym53 int
-	for _, call_sym53 := range f_sym53.FindByUserAccountCalls {
-		if reflect.DeepEqual(call_sym53.Parameters.UserID, userID) && reflect.DeepEqual(call_sym53.Parameters.AccountID, accountID) {
-			count_sym53++
+	var count_sym319 int
+	for _, call_sym319 := range f_sym319.FindBatchCalls {
+		if reflect.DeepEqual(call_sym319.Parameters.Id, id) {
+			count_sym319++
 		}
 	}
 
-	if count_sym53 != 1 {
-		t.Errorf("FakeTransactionRepo.FindByUserAccount called %d times with expected parameters, expected one", count_sym53)
+	if count_sym319 != 1 {
+		t.Errorf("FakePaymentRepo.FindBatch called %d times with expected parameters, expected one", count_sym319)
 	}
 }
 
-// FindByUserAccountResultsForCall returns the result values for the first call to FakeTransactionRepo.FindByUserAccount with the given values
-func (f_sym54 *FakeTransactionRepo) FindByUserAccountResultsForCall(userID int, accountID int) (ident1 []model.Transaction, ident2 error, found_sym54 bool) {
-	for _, call_sym54 := range f_sym54.FindByUserAccountCalls {
-		if reflect.DeepEqual(call_sym54.Parameters.UserID, userID) && reflect.DeepEqual(call_sym54.Parameters.AccountID, accountID) {
-			ident1 = call_sym54.Results.Ident1
-			ident2 = call_sym54.Results.Ident2
-			found_sym54 = true
+// FindBatchResultsForCall returns the result values for the first call to FakePaymentRepo.FindBatch with the given values
+func (f_sym320 *FakePaymentRepo) FindBatchResultsForCall(id int) (ident1 model.PaymentBatch, ident2 error, found_sym320 bool) {
+	for _, call_sym320 := range f_sym320.FindBatchCalls {
+		if reflect.DeepEqual(call_sym320.Parameters.Id, id) {
+			ident1 = call_sym320.Results.Ident1
+			ident2 = call_sym320.Results.Ident2
+			found_sym320 = true
 			break
 		}
 	}
@@ -1480,102 +4358,104 @@ func (f_sym54 *FakeTransactionRepo) FindByUserAccountResultsForCall(userID int,
 	return
 }
 
-func (f_sym55 *FakeTransactionRepo) Create(ident1 *model.Transaction) (ident2 error) {
-	if f_sym55.CreateHook == nil {
-		panic("TransactionRepo.Create() called but FakeTransactionRepo.CreateHook is nil")
+func (f_sym323 *FakePaymentRepo) FindByAccount(accountID int) (ident1 []model.PaymentBatch, ident2 error) {
+	if f_sym323.FindByAccountHook == nil {
+		panic("PaymentRepo.FindByAccount() called but FakePaymentRepo.FindByAccountHook is nil")
 	}
 
-	invocation_sym55 := new(TransactionRepoCreateInvocation)
-	f_sym55.CreateCalls = append(f_sym55.CreateCalls, invocation_sym55)
+	invocation_sym323 := new(PaymentRepoFindByAccountInvocation)
+	f_sym323.FindByAccountCalls = append(f_sym323.FindByAccountCalls, invocation_sym323)
 
-	invocation_sym55.Parameters.Ident1 = ident1
+	invocation_sym323.Parameters.AccountID = accountID
 
-	ident2 = f_sym55.CreateHook(ident1)
+	ident1, ident2 = f_sym323.FindByAccountHook(accountID)
 
-	invocation_sym55.Results.Ident2 = ident2
+	invocation_sym323.Results.Ident1 = ident1
+	invocation_sym323.Results.Ident2 = ident2
 
 	return
 }
 
-// SetCreateStub configures TransactionRepo.Create to always return the given values
-func (f_sym56 *FakeTransactionRepo) SetCreateStub(ident2 error) {
-	f_sym56.CreateHook = func(*model.Transaction) error {
-		return ident2
+// SetFindByAccountStub configures PaymentRepo.FindByAccount to always return the given values
+func (f_sym324 *FakePaymentRepo) SetFindByAccountStub(ident1 []model.PaymentBatch, ident2 error) {
+	f_sym324.FindByAccountHook = func(int) ([]model.PaymentBatch, error) {
+		return ident1, ident2
 	}
 }
 
-// SetCreateInvocation configures TransactionRepo.Create to return the given results when called with the given parameters
+// SetFindByAccountInvocation configures PaymentRepo.FindByAccount to return the given results when called with the given parameters
 // If no match is found for an invocation the result(s) of the fallback function are returned
-func (f_sym57 *FakeTransactionRepo) SetCreateInvocation(calls_sym57 []*TransactionRepoCreateInvocation, fallback_sym57 func() error) {
-	f_sym57.CreateHook = func(ident1 *model.Transaction) (ident2 error) {
-		for _, call_sym57 := range calls_sym57 {
-			if reflect.DeepEqual(call_sym57.Parameters.Ident1, ident1) {
-				ident2 = call_sym57.Results.Ident2
+func (f_sym325 *FakePaymentRepo) SetFindByAccountInvocation(calls_sym325 []*PaymentRepoFindByAccountInvocation, fallback_sym325 func() ([]model.PaymentBatch, error)) {
+	f_sym325.FindByAccountHook = func(accountID int) (ident1 []model.PaymentBatch, ident2 error) {
+		for _, call_sym325 := range calls_sym325 {
+			if reflect.DeepEqual(call_sym325.Parameters.AccountID, accountID) {
+				ident1 = call_sym325.Results.Ident1
+				ident2 = call_sym325.Results.Ident2
 
 				return
 			}
 		}
 
-		return fallback_sym57()
+		return fallback_sym325()
 	}
 }
 
-// CreateCalled returns true if FakeTransactionRepo.Create was called
-func (f *FakeTransactionRepo) CreateCalled() bool {
-	return len(f.CreateCalls) != 0
+// FindByAccountCalled returns true if FakePaymentRepo.FindByAccount was called
+func (f *FakePaymentRepo) FindByAccountCalled() bool {
+	return len(f.FindByAccountCalls) != 0
 }
 
-// AssertCreateCalled calls t.Error if FakeTransactionRepo.Create was not called
-func (f *FakeTransactionRepo) AssertCreateCalled(t TransactionRepoTestingT) {
+// AssertFindByAccountCalled calls t.Error if FakePaymentRepo.FindByAccount was not called
+func (f *FakePaymentRepo) AssertFindByAccountCalled(t PaymentRepoTestingT) {
 	t.Helper()
-	if len(f.CreateCalls) == 0 {
-		t.Error("FakeTransactionRepo.Create not called, expected at least one")
+	if len(f.FindByAccountCalls) == 0 {
+		t.Error("FakePaymentRepo.FindByAccount not called, expected at least one")
 	}
 }
 
-// CreateNotCalled returns true if FakeTransactionRepo.Create was not called
-func (f *FakeTransactionRepo) CreateNotCalled() bool {
-	return len(f.CreateCalls) == 0
+// FindByAccountNotCalled returns true if FakePaymentRepo.FindByAccount was not called
+func (f *FakePaymentRepo) FindByAccountNotCalled() bool {
+	return len(f.FindByAccountCalls) == 0
 }
 
-// AssertCreateNotCalled calls t.Error if FakeTransactionRepo.Create was called
-func (f *FakeTransactionRepo) AssertCreateNotCalled(t TransactionRepoTestingT) {
+// AssertFindByAccountNotCalled calls t.Error if FakePaymentRepo.FindByAccount was called
+func (f *FakePaymentRepo) AssertFindByAccountNotCalled(t PaymentRepoTestingT) {
 	t.Helper()
-	if len(f.CreateCalls) != 0 {
-		t.Error("FakeTransactionRepo.Create called, expected none")
+	if len(f.FindByAccountCalls) != 0 {
+		t.Error("FakePaymentRepo.FindByAccount called, expected none")
 	}
 }
 
-// CreateCalledOnce returns true if FakeTransactionRepo.Create was called exactly once
-func (f *FakeTransactionRepo) CreateCalledOnce() bool {
-	return len(f.CreateCalls) == 1
+// FindByAccountCalledOnce returns true if FakePaymentRepo.FindByAccount was called exactly once
+func (f *FakePaymentRepo) FindByAccountCalledOnce() bool {
+	return len(f.FindByAccountCalls) == 1
 }
 
-// AssertCreateCalledOnce calls t.Error if FakeTransactionRepo.Create was not called exactly once
-func (f *FakeTransactionRepo) AssertCreateCalledOnce(t TransactionRepoTestingT) {
+// AssertFindByAccountCalledOnce calls t.Error if FakePaymentRepo.FindByAccount was not called exactly once
+func (f *FakePaymentRepo) AssertFindByAccountCalledOnce(t PaymentRepoTestingT) {
 	t.Helper()
-	if len(f.CreateCalls) != 1 {
-		t.Errorf("FakeTransactionRepo.Create called %d times, expected 1", len(f.CreateCalls))
+	if len(f.FindByAccountCalls) != 1 {
+		t.Errorf("FakePaymentRepo.FindByAccount called %d times, expected 1", len(f.FindByAccountCalls))
 	}
 }
 
-// CreateCalledN returns true if FakeTransactionRepo.Create was called at least n times
-func (f *FakeTransactionRepo) CreateCalledN(n int) bool {
-	return len(f.CreateCalls) >= n
+// FindByAccountCalledN returns true if FakePaymentRepo.FindByAccount was called at least n times
+func (f *FakePaymentRepo) FindByAccountCalledN(n int) bool {
+	return len(f.FindByAccountCalls) >= n
 }
 
-// AssertCreateCalledN calls t.Error if FakeTransactionRepo.Create was called less than n times
-func (f *FakeTransactionRepo) AssertCreateCalledN(t TransactionRepoTestingT, n int) {
+// AssertFindByAccountCalledN calls t.Error if FakePaymentRepo.FindByAccount was called less than n times
+func (f *FakePaymentRepo) AssertFindByAccountCalledN(t PaymentRepoTestingT, n int) {
 	t.Helper()
-	if len(f.CreateCalls) < n {
-		t.Errorf("FakeTransactionRepo.Create called %d times, expected >= %d", len(f.CreateCalls), n)
+	if len(f.FindByAccountCalls) < n {
+		t.Errorf("FakePaymentRepo.FindByAccount called %d times, expected >= %d", len(f.FindByAccountCalls), n)
 	}
 }
 
-// CreateCalledWith returns true if FakeTransactionRepo.Create was called with the given values
-func (f_sym58 *FakeTransactionRepo) CreateCalledWith(ident1 *model.Transaction) bool {
-	for _, call_sym58 := range f_sym58.CreateCalls {
-		if reflect.DeepEqual(call_sym58.Parameters.Ident1, ident1) {
+// FindByAccountCalledWith returns true if FakePaymentRepo.FindByAccount was called with the given values
+func (f_sym326 *FakePaymentRepo) FindByAccountCalledWith(accountID int) bool {
+	for _, call_sym326 := range f_sym326.FindByAccountCalls {
+		if reflect.DeepEqual(call_sym326.Parameters.AccountID, accountID) {
 			return true
 		}
 	}
@@ -1583,55 +4463,56 @@ func (f_sym58 *FakeTransactionRepo) CreateCalledWith(ident1 *model.Transaction)
 	return false
 }
 
-// AssertCreateCalledWith calls t.Error if FakeTransactionRepo.Create was not called with the given values
-func (f_sym59 *FakeTransactionRepo) AssertCreateCalledWith(t TransactionRepoTestingT, ident1 *model.Transaction) {
+// AssertFindByAccountCalledWith calls t.Error if FakePaymentRepo.FindByAccount was not called with the given values
+func (f_sym327 *FakePaymentRepo) AssertFindByAccountCalledWith(t PaymentRepoTestingT, accountID int) {
 	t.Helper()
-	var found_sym59 bool
-	for _, call_sym59 := range f_sym59.CreateCalls {
-		if reflect.DeepEqual(call_sym59.Parameters.Ident1, ident1) {
-			found_sym59 = true
+	var found_sym327 bool
+	for _, call_sym327 := range f_sym327.FindByAccountCalls {
+		if reflect.DeepEqual(call_sym327.Parameters.AccountID, accountID) {
+			found_sym327 = true
 			break
 		}
 	}
 
-	if !found_sym59 {
-		t.Error("FakeTransactionRepo.Create not called with expected parameters")
+	if !found_sym327 {
+		t.Error("FakePaymentRepo.FindByAccount not called with expected parameters")
 	}
 }
 
-// CreateCalledOnceWith returns true if FakeTransactionRepo.Create was called exactly once with the given values
-func (f_sym60 *FakeTransactionRepo) CreateCalledOnceWith(ident1 *model.Transaction) bool {
-	var count_sym60 int
-	for _, call_sym60 := range f_sym60.CreateCalls {
-		if reflect.DeepEqual(call_sym60.Parameters.Ident1, ident1) {
-			count_sym60++
+// FindByAccountCalledOnceWith returns true if FakePaymentRepo.FindByAccount was called exactly once with the given values
+func (f_sym328 *FakePaymentRepo) FindByAccountCalledOnceWith(accountID int) bool {
+	var count_sym328 int
+	for _, call_sym328 := range f_sym328.FindByAccountCalls {
+		if reflect.DeepEqual(call_sym328.Parameters.AccountID, accountID) {
+			count_sym328++
 		}
 	}
 
-	return count_sym60 == 1
+	return count_sym328 == 1
 }
 
-// AssertCreateCalledOnceWith calls t.Error if FakeTransactionRepo.Create was not called exactly once with the given values
-func (f_sym61 *FakeTransactionRepo) AssertCreateCalledOnceWith(t TransactionRepoTestingT, ident1 *model.Transaction) {
+// AssertFindByAccountCalledOnceWith calls t.Error if FakePaymentRepo.FindByAccount was not called exactly once with the given values
+func (f_sym329 *FakePaymentRepo) AssertFindByAccountCalledOnceWith(t PaymentRepoTestingT, accountID int) {
 	t.Helper()
-	var count_sym61 int
-	for _, call_sym61 := range f_sym61.CreateCalls {
-		if reflect.DeepEqual(call_sym61.Parameters.Ident1, ident1) {
-			count_sym61++
+	var count_sym329 int
+	for _, call_sym329 := range f_sym329.FindByAccountCalls {
+		if reflect.DeepEqual(call_sym329.Parameters.AccountID, accountID) {
+			count_sym329++
 		}
 	}
 
-	if count_sym61 != 1 {
-		t.Errorf("FakeTransactionRepo.Create called %d times with expected parameters, expected one", count_sym61)
+	if count_sym329 != 1 {
+		t.Errorf("FakePaymentRepo.FindByAccount called %d times with expected parameters, expected one", count_sym329)
 	}
 }
 
-// CreateResultsForCall returns the result values for the first call to FakeTransactionRepo.Create with the given values
-func (f_sym62 *FakeTransactionRepo) CreateResultsForCall(ident1 *model.Transaction) (ident2 error, found_sym62 bool) {
-	for _, call_sym62 := range f_sym62.CreateCalls {
-		if reflect.DeepEqual(call_sym62.Parameters.Ident1, ident1) {
-			ident2 = call_sym62.Results.Ident2
-			found_sym62 = true
+// FindByAccountResultsForCall returns the result values for the first call to FakePaymentRepo.FindByAccount with the given values
+func (f_sym330 *FakePaymentRepo) FindByAccountResultsForCall(accountID int) (ident1 []model.PaymentBatch, ident2 error, found_sym330 bool) {
+	for _, call_sym330 := range f_sym330.FindByAccountCalls {
+		if reflect.DeepEqual(call_sym330.Parameters.AccountID, accountID) {
+			ident1 = call_sym330.Results.Ident1
+			ident2 = call_sym330.Results.Ident2
+			found_sym330 = true
 			break
 		}
 	}
@@ -1639,102 +4520,104 @@ func (f_sym62 *FakeTransactionRepo) CreateResultsForCall(ident1 *model.Transacti
 	return
 }
 
-func (f_sym63 *FakeTransactionRepo) Update(ident1 *model.Transaction) (ident2 error) {
-	if f_sym63.UpdateHook == nil {
-		panic("TransactionRepo.Update() called but FakeTransactionRepo.UpdateHook is nil")
+func (f_sym333 *FakePaymentRepo) Confirm(transactionID int) (ident1 model.Payment, ident2 error) {
+	if f_sym333.ConfirmHook == nil {
+		panic("PaymentRepo.Confirm() called but FakePaymentRepo.ConfirmHook is nil")
 	}
 
-	invocation_sym63 := new(TransactionRepoUpdateInvocation)
-	f_sym63.UpdateCalls = append(f_sym63.UpdateCalls, invocation_sym63)
+	invocation_sym333 := new(PaymentRepoConfirmInvocation)
+	f_sym333.ConfirmCalls = append(f_sym333.ConfirmCalls, invocation_sym333)
 
-	invocation_sym63.Parameters.Ident1 = ident1
+	invocation_sym333.Parameters.TransactionID = transactionID
 
-	ident2 = f_sym63.UpdateHook(ident1)
+	ident1, ident2 = f_sym333.ConfirmHook(transactionID)
 
-	invocation_sym63.Results.Ident2 = ident2
+	invocation_sym333.Results.Ident1 = ident1
+	invocation_sym333.Results.Ident2 = ident2
 
 	return
 }
 
-// SetUpdateStub configures TransactionRepo.Update to always return the given values
-func (f_sym64 *FakeTransactionRepo) SetUpdateStub(ident2 error) {
-	f_sym64.UpdateHook = func(*model.Transaction) error {
-		return ident2
+// SetConfirmStub configures PaymentRepo.Confirm to always return the given values
+func (f_sym334 *FakePaymentRepo) SetConfirmStub(ident1 model.Payment, ident2 error) {
+	f_sym334.ConfirmHook = func(int) (model.Payment, error) {
+		return ident1, ident2
 	}
 }
 
-// SetUpdateInvocation configures TransactionRepo.Update to return the given results when called with the given parameters
+// SetConfirmInvocation configures PaymentRepo.Confirm to return the given results when called with the given parameters
 // If no match is found for an invocation the result(s) of the fallback function are returned
-func (f_sym65 *FakeTransactionRepo) SetUpdateInvocation(calls_sym65 []*TransactionRepoUpdateInvocation, fallback_sym65 func() error) {
-	f_sym65.UpdateHook = func(ident1 *model.Transaction) (ident2 error) {
-		for _, call_sym65 := range calls_sym65 {
-			if reflect.DeepEqual(call_sym65.Parameters.Ident1, ident1) {
-				ident2 = call_sym65.Results.Ident2
+func (f_sym335 *FakePaymentRepo) SetConfirmInvocation(calls_sym335 []*PaymentRepoConfirmInvocation, fallback_sym335 func() (model.Payment, error)) {
+	f_sym335.ConfirmHook = func(transactionID int) (ident1 model.Payment, ident2 error) {
+		for _, call_sym335 := range calls_sym335 {
+			if reflect.DeepEqual(call_sym335.Parameters.TransactionID, transactionID) {
+				ident1 = call_sym335.Results.Ident1
+				ident2 = call_sym335.Results.Ident2
 
 				return
 			}
 		}
 
-		return fallback_sym65()
+		return fallback_sym335()
 	}
 }
 
-// UpdateCalled returns true if FakeTransactionRepo.Update was called
-func (f *FakeTransactionRepo) UpdateCalled() bool {
-	return len(f.UpdateCalls) != 0
+// ConfirmCalled returns true if FakePaymentRepo.Confirm was called
+func (f *FakePaymentRepo) ConfirmCalled() bool {
+	return len(f.ConfirmCalls) != 0
 }
 
-// AssertUpdateCalled calls t.Error if FakeTransactionRepo.Update was not called
-func (f *FakeTransactionRepo) AssertUpdateCalled(t TransactionRepoTestingT) {
+// AssertConfirmCalled calls t.Error if FakePaymentRepo.Confirm was not called
+func (f *FakePaymentRepo) AssertConfirmCalled(t PaymentRepoTestingT) {
 	t.Helper()
-	if len(f.UpdateCalls) == 0 {
-		t.Error("FakeTransactionRepo.Update not called, expected at least one")
+	if len(f.ConfirmCalls) == 0 {
+		t.Error("FakePaymentRepo.Confirm not called, expected at least one")
 	}
 }
 
-// UpdateNotCalled returns true if FakeTransactionRepo.Update was not called
-func (f *FakeTransactionRepo) UpdateNotCalled() bool {
-	return len(f.UpdateCalls) == 0
+// ConfirmNotCalled returns true if FakePaymentRepo.Confirm was not called
+func (f *FakePaymentRepo) ConfirmNotCalled() bool {
+	return len(f.ConfirmCalls) == 0
 }
 
-// AssertUpdateNotCalled calls t.Error if FakeTransactionRepo.Update was called
-func (f *FakeTransactionRepo) AssertUpdateNotCalled(t TransactionRepoTestingT) {
+// AssertConfirmNotCalled calls t.Error if FakePaymentRepo.Confirm was called
+func (f *FakePaymentRepo) AssertConfirmNotCalled(t PaymentRepoTestingT) {
 	t.Helper()
-	if len(f.UpdateCalls) != 0 {
-		t.Error("FakeTransactionRepo.Update called, expected none")
+	if len(f.ConfirmCalls) != 0 {
+		t.Error("FakePaymentRepo.Confirm called, expected none")
 	}
 }
 
-// UpdateCalledOnce returns true if FakeTransactionRepo.Update was called exactly once
-func (f *FakeTransactionRepo) UpdateCalledOnce() bool {
-	return len(f.UpdateCalls) == 1
+// ConfirmCalledOnce returns true if FakePaymentRepo.Confirm was called exactly once
+func (f *FakePaymentRepo) ConfirmCalledOnce() bool {
+	return len(f.ConfirmCalls) == 1
 }
 
-// AssertUpdateCalledOnce calls t.Error if FakeTransactionRepo.Update was not called exactly once
-func (f *FakeTransactionRepo) AssertUpdateCalledOnce(t TransactionRepoTestingT) {
+// AssertConfirmCalledOnce calls t.Error if FakePaymentRepo.Confirm was not called exactly once
+func (f *FakePaymentRepo) AssertConfirmCalledOnce(t PaymentRepoTestingT) {
 	t.Helper()
-	if len(f.UpdateCalls) != 1 {
-		t.Errorf("FakeTransactionRepo.Update called %d times, expected 1", len(f.UpdateCalls))
+	if len(f.ConfirmCalls) != 1 {
+		t.Errorf("FakePaymentRepo.Confirm called %d times, expected 1", len(f.ConfirmCalls))
 	}
 }
 
-// UpdateCalledN returns true if FakeTransactionRepo.Update was called at least n times
-func (f *FakeTransactionRepo) UpdateCalledN(n int) bool {
-	return len(f.UpdateCalls) >= n
+// ConfirmCalledN returns true if FakePaymentRepo.Confirm was called at least n times
+func (f *FakePaymentRepo) ConfirmCalledN(n int) bool {
+	return len(f.ConfirmCalls) >= n
 }
 
-// AssertUpdateCalledN calls t.Error if FakeTransactionRepo.Update was called less than n times
-func (f *FakeTransactionRepo) AssertUpdateCalledN(t TransactionRepoTestingT, n int) {
+// AssertConfirmCalledN calls t.Error if FakePaymentRepo.Confirm was called less than n times
+func (f *FakePaymentRepo) AssertConfirmCalledN(t PaymentRepoTestingT, n int) {
 	t.Helper()
-	if len(f.UpdateCalls) < n {
-		t.Errorf("FakeTransactionRepo.Update called %d times, expected >= %d", len(f.UpdateCalls), n)
+	if len(f.ConfirmCalls) < n {
+		t.Errorf("FakePaymentRepo.Confirm called %d times, expected >= %d", len(f.ConfirmCalls), n)
 	}
 }
 
-// UpdateCalledWith returns true if FakeTransactionRepo.Update was called with the given values
-func (f_sym66 *FakeTransactionRepo) UpdateCalledWith(ident1 *model.Transaction) bool {
-	for _, call_sym66 := range f_sym66.UpdateCalls {
-		if reflect.DeepEqual(call_sym66.Parameters.Ident1, ident1) {
+// ConfirmCalledWith returns true if FakePaymentRepo.Confirm was called with the given values
+func (f_sym336 *FakePaymentRepo) ConfirmCalledWith(transactionID int) bool {
+	for _, call_sym336 := range f_sym336.ConfirmCalls {
+		if reflect.DeepEqual(call_sym336.Parameters.TransactionID, transactionID) {
 			return true
 		}
 	}
@@ -1742,55 +4625,56 @@ func (f_sym66 *FakeTransactionRepo) UpdateCalledWith(ident1 *model.Transaction)
 	return false
 }
 
-// AssertUpdateCalledWith calls t.Error if FakeTransactionRepo.Update was not called with the given values
-func (f_sym67 *FakeTransactionRepo) AssertUpdateCalledWith(t TransactionRepoTestingT, ident1 *model.Transaction) {
+// AssertConfirmCalledWith calls t.Error if FakePaymentRepo.Confirm was not called with the given values
+func (f_sym337 *FakePaymentRepo) AssertConfirmCalledWith(t PaymentRepoTestingT, transactionID int) {
 	t.Helper()
-	var found_sym67 bool
-	for _, call_sym67 := range f_sym67.UpdateCalls {
-		if reflect.DeepEqual(call_sym67.Parameters.Ident1, ident1) {
-			found_sym67 = true
+	var found_sym337 bool
+	for _, call_sym337 := range f_sym337.ConfirmCalls {
+		if reflect.DeepEqual(call_sym337.Parameters.TransactionID, transactionID) {
+			found_sym337 = true
 			break
 		}
 	}
 
-	if !found_sym67 {
-		t.Error("FakeTransactionRepo.Update not called with expected parameters")
+	if !found_sym337 {
+		t.Error("FakePaymentRepo.Confirm not called with expected parameters")
 	}
 }
 
-// UpdateCalledOnceWith returns true if FakeTransactionRepo.Update was called exactly once with the given values
-func (f_sym68 *FakeTransactionRepo) UpdateCalledOnceWith(ident1 *model.Transaction) bool {
-	var count_sym68 int
-	for _, call_sym68 := range f_sym68.UpdateCalls {
-		if reflect.DeepEqual(call_sym68.Parameters.Ident1, ident1) {
-			count_sym68++
+// ConfirmCalledOnceWith returns true if FakePaymentRepo.Confirm was called exactly once with the given values
+func (f_sym338 *FakePaymentRepo) ConfirmCalledOnceWith(transactionID int) bool {
+	var count_sym338 int
+	for _, call_sym338 := range f_sym338.ConfirmCalls {
+		if reflect.DeepEqual(call_sym338.Parameters.TransactionID, transactionID) {
+			count_sym338++
 		}
 	}
 
-	return count_sym68 == 1
+	return count_sym338 == 1
 }
 
-// AssertUpdateCalledOnceWith calls t.Error if FakeTransactionRepo.Update was not called exactly once with the given values
-func (f_sym69 *FakeTransactionRepo) AssertUpdateCalledOnceWith(t TransactionRepoTestingT, ident1 *model.Transaction) {
+// AssertConfirmCalledOnceWith calls t.Error if FakePaymentRepo.Confirm was not called exactly once with the given values
+func (f_sym339 *FakePaymentRepo) AssertConfirmCalledOnceWith(t PaymentRepoTestingT, transactionID int) {
 	t.Helper()
-	var count_sym69 int
-	for _, call_sym69 := range f_sym69.UpdateCalls {
-		if reflect.DeepEqual(call_sym69.Parameters.Ident1, ident1) {
-			count_sym69++
+	var count_sym339 int
+	for _, call_sym339 := range f_sym339.ConfirmCalls {
+		if reflect.DeepEqual(call_sym339.Parameters.TransactionID, transactionID) {
+			count_sym339++
 		}
 	}
 
-	if count_sym69 != 1 {
-		t.Errorf("FakeTransactionRepo.Update called %d times with expected parameters, expected one", count_sym69)
+	if count_sym339 != 1 {
+		t.Errorf("FakePaymentRepo.Confirm called %d times with expected parameters, expected one", count_sym339)
 	}
 }
 
-// UpdateResultsForCall returns the result values for the first call to FakeTransactionRepo.Update with the given values
-func (f_sym70 *FakeTransactionRepo) UpdateResultsForCall(ident1 *model.Transaction) (ident2 error, found_sym70 bool) {
-	for _, call_sym70 := range f_sym70.UpdateCalls {
-		if reflect.DeepEqual(call_sym70.Parameters.Ident1, ident1) {
-			ident2 = call_sym70.Results.Ident2
-			found_sym70 = true
+// ConfirmResultsForCall returns the result values for the first call to FakePaymentRepo.Confirm with the given values
+func (f_sym340 *FakePaymentRepo) ConfirmResultsForCall(transactionID int) (ident1 model.Payment, ident2 error, found_sym340 bool) {
+	for _, call_sym340 := range f_sym340.ConfirmCalls {
+		if reflect.DeepEqual(call_sym340.Parameters.TransactionID, transactionID) {
+			ident1 = call_sym340.Results.Ident1
+			ident2 = call_sym340.Results.Ident2
+			found_sym340 = true
 			break
 		}
 	}
@@ -1798,103 +4682,103 @@ func (f_sym70 *FakeTransactionRepo) UpdateResultsForCall(ident1 *model.Transacti
 	return
 }
 
-func (f_sym71 *FakeTransactionRepo) Delete(userID int, tranID int) (ident1 error) {
-	if f_sym71.DeleteHook == nil {
-		panic("TransactionRepo.Delete() called but FakeTransactionRepo.DeleteHook is nil")
+func (f_sym477 *FakePaymentRepo) CreateBatch(b *model.PaymentBatch, withdrawals []*model.Transaction) (ident1 error) {
+	if f_sym477.CreateBatchHook == nil {
+		panic("PaymentRepo.CreateBatch() called but FakePaymentRepo.CreateBatchHook is nil")
 	}
 
-	invocation_sym71 := new(TransactionRepoDeleteInvocation)
-	f_sym71.DeleteCalls = append(f_sym71.DeleteCalls, invocation_sym71)
+	invocation_sym477 := new(PaymentRepoCreateBatchInvocation)
+	f_sym477.CreateBatchCalls = append(f_sym477.CreateBatchCalls, invocation_sym477)
 
-	invocation_sym71.Parameters.UserID = userID
-	invocation_sym71.Parameters.TranID = tranID
+	invocation_sym477.Parameters.B = b
+	invocation_sym477.Parameters.Withdrawals = withdrawals
 
-	ident1 = f_sym71.DeleteHook(userID, tranID)
+	ident1 = f_sym477.CreateBatchHook(b, withdrawals)
 
-	invocation_sym71.Results.Ident1 = ident1
+	invocation_sym477.Results.Ident1 = ident1
 
 	return
 }
 
-// SetDeleteStub configures TransactionRepo.Delete to always return the given values
-func (f_sym72 *FakeTransactionRepo) SetDeleteStub(ident1 error) {
-	f_sym72.DeleteHook = func(int, int) error {
+// SetCreateBatchStub configures PaymentRepo.CreateBatch to always return the given values
+func (f_sym478 *FakePaymentRepo) SetCreateBatchStub(ident1 error) {
+	f_sym478.CreateBatchHook = func(*model.PaymentBatch, []*model.Transaction) error {
 		return ident1
 	}
 }
 
-// SetDeleteInvocation configures TransactionRepo.Delete to return the given results when called with the given parameters
+// SetCreateBatchInvocation configures PaymentRepo.CreateBatch to return the given results when called with the given parameters
 // If no match is found for an invocation the result(s) of the fallback function are returned
-func (f_sym73 *FakeTransactionRepo) SetDeleteInvocation(calls_sym73 []*TransactionRepoDeleteInvocation, fallback_sym73 func() error) {
-	f_sym73.DeleteHook = func(userID int, tranID int) (ident1 error) {
-		for _, call_sym73 := range calls_sym73 {
-			if reflect.DeepEqual(call_sym73.Parameters.UserID, userID) && reflect.DeepEqual(call_sym73.Parameters.TranID, tranID) {
-				ident1 = call_sym73.Results.Ident1
+func (f_sym479 *FakePaymentRepo) SetCreateBatchInvocation(calls_sym479 []*PaymentRepoCreateBatchInvocation, fallback_sym479 func() error) {
+	f_sym479.CreateBatchHook = func(b *model.PaymentBatch, withdrawals []*model.Transaction) (ident1 error) {
+		for _, call_sym479 := range calls_sym479 {
+			if reflect.DeepEqual(call_sym479.Parameters.B, b) && reflect.DeepEqual(call_sym479.Parameters.Withdrawals, withdrawals) {
+				ident1 = call_sym479.Results.Ident1
 
 				return
 			}
 		}
 
-		return fallback_sym73()
+		return fallback_sym479()
 	}
 }
 
-// DeleteCalled returns true if FakeTransactionRepo.Delete was called
-func (f *FakeTransactionRepo) DeleteCalled() bool {
-	return len(f.DeleteCalls) != 0
+// CreateBatchCalled returns true if FakePaymentRepo.CreateBatch was called
+func (f *FakePaymentRepo) CreateBatchCalled() bool {
+	return len(f.CreateBatchCalls) != 0
 }
 
-// AssertDeleteCalled calls t.Error if FakeTransactionRepo.Delete was not called
-func (f *FakeTransactionRepo) AssertDeleteCalled(t TransactionRepoTestingT) {
+// AssertCreateBatchCalled calls t.Error if FakePaymentRepo.CreateBatch was not called
+func (f *FakePaymentRepo) AssertCreateBatchCalled(t PaymentRepoTestingT) {
 	t.Helper()
-	if len(f.DeleteCalls) == 0 {
-		t.Error("FakeTransactionRepo.Delete not called, expected at least one")
+	if len(f.CreateBatchCalls) == 0 {
+		t.Error("FakePaymentRepo.CreateBatch not called, expected at least one")
 	}
 }
 
-// DeleteNotCalled returns true if FakeTransactionRepo.Delete was not called
-func (f *FakeTransactionRepo) DeleteNotCalled() bool {
-	return len(f.DeleteCalls) == 0
+// CreateBatchNotCalled returns true if FakePaymentRepo.CreateBatch was not called
+func (f *FakePaymentRepo) CreateBatchNotCalled() bool {
+	return len(f.CreateBatchCalls) == 0
 }
 
-// AssertDeleteNotCalled calls t.Error if FakeTransactionRepo.Delete was called
-func (f *FakeTransactionRepo) AssertDeleteNotCalled(t TransactionRepoTestingT) {
+// AssertCreateBatchNotCalled calls t.Error if FakePaymentRepo.CreateBatch was called
+func (f *FakePaymentRepo) AssertCreateBatchNotCalled(t PaymentRepoTestingT) {
 	t.Helper()
-	if len(f.DeleteCalls) != 0 {
-		t.Error("FakeTransactionRepo.Delete called, expected none")
+	if len(f.CreateBatchCalls) != 0 {
+		t.Error("FakePaymentRepo.CreateBatch called, expected none")
 	}
 }
 
-// DeleteCalledOnce returns true if FakeTransactionRepo.Delete was called exactly once
-func (f *FakeTransactionRepo) DeleteCalledOnce() bool {
-	return len(f.DeleteCalls) == 1
+// CreateBatchCalledOnce returns true if FakePaymentRepo.CreateBatch was called exactly once
+func (f *FakePaymentRepo) CreateBatchCalledOnce() bool {
+	return len(f.CreateBatchCalls) == 1
 }
 
-// AssertDeleteCalledOnce calls t.Error if FakeTransactionRepo.Delete was not called exactly once
-func (f *FakeTransactionRepo) AssertDeleteCalledOnce(t TransactionRepoTestingT) {
+// AssertCreateBatchCalledOnce calls t.Error if FakePaymentRepo.CreateBatch was not called exactly once
+func (f *FakePaymentRepo) AssertCreateBatchCalledOnce(t PaymentRepoTestingT) {
 	t.Helper()
-	if len(f.DeleteCalls) != 1 {
-		t.Errorf("FakeTransactionRepo.Delete called %d times, expected 1", len(f.DeleteCalls))
+	if len(f.CreateBatchCalls) != 1 {
+		t.Errorf("FakePaymentRepo.CreateBatch called %d times, expected 1", len(f.CreateBatchCalls))
 	}
 }
 
-// DeleteCalledN returns true if FakeTransactionRepo.Delete was called at least n times
-func (f *FakeTransactionRepo) DeleteCalledN(n int) bool {
-	return len(f.DeleteCalls) >= n
+// CreateBatchCalledN returns true if FakePaymentRepo.CreateBatch was called at least n times
+func (f *FakePaymentRepo) CreateBatchCalledN(n int) bool {
+	return len(f.CreateBatchCalls) >= n
 }
 
-// AssertDeleteCalledN calls t.Error if FakeTransactionRepo.Delete was called less than n times
-func (f *FakeTransactionRepo) AssertDeleteCalledN(t TransactionRepoTestingT, n int) {
+// AssertCreateBatchCalledN calls t.Error if FakePaymentRepo.CreateBatch was called less than n times
+func (f *FakePaymentRepo) AssertCreateBatchCalledN(t PaymentRepoTestingT, n int) {
 	t.Helper()
-	if len(f.DeleteCalls) < n {
-		t.Errorf("FakeTransactionRepo.Delete called %d times, expected >= %d", len(f.DeleteCalls), n)
+	if len(f.CreateBatchCalls) < n {
+		t.Errorf("FakePaymentRepo.CreateBatch called %d times, expected >= %d", len(f.CreateBatchCalls), n)
 	}
 }
 
-// DeleteCalledWith returns true if FakeTransactionRepo.Delete was called with the given values
-func (f_sym74 *FakeTransactionRepo) DeleteCalledWith(userID int, tranID int) bool {
-	for _, call_sym74 := range f_sym74.DeleteCalls {
-		if reflect.DeepEqual(call_sym74.Parameters.UserID, userID) && reflect.DeepEqual(call_sym74.Parameters.TranID, tranID) {
+// CreateBatchCalledWith returns true if FakePaymentRepo.CreateBatch was called with the given values
+func (f_sym480 *FakePaymentRepo) CreateBatchCalledWith(b *model.PaymentBatch, withdrawals []*model.Transaction) bool {
+	for _, call_sym480 := range f_sym480.CreateBatchCalls {
+		if reflect.DeepEqual(call_sym480.Parameters.B, b) && reflect.DeepEqual(call_sym480.Parameters.Withdrawals, withdrawals) {
 			return true
 		}
 	}
@@ -1902,55 +4786,55 @@ func (f_sym74 *FakeTransactionRepo) DeleteCalledWith(userID int, tranID int) boo
 	return false
 }
 
-// AssertDeleteCalledWith calls t.Error if FakeTransactionRepo.Delete was not called with the given values
-func (f_sym75 *FakeTransactionRepo) AssertDeleteCalledWith(t TransactionRepoTestingT, userID int, tranID int) {
+// AssertCreateBatchCalledWith calls t.Error if FakePaymentRepo.CreateBatch was not called with the given values
+func (f_sym481 *FakePaymentRepo) AssertCreateBatchCalledWith(t PaymentRepoTestingT, b *model.PaymentBatch, withdrawals []*model.Transaction) {
 	t.Helper()
-	var found_sym75 bool
-	for _, call_sym75 := range f_sym75.DeleteCalls {
-		if reflect.DeepEqual(call_sym75.Parameters.UserID, userID) && reflect.DeepEqual(call_sym75.Parameters.TranID, tranID) {
-			found_sym75 = true
+	var found_sym481 bool
+	for _, call_sym481 := range f_sym481.CreateBatchCalls {
+		if reflect.DeepEqual(call_sym481.Parameters.B, b) && reflect.DeepEqual(call_sym481.Parameters.Withdrawals, withdrawals) {
+			found_sym481 = true
 			break
 		}
 	}
 
-	if !found_sym75 {
-		t.Error("FakeTransactionRepo.Delete not called with expected parameters")
+	if !found_sym481 {
+		t.Error("FakePaymentRepo.CreateBatch not called with expected parameters")
 	}
 }
 
-// DeleteCalledOnceWith returns true if FakeTransactionRepo.Delete was called exactly once with the given values
-func (f_sym76 *FakeTransactionRepo) DeleteCalledOnceWith(userID int, tranID int) bool {
-	var count_sym76 int
-	for _, call_sym76 := range f_sym76.DeleteCalls {
-		if reflect.DeepEqual(call_sym76.Parameters.UserID, userID) && reflect.DeepEqual(call_sym76.Parameters.TranID, tranID) {
-			count_sym76++
+// CreateBatchCalledOnceWith returns true if FakePaymentRepo.CreateBatch was called exactly once with the given values
+func (f_sym482 *FakePaymentRepo) CreateBatchCalledOnceWith(b *model.PaymentBatch, withdrawals []*model.Transaction) bool {
+	var count_sym482 int
+	for _, call_sym482 := range f_sym482.CreateBatchCalls {
+		if reflect.DeepEqual(call_sym482.Parameters.B, b) && reflect.DeepEqual(call_sym482.Parameters.Withdrawals, withdrawals) {
+			count_sym482++
 		}
 	}
 
-	return count_sym76 == 1
+	return count_sym482 == 1
 }
 
-// AssertDeleteCalledOnceWith calls t.Error if FakeTransactionRepo.Delete was not called exactly once with the given values
-func (f_sym77 *FakeTransactionRepo) AssertDeleteCalledOnceWith(t TransactionRepoTestingT, userID int, tranID int) {
+// AssertCreateBatchCalledOnceWith calls t.Error if FakePaymentRepo.CreateBatch was not called exactly once with the given values
+func (f_sym483 *FakePaymentRepo) AssertCreateBatchCalledOnceWith(t PaymentRepoTestingT, b *model.PaymentBatch, withdrawals []*model.Transaction) {
 	t.Helper()
-	var count_sym77 int
-	for _, call_sym77 := range f_sym77.DeleteCalls {
-		if reflect.DeepEqual(call_sym77.Parameters.UserID, userID) && reflect.DeepEqual(call_sym77.Parameters.TranID, tranID) {
-			count_sym77++
+	var count_sym483 int
+	for _, call_sym483 := range f_sym483.CreateBatchCalls {
+		if reflect.DeepEqual(call_sym483.Parameters.B, b) && reflect.DeepEqual(call_sym483.Parameters.Withdrawals, withdrawals) {
+			count_sym483++
 		}
 	}
 
-	if count_sym77 != 1 {
-		t.Errorf("FakeTransactionRepo.Delete called %d times with expected parameters, expected one", count_sym77)
+	if count_sym483 != 1 {
+		t.Errorf("FakePaymentRepo.CreateBatch called %d times with expected parameters, expected one", count_sym483)
 	}
 }
 
-// DeleteResultsForCall returns the result values for the first call to FakeTransactionRepo.Delete with the given values
-func (f_sym78 *FakeTransactionRepo) DeleteResultsForCall(userID int, tranID int) (ident1 error, found_sym78 bool) {
-	for _, call_sym78 := range f_sym78.DeleteCalls {
-		if reflect.DeepEqual(call_sym78.Parameters.UserID, userID) && reflect.DeepEqual(call_sym78.Parameters.TranID, tranID) {
-			ident1 = call_sym78.Results.Ident1
-			found_sym78 = true
+// CreateBatchResultsForCall returns the result values for the first call to FakePaymentRepo.CreateBatch with the given values
+func (f_sym484 *FakePaymentRepo) CreateBatchResultsForCall(b *model.PaymentBatch, withdrawals []*model.Transaction) (ident1 error, found_sym484 bool) {
+	for _, call_sym484 := range f_sym484.CreateBatchCalls {
+		if reflect.DeepEqual(call_sym484.Parameters.B, b) && reflect.DeepEqual(call_sym484.Parameters.Withdrawals, withdrawals) {
+			ident1 = call_sym484.Results.Ident1
+			found_sym484 = true
 			break
 		}
 	}
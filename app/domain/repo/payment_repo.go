@@ -0,0 +1,24 @@
+package repo
+
+import "go-prj-skeleton/app/domain/model"
+
+type PaymentRepo interface {
+	// FindBatch returns the batch with its payments, ordered by transaction
+	// id. A missing batch is ErrNotFound.
+	FindBatch(id int) (model.PaymentBatch, error)
+	// FindByAccount returns the batches of the account with their payments,
+	// ordered by id.
+	FindByAccount(accountID int) ([]model.PaymentBatch, error)
+	// CreateBatch inserts the withdrawals, then the batch and its payments,
+	// pending, in one database transaction: nothing is stored when it fails.
+	// The payments without TransactionID pay the withdrawals, in order. The
+	// account is locked while its balance is read, concurrent batches wait
+	// for each other, and withdrawals over the balance are
+	// ErrInsufficientFunds. It sets the ids, CreatedAt and the BatchID of the
+	// payments, a missing transaction is ErrNotFound.
+	CreateBatch(b *model.PaymentBatch, withdrawals []*model.Transaction) error
+	// Confirm marks the payment of the transaction confirmed, once: the
+	// ConfirmedAt of confirmed payments is kept. A transaction without
+	// payment is ErrNotFound.
+	Confirm(transactionID int) (model.Payment, error)
+}
@@ -0,0 +1,19 @@
+package repo
+
+import "go-prj-skeleton/app/domain/model"
+
+type ReconciliationRepo interface {
+	// FindByAccount returns the reconciliations of the transactions of the
+	// account, ordered by transaction id.
+	FindByAccount(accountID int) ([]model.Reconciliation, error)
+	// Save inserts the reconciliation of its transaction or replaces it. It
+	// sets AccountID to the account of the transaction and ReconciledAt, a
+	// missing transaction is ErrNotFound.
+	Save(*model.Reconciliation) error
+	// SaveStatement stores the outcome of a statement import in one database
+	// transaction, nothing is stored when it fails. It inserts the
+	// transactions of created, as TransactionRepo.Create does, sets the
+	// TransactionID of their reconciliations, then saves them and saved as
+	// Save does.
+	SaveStatement(saved []*model.Reconciliation, created []model.ReconciledTransaction) error
+}
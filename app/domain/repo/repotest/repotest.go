@@ -0,0 +1,840 @@
+// Package repotest is a conformance suite for implementations of the
+// repository interfaces declared in package repo.
+package repotest
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/domain/repo"
+)
+
+// Repos groups the repositories of one storage backend.
+type Repos struct {
+	User           repo.UserRepo
+	Account        repo.AccountRepo
+	Transaction    repo.TransactionRepo
+	Reconciliation repo.ReconciliationRepo
+	Payment        repo.PaymentRepo
+}
+
+// Fixture is the data a backend must contain before a test starts.
+type Fixture struct {
+	Users    []model.User
+	Accounts []model.Account
+	// Transactions are inserted as they are, with their id and created_at
+	Transactions []model.Transaction
+}
+
+// Factory returns repositories backed by an empty store seeded with the
+// fixture. It is called once per test case.
+type Factory func(t *testing.T, fixture Fixture) Repos
+
+// DefaultFixture is the data every test case runs against.
+var DefaultFixture = Fixture{
+	Users: []model.User{
+		{ID: 1, Name: "Alice"},
+		{ID: 2, Name: "Bob"},
+	},
+	Accounts: []model.Account{
+		{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB", Number: "0071000123456"},
+		{ID: 2, UserID: 1, Name: "Alice", Bank: "VIB"},
+		{ID: 3, UserID: 2, Name: "Bob", Bank: "ACB", Number: "190123", IBAN: "GB82WEST12345698765432"},
+	},
+}
+
+// Run exercises every method of UserRepo, AccountRepo, TransactionRepo,
+// ReconciliationRepo and PaymentRepo against the repositories returned by
+// factory.
+func Run(t *testing.T, factory Factory) {
+	t.Run("UserRepo", func(t *testing.T) {
+		testUserRepo(t, factory)
+	})
+
+	t.Run("AccountRepo", func(t *testing.T) {
+		testAccountRepo(t, factory)
+	})
+
+	t.Run("TransactionRepo", func(t *testing.T) {
+		testTransactionRepo(t, factory)
+	})
+
+	t.Run("ReconciliationRepo", func(t *testing.T) {
+		testReconciliationRepo(t, factory)
+	})
+
+	t.Run("PaymentRepo", func(t *testing.T) {
+		testPaymentRepo(t, factory)
+	})
+}
+
+func testUserRepo(t *testing.T, factory Factory) {
+	t.Run("FindByID", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		u, err := repos.User.FindByID(1)
+		require.NoError(t, err)
+		assert.Equal(t, model.User{ID: 1, Name: "Alice"}, u)
+	})
+
+	t.Run("FindByID not found", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		_, err := repos.User.FindByID(404)
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+
+	t.Run("Create and Update", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		u := &model.User{Name: "Carol"}
+		require.NoError(t, repos.User.Create(u))
+		assert.Greater(t, u.ID, 2)
+
+		u.Name = "Caroline"
+		require.NoError(t, repos.User.Update(u))
+
+		got, err := repos.User.FindByID(u.ID)
+		require.NoError(t, err)
+		assert.Equal(t, *u, got)
+	})
+
+	t.Run("Create and Update taken name", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		err := repos.User.Create(&model.User{Name: "Alice"})
+		assert.True(t, errors.Is(err, model.ErrConflict), "got %v", err)
+
+		err = repos.User.Update(&model.User{ID: 2, Name: "Alice"})
+		assert.True(t, errors.Is(err, model.ErrConflict), "got %v", err)
+	})
+
+	t.Run("Update not found", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		err := repos.User.Update(&model.User{ID: 404, Name: "Nobody"})
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+}
+
+func testAccountRepo(t *testing.T, factory Factory) {
+	t.Run("FindByID", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		acc, err := repos.Account.FindByID(3)
+		require.NoError(t, err)
+		assert.Equal(t, model.Account{ID: 3, UserID: 2, Name: "Bob", Bank: "ACB", Number: "190123", IBAN: "GB82WEST12345698765432"}, acc)
+	})
+
+	t.Run("FindByID not found", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		_, err := repos.Account.FindByID(404)
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+
+	t.Run("FindByUser is ordered by id", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		accs, err := repos.Account.FindByUser(1)
+		require.NoError(t, err)
+		assert.Equal(t, []model.Account{
+			{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB", Number: "0071000123456"},
+			{ID: 2, UserID: 1, Name: "Alice", Bank: "VIB"},
+		}, accs)
+	})
+
+	t.Run("FindByUser unknown user", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		accs, err := repos.Account.FindByUser(404)
+		require.NoError(t, err)
+		assert.Empty(t, accs)
+	})
+
+	t.Run("FindByNumber", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		acc, err := repos.Account.FindByNumber("ACB", "190123")
+		require.NoError(t, err)
+		assert.Equal(t, 3, acc.ID)
+		assert.Equal(t, "GB82WEST12345698765432", acc.IBAN)
+	})
+
+	t.Run("FindByNumber not found", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		// numbers are unique within a bank only, accounts without a number
+		// are not found by an empty one
+		for _, tc := range [][2]string{{"VIB", "0071000123456"}, {"VIB", ""}, {"VCB", "404"}} {
+			_, err := repos.Account.FindByNumber(tc[0], tc[1])
+			assert.True(t, errors.Is(err, model.ErrNotFound), "%v: got %v", tc, err)
+		}
+	})
+
+	t.Run("Create and Update", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		acc := &model.Account{UserID: 2, Name: "Bob", Bank: "VCB", Number: "0071000654321"}
+		require.NoError(t, repos.Account.Create(acc))
+		assert.Greater(t, acc.ID, 3)
+
+		got, err := repos.Account.FindByNumber("VCB", "0071000654321")
+		require.NoError(t, err)
+		assert.Equal(t, *acc, got)
+
+		// the user and bank are kept, an empty number is removed
+		require.NoError(t, repos.Account.Update(&model.Account{ID: acc.ID, UserID: 1, Name: "Bob Savings", Bank: "ACB", IBAN: "GB33BUKB20201555555555"}))
+
+		got, err = repos.Account.FindByID(acc.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.Account{ID: acc.ID, UserID: 2, Name: "Bob Savings", Bank: "VCB", IBAN: "GB33BUKB20201555555555"}, got)
+	})
+
+	t.Run("Create and Update number taken at the bank", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		err := repos.Account.Create(&model.Account{UserID: 2, Name: "Bob", Bank: "VCB", Number: "0071000123456"})
+		assert.True(t, errors.Is(err, model.ErrConflict), "got %v", err)
+
+		// numbers are unique within a bank only
+		require.NoError(t, repos.Account.Update(&model.Account{ID: 2, Name: "Alice", Number: "0071000123456"}))
+
+		acc := &model.Account{UserID: 2, Name: "Bob", Bank: "VCB", Number: "0071000654321"}
+		require.NoError(t, repos.Account.Create(acc))
+
+		err = repos.Account.Update(&model.Account{ID: acc.ID, Name: "Bob", Number: "0071000123456"})
+		assert.True(t, errors.Is(err, model.ErrConflict), "got %v", err)
+
+		// accounts without a number never clash
+		require.NoError(t, repos.Account.Create(&model.Account{UserID: 2, Name: "Bob", Bank: "ACB"}))
+	})
+
+	t.Run("Update not found", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		err := repos.Account.Update(&model.Account{ID: 404, Name: "Nobody"})
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+}
+
+func testTransactionRepo(t *testing.T, factory Factory) {
+	t.Run("Create and FindByID", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		tran := model.NewTransaction(1, 2, decimal.NewFromFloat(250.5), model.TransactionTypeDeposit)
+		require.NoError(t, repos.Transaction.Create(tran))
+		assert.NotZero(t, tran.ID)
+		assert.NotEmpty(t, tran.CreatedAt)
+
+		got, err := repos.Transaction.FindByID(tran.ID)
+		require.NoError(t, err)
+		assertTransaction(t, *tran, got)
+	})
+
+	t.Run("FindByID not found", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		_, err := repos.Transaction.FindByID(404)
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+
+	t.Run("FindByUser is ordered by id", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeDeposit),
+			model.NewTransaction(2, 3, decimal.NewFromInt(200), model.TransactionTypeDeposit),
+			model.NewTransaction(1, 2, decimal.NewFromInt(300), model.TransactionTypeWithdraw),
+			model.NewTransaction(1, 1, decimal.NewFromInt(400), model.TransactionTypeWithdraw),
+		)
+
+		trans, err := repos.Transaction.FindByUser(1)
+		require.NoError(t, err)
+		assertTransactions(t, []model.Transaction{created[0], created[2], created[3]}, trans)
+
+		trans, err = repos.Transaction.FindByUser(404)
+		require.NoError(t, err)
+		assert.Empty(t, trans)
+	})
+
+	t.Run("FindByUserAccount", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeDeposit),
+			model.NewTransaction(1, 2, decimal.NewFromInt(200), model.TransactionTypeDeposit),
+			model.NewTransaction(1, 1, decimal.NewFromInt(300), model.TransactionTypeWithdraw),
+			model.NewTransaction(2, 3, decimal.NewFromInt(400), model.TransactionTypeDeposit),
+		)
+
+		trans, err := repos.Transaction.FindByUserAccount(1, 1)
+		require.NoError(t, err)
+		assertTransactions(t, []model.Transaction{created[0], created[2]}, trans)
+
+		// account 3 belongs to user 2
+		trans, err = repos.Transaction.FindByUserAccount(1, 3)
+		require.NoError(t, err)
+		assert.Empty(t, trans)
+
+		trans, err = repos.Transaction.FindByUserAccount(1, 404)
+		require.NoError(t, err)
+		assert.Empty(t, trans)
+	})
+
+	t.Run("EachByUser", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeDeposit),
+			model.NewTransaction(1, 2, decimal.NewFromInt(200), model.TransactionTypeDeposit),
+			model.NewTransaction(2, 3, decimal.NewFromInt(300), model.TransactionTypeDeposit),
+			model.NewTransaction(1, 1, decimal.NewFromInt(400), model.TransactionTypeWithdraw),
+		)
+
+		each := func(userID int, accountID *int) []model.Transaction {
+			trans := []model.Transaction{}
+			require.NoError(t, repos.Transaction.EachByUser(userID, accountID, func(tran model.Transaction) error {
+				trans = append(trans, tran)
+				return nil
+			}))
+
+			return trans
+		}
+
+		assertTransactions(t, []model.Transaction{created[0], created[1], created[3]}, each(1, nil))
+
+		accountID := 1
+		assertTransactions(t, []model.Transaction{created[0], created[3]}, each(1, &accountID))
+
+		// account 3 belongs to user 2
+		accountID = 3
+		assert.Empty(t, each(1, &accountID))
+
+		stop := errors.New("stop")
+		calls := 0
+		err := repos.Transaction.EachByUser(1, nil, func(model.Transaction) error {
+			calls++
+			return stop
+		})
+		assert.True(t, errors.Is(err, stop), "got %v", err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("EachByUser is ordered by created_at then id", func(t *testing.T) {
+		fixture := DefaultFixture
+		fixture.Transactions = []model.Transaction{
+			{ID: 1, UserID: 1, AccountID: 1, Amount: decimal.NewFromInt(100), TransactionType: model.TransactionTypeDeposit, CreatedAt: "2021-01-02 10:00:00 +0000"},
+			{ID: 2, UserID: 1, AccountID: 1, Amount: decimal.NewFromInt(200), TransactionType: model.TransactionTypeDeposit, CreatedAt: "2021-01-01 10:00:00 +0000"},
+			{ID: 3, UserID: 1, AccountID: 2, Amount: decimal.NewFromInt(300), TransactionType: model.TransactionTypeDeposit, CreatedAt: "2021-01-02 10:00:00 +0000"},
+			{ID: 4, UserID: 1, AccountID: 1, Amount: decimal.NewFromInt(400), TransactionType: model.TransactionTypeWithdraw, CreatedAt: "2020-12-31 10:00:00 +0000"},
+		}
+		repos := factory(t, fixture)
+
+		ids := func(accountID *int) []int {
+			out := []int{}
+			require.NoError(t, repos.Transaction.EachByUser(1, accountID, func(tran model.Transaction) error {
+				out = append(out, tran.ID)
+				return nil
+			}))
+
+			return out
+		}
+
+		assert.Equal(t, []int{4, 2, 1, 3}, ids(nil))
+
+		accountID := 1
+		assert.Equal(t, []int{4, 2, 1}, ids(&accountID))
+
+		// listings and pages stay in id order
+		trans, err := repos.Transaction.FindPage(1, nil, 0, 0)
+		require.NoError(t, err)
+		assertTransactions(t, fixture.Transactions, trans)
+	})
+
+	t.Run("FindPage", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeDeposit),
+			model.NewTransaction(1, 2, decimal.NewFromInt(200), model.TransactionTypeDeposit),
+			model.NewTransaction(2, 3, decimal.NewFromInt(300), model.TransactionTypeDeposit),
+			model.NewTransaction(1, 1, decimal.NewFromInt(400), model.TransactionTypeWithdraw),
+			model.NewTransaction(1, 1, decimal.NewFromInt(500), model.TransactionTypeDeposit),
+		)
+
+		trans, err := repos.Transaction.FindPage(1, nil, 0, 0)
+		require.NoError(t, err)
+		assertTransactions(t, []model.Transaction{created[0], created[1], created[3], created[4]}, trans)
+
+		trans, err = repos.Transaction.FindPage(1, nil, created[0].ID, 2)
+		require.NoError(t, err)
+		assertTransactions(t, []model.Transaction{created[1], created[3]}, trans)
+
+		accountID := 1
+		trans, err = repos.Transaction.FindPage(1, &accountID, created[3].ID, 2)
+		require.NoError(t, err)
+		assertTransactions(t, []model.Transaction{created[4]}, trans)
+
+		// account 3 belongs to user 2
+		accountID = 3
+		trans, err = repos.Transaction.FindPage(1, &accountID, 0, 0)
+		require.NoError(t, err)
+		assert.Empty(t, trans)
+	})
+
+	t.Run("Update only changes amount", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeDeposit),
+			model.NewTransaction(1, 1, decimal.NewFromInt(200), model.TransactionTypeDeposit),
+		)
+
+		update := created[0]
+		update.Amount = decimal.NewFromInt(150)
+		update.TransactionType = model.TransactionTypeWithdraw
+		require.NoError(t, repos.Transaction.Update(&update))
+
+		got, err := repos.Transaction.FindByID(created[0].ID)
+		require.NoError(t, err)
+		want := created[0]
+		want.Amount = decimal.NewFromInt(150)
+		assertTransaction(t, want, got)
+
+		got, err = repos.Transaction.FindByID(created[1].ID)
+		require.NoError(t, err)
+		assertTransaction(t, created[1], got)
+	})
+
+	t.Run("Delete by owner", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeDeposit),
+		)
+
+		require.NoError(t, repos.Transaction.Delete(1, created[0].ID))
+
+		_, err := repos.Transaction.FindByID(created[0].ID)
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+
+	t.Run("Delete by another user is a no-op", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeDeposit),
+		)
+
+		require.NoError(t, repos.Transaction.Delete(2, created[0].ID))
+
+		got, err := repos.Transaction.FindByID(created[0].ID)
+		require.NoError(t, err)
+		assertTransaction(t, created[0], got)
+	})
+
+	t.Run("Create keeps a set CreatedAt", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		tran := model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeDeposit)
+		tran.CreatedAt = "2021-01-03 00:00:00 +0000"
+		require.NoError(t, repos.Transaction.Create(tran))
+		assert.Equal(t, "2021-01-03 00:00:00 +0000", tran.CreatedAt)
+
+		got, err := repos.Transaction.FindByID(tran.ID)
+		require.NoError(t, err)
+		assertTransaction(t, *tran, got)
+	})
+
+	t.Run("Delete missing transaction", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		assert.NoError(t, repos.Transaction.Delete(1, 404))
+	})
+
+	t.Run("concurrent Create", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		const n = 20
+
+		var wg sync.WaitGroup
+		created := make([]*model.Transaction, n)
+		errs := make([]error, n)
+		for i := 0; i < n; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				created[i] = model.NewTransaction(1, 1+i%2, decimal.NewFromInt(int64(i+1)), model.TransactionTypeDeposit)
+				errs[i] = repos.Transaction.Create(created[i])
+			}(i)
+		}
+		wg.Wait()
+
+		ids := map[int]bool{}
+		for i := 0; i < n; i++ {
+			require.NoError(t, errs[i])
+			assert.False(t, ids[created[i].ID], "duplicated id %v", created[i].ID)
+			ids[created[i].ID] = true
+		}
+
+		trans, err := repos.Transaction.FindByUser(1)
+		require.NoError(t, err)
+		assert.Len(t, trans, n)
+		for i := 1; i < len(trans); i++ {
+			assert.Less(t, trans[i-1].ID, trans[i].ID)
+		}
+	})
+}
+
+func testReconciliationRepo(t *testing.T, factory Factory) {
+	t.Run("Save and FindByAccount", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeDeposit),
+			model.NewTransaction(1, 2, decimal.NewFromInt(200), model.TransactionTypeDeposit),
+			model.NewTransaction(1, 1, decimal.NewFromInt(300), model.TransactionTypeWithdraw),
+		)
+
+		saved := []*model.Reconciliation{
+			{TransactionID: created[2].ID, Status: model.ReconciliationStatusCreated, Reference: "FT21003", StatementDate: "2021-01-03"},
+			{TransactionID: created[0].ID, Status: model.ReconciliationStatusMatched, Reference: "FT21001", StatementDate: "2021-01-01"},
+			{TransactionID: created[1].ID, Status: model.ReconciliationStatusMatched, StatementDate: "2021-01-02"},
+		}
+		for _, r := range saved {
+			require.NoError(t, repos.Reconciliation.Save(r))
+			assert.NotEmpty(t, r.ReconciledAt)
+		}
+		assert.Equal(t, 1, saved[0].AccountID)
+		assert.Equal(t, 2, saved[2].AccountID)
+
+		rs, err := repos.Reconciliation.FindByAccount(1)
+		require.NoError(t, err)
+		assert.Equal(t, []model.Reconciliation{*saved[1], *saved[0]}, rs)
+
+		rs, err = repos.Reconciliation.FindByAccount(3)
+		require.NoError(t, err)
+		assert.Empty(t, rs)
+	})
+
+	t.Run("Save replaces", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeDeposit),
+		)
+
+		require.NoError(t, repos.Reconciliation.Save(&model.Reconciliation{
+			TransactionID: created[0].ID, Status: model.ReconciliationStatusMatched, Reference: "A", StatementDate: "2021-01-01",
+		}))
+		r := &model.Reconciliation{TransactionID: created[0].ID, Status: model.ReconciliationStatusCreated, Reference: "B", StatementDate: "2021-01-02"}
+		require.NoError(t, repos.Reconciliation.Save(r))
+
+		rs, err := repos.Reconciliation.FindByAccount(1)
+		require.NoError(t, err)
+		assert.Equal(t, []model.Reconciliation{*r}, rs)
+	})
+
+	t.Run("Save value date", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeDeposit),
+		)
+
+		r := &model.Reconciliation{TransactionID: created[0].ID, Status: model.ReconciliationStatusMatched, StatementDate: "2021-01-02", ValueDate: "2021-01-04"}
+		require.NoError(t, repos.Reconciliation.Save(r))
+
+		rs, err := repos.Reconciliation.FindByAccount(1)
+		require.NoError(t, err)
+		require.Len(t, rs, 1)
+		assert.Equal(t, "2021-01-04", rs[0].ValueDate)
+	})
+
+	t.Run("SaveStatement", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		matched := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeDeposit),
+		)
+
+		tran := model.NewTransaction(1, 1, decimal.NewFromInt(20), model.TransactionTypeWithdraw)
+		tran.CreatedAt = "2021-01-02 00:00:00 +0000"
+		saved := []*model.Reconciliation{
+			{TransactionID: matched[0].ID, Status: model.ReconciliationStatusMatched, Reference: "FT21001", StatementDate: "2021-01-01"},
+		}
+		created := []model.ReconciledTransaction{{
+			Transaction:    tran,
+			Reconciliation: &model.Reconciliation{Status: model.ReconciliationStatusCreated, Reference: "FEE", StatementDate: "2021-01-02", ValueDate: "2021-01-03"},
+		}}
+		require.NoError(t, repos.Reconciliation.SaveStatement(saved, created))
+		assert.NotZero(t, tran.ID)
+		assert.Equal(t, tran.ID, created[0].Reconciliation.TransactionID)
+
+		got, err := repos.Transaction.FindByID(tran.ID)
+		require.NoError(t, err)
+		assertTransaction(t, *tran, got)
+
+		rs, err := repos.Reconciliation.FindByAccount(1)
+		require.NoError(t, err)
+		assert.Equal(t, []model.Reconciliation{*saved[0], *created[0].Reconciliation}, rs)
+	})
+
+	t.Run("SaveStatement stores nothing when it fails", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		saved := []*model.Reconciliation{
+			{TransactionID: 404, Status: model.ReconciliationStatusMatched, StatementDate: "2021-01-01"},
+		}
+		created := []model.ReconciledTransaction{{
+			Transaction:    model.NewTransaction(1, 1, decimal.NewFromInt(20), model.TransactionTypeWithdraw),
+			Reconciliation: &model.Reconciliation{Status: model.ReconciliationStatusCreated, StatementDate: "2021-01-02"},
+		}}
+		err := repos.Reconciliation.SaveStatement(saved, created)
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+
+		trans, err := repos.Transaction.FindByUser(1)
+		require.NoError(t, err)
+		assert.Empty(t, trans)
+
+		rs, err := repos.Reconciliation.FindByAccount(1)
+		require.NoError(t, err)
+		assert.Empty(t, rs)
+	})
+
+	t.Run("Save missing transaction", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		err := repos.Reconciliation.Save(&model.Reconciliation{
+			TransactionID: 404, Status: model.ReconciliationStatusMatched, StatementDate: "2021-01-01",
+		})
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+
+	t.Run("deleted with its transaction", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeDeposit),
+		)
+		require.NoError(t, repos.Reconciliation.Save(&model.Reconciliation{
+			TransactionID: created[0].ID, Status: model.ReconciliationStatusMatched, StatementDate: "2021-01-01",
+		}))
+
+		require.NoError(t, repos.Transaction.Delete(1, created[0].ID))
+
+		rs, err := repos.Reconciliation.FindByAccount(1)
+		require.NoError(t, err)
+		assert.Empty(t, rs)
+	})
+}
+
+func testPaymentRepo(t *testing.T, factory Factory) {
+	newBatch := func(accountID int, trans ...model.Transaction) *model.PaymentBatch {
+		b := &model.PaymentBatch{UserID: 1, AccountID: accountID, DebtorAccount: "0011001234567", ExecutionDate: "2021-01-05"}
+		for _, tran := range trans {
+			b.Payments = append(b.Payments, model.Payment{
+				TransactionID: tran.ID, CreditorName: "Bob", CreditorAccount: "123456789", CreditorBank: "ACB", Remittance: "Invoice 1",
+			})
+		}
+
+		return b
+	}
+
+	t.Run("CreateBatch and FindBatch", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeWithdraw),
+			model.NewTransaction(1, 1, decimal.NewFromInt(200), model.TransactionTypeWithdraw),
+		)
+
+		b := newBatch(1, created[1], created[0])
+		require.NoError(t, repos.Payment.CreateBatch(b, nil))
+		assert.NotZero(t, b.ID)
+		assert.NotEmpty(t, b.CreatedAt)
+		for _, p := range b.Payments {
+			assert.Equal(t, b.ID, p.BatchID)
+			assert.Equal(t, model.PaymentStatusPending, p.Status)
+		}
+
+		got, err := repos.Payment.FindBatch(b.ID)
+		require.NoError(t, err)
+		want := *b
+		want.Payments = []model.Payment{b.Payments[1], b.Payments[0]}
+		assert.Equal(t, want, got)
+
+		_, err = repos.Payment.FindBatch(404)
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+
+	t.Run("FindByAccount is ordered by id", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeWithdraw),
+			model.NewTransaction(1, 2, decimal.NewFromInt(200), model.TransactionTypeWithdraw),
+			model.NewTransaction(1, 1, decimal.NewFromInt(300), model.TransactionTypeWithdraw),
+		)
+
+		first, other, second := newBatch(1, created[0]), newBatch(2, created[1]), newBatch(1, created[2])
+		for _, b := range []*model.PaymentBatch{first, other, second} {
+			require.NoError(t, repos.Payment.CreateBatch(b, nil))
+		}
+
+		bs, err := repos.Payment.FindByAccount(1)
+		require.NoError(t, err)
+		assert.Equal(t, []model.PaymentBatch{*first, *second}, bs)
+
+		bs, err = repos.Payment.FindByAccount(3)
+		require.NoError(t, err)
+		assert.Empty(t, bs)
+	})
+
+	t.Run("CreateBatch missing transaction", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		err := repos.Payment.CreateBatch(newBatch(1, model.Transaction{ID: 404}), nil)
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+
+		bs, err := repos.Payment.FindByAccount(1)
+		require.NoError(t, err)
+		assert.Empty(t, bs)
+	})
+
+	t.Run("CreateBatch with withdrawals", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(500), model.TransactionTypeDeposit),
+		)
+		withdrawals := []*model.Transaction{
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeWithdraw),
+			model.NewTransaction(1, 1, decimal.NewFromInt(200), model.TransactionTypeWithdraw),
+		}
+
+		b := newBatch(1, model.Transaction{}, model.Transaction{})
+		require.NoError(t, repos.Payment.CreateBatch(b, withdrawals))
+		for i, w := range withdrawals {
+			assert.NotZero(t, w.ID)
+			assert.Equal(t, w.ID, b.Payments[i].TransactionID)
+		}
+
+		got, err := repos.Payment.FindBatch(b.ID)
+		require.NoError(t, err)
+		assert.Equal(t, *b, got)
+
+		trans, err := repos.Transaction.FindByUserAccount(1, 1)
+		require.NoError(t, err)
+		assert.Len(t, trans, 3)
+	})
+
+	t.Run("CreateBatch over the balance stores nothing", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(250), model.TransactionTypeDeposit),
+		)
+		withdrawals := []*model.Transaction{
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeWithdraw),
+			model.NewTransaction(1, 1, decimal.NewFromInt(200), model.TransactionTypeWithdraw),
+		}
+
+		err := repos.Payment.CreateBatch(newBatch(1, model.Transaction{}, model.Transaction{}), withdrawals)
+		assert.True(t, errors.Is(err, model.ErrInsufficientFunds), "got %v", err)
+
+		bs, err := repos.Payment.FindByAccount(1)
+		require.NoError(t, err)
+		assert.Empty(t, bs)
+
+		trans, err := repos.Transaction.FindByUserAccount(1, 1)
+		require.NoError(t, err)
+		assert.Len(t, trans, 1)
+	})
+
+	t.Run("Confirm", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeWithdraw),
+			model.NewTransaction(1, 1, decimal.NewFromInt(200), model.TransactionTypeWithdraw),
+		)
+		b := newBatch(1, created...)
+		require.NoError(t, repos.Payment.CreateBatch(b, nil))
+
+		p, err := repos.Payment.Confirm(created[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusConfirmed, p.Status)
+		assert.NotEmpty(t, p.ConfirmedAt)
+
+		again, err := repos.Payment.Confirm(created[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, p, again)
+
+		got, err := repos.Payment.FindBatch(b.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []model.Payment{p, b.Payments[1]}, got.Payments)
+
+		_, err = repos.Payment.Confirm(404)
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+
+	t.Run("deleted with its transaction", func(t *testing.T) {
+		repos := factory(t, DefaultFixture)
+
+		created := createTransactions(t, repos.Transaction,
+			model.NewTransaction(1, 1, decimal.NewFromInt(100), model.TransactionTypeWithdraw),
+		)
+		b := newBatch(1, created[0])
+		require.NoError(t, repos.Payment.CreateBatch(b, nil))
+
+		require.NoError(t, repos.Transaction.Delete(1, created[0].ID))
+
+		got, err := repos.Payment.FindBatch(b.ID)
+		require.NoError(t, err)
+		assert.Empty(t, got.Payments)
+	})
+}
+
+func createTransactions(t *testing.T, tranRepo repo.TransactionRepo, trans ...*model.Transaction) []model.Transaction {
+	t.Helper()
+
+	out := make([]model.Transaction, len(trans))
+	for i, tran := range trans {
+		require.NoError(t, tranRepo.Create(tran))
+		out[i] = *tran
+	}
+
+	return out
+}
+
+func assertTransactions(t *testing.T, want, got []model.Transaction) {
+	t.Helper()
+
+	if !assert.Len(t, got, len(want)) {
+		return
+	}
+
+	for i := range want {
+		assertTransaction(t, want[i], got[i])
+	}
+}
+
+func assertTransaction(t *testing.T, want, got model.Transaction) {
+	t.Helper()
+
+	assert.Equal(t, want.ID, got.ID)
+	assert.Equal(t, want.UserID, got.UserID)
+	assert.Equal(t, want.AccountID, got.AccountID)
+	assert.True(t, want.Amount.Equal(got.Amount), "amount: want %v, got %v", want.Amount, got.Amount)
+	assert.Equal(t, want.TransactionType, got.TransactionType)
+	assert.Equal(t, want.CreatedAt, got.CreatedAt)
+}
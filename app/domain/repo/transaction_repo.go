@@ -6,6 +6,18 @@ type TransactionRepo interface {
 	FindByID(id int) (model.Transaction, error)
 	FindByUser(userID int) ([]model.Transaction, error)
 	FindByUserAccount(userID, accountID int) ([]model.Transaction, error)
+	// EachByUser calls fn with the transactions FindByUser, or
+	// FindByUserAccount when accountID is not nil, would return, one at a
+	// time without loading them all. They come in the order they happened,
+	// by created_at then id, as a statement needs them for its running
+	// balance. It stops at the first error of fn.
+	EachByUser(userID int, accountID *int, fn func(model.Transaction) error) error
+	// FindPage returns, in id order, at most limit of the transactions
+	// EachByUser would call fn with whose id is greater than after. A zero
+	// limit returns all of them.
+	FindPage(userID int, accountID *int, after, limit int) ([]model.Transaction, error)
+	// Create inserts the transaction and sets its ID. It keeps a CreatedAt
+	// set, the booking date of a statement line, else sets it to now.
 	Create(*model.Transaction) error
 	Update(*model.Transaction) error
 	Delete(userID, tranID int) error
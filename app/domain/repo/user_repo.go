@@ -4,4 +4,8 @@ import "go-prj-skeleton/app/domain/model"
 
 type UserRepo interface {
 	FindByID(id int) (model.User, error)
+	// Create inserts u and sets its ID, a taken name is model.ErrConflict
+	Create(u *model.User) error
+	// Update saves the name of u, a missing user is model.ErrNotFound
+	Update(u *model.User) error
 }
@@ -0,0 +1,94 @@
+// Package health checks the dependencies the service needs to serve
+// requests, for the liveness, readiness and status probes.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Version and Commit describe the build, they are set with
+// -ldflags "-X go-prj-skeleton/app/health.Version=... -X go-prj-skeleton/app/health.Commit=..."
+var (
+	Version = "dev"
+	Commit  = ""
+)
+
+var started = time.Now()
+
+// Uptime is how long the process has been running
+func Uptime() time.Duration {
+	return time.Since(started)
+}
+
+// Check is one dependency, Func fails when the service can't use it
+type Check struct {
+	Name string
+	Func func(ctx context.Context) error
+}
+
+// Result is the outcome of a Check
+type Result struct {
+	Name    string
+	Latency time.Duration
+	Err     error
+}
+
+// Checker runs the checks of the dependencies
+type Checker struct {
+	timeout time.Duration
+	checks  []Check
+}
+
+// NewChecker runs checks, each one failing if it takes longer than timeout
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{
+		timeout,
+		checks,
+	}
+}
+
+// Run runs every check concurrently and returns their results in order,
+// ok tells they all passed
+func (c *Checker) Run(ctx context.Context) (_ []Result, ok bool) {
+	results := make([]Result, len(c.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = c.run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	ok = true
+	for _, r := range results {
+		if r.Err != nil {
+			ok = false
+		}
+	}
+
+	return results, ok
+}
+
+func (c *Checker) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// a check ignoring its context still fails on time
+	done := make(chan error, 1)
+	start := time.Now()
+	go func() { done <- check.Func(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	return Result{check.Name, time.Since(start), err}
+}
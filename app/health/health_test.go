@@ -0,0 +1,47 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecker_Run(t *testing.T) {
+	t.Parallel()
+
+	ok := Check{"ok", func(context.Context) error { return nil }}
+	failed := Check{"failed", func(context.Context) error { return errors.New("down") }}
+	slow := Check{"slow", func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	}}
+
+	t.Run("all pass", func(t *testing.T) {
+		results, passed := NewChecker(time.Second, ok).Run(context.Background())
+		assert.True(t, passed)
+		require.Len(t, results, 1)
+		assert.Equal(t, "ok", results[0].Name)
+		assert.NoError(t, results[0].Err)
+	})
+
+	t.Run("failed and timed out", func(t *testing.T) {
+		results, passed := NewChecker(10*time.Millisecond, ok, failed, slow).Run(context.Background())
+		assert.False(t, passed)
+		require.Len(t, results, 3)
+		assert.NoError(t, results[0].Err)
+		assert.EqualError(t, results[1].Err, "down")
+		assert.Equal(t, "slow", results[2].Name)
+		assert.ErrorIs(t, results[2].Err, context.DeadlineExceeded)
+		assert.Less(t, int64(results[2].Latency), int64(time.Second))
+	})
+
+	t.Run("no checks", func(t *testing.T) {
+		results, passed := NewChecker(time.Second).Run(context.Background())
+		assert.True(t, passed)
+		assert.Empty(t, results)
+	})
+}
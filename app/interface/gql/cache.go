@@ -0,0 +1,116 @@
+package gql
+
+import (
+	"context"
+	"sync"
+
+	"go-prj-skeleton/app/usecase"
+)
+
+type cacheKey struct{}
+
+// cache memoizes the reads of one request: the accounts of a user, and the
+// transactions of a user or of one of its accounts, are read once however
+// many fields need them. It doesn't batch, each user and each account listing
+// is its own read, filtered by the repository.
+type cache struct {
+	ctx         context.Context
+	userUsecase usecase.UserUsecase
+
+	mu           sync.Mutex
+	accounts     map[int]*accountsCall
+	transactions map[transactionsKey]*transactionsCall
+}
+
+// transactionsKey is a user, and an account of the user or 0 for all of them
+type transactionsKey struct {
+	userID    int
+	accountID int
+}
+
+type accountsCall struct {
+	once     sync.Once
+	accounts []usecase.Account
+	err      error
+}
+
+type transactionsCall struct {
+	once         sync.Once
+	transactions []usecase.Transaction
+	err          error
+}
+
+// newCache returns the cache of the request of ctx
+func newCache(ctx context.Context, userUsecase usecase.UserUsecase) *cache {
+	return &cache{
+		ctx:          ctx,
+		userUsecase:  userUsecase,
+		accounts:     map[int]*accountsCall{},
+		transactions: map[transactionsKey]*transactionsCall{},
+	}
+}
+
+func withCache(ctx context.Context, c *cache) context.Context {
+	return context.WithValue(ctx, cacheKey{}, c)
+}
+
+func cacheFrom(ctx context.Context) *cache {
+	return ctx.Value(cacheKey{}).(*cache)
+}
+
+// Accounts returns the accounts of userID
+func (c *cache) Accounts(userID int) ([]usecase.Account, error) {
+	c.mu.Lock()
+	call, ok := c.accounts[userID]
+	if !ok {
+		call = &accountsCall{}
+		c.accounts[userID] = call
+	}
+	c.mu.Unlock()
+
+	call.once.Do(func() {
+		call.accounts, call.err = c.userUsecase.FindAccounts(c.ctx, userID)
+	})
+
+	return call.accounts, call.err
+}
+
+// Account returns the account id of userID, ok is false when userID has no
+// such account
+func (c *cache) Account(userID, id int) (acc usecase.Account, ok bool, err error) {
+	accs, err := c.Accounts(userID)
+	if err != nil {
+		return usecase.Account{}, false, err
+	}
+
+	for _, acc := range accs {
+		if acc.ID == id {
+			return acc, true, nil
+		}
+	}
+
+	return usecase.Account{}, false, nil
+}
+
+// Transactions returns the transactions of userID, of every account when
+// accountID is nil
+func (c *cache) Transactions(userID int, accountID *int) ([]usecase.Transaction, error) {
+	key := transactionsKey{userID: userID}
+	if accountID != nil {
+		key.accountID = *accountID
+	}
+
+	c.mu.Lock()
+	call, ok := c.transactions[key]
+	if !ok {
+		call = &transactionsCall{}
+		c.transactions[key] = call
+	}
+	c.mu.Unlock()
+
+	call.once.Do(func() {
+		call.transactions, call.err = c.userUsecase.FindTransactions(c.ctx, userID, accountID)
+	})
+
+	return call.transactions, call.err
+}
@@ -0,0 +1,78 @@
+package gql
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/logger"
+	"go-prj-skeleton/app/requestid"
+)
+
+// resolverError is returned by every resolver. Its extensions carry the
+// stable error code of package problem, the request ID and the invalid
+// fields; internal errors don't expose their message.
+type resolverError struct {
+	err       error
+	code      model.ErrorCode
+	requestID string
+}
+
+func newError(ctx context.Context, err error) *resolverError {
+	if model.ErrorCodeOf(err) == model.CodeInternal {
+		logger.FromContext(ctx).WithError(err).Error("resolver failed")
+	}
+
+	return &resolverError{
+		err:       err,
+		code:      model.ErrorCodeOf(err),
+		requestID: requestid.FromContext(ctx),
+	}
+}
+
+func (e *resolverError) Error() string {
+	if e.code == model.CodeInternal {
+		return "the server could not process the request, quote the request_id when reporting it"
+	}
+
+	return e.err.Error()
+}
+
+func (e *resolverError) Unwrap() error {
+	return e.err
+}
+
+func (e *resolverError) Extensions() map[string]interface{} {
+	ext := map[string]interface{}{
+		"code": e.code,
+	}
+
+	if e.requestID != "" {
+		ext["request_id"] = e.requestID
+	}
+
+	var fieldErrs model.FieldErrors
+	if !errors.As(e.err, &fieldErrs) {
+		var fieldErr *model.FieldError
+		if errors.As(e.err, &fieldErr) {
+			fieldErrs = model.FieldErrors{fieldErr}
+		}
+	}
+
+	if len(fieldErrs) > 0 {
+		fields := make([]map[string]interface{}, len(fieldErrs))
+		for i, fe := range fieldErrs {
+			fields[i] = map[string]interface{}{
+				// validate reports JSON pointers, GraphQL input fields have no leading slash
+				"field":  strings.TrimPrefix(fe.Field, "/"),
+				"code":   model.ErrorCodeOf(fe.Err),
+				"detail": fe.Err.Error(),
+			}
+		}
+
+		ext["errors"] = fields
+	}
+
+	return ext
+}
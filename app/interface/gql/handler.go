@@ -0,0 +1,80 @@
+// Package gql serves the use cases as a GraphQL API, see schema.graphql.
+// Resolvers go through usecase.UserUsecase and share a per request cache,
+// errors carry the stable error codes of package problem in their
+// extensions.
+package gql
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	graphql "github.com/graph-gophers/graphql-go"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/problem"
+	"go-prj-skeleton/app/jsonutil"
+	"go-prj-skeleton/app/ratelimit"
+	"go-prj-skeleton/app/usecase"
+)
+
+//go:embed schema.graphql
+var schema string
+
+// maxBodyBytes limits the size of request bodies, like the REST API
+const maxBodyBytes = 64 << 10
+
+// maxDepth limits the nesting of queries
+const maxDepth = 8
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+type handler struct {
+	schema      *graphql.Schema
+	userUsecase usecase.UserUsecase
+}
+
+// NewHandler returns the handler of POST requests carrying a GraphQL query.
+// The mutations of transactions take their tokens from limiter like the
+// REST routes, for the client middleware.RateLimit identified; a nil limiter
+// doesn't limit them.
+func NewHandler(userUsecase usecase.UserUsecase, limiter *ratelimit.Limiter) *handler {
+	return &handler{
+		schema: graphql.MustParseSchema(schema, &rootResolver{userUsecase, limiter},
+			graphql.MaxDepth(maxDepth),
+		),
+		userUsecase: userUsecase,
+	}
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxBodyBytes+1))
+	if err != nil {
+		problem.Write(w, r, fmt.Errorf("read body: %w", err))
+		return
+	}
+
+	if len(body) > maxBodyBytes {
+		problem.Write(w, r, fmt.Errorf("body is larger than %v bytes: %w", maxBodyBytes, model.ErrPayloadTooLarge))
+		return
+	}
+
+	req := request{}
+	if err := json.Unmarshal(body, &req); err != nil || req.Query == "" {
+		problem.Write(w, r, model.FieldErrors{
+			{Field: "/query", Err: fmt.Errorf("must be a JSON object with a query: %w", model.ErrInvalid)},
+		})
+		return
+	}
+
+	ctx := withCache(r.Context(), newCache(r.Context(), h.userUsecase))
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Write(jsonutil.Marshal(resp))
+}
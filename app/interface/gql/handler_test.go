@@ -0,0 +1,224 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+	"go-prj-skeleton/app/interface/restful/middleware"
+	"go-prj-skeleton/app/ratelimit"
+	"go-prj-skeleton/app/usecase"
+)
+
+// countingUsecase counts the reads reaching the use case
+type countingUsecase struct {
+	usecase.UserUsecase
+
+	findAccounts     int32
+	findTransactions int32
+}
+
+func (u *countingUsecase) FindAccounts(ctx context.Context, userID int) ([]usecase.Account, error) {
+	atomic.AddInt32(&u.findAccounts, 1)
+	return u.UserUsecase.FindAccounts(ctx, userID)
+}
+
+func (u *countingUsecase) FindTransactions(ctx context.Context, userID int, accountID *int) ([]usecase.Transaction, error) {
+	atomic.AddInt32(&u.findTransactions, 1)
+	return u.UserUsecase.FindTransactions(ctx, userID, accountID)
+}
+
+func newTestUsecase() *countingUsecase {
+	store := memory.NewStore()
+	store.AddUser(model.User{ID: 1, Name: "Alice"})
+	store.AddUser(model.User{ID: 3, Name: "Bob"})
+	store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB", Number: "0071000123456"})
+	store.AddAccount(model.Account{ID: 2, UserID: 1, Name: "Alice", Bank: "ACB"})
+
+	return &countingUsecase{
+		UserUsecase: usecase.NewUserUsecase(
+			memory.NewUserRepo(store),
+			memory.NewAccountRepo(store),
+			memory.NewTransactionRepo(store),
+		),
+	}
+}
+
+type response struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func exec(t *testing.T, h http.Handler, query string, variables map[string]interface{}) response {
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	resp := response{}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+
+	return resp
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	uc := newTestUsecase()
+	h := NewHandler(uc, nil)
+
+	for _, acc := range []int{1, 2, 2} {
+		resp := exec(t, h, `mutation($acc: Int!) {
+			createTransaction(userId: 1, input: {accountId: $acc, amount: "100.5", transactionType: DEPOSIT}) { id amount bank transactionType }
+		}`, map[string]interface{}{"acc": acc})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, "100.50", resp.Data["createTransaction"].(map[string]interface{})["amount"])
+		assert.Equal(t, "DEPOSIT", resp.Data["createTransaction"].(map[string]interface{})["transactionType"])
+	}
+
+	t.Run("nested query is cached", func(t *testing.T) {
+		atomic.StoreInt32(&uc.findAccounts, 0)
+		atomic.StoreInt32(&uc.findTransactions, 0)
+
+		resp := exec(t, h, `{
+			user(id: 1) {
+				name
+				accounts { id bank transactions { id account { bank } } }
+				transactions { id bank account { id name } }
+			}
+		}`, nil)
+		require.Empty(t, resp.Errors)
+
+		user := resp.Data["user"].(map[string]interface{})
+		assert.Equal(t, "Alice", user["name"])
+		assert.Len(t, user["accounts"], 2)
+		assert.Len(t, user["transactions"], 3)
+
+		accounts := user["accounts"].([]interface{})
+		assert.Len(t, accounts[1].(map[string]interface{})["transactions"], 2)
+
+		// the accounts once, the transactions of the user and of each account
+		assert.Equal(t, int32(1), atomic.LoadInt32(&uc.findAccounts))
+		assert.Equal(t, int32(3), atomic.LoadInt32(&uc.findTransactions))
+	})
+
+	t.Run("account by number", func(t *testing.T) {
+		query := `query($user: Int!) { account(userId: $user, bank: "VCB", number: "0071000123456") { id number iban own } }`
+
+		resp := exec(t, h, query, map[string]interface{}{"user": 1})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"id": float64(1), "number": "*********3456", "iban": nil, "own": true}, resp.Data["account"])
+
+		resp = exec(t, h, query, map[string]interface{}{"user": 3})
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, map[string]interface{}{"id": float64(1), "number": "*********3456", "iban": nil, "own": false}, resp.Data["account"])
+
+		resp = exec(t, h, `{ user(id: 1) { accounts { number } } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"number": "*********3456"},
+			map[string]interface{}{"number": nil},
+		}, resp.Data["user"].(map[string]interface{})["accounts"])
+	})
+
+	t.Run("update and delete", func(t *testing.T) {
+		resp := exec(t, h, `mutation { updateTransaction(userId: 1, id: 1, input: {amount: "7"}) { amount } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, "7.00", resp.Data["updateTransaction"].(map[string]interface{})["amount"])
+
+		resp = exec(t, h, `mutation { deleteTransaction(userId: 1, id: 1) }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Equal(t, true, resp.Data["deleteTransaction"])
+
+		resp = exec(t, h, `{ transactions(userId: 1, accountId: 1) { id } }`, nil)
+		require.Empty(t, resp.Errors)
+		assert.Empty(t, resp.Data["transactions"])
+	})
+}
+
+func TestHandler_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	limits, err := ratelimit.ParseLimits("transactions.ip=1/m:1")
+	require.NoError(t, err)
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), limits)
+
+	// the route of /graphql is in no transactions group, its mutations
+	// take their tokens themselves from the client RateLimit identified
+	h := middleware.RateLimit(limiter, nil)(NewHandler(newTestUsecase(), limiter))
+
+	create := `mutation { createTransaction(userId: 1, input: {accountId: 1, amount: "1", transactionType: DEPOSIT}) { id } }`
+	resp := exec(t, h, create, nil)
+	require.Empty(t, resp.Errors)
+
+	resp = exec(t, h, create, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, string(model.CodeRateLimited), resp.Errors[0].Extensions["code"])
+
+	resp = exec(t, h, `{ transactions(userId: 1) { id } }`, nil)
+	require.Empty(t, resp.Errors)
+}
+
+func TestHandler_Errors(t *testing.T) {
+	t.Parallel()
+
+	h := NewHandler(newTestUsecase(), nil)
+
+	cases := []struct {
+		name   string
+		query  string
+		code   string
+		fields []interface{}
+	}{
+		{"unknown user", `{ user(id: 2) { name } }`, string(model.CodeNotFound), nil},
+		{"invalid amount", `mutation { createTransaction(userId: 1, input: {accountId: 1, amount: "0.001", transactionType: WITHDRAW}) { id } }`,
+			string(model.CodeInvalid), []interface{}{"amount"}},
+		{"malformed amount", `mutation { updateTransaction(userId: 1, id: 1, input: {amount: "ten"}) { id } }`,
+			string(model.CodeInvalid), []interface{}{"amount"}},
+		{"unknown account number", `{ account(userId: 1, bank: "VCB", number: "0071000999999") { id } }`, string(model.CodeNotFound), nil},
+		{"invalid account number", `{ account(userId: 1, bank: "VCB", number: "12") { id } }`, string(model.CodeInvalid), nil},
+		{"account of another user", `mutation { createTransaction(userId: 1, input: {accountId: 3, amount: "1", transactionType: WITHDRAW}) { id } }`,
+			string(model.CodeInvalid), nil},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			resp := exec(t, h, c.query, nil)
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, c.code, resp.Errors[0].Extensions["code"])
+
+			fields := []interface{}{}
+			if errs, ok := resp.Errors[0].Extensions["errors"].([]interface{}); ok {
+				for _, e := range errs {
+					fields = append(fields, e.(map[string]interface{})["field"])
+				}
+			}
+			assert.ElementsMatch(t, c.fields, fields)
+		})
+	}
+
+	t.Run("malformed request", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader("{")))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"field":"/query"`)
+	})
+}
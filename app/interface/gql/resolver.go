@@ -0,0 +1,347 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/middleware"
+	"go-prj-skeleton/app/interface/restful/validate"
+	"go-prj-skeleton/app/ratelimit"
+	"go-prj-skeleton/app/usecase"
+)
+
+// createTransaction and updateTransaction carry the same validation rules
+// as the REST request bodies, a nil field is a missing one
+type createTransaction struct {
+	AccountID *int             `json:"accountId" validate:"required,min=1"`
+	Amount    *decimal.Decimal `json:"amount" validate:"required,gt=0,max=99999999999999999.99,scale=2"`
+}
+
+type updateTransaction struct {
+	Amount *decimal.Decimal `json:"amount" validate:"required,gt=0,max=99999999999999999.99,scale=2"`
+}
+
+type rootResolver struct {
+	userUsecase usecase.UserUsecase
+	limiter     *ratelimit.Limiter
+}
+
+// limit takes a token of the transactions group from the buckets of the
+// client of ctx, the REST routes writing transactions take theirs in
+// middleware.RateLimit
+func (r *rootResolver) limit(ctx context.Context) error {
+	if r.limiter == nil {
+		return nil
+	}
+
+	return middleware.Limit(ctx, r.limiter, ratelimit.GroupTransactions, ratelimit.FromContext(ctx))
+}
+
+func (r *rootResolver) User(ctx context.Context, args struct{ ID int32 }) (*userResolver, error) {
+	user, err := r.userUsecase.FindUser(ctx, int(args.ID))
+	if err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	return &userResolver{*user}, nil
+}
+
+func (r *rootResolver) Account(ctx context.Context, args struct {
+	UserID int32
+	Bank   string
+	Number string
+}) (*accountMatchResolver, error) {
+	acc, err := r.userUsecase.FindAccountByNumber(ctx, int(args.UserID), args.Bank, args.Number)
+	if err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	return &accountMatchResolver{int(args.UserID), *acc}, nil
+}
+
+func (r *rootResolver) Transactions(ctx context.Context, args struct {
+	UserID    int32
+	AccountID *int32
+}) ([]*transactionResolver, error) {
+	var accountID *int
+	if args.AccountID != nil {
+		id := int(*args.AccountID)
+		accountID = &id
+	}
+
+	trans, err := r.userUsecase.FindTransactions(ctx, int(args.UserID), accountID)
+	if err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	return toTransactionResolvers(int(args.UserID), trans), nil
+}
+
+func (r *rootResolver) CreateTransaction(ctx context.Context, args struct {
+	UserID int32
+	Input  struct {
+		AccountID       int32
+		Amount          string
+		TransactionType string
+	}
+}) (*transactionResolver, error) {
+	if err := r.limit(ctx); err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	accountID := int(args.Input.AccountID)
+	payl := createTransaction{AccountID: &accountID}
+
+	amount, err := parseAmount(args.Input.Amount)
+	if err != nil {
+		return nil, newError(ctx, err)
+	}
+	payl.Amount = amount
+
+	if err := validate.Struct(&payl); err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	created, err := r.userUsecase.CreateTransaction(ctx, int(args.UserID), usecase.CreateTransaction{
+		AccountID:       *payl.AccountID,
+		Amount:          *payl.Amount,
+		TransactionType: model.TransactionType(strings.ToLower(args.Input.TransactionType)),
+	})
+	if err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	return &transactionResolver{int(args.UserID), *created}, nil
+}
+
+func (r *rootResolver) UpdateTransaction(ctx context.Context, args struct {
+	UserID int32
+	ID     int32
+	Input  struct{ Amount string }
+}) (*transactionResolver, error) {
+	if err := r.limit(ctx); err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	amount, err := parseAmount(args.Input.Amount)
+	if err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	payl := updateTransaction{Amount: amount}
+	if err := validate.Struct(&payl); err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	updated, err := r.userUsecase.UpdateTransaction(ctx, int(args.UserID), int(args.ID), usecase.UpdateTransaction{
+		Amount: *payl.Amount,
+	})
+	if err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	return &transactionResolver{int(args.UserID), *updated}, nil
+}
+
+func (r *rootResolver) DeleteTransaction(ctx context.Context, args struct {
+	UserID int32
+	ID     int32
+}) (bool, error) {
+	if err := r.limit(ctx); err != nil {
+		return false, newError(ctx, err)
+	}
+
+	if err := r.userUsecase.DeleteTransaction(ctx, int(args.UserID), int(args.ID)); err != nil {
+		return false, newError(ctx, err)
+	}
+
+	return true, nil
+}
+
+type userResolver struct {
+	user usecase.User
+}
+
+func (r *userResolver) ID() int32 {
+	return int32(r.user.ID)
+}
+
+func (r *userResolver) Name() string {
+	return r.user.Name
+}
+
+func (r *userResolver) Accounts(ctx context.Context) ([]*accountResolver, error) {
+	accs, err := cacheFrom(ctx).Accounts(r.user.ID)
+	if err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	out := make([]*accountResolver, len(accs))
+	for i := range accs {
+		out[i] = &accountResolver{accs[i]}
+	}
+
+	return out, nil
+}
+
+func (r *userResolver) Transactions(ctx context.Context, args struct{ AccountID *int32 }) ([]*transactionResolver, error) {
+	var accountID *int
+	if args.AccountID != nil {
+		id := int(*args.AccountID)
+		accountID = &id
+	}
+
+	trans, err := cacheFrom(ctx).Transactions(r.user.ID, accountID)
+	if err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	return toTransactionResolvers(r.user.ID, trans), nil
+}
+
+// accountResolver serves the number and IBAN of the account masked, the
+// GraphQL API does not authenticate its callers
+type accountResolver struct {
+	account usecase.Account
+}
+
+func (r *accountResolver) ID() int32 {
+	return int32(r.account.ID)
+}
+
+func (r *accountResolver) Name() string {
+	return r.account.Name
+}
+
+func (r *accountResolver) Bank() string {
+	return r.account.Bank
+}
+
+func (r *accountResolver) Number() *string {
+	return optional(r.account.Masked().Number)
+}
+
+func (r *accountResolver) IBAN() *string {
+	return optional(r.account.Masked().IBAN)
+}
+
+func (r *accountResolver) Transactions(ctx context.Context) ([]*transactionResolver, error) {
+	trans, err := cacheFrom(ctx).Transactions(r.account.UserID, &r.account.ID)
+	if err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	return toTransactionResolvers(r.account.UserID, trans), nil
+}
+
+// accountMatchResolver is an account found by its number for userID, it has
+// no transactions since the account may be of another user. Its number and
+// IBAN come masked from the use case.
+type accountMatchResolver struct {
+	userID  int
+	account usecase.Account
+}
+
+func (r *accountMatchResolver) ID() int32 {
+	return int32(r.account.ID)
+}
+
+func (r *accountMatchResolver) Name() string {
+	return r.account.Name
+}
+
+func (r *accountMatchResolver) Bank() string {
+	return r.account.Bank
+}
+
+func (r *accountMatchResolver) Number() *string {
+	return optional(r.account.Number)
+}
+
+func (r *accountMatchResolver) IBAN() *string {
+	return optional(r.account.IBAN)
+}
+
+func (r *accountMatchResolver) Own() bool {
+	return r.account.UserID == r.userID
+}
+
+type transactionResolver struct {
+	userID      int
+	transaction usecase.Transaction
+}
+
+func toTransactionResolvers(userID int, trans []usecase.Transaction) []*transactionResolver {
+	out := make([]*transactionResolver, len(trans))
+	for i := range trans {
+		out[i] = &transactionResolver{userID, trans[i]}
+	}
+
+	return out
+}
+
+func (r *transactionResolver) ID() int32 {
+	return int32(r.transaction.ID)
+}
+
+func (r *transactionResolver) AccountID() int32 {
+	return int32(r.transaction.AccountID)
+}
+
+func (r *transactionResolver) Amount() string {
+	return r.transaction.Amount.StringFixed(2)
+}
+
+func (r *transactionResolver) Bank() string {
+	return r.transaction.Bank
+}
+
+func (r *transactionResolver) TransactionType() string {
+	return strings.ToUpper(string(r.transaction.TransactionType))
+}
+
+func (r *transactionResolver) CreatedAt() string {
+	return r.transaction.CreatedAt
+}
+
+func (r *transactionResolver) Account(ctx context.Context) (*accountResolver, error) {
+	acc, ok, err := cacheFrom(ctx).Account(r.userID, r.transaction.AccountID)
+	if err != nil {
+		return nil, newError(ctx, err)
+	}
+
+	if !ok {
+		return nil, newError(ctx, fmt.Errorf("account[%v] %w", r.transaction.AccountID, model.ErrNotFound))
+	}
+
+	return &accountResolver{acc}, nil
+}
+
+// optional is nil for an empty s
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+// parseAmount parses a decimal amount, an empty string is a missing amount
+func parseAmount(s string) (*decimal.Decimal, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return nil, model.FieldErrors{
+			{Field: "/amount", Err: fmt.Errorf("must be a decimal number: %w", model.ErrInvalid)},
+		}
+	}
+
+	return &d, nil
+}
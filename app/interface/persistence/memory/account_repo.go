@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"sort"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+type accountRepo struct {
+	store *Store
+}
+
+func NewAccountRepo(store *Store) *accountRepo {
+	return &accountRepo{store}
+}
+
+func (repo *accountRepo) FindByUser(userID int) ([]model.Account, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	out := []model.Account{}
+	for _, acc := range repo.store.accounts {
+		if acc.UserID == userID {
+			out = append(out, acc)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].ID < out[j].ID
+	})
+
+	return out, nil
+}
+
+func (repo *accountRepo) FindByID(id int) (model.Account, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	acc, ok := repo.store.accounts[id]
+	if !ok {
+		return model.Account{}, model.ErrNotFound
+	}
+
+	return acc, nil
+}
+
+func (repo *accountRepo) FindByNumber(bank, number string) (model.Account, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	for _, acc := range repo.store.accounts {
+		if acc.Bank == bank && acc.Number == number && number != "" {
+			return acc, nil
+		}
+	}
+
+	return model.Account{}, model.ErrNotFound
+}
+
+func (repo *accountRepo) Create(acc *model.Account) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if repo.numberTaken(acc.Bank, acc.Number, 0) {
+		return model.ErrConflict
+	}
+
+	repo.store.lastAccountID++
+	acc.ID = repo.store.lastAccountID
+	repo.store.accounts[acc.ID] = *acc
+
+	return nil
+}
+
+func (repo *accountRepo) Update(acc *model.Account) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	saved, ok := repo.store.accounts[acc.ID]
+	if !ok {
+		return model.ErrNotFound
+	}
+
+	if repo.numberTaken(saved.Bank, acc.Number, acc.ID) {
+		return model.ErrConflict
+	}
+
+	saved.Name = acc.Name
+	saved.Number = acc.Number
+	saved.IBAN = acc.IBAN
+	repo.store.accounts[acc.ID] = saved
+
+	return nil
+}
+
+// numberTaken reports whether an account other than the one of id is
+// numbered number at bank, like the unique constraint on accounts. Accounts
+// without a number never clash.
+func (repo *accountRepo) numberTaken(bank, number string, id int) bool {
+	if number == "" {
+		return false
+	}
+
+	for _, acc := range repo.store.accounts {
+		if acc.Bank == bank && acc.Number == number && acc.ID != id {
+			return true
+		}
+	}
+
+	return false
+}
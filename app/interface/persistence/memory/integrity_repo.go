@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"sort"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+type integrityRepo struct {
+	store *Store
+}
+
+func NewIntegrityRepo(store *Store) *integrityRepo {
+	return &integrityRepo{store}
+}
+
+func (repo integrityRepo) EachAccount(fn func(model.Account) error) error {
+	repo.store.mu.RLock()
+	accs := make([]model.Account, 0, len(repo.store.accounts))
+	for _, acc := range repo.store.accounts {
+		accs = append(accs, acc)
+	}
+	repo.store.mu.RUnlock()
+
+	sort.Slice(accs, func(i, j int) bool {
+		return accs[i].ID < accs[j].ID
+	})
+
+	for _, acc := range accs {
+		if err := fn(acc); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo integrityRepo) EachTransaction(fn func(model.Transaction) error) error {
+	repo.store.mu.RLock()
+	trans := make([]model.Transaction, 0, len(repo.store.transactions))
+	for _, tran := range repo.store.transactions {
+		trans = append(trans, tran)
+	}
+	repo.store.mu.RUnlock()
+
+	sortTransactions(trans)
+
+	for _, tran := range trans {
+		if err := fn(tran); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo integrityRepo) SetTransactionUser(tranID, userID int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	tran, ok := repo.store.transactions[tranID]
+	if !ok {
+		return nil
+	}
+
+	tran.UserID = userID
+	repo.store.transactions[tranID] = tran
+
+	return nil
+}
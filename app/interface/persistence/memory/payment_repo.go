@@ -0,0 +1,159 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+type paymentRepo struct {
+	store *Store
+}
+
+func NewPaymentRepo(store *Store) *paymentRepo {
+	return &paymentRepo{store}
+}
+
+// withPayments returns b with its payments, the store must be locked
+func (repo paymentRepo) withPayments(b model.PaymentBatch) model.PaymentBatch {
+	b.Payments = []model.Payment{}
+	for _, p := range repo.store.payments {
+		if p.BatchID == b.ID {
+			b.Payments = append(b.Payments, p)
+		}
+	}
+	sort.Slice(b.Payments, func(i, j int) bool {
+		return b.Payments[i].TransactionID < b.Payments[j].TransactionID
+	})
+
+	return b
+}
+
+func (repo paymentRepo) FindBatch(id int) (model.PaymentBatch, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	b, ok := repo.store.paymentBatches[id]
+	if !ok {
+		return model.PaymentBatch{}, fmt.Errorf("payment batch[%v] %w", id, model.ErrNotFound)
+	}
+
+	return repo.withPayments(b), nil
+}
+
+func (repo paymentRepo) FindByAccount(accountID int) ([]model.PaymentBatch, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	out := []model.PaymentBatch{}
+	for _, b := range repo.store.paymentBatches {
+		if b.AccountID == accountID {
+			out = append(out, repo.withPayments(b))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].ID < out[j].ID
+	})
+
+	return out, nil
+}
+
+func (repo paymentRepo) CreateBatch(b *model.PaymentBatch, withdrawals []*model.Transaction) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	for _, p := range b.Payments {
+		if p.TransactionID == 0 {
+			continue
+		}
+
+		if _, ok := repo.store.transactions[p.TransactionID]; !ok {
+			return fmt.Errorf("create payment batch: transaction[%v] %w", p.TransactionID, model.ErrNotFound)
+		}
+
+		if paid, ok := repo.store.payments[p.TransactionID]; ok {
+			return fmt.Errorf("create payment batch: transaction[%v] is paid by batch[%v]: %w", p.TransactionID, paid.BatchID, model.ErrInvalid)
+		}
+	}
+
+	if len(withdrawals) > 0 {
+		if err := repo.insertWithdrawals(b.AccountID, withdrawals); err != nil {
+			return err
+		}
+	}
+
+	if err := b.Pay(withdrawals); err != nil {
+		// nobody saw the withdrawals, the store is locked
+		for _, t := range withdrawals {
+			delete(repo.store.transactions, t.ID)
+		}
+
+		return err
+	}
+
+	repo.store.lastPaymentBatchID++
+	b.ID = repo.store.lastPaymentBatchID
+	b.CreatedAt = time.Now().UTC().Format(model.CreatedAtLayout)
+
+	for i := range b.Payments {
+		b.Payments[i].BatchID = b.ID
+		b.Payments[i].Status = model.PaymentStatusPending
+		b.Payments[i].ConfirmedAt = ""
+		repo.store.payments[b.Payments[i].TransactionID] = b.Payments[i]
+	}
+
+	batch := *b
+	batch.Payments = nil
+	repo.store.paymentBatches[b.ID] = batch
+
+	return nil
+}
+
+// insertWithdrawals inserts the withdrawals of the account once its balance
+// covers them, s.mu is held
+func (repo paymentRepo) insertWithdrawals(accountID int, withdrawals []*model.Transaction) error {
+	balance := decimal.Zero
+	for _, t := range repo.store.transactions {
+		if t.AccountID == accountID {
+			balance = balance.Add(model.SignedAmount(t.TransactionType, t.Amount))
+		}
+	}
+
+	if err := model.CheckFunds(accountID, balance, withdrawals); err != nil {
+		return err
+	}
+
+	for _, t := range withdrawals {
+		if err := repo.store.checkTransaction(*t); err != nil {
+			return err
+		}
+	}
+
+	for _, t := range withdrawals {
+		repo.store.insertTransaction(t)
+	}
+
+	return nil
+}
+
+func (repo paymentRepo) Confirm(transactionID int) (model.Payment, error) {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	p, ok := repo.store.payments[transactionID]
+	if !ok {
+		return model.Payment{}, fmt.Errorf("payment of transaction[%v] %w", transactionID, model.ErrNotFound)
+	}
+
+	if p.Status != model.PaymentStatusConfirmed {
+		p.Status = model.PaymentStatusConfirmed
+		p.ConfirmedAt = time.Now().UTC().Format(model.CreatedAtLayout)
+		repo.store.payments[transactionID] = p
+	}
+
+	return p, nil
+}
@@ -0,0 +1,90 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+type reconciliationRepo struct {
+	store *Store
+}
+
+func NewReconciliationRepo(store *Store) *reconciliationRepo {
+	return &reconciliationRepo{store}
+}
+
+func (repo reconciliationRepo) FindByAccount(accountID int) ([]model.Reconciliation, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	out := []model.Reconciliation{}
+	for _, r := range repo.store.reconciliations {
+		if r.AccountID == accountID {
+			out = append(out, r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].TransactionID < out[j].TransactionID
+	})
+
+	return out, nil
+}
+
+func (repo reconciliationRepo) Save(r *model.Reconciliation) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	return repo.save(r)
+}
+
+// save saves r, s.mu is held
+func (repo reconciliationRepo) save(r *model.Reconciliation) error {
+	tran, ok := repo.store.transactions[r.TransactionID]
+	if !ok {
+		return fmt.Errorf("save reconciliation: transaction[%v] %w", r.TransactionID, model.ErrNotFound)
+	}
+
+	r.AccountID = tran.AccountID
+	r.ReconciledAt = time.Now().UTC().Format(model.CreatedAtLayout)
+	repo.store.reconciliations[r.TransactionID] = *r
+
+	return nil
+}
+
+// SaveStatement checks every write before the first one, the store is left
+// as is when one would fail
+func (repo reconciliationRepo) SaveStatement(saved []*model.Reconciliation, created []model.ReconciledTransaction) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	for _, r := range saved {
+		if _, ok := repo.store.transactions[r.TransactionID]; !ok {
+			return fmt.Errorf("save reconciliation: transaction[%v] %w", r.TransactionID, model.ErrNotFound)
+		}
+	}
+
+	for _, c := range created {
+		if err := repo.store.checkTransaction(*c.Transaction); err != nil {
+			return err
+		}
+	}
+
+	for _, c := range created {
+		repo.store.insertTransaction(c.Transaction)
+		c.Reconciliation.TransactionID = c.Transaction.ID
+		if err := repo.save(c.Reconciliation); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range saved {
+		if err := repo.save(r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
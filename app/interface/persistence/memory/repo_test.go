@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"testing"
+
+	"go-prj-skeleton/app/domain/repo/repotest"
+)
+
+func TestRepos(t *testing.T) {
+	repotest.Run(t, func(t *testing.T, fixture repotest.Fixture) repotest.Repos {
+		store := NewStore()
+		for _, u := range fixture.Users {
+			store.AddUser(u)
+		}
+		for _, acc := range fixture.Accounts {
+			store.AddAccount(acc)
+		}
+		for _, tran := range fixture.Transactions {
+			store.AddTransaction(tran)
+		}
+
+		return repotest.Repos{
+			User:           NewUserRepo(store),
+			Account:        NewAccountRepo(store),
+			Transaction:    NewTransactionRepo(store),
+			Reconciliation: NewReconciliationRepo(store),
+			Payment:        NewPaymentRepo(store),
+		}
+	})
+}
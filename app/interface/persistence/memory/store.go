@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+// Store keeps users, accounts, transactions, their reconciliations and
+// payment batches in memory. It is safe for concurrent use and is meant for tests and local
+// development.
+type Store struct {
+	mu sync.RWMutex
+
+	users        map[int]model.User
+	accounts     map[int]model.Account
+	transactions map[int]model.Transaction
+	// reconciliations are keyed by transaction id
+	reconciliations map[int]model.Reconciliation
+	// paymentBatches are kept without their payments, which are keyed by
+	// transaction id
+	paymentBatches map[int]model.PaymentBatch
+	payments       map[int]model.Payment
+
+	lastUserID         int
+	lastAccountID      int
+	lastTransactionID  int
+	lastPaymentBatchID int
+}
+
+func NewStore() *Store {
+	return &Store{
+		users:        map[int]model.User{},
+		accounts:     map[int]model.Account{},
+		transactions: map[int]model.Transaction{},
+
+		reconciliations: map[int]model.Reconciliation{},
+		paymentBatches:  map[int]model.PaymentBatch{},
+		payments:        map[int]model.Payment{},
+	}
+}
+
+// AddUser inserts or replaces a user.
+func (s *Store) AddUser(u model.User) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.users[u.ID] = u
+	if u.ID > s.lastUserID {
+		s.lastUserID = u.ID
+	}
+}
+
+// AddAccount inserts or replaces an account.
+func (s *Store) AddAccount(acc model.Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accounts[acc.ID] = acc
+	if acc.ID > s.lastAccountID {
+		s.lastAccountID = acc.ID
+	}
+}
+
+// AddTransaction inserts or replaces a transaction as is, without any of
+// the checks done by the transaction repository.
+func (s *Store) AddTransaction(t model.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.transactions[t.ID] = t
+	if t.ID > s.lastTransactionID {
+		s.lastTransactionID = t.ID
+	}
+}
+
+func sortTransactions(trans []model.Transaction) {
+	sort.Slice(trans, func(i, j int) bool {
+		return trans[i].ID < trans[j].ID
+	})
+}
+
+// sortTransactionsByCreatedAt sorts trans in the order they happened, by
+// created_at then id. A created_at that doesn't parse sorts as the zero
+// time.
+func sortTransactionsByCreatedAt(trans []model.Transaction) {
+	at := make(map[int]time.Time, len(trans))
+	for _, t := range trans {
+		at[t.ID], _ = model.ParseCreatedAt(t.CreatedAt)
+	}
+
+	sort.Slice(trans, func(i, j int) bool {
+		if a, b := at[trans[i].ID], at[trans[j].ID]; !a.Equal(b) {
+			return a.Before(b)
+		}
+
+		return trans[i].ID < trans[j].ID
+	})
+}
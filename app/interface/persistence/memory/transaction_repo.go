@@ -0,0 +1,176 @@
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+type transactionRepo struct {
+	store *Store
+}
+
+func NewTransactionRepo(store *Store) *transactionRepo {
+	return &transactionRepo{store}
+}
+
+// checkTransaction tells whether t can be inserted, its user and account
+// have to exist. s.mu is held.
+func (s *Store) checkTransaction(t model.Transaction) error {
+	if _, ok := s.users[t.UserID]; !ok {
+		return fmt.Errorf("exec Insert fail: user[%v] %w", t.UserID, model.ErrNotFound)
+	}
+
+	if _, ok := s.accounts[t.AccountID]; !ok {
+		return fmt.Errorf("exec Insert fail: account[%v] %w", t.AccountID, model.ErrNotFound)
+	}
+
+	return nil
+}
+
+// insertTransaction inserts t, checked, and sets its ID and, unless set,
+// its CreatedAt. s.mu is held.
+func (s *Store) insertTransaction(t *model.Transaction) {
+	s.lastTransactionID++
+	t.ID = s.lastTransactionID
+	if t.CreatedAt == "" {
+		t.CreatedAt = time.Now().UTC().Format(model.CreatedAtLayout)
+	}
+	s.transactions[t.ID] = *t
+}
+
+func (repo transactionRepo) FindByID(id int) (model.Transaction, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	tran, ok := repo.store.transactions[id]
+	if !ok {
+		return model.Transaction{}, model.ErrNotFound
+	}
+
+	return tran, nil
+}
+
+func (repo transactionRepo) FindByUser(userID int) ([]model.Transaction, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	out := []model.Transaction{}
+	for _, tran := range repo.store.transactions {
+		if tran.UserID == userID {
+			out = append(out, tran)
+		}
+	}
+	sortTransactions(out)
+
+	return out, nil
+}
+
+func (repo transactionRepo) FindByUserAccount(userID, accountID int) ([]model.Transaction, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	out := []model.Transaction{}
+
+	acc, ok := repo.store.accounts[accountID]
+	if !ok || acc.UserID != userID {
+		return out, nil
+	}
+
+	for _, tran := range repo.store.transactions {
+		if tran.AccountID == accountID {
+			out = append(out, tran)
+		}
+	}
+	sortTransactions(out)
+
+	return out, nil
+}
+
+// byUser returns the transactions of userID, or of its account accountID,
+// in id order
+func (repo transactionRepo) byUser(userID int, accountID *int) ([]model.Transaction, error) {
+	if accountID == nil {
+		return repo.FindByUser(userID)
+	}
+
+	return repo.FindByUserAccount(userID, *accountID)
+}
+
+// EachByUser calls fn on a copy of the transactions so fn may use the store
+func (repo transactionRepo) EachByUser(userID int, accountID *int, fn func(model.Transaction) error) error {
+	trans, err := repo.byUser(userID, accountID)
+	if err != nil {
+		return err
+	}
+	sortTransactionsByCreatedAt(trans)
+
+	for _, tran := range trans {
+		if err := fn(tran); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo transactionRepo) FindPage(userID int, accountID *int, after, limit int) ([]model.Transaction, error) {
+	trans, err := repo.byUser(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []model.Transaction{}
+	for _, tran := range trans {
+		if tran.ID > after && (limit == 0 || len(out) < limit) {
+			out = append(out, tran)
+		}
+	}
+
+	return out, nil
+}
+
+func (repo transactionRepo) Create(t *model.Transaction) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if err := repo.store.checkTransaction(*t); err != nil {
+		return err
+	}
+
+	repo.store.insertTransaction(t)
+
+	return nil
+}
+
+func (repo transactionRepo) Update(t *model.Transaction) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	tran, ok := repo.store.transactions[t.ID]
+	if !ok {
+		return nil
+	}
+
+	tran.Amount = t.Amount
+	repo.store.transactions[t.ID] = tran
+
+	return nil
+}
+
+func (repo transactionRepo) Delete(userID, tranID int) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	tran, ok := repo.store.transactions[tranID]
+	if !ok || tran.UserID != userID {
+		return nil
+	}
+
+	delete(repo.store.transactions, tranID)
+	delete(repo.store.reconciliations, tranID)
+	delete(repo.store.payments, tranID)
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"go-prj-skeleton/app/domain/model"
+)
+
+type userRepo struct {
+	store *Store
+}
+
+func NewUserRepo(store *Store) *userRepo {
+	return &userRepo{store}
+}
+
+func (repo userRepo) FindByID(id int) (model.User, error) {
+	repo.store.mu.RLock()
+	defer repo.store.mu.RUnlock()
+
+	u, ok := repo.store.users[id]
+	if !ok {
+		return model.User{}, model.ErrNotFound
+	}
+
+	return u, nil
+}
+
+func (repo userRepo) Create(u *model.User) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if repo.nameTaken(u.Name, 0) {
+		return model.ErrConflict
+	}
+
+	repo.store.lastUserID++
+	u.ID = repo.store.lastUserID
+	repo.store.users[u.ID] = *u
+
+	return nil
+}
+
+func (repo userRepo) Update(u *model.User) error {
+	repo.store.mu.Lock()
+	defer repo.store.mu.Unlock()
+
+	if _, ok := repo.store.users[u.ID]; !ok {
+		return model.ErrNotFound
+	}
+
+	if repo.nameTaken(u.Name, u.ID) {
+		return model.ErrConflict
+	}
+
+	repo.store.users[u.ID] = *u
+
+	return nil
+}
+
+// nameTaken reports whether a user other than the one of id is named name,
+// like the unique index on users.name
+func (repo userRepo) nameTaken(name string, id int) bool {
+	for _, u := range repo.store.users {
+		if u.Name == name && u.ID != id {
+			return true
+		}
+	}
+
+	return false
+}
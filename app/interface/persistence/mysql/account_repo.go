@@ -0,0 +1,130 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/mysqlutil"
+)
+
+// accountColumns reads the NULL columns as empty strings: the number and
+// IBAN of accounts without them, and the name and bank the schema leaves
+// nullable
+const accountColumns = "id, user_id, COALESCE(name, ''), COALESCE(bank, ''), COALESCE(number, ''), COALESCE(iban, '')"
+
+type account struct {
+	ID int `json:"id"`
+
+	UserID int `json:"user_id"`
+
+	Name   string `json:"name"`
+	Bank   string `json:"bank"`
+	Number string `json:"number"`
+	IBAN   string `json:"iban"`
+}
+
+func (acc *account) scan(s interface{ Scan(...interface{}) error }) error {
+	return s.Scan(&acc.ID, &acc.UserID, &acc.Name, &acc.Bank, &acc.Number, &acc.IBAN)
+}
+
+func toAccount(acc account) model.Account {
+	return model.Account{
+		ID:     acc.ID,
+		UserID: acc.UserID,
+		Name:   acc.Name,
+		Bank:   acc.Bank,
+		Number: acc.Number,
+		IBAN:   acc.IBAN,
+	}
+}
+
+type accountRepo struct {
+}
+
+func NewAccountRepo() *accountRepo {
+	return &accountRepo{}
+}
+
+func (repo *accountRepo) FindByUser(userID int) ([]model.Account, error) {
+	rows, err := mysqlutil.DB().Query("SELECT "+accountColumns+" FROM accounts WHERE user_id=? ORDER BY id", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.Account{}
+	for rows.Next() {
+		acc := account{}
+		if err := acc.scan(rows); err != nil {
+			return nil, err
+		}
+
+		out = append(out, toAccount(acc))
+	}
+
+	return out, rows.Err()
+}
+
+func (repo *accountRepo) FindByID(id int) (model.Account, error) {
+	return repo.findOne("SELECT "+accountColumns+" FROM accounts WHERE id=?", id)
+}
+
+func (repo *accountRepo) FindByNumber(bank, number string) (model.Account, error) {
+	return repo.findOne("SELECT "+accountColumns+" FROM accounts WHERE bank=? AND number=?", bank, number)
+}
+
+func (repo *accountRepo) findOne(query string, args ...interface{}) (model.Account, error) {
+	acc := account{}
+
+	if err := acc.scan(mysqlutil.DB().QueryRow(query, args...)); err != nil {
+		if err == sql.ErrNoRows {
+			return model.Account{}, model.ErrNotFound
+		}
+
+		return model.Account{}, err
+	}
+
+	return toAccount(acc), nil
+}
+
+func (repo *accountRepo) Create(acc *model.Account) error {
+	res, err := mysqlutil.DB().Exec("INSERT INTO accounts (user_id, name, bank, number, iban) VALUES (?, ?, ?, NULLIF(?, ''), NULLIF(?, ''))",
+		acc.UserID, acc.Name, acc.Bank, acc.Number, acc.IBAN)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return fmt.Errorf("account number[%v] at bank[%v] %w", acc.Number, acc.Bank, model.ErrConflict)
+		}
+
+		return fmt.Errorf("create account fail: %v", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("create account fail: %v", err)
+	}
+
+	acc.ID = int(id)
+
+	return nil
+}
+
+func (repo *accountRepo) Update(acc *model.Account) error {
+	// like users, a missing account is told apart from an unchanged one
+	// beforehand
+	if _, err := repo.FindByID(acc.ID); err != nil {
+		return err
+	}
+
+	_, err := mysqlutil.DB().Exec("UPDATE accounts SET name=?, number=NULLIF(?, ''), iban=NULLIF(?, '') WHERE id=?",
+		acc.Name, acc.Number, acc.IBAN, acc.ID)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return fmt.Errorf("account number[%v] %w", acc.Number, model.ErrConflict)
+		}
+
+		return fmt.Errorf("update account fail: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"fmt"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/mysqlutil"
+)
+
+type integrityRepo struct {
+}
+
+func NewIntegrityRepo() *integrityRepo {
+	return &integrityRepo{}
+}
+
+func (repo integrityRepo) EachAccount(fn func(model.Account) error) error {
+	rows, err := mysqlutil.DB().Query("SELECT " + accountColumns + " FROM accounts ORDER BY id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		acc := account{}
+		if err := acc.scan(rows); err != nil {
+			return err
+		}
+
+		if err := fn(toAccount(acc)); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (repo integrityRepo) EachTransaction(fn func(model.Transaction) error) error {
+	rows, err := mysqlutil.DB().Query("SELECT " + transactionColumns + " FROM transactions t ORDER BY t.id")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		tran := transaction{}
+		if err := tran.scan(rows); err != nil {
+			return err
+		}
+
+		if err := fn(toTransaction(tran)); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (repo integrityRepo) SetTransactionUser(tranID, userID int) error {
+	_, err := mysqlutil.DB().Exec("UPDATE transactions SET user_id=? WHERE id=?", userID, tranID)
+	if err != nil {
+		return fmt.Errorf("update transaction fail: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,283 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/mysqlutil"
+)
+
+const paymentColumns = "transaction_id, batch_id, creditor_name, creditor_account, creditor_bank, remittance, status, confirmed_at"
+
+type paymentBatch struct {
+	ID int `json:"id"`
+
+	UserID    int `json:"user_id"`
+	AccountID int `json:"account_id"`
+
+	DebtorAccount string `json:"debtor_account"`
+	ExecutionDate string `json:"execution_date"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type payment struct {
+	TransactionID int `json:"transaction_id"`
+	BatchID       int `json:"batch_id"`
+
+	CreditorName    string              `json:"creditor_name"`
+	CreditorAccount string              `json:"creditor_account"`
+	CreditorBank    string              `json:"creditor_bank"`
+	Remittance      string              `json:"remittance"`
+	Status          model.PaymentStatus `json:"status"`
+	ConfirmedAt     string              `json:"confirmed_at"`
+}
+
+func toPaymentBatch(b paymentBatch, ps []payment) model.PaymentBatch {
+	out := model.PaymentBatch{
+		ID:            b.ID,
+		UserID:        b.UserID,
+		AccountID:     b.AccountID,
+		DebtorAccount: b.DebtorAccount,
+		ExecutionDate: b.ExecutionDate,
+		CreatedAt:     b.CreatedAt,
+		Payments:      []model.Payment{},
+	}
+
+	for _, p := range ps {
+		if p.BatchID == b.ID {
+			out.Payments = append(out.Payments, toPayment(p))
+		}
+	}
+
+	return out
+}
+
+func toPayment(p payment) model.Payment {
+	return model.Payment{
+		TransactionID:   p.TransactionID,
+		BatchID:         p.BatchID,
+		CreditorName:    p.CreditorName,
+		CreditorAccount: p.CreditorAccount,
+		CreditorBank:    p.CreditorBank,
+		Remittance:      p.Remittance,
+		Status:          p.Status,
+		ConfirmedAt:     p.ConfirmedAt,
+	}
+}
+
+func scanPayments(rows *sql.Rows) ([]payment, error) {
+	defer rows.Close()
+
+	out := []payment{}
+	for rows.Next() {
+		p := payment{}
+		if err := rows.Scan(&p.TransactionID, &p.BatchID, &p.CreditorName, &p.CreditorAccount, &p.CreditorBank, &p.Remittance, &p.Status, &p.ConfirmedAt); err != nil {
+			return nil, err
+		}
+
+		out = append(out, p)
+	}
+
+	return out, rows.Err()
+}
+
+type paymentRepo struct {
+}
+
+func NewPaymentRepo() *paymentRepo {
+	return &paymentRepo{}
+}
+
+func (repo paymentRepo) FindBatch(id int) (model.PaymentBatch, error) {
+	db := mysqlutil.DB()
+
+	b := paymentBatch{}
+	err := db.QueryRow("SELECT id, user_id, account_id, debtor_account, execution_date, created_at FROM payment_batches WHERE id=?", id).
+		Scan(&b.ID, &b.UserID, &b.AccountID, &b.DebtorAccount, &b.ExecutionDate, &b.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.PaymentBatch{}, fmt.Errorf("payment batch[%v] %w", id, model.ErrNotFound)
+		}
+
+		return model.PaymentBatch{}, err
+	}
+
+	rows, err := db.Query("SELECT "+paymentColumns+" FROM payments WHERE batch_id=? ORDER BY transaction_id", id)
+	if err != nil {
+		return model.PaymentBatch{}, err
+	}
+
+	ps, err := scanPayments(rows)
+	if err != nil {
+		return model.PaymentBatch{}, err
+	}
+
+	return toPaymentBatch(b, ps), nil
+}
+
+func (repo paymentRepo) FindByAccount(accountID int) ([]model.PaymentBatch, error) {
+	db := mysqlutil.DB()
+
+	rows, err := db.Query("SELECT id, user_id, account_id, debtor_account, execution_date, created_at FROM payment_batches WHERE account_id=? ORDER BY id", accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bs := []paymentBatch{}
+	for rows.Next() {
+		b := paymentBatch{}
+		if err := rows.Scan(&b.ID, &b.UserID, &b.AccountID, &b.DebtorAccount, &b.ExecutionDate, &b.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		bs = append(bs, b)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	paymentRows, err := db.Query("SELECT payments."+strings.ReplaceAll(paymentColumns, ", ", ", payments.")+" FROM payments "+
+		"JOIN payment_batches ON payment_batches.id=payments.batch_id WHERE payment_batches.account_id=? ORDER BY payments.transaction_id", accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	ps, err := scanPayments(paymentRows)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]model.PaymentBatch, len(bs))
+	for i := range bs {
+		out[i] = toPaymentBatch(bs[i], ps)
+	}
+
+	return out, nil
+}
+
+func (repo paymentRepo) CreateBatch(b *model.PaymentBatch, withdrawals []*model.Transaction) error {
+	tx, err := mysqlutil.DB().Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if len(withdrawals) > 0 {
+		if err := insertWithdrawals(tx, b.AccountID, withdrawals); err != nil {
+			return err
+		}
+	}
+
+	if err := b.Pay(withdrawals); err != nil {
+		return err
+	}
+
+	createdAt := time.Now().UTC().Format(model.CreatedAtLayout)
+	res, err := tx.Exec("INSERT INTO payment_batches (user_id, account_id, debtor_account, execution_date, created_at) VALUES (?, ?, ?, ?, ?)",
+		b.UserID, b.AccountID, b.DebtorAccount, b.ExecutionDate, createdAt)
+	if err != nil {
+		return fmt.Errorf("create payment batch fail: %v", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("create payment batch fail: %v", err)
+	}
+
+	for _, p := range b.Payments {
+		res, err := tx.Exec("INSERT INTO payments (transaction_id, batch_id, creditor_name, creditor_account, creditor_bank, remittance, status) "+
+			"SELECT id, ?, ?, ?, ?, ?, ? FROM transactions WHERE id=?",
+			id, p.CreditorName, p.CreditorAccount, p.CreditorBank, p.Remittance, string(model.PaymentStatusPending), p.TransactionID)
+		if err != nil {
+			return fmt.Errorf("create payment fail: %v", err)
+		}
+
+		n, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("create payment fail: %v", err)
+		}
+
+		if n == 0 {
+			return fmt.Errorf("create payment batch: transaction[%v] %w", p.TransactionID, model.ErrNotFound)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	b.ID = int(id)
+	b.CreatedAt = createdAt
+	for i := range b.Payments {
+		b.Payments[i].BatchID = b.ID
+		b.Payments[i].Status = model.PaymentStatusPending
+		b.Payments[i].ConfirmedAt = ""
+	}
+
+	return nil
+}
+
+// insertWithdrawals inserts the withdrawals of the account in tx once its
+// balance covers them. The account row stays locked until tx ends, the
+// balance can't change in between.
+func insertWithdrawals(tx *sql.Tx, accountID int, withdrawals []*model.Transaction) error {
+	var id int
+	if err := tx.QueryRow("SELECT id FROM accounts WHERE id=? FOR UPDATE", accountID).Scan(&id); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("account[%v] %w", accountID, model.ErrNotFound)
+		}
+
+		return fmt.Errorf("lock account fail: %v", err)
+	}
+
+	var balance decimal.Decimal
+	err := tx.QueryRow("SELECT COALESCE(SUM(CASE WHEN transaction_type=? THEN -amount ELSE amount END), 0) FROM transactions WHERE account_id=?",
+		string(model.TransactionTypeWithdraw), accountID).Scan(&balance)
+	if err != nil {
+		return fmt.Errorf("read balance fail: %v", err)
+	}
+
+	if err := model.CheckFunds(accountID, balance, withdrawals); err != nil {
+		return err
+	}
+
+	for _, t := range withdrawals {
+		if err := insertTransaction(tx, t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo paymentRepo) Confirm(transactionID int) (model.Payment, error) {
+	db := mysqlutil.DB()
+
+	_, err := db.Exec("UPDATE payments SET status=?, confirmed_at=? WHERE transaction_id=? AND status<>?",
+		string(model.PaymentStatusConfirmed), time.Now().UTC().Format(model.CreatedAtLayout), transactionID, string(model.PaymentStatusConfirmed))
+	if err != nil {
+		return model.Payment{}, fmt.Errorf("confirm payment fail: %v", err)
+	}
+
+	rows, err := db.Query("SELECT "+paymentColumns+" FROM payments WHERE transaction_id=?", transactionID)
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	ps, err := scanPayments(rows)
+	if err != nil {
+		return model.Payment{}, err
+	}
+
+	if len(ps) == 0 {
+		return model.Payment{}, fmt.Errorf("payment of transaction[%v] %w", transactionID, model.ErrNotFound)
+	}
+
+	return toPayment(ps[0]), nil
+}
@@ -0,0 +1,117 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/mysqlutil"
+)
+
+type reconciliation struct {
+	TransactionID int `json:"transaction_id"`
+	AccountID     int `json:"account_id"`
+
+	Status        model.ReconciliationStatus `json:"status"`
+	Reference     string                     `json:"reference"`
+	StatementDate string                     `json:"statement_date"`
+	ValueDate     string                     `json:"value_date"`
+	ReconciledAt  string                     `json:"reconciled_at"`
+}
+
+func toReconciliation(r reconciliation) model.Reconciliation {
+	return model.Reconciliation{
+		TransactionID: r.TransactionID,
+		AccountID:     r.AccountID,
+		Status:        r.Status,
+		Reference:     r.Reference,
+		StatementDate: r.StatementDate,
+		ValueDate:     r.ValueDate,
+		ReconciledAt:  r.ReconciledAt,
+	}
+}
+
+type reconciliationRepo struct {
+}
+
+func NewReconciliationRepo() *reconciliationRepo {
+	return &reconciliationRepo{}
+}
+
+func (repo reconciliationRepo) FindByAccount(accountID int) ([]model.Reconciliation, error) {
+	rows, err := mysqlutil.DB().Query("SELECT transaction_id, account_id, status, reference, statement_date, COALESCE(value_date, ''), reconciled_at FROM transaction_reconciliations WHERE account_id=? ORDER BY transaction_id", accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []model.Reconciliation{}
+	for rows.Next() {
+		r := reconciliation{}
+		if err := rows.Scan(&r.TransactionID, &r.AccountID, &r.Status, &r.Reference, &r.StatementDate, &r.ValueDate, &r.ReconciledAt); err != nil {
+			return nil, err
+		}
+
+		out = append(out, toReconciliation(r))
+	}
+
+	return out, rows.Err()
+}
+
+func (repo reconciliationRepo) Save(r *model.Reconciliation) error {
+	return saveReconciliation(mysqlutil.DB(), r)
+}
+
+// saveReconciliation saves r with db, the database or a transaction
+func saveReconciliation(db execer, r *model.Reconciliation) error {
+	var accountID int
+	err := db.QueryRow("SELECT account_id FROM transactions WHERE id=?", r.TransactionID).Scan(&accountID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("save reconciliation: transaction[%v] %w", r.TransactionID, model.ErrNotFound)
+		}
+
+		return err
+	}
+
+	reconciledAt := time.Now().UTC().Format(model.CreatedAtLayout)
+	_, err = db.Exec("INSERT INTO transaction_reconciliations (transaction_id, account_id, status, reference, statement_date, value_date, reconciled_at) VALUES (?, ?, ?, ?, ?, NULLIF(?, ''), ?) "+
+		"ON DUPLICATE KEY UPDATE status=VALUES(status), reference=VALUES(reference), statement_date=VALUES(statement_date), value_date=VALUES(value_date), reconciled_at=VALUES(reconciled_at)",
+		r.TransactionID, accountID, string(r.Status), r.Reference, r.StatementDate, r.ValueDate, reconciledAt)
+	if err != nil {
+		return fmt.Errorf("save reconciliation fail: %v", err)
+	}
+
+	r.AccountID = accountID
+	r.ReconciledAt = reconciledAt
+
+	return nil
+}
+
+func (repo reconciliationRepo) SaveStatement(saved []*model.Reconciliation, created []model.ReconciledTransaction) error {
+	tx, err := mysqlutil.DB().Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, c := range created {
+		if err := insertTransaction(tx, c.Transaction); err != nil {
+			return err
+		}
+
+		c.Reconciliation.TransactionID = c.Transaction.ID
+		if err := saveReconciliation(tx, c.Reconciliation); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range saved {
+		if err := saveReconciliation(tx, r); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,56 @@
+package mysql
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/repo/repotest"
+	"go-prj-skeleton/app/mysqlutil"
+)
+
+// TestRepos runs the repository contract suite against the database in
+// MYSQL_TEST_URL. All rows of that database are deleted, so point it at a
+// dedicated, migrated test database.
+func TestRepos(t *testing.T) {
+	url := os.Getenv("MYSQL_TEST_URL")
+	if url == "" {
+		t.Skip("MYSQL_TEST_URL is not set")
+	}
+
+	mysqlutil.StartUp(mysqlutil.Configuration{URL: url})
+	defer mysqlutil.Shutdown()
+
+	repotest.Run(t, func(t *testing.T, fixture repotest.Fixture) repotest.Repos {
+		db := mysqlutil.DB()
+
+		for _, table := range []string{"payments", "payment_batches", "transaction_reconciliations", "transactions", "accounts", "users"} {
+			_, err := db.Exec("DELETE FROM " + table)
+			require.NoError(t, err)
+		}
+
+		for _, u := range fixture.Users {
+			_, err := db.Exec("INSERT INTO users (id, name) VALUES (?, ?)", u.ID, u.Name)
+			require.NoError(t, err)
+		}
+		for _, acc := range fixture.Accounts {
+			_, err := db.Exec("INSERT INTO accounts (id, user_id, name, bank, number, iban) VALUES (?, ?, ?, ?, NULLIF(?, ''), NULLIF(?, ''))",
+				acc.ID, acc.UserID, acc.Name, acc.Bank, acc.Number, acc.IBAN)
+			require.NoError(t, err)
+		}
+		for _, tran := range fixture.Transactions {
+			_, err := db.Exec("INSERT INTO transactions (id, user_id, account_id, amount, transaction_type, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+				tran.ID, tran.UserID, tran.AccountID, tran.Amount, string(tran.TransactionType), tran.CreatedAt)
+			require.NoError(t, err)
+		}
+
+		return repotest.Repos{
+			User:           NewUserRepo(),
+			Account:        NewAccountRepo(),
+			Transaction:    NewTransactionRepo(),
+			Reconciliation: NewReconciliationRepo(),
+			Payment:        NewPaymentRepo(),
+		}
+	})
+}
@@ -0,0 +1,218 @@
+package mysql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/mysqlutil"
+)
+
+// transactionColumns reads the columns the schema leaves nullable as zero
+// values, which the integrity checker reports
+const transactionColumns = "t.id, t.user_id, t.account_id, COALESCE(t.amount, 0), COALESCE(t.transaction_type, ''), COALESCE(t.created_at, '')"
+
+const (
+	findByUserQuery        = "SELECT " + transactionColumns + " FROM transactions t WHERE t.user_id=? ORDER BY t.id"
+	findByUserAccountQuery = "SELECT " + transactionColumns + " FROM transactions t INNER JOIN accounts a ON t.account_id = a.id INNER JOIN users u ON a.user_id = u.id WHERE u.id=? AND a.id=? ORDER BY t.id"
+
+	// created_at is written in UTC by the service, so its strings sort in
+	// time order
+	eachByUserQuery        = "SELECT " + transactionColumns + " FROM transactions t WHERE t.user_id=? ORDER BY t.created_at, t.id"
+	eachByUserAccountQuery = "SELECT " + transactionColumns + " FROM transactions t INNER JOIN accounts a ON t.account_id = a.id WHERE a.user_id=? AND a.id=? ORDER BY t.created_at, t.id"
+
+	findPageByUserQuery        = "SELECT " + transactionColumns + " FROM transactions t WHERE t.user_id=? AND t.id>? ORDER BY t.id LIMIT ?"
+	findPageByUserAccountQuery = "SELECT " + transactionColumns + " FROM transactions t INNER JOIN accounts a ON t.account_id = a.id WHERE a.user_id=? AND a.id=? AND t.id>? ORDER BY t.id LIMIT ?"
+)
+
+type transaction struct {
+	ID int `json:"id"`
+
+	UserID    int `json:"user_id"`
+	AccountID int `json:"account_id"`
+
+	Amount          decimal.Decimal       `json:"amount"`
+	TransactionType model.TransactionType `json:"transaction_type"`
+	CreatedAt       string                `json:"created_at"`
+}
+
+func (t *transaction) scan(s interface{ Scan(...interface{}) error }) error {
+	return s.Scan(&t.ID, &t.UserID, &t.AccountID, &t.Amount, &t.TransactionType, &t.CreatedAt)
+}
+
+func toTransaction(t transaction) model.Transaction {
+	return model.Transaction{
+		ID:              t.ID,
+		UserID:          t.UserID,
+		AccountID:       t.AccountID,
+		Amount:          t.Amount,
+		TransactionType: t.TransactionType,
+		CreatedAt:       t.CreatedAt,
+	}
+}
+
+type transactionRepo struct {
+}
+
+func NewTransactionRepo() *transactionRepo {
+	return &transactionRepo{}
+}
+
+func (repo transactionRepo) query(query string, args ...interface{}) ([]model.Transaction, error) {
+	out := []model.Transaction{}
+	err := repo.each(func(t model.Transaction) error {
+		out = append(out, t)
+		return nil
+	}, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// each calls fn with the transactions of query as they are read
+func (repo transactionRepo) each(fn func(model.Transaction) error, query string, args ...interface{}) error {
+	rows, err := mysqlutil.DB().Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		tran := transaction{}
+		if err := tran.scan(rows); err != nil {
+			return err
+		}
+
+		if err := fn(toTransaction(tran)); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (repo transactionRepo) FindByID(id int) (model.Transaction, error) {
+	tran := transaction{}
+
+	err := tran.scan(mysqlutil.DB().QueryRow("SELECT "+transactionColumns+" FROM transactions t WHERE t.id=?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.Transaction{}, model.ErrNotFound
+		}
+
+		return model.Transaction{}, err
+	}
+
+	return toTransaction(tran), nil
+}
+
+func (repo transactionRepo) FindByUser(userID int) ([]model.Transaction, error) {
+	return repo.query(findByUserQuery, userID)
+}
+
+func (repo transactionRepo) FindByUserAccount(userID, accountID int) ([]model.Transaction, error) {
+	return repo.query(findByUserAccountQuery, userID, accountID)
+}
+
+func (repo transactionRepo) EachByUser(userID int, accountID *int, fn func(model.Transaction) error) error {
+	if accountID == nil {
+		return repo.each(fn, eachByUserQuery, userID)
+	}
+
+	return repo.each(fn, eachByUserAccountQuery, userID, *accountID)
+}
+
+func (repo transactionRepo) FindPage(userID int, accountID *int, after, limit int) ([]model.Transaction, error) {
+	if limit == 0 {
+		limit = math.MaxInt32
+	}
+
+	if accountID == nil {
+		return repo.query(findPageByUserQuery, userID, after, limit)
+	}
+
+	return repo.query(findPageByUserAccountQuery, userID, *accountID, after, limit)
+}
+
+func (repo transactionRepo) Create(t *model.Transaction) error {
+	return insertTransaction(mysqlutil.DB(), t)
+}
+
+// execer is the database or a transaction
+type execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// insertTransaction inserts t with db, the database or a transaction
+func insertTransaction(db execer, t *model.Transaction) error {
+	tran := transaction{
+		AccountID:       t.AccountID,
+		UserID:          t.UserID,
+		Amount:          t.Amount,
+		TransactionType: t.TransactionType,
+	}
+
+	createdAt := time.Now()
+	if t.CreatedAt != "" {
+		var err error
+		if createdAt, err = model.ParseCreatedAt(t.CreatedAt); err != nil {
+			return fmt.Errorf("created_at[%v] %w", t.CreatedAt, model.ErrInvalid)
+		}
+	}
+
+	tran.CreatedAt = createdAt.UTC().Format(model.CreatedAtLayout)
+	res, err := db.Exec("INSERT INTO transactions (user_id, account_id, amount, transaction_type, created_at) VALUES (?, ?, ?, ?, ?)",
+		tran.UserID, tran.AccountID, tran.Amount, string(tran.TransactionType), tran.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("exec Insert fail: %v", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("exec Insert fail: %v", err)
+	}
+	tran.ID = int(id)
+
+	t.CreatedAt = tran.CreatedAt
+	t.ID = tran.ID
+
+	return nil
+}
+
+func (repo transactionRepo) Update(t *model.Transaction) error {
+	_, err := mysqlutil.DB().Exec("UPDATE transactions SET amount=? WHERE id=?", t.Amount, t.ID)
+	if err != nil {
+		return fmt.Errorf("update transaction fail: %v", err)
+	}
+
+	return nil
+}
+
+func (repo transactionRepo) Delete(userID, tranID int) error {
+	tran, err := repo.FindByID(tranID)
+	if err != nil {
+		if errors.Is(err, model.ErrNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	if tran.UserID != userID {
+		return nil
+	}
+
+	if _, err := mysqlutil.DB().Exec("DELETE FROM transactions WHERE id=?", tran.ID); err != nil {
+		return fmt.Errorf("delete failed: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package mysql
+
+import (
+	"database/sql"
+	"fmt"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/mysqlutil"
+)
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func toUser(u user) model.User {
+	return model.User{
+		ID:   u.ID,
+		Name: u.Name,
+	}
+}
+
+type userRepo struct {
+}
+
+func NewUserRepo() *userRepo {
+	return &userRepo{}
+}
+
+func (repo userRepo) FindByID(id int) (model.User, error) {
+	u := user{}
+
+	err := mysqlutil.DB().QueryRow("SELECT id, name FROM users WHERE id=?", id).Scan(&u.ID, &u.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return model.User{}, model.ErrNotFound
+		}
+
+		return model.User{}, err
+	}
+
+	return toUser(u), nil
+}
+
+func (repo userRepo) Create(u *model.User) error {
+	res, err := mysqlutil.DB().Exec("INSERT INTO users (name) VALUES (?)", u.Name)
+	if err != nil {
+		if isDuplicateEntry(err) {
+			return fmt.Errorf("user name[%v] %w", u.Name, model.ErrConflict)
+		}
+
+		return fmt.Errorf("create user fail: %v", err)
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("create user fail: %v", err)
+	}
+
+	u.ID = int(id)
+
+	return nil
+}
+
+func (repo userRepo) Update(u *model.User) error {
+	// MySQL counts the rows changed rather than the ones matched, so a
+	// missing user is told apart from an unchanged name beforehand
+	if _, err := repo.FindByID(u.ID); err != nil {
+		return err
+	}
+
+	if _, err := mysqlutil.DB().Exec("UPDATE users SET name=? WHERE id=?", u.Name, u.ID); err != nil {
+		if isDuplicateEntry(err) {
+			return fmt.Errorf("user name[%v] %w", u.Name, model.ErrConflict)
+		}
+
+		return fmt.Errorf("update user fail: %v", err)
+	}
+
+	return nil
+}
+
+// duplicateEntry is the MySQL error of an insert or update breaking a unique
+// key
+const duplicateEntry = 1062
+
+// isDuplicateEntry reports whether err breaks a unique key
+func isDuplicateEntry(err error) bool {
+	myErr, ok := err.(*mysqldriver.MySQLError)
+	return ok && myErr.Number == duplicateEntry
+}
@@ -1,10 +1,12 @@
 package postgre
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/go-pg/pg/v9"
 
 	"go-prj-skeleton/app/domain/model"
-	"go-prj-skeleton/app/pgutil"
 )
 
 type account struct {
@@ -14,6 +16,9 @@ type account struct {
 
 	Name string `json:"name"`
 	Bank string `json:"bank"`
+	// Number and IBAN are NULL when empty, go-pg writes zero values as NULL
+	Number string `json:"number"`
+	IBAN   string `json:"iban"`
 }
 
 func toAccount(acc account) model.Account {
@@ -22,20 +27,28 @@ func toAccount(acc account) model.Account {
 		UserID: acc.UserID,
 		Name:   acc.Name,
 		Bank:   acc.Bank,
+		Number: acc.Number,
+		IBAN:   acc.IBAN,
 	}
 }
 
 type accountRepo struct {
+	ctx context.Context
 }
 
 func NewAccountRepo() *accountRepo {
 	return &accountRepo{}
 }
 
+// WithContext returns a copy of repo running its queries in ctx
+func (repo *accountRepo) WithContext(ctx context.Context) interface{} {
+	return &accountRepo{ctx}
+}
+
 func (repo *accountRepo) FindByUser(userID int) ([]model.Account, error) {
 	accs := []account{}
 
-	_, err := pgutil.DB().Query(&accs, "SELECT * FROM accounts WHERE user_id=?", userID)
+	_, err := db(repo.ctx).Query(&accs, "SELECT * FROM accounts WHERE user_id=? ORDER BY id", userID)
 	if err != nil {
 		return nil, err
 	}
@@ -49,9 +62,17 @@ func (repo *accountRepo) FindByUser(userID int) ([]model.Account, error) {
 }
 
 func (repo *accountRepo) FindByID(id int) (model.Account, error) {
+	return repo.findOne("SELECT * FROM accounts WHERE id=?", id)
+}
+
+func (repo *accountRepo) FindByNumber(bank, number string) (model.Account, error) {
+	return repo.findOne("SELECT * FROM accounts WHERE bank=? AND number=?", bank, number)
+}
+
+func (repo *accountRepo) findOne(query string, params ...interface{}) (model.Account, error) {
 	acc := account{}
 
-	_, err := pgutil.DB().QueryOne(&acc, "SELECT * FROM accounts WHERE id=?", id)
+	_, err := db(repo.ctx).QueryOne(&acc, query, params...)
 	if err != nil {
 		if err == pg.ErrNoRows {
 			return model.Account{}, model.ErrNotFound
@@ -62,3 +83,46 @@ func (repo *accountRepo) FindByID(id int) (model.Account, error) {
 
 	return toAccount(acc), nil
 }
+
+func (repo *accountRepo) Create(acc *model.Account) error {
+	row := account{
+		UserID: acc.UserID,
+		Name:   acc.Name,
+		Bank:   acc.Bank,
+		Number: acc.Number,
+		IBAN:   acc.IBAN,
+	}
+
+	if err := db(repo.ctx).Insert(&row); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("account number[%v] at bank[%v] %w", acc.Number, acc.Bank, model.ErrConflict)
+		}
+
+		return fmt.Errorf("create account fail: %v", err)
+	}
+
+	acc.ID = row.ID
+
+	return nil
+}
+
+func (repo *accountRepo) Update(acc *model.Account) error {
+	res, err := db(repo.ctx).Model(&account{}).
+		Set("name=?", acc.Name).
+		Set("number=NULLIF(?, '')", acc.Number).
+		Set("iban=NULLIF(?, '')", acc.IBAN).
+		Where("id=?", acc.ID).Update()
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("account number[%v] %w", acc.Number, model.ErrConflict)
+		}
+
+		return fmt.Errorf("update account fail: %v", err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("account[%v] %w", acc.ID, model.ErrNotFound)
+	}
+
+	return nil
+}
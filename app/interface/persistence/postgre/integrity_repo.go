@@ -0,0 +1,37 @@
+package postgre
+
+import (
+	"fmt"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/pgutil"
+)
+
+type integrityRepo struct {
+}
+
+func NewIntegrityRepo() *integrityRepo {
+	return &integrityRepo{}
+}
+
+func (repo integrityRepo) EachAccount(fn func(model.Account) error) error {
+	return pgutil.DB().Model((*account)(nil)).Order("id").ForEach(func(acc *account) error {
+		return fn(toAccount(*acc))
+	})
+}
+
+func (repo integrityRepo) EachTransaction(fn func(model.Transaction) error) error {
+	return pgutil.DB().Model((*transaction)(nil)).Order("id").ForEach(func(t *transaction) error {
+		return fn(toTransaction(*t))
+	})
+}
+
+func (repo integrityRepo) SetTransactionUser(tranID, userID int) error {
+	_, err := pgutil.DB().Model(&transaction{}).Set("user_id=?", userID).
+		Where("id=?", tranID).Update()
+	if err != nil {
+		return fmt.Errorf("update transaction fail: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package postgre
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v9"
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/pgutil"
+)
+
+type paymentBatch struct {
+	ID int `json:"id"`
+
+	UserID    int `json:"user_id"`
+	AccountID int `json:"account_id"`
+
+	DebtorAccount string    `json:"debtor_account"`
+	ExecutionDate time.Time `json:"execution_date"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type payment struct {
+	TransactionID int `json:"transaction_id"`
+	BatchID       int `json:"batch_id"`
+
+	CreditorName    string              `json:"creditor_name"`
+	CreditorAccount string              `json:"creditor_account"`
+	CreditorBank    string              `json:"creditor_bank"`
+	Remittance      string              `json:"remittance"`
+	Status          model.PaymentStatus `json:"status"`
+	ConfirmedAt     *time.Time          `json:"confirmed_at"`
+}
+
+func toPaymentBatch(b paymentBatch, ps []payment) model.PaymentBatch {
+	out := model.PaymentBatch{
+		ID:            b.ID,
+		UserID:        b.UserID,
+		AccountID:     b.AccountID,
+		DebtorAccount: b.DebtorAccount,
+		ExecutionDate: b.ExecutionDate.Format(model.ExecutionDateLayout),
+		CreatedAt:     b.CreatedAt.UTC().Format(model.CreatedAtLayout),
+		Payments:      []model.Payment{},
+	}
+
+	for _, p := range ps {
+		if p.BatchID == b.ID {
+			out.Payments = append(out.Payments, toPayment(p))
+		}
+	}
+
+	return out
+}
+
+func toPayment(p payment) model.Payment {
+	out := model.Payment{
+		TransactionID:   p.TransactionID,
+		BatchID:         p.BatchID,
+		CreditorName:    p.CreditorName,
+		CreditorAccount: p.CreditorAccount,
+		CreditorBank:    p.CreditorBank,
+		Remittance:      p.Remittance,
+		Status:          p.Status,
+	}
+
+	if p.ConfirmedAt != nil {
+		out.ConfirmedAt = p.ConfirmedAt.UTC().Format(model.CreatedAtLayout)
+	}
+
+	return out
+}
+
+type paymentRepo struct {
+}
+
+func NewPaymentRepo() *paymentRepo {
+	return &paymentRepo{}
+}
+
+func (repo paymentRepo) FindBatch(id int) (model.PaymentBatch, error) {
+	db := pgutil.DB()
+
+	b := paymentBatch{}
+	if _, err := db.QueryOne(&b, "SELECT * FROM payment_batches WHERE id=?", id); err != nil {
+		if err == pg.ErrNoRows {
+			return model.PaymentBatch{}, fmt.Errorf("payment batch[%v] %w", id, model.ErrNotFound)
+		}
+
+		return model.PaymentBatch{}, err
+	}
+
+	ps := []payment{}
+	if _, err := db.Query(&ps, "SELECT * FROM payments WHERE batch_id=? ORDER BY transaction_id", id); err != nil {
+		return model.PaymentBatch{}, err
+	}
+
+	return toPaymentBatch(b, ps), nil
+}
+
+func (repo paymentRepo) FindByAccount(accountID int) ([]model.PaymentBatch, error) {
+	db := pgutil.DB()
+
+	bs := []paymentBatch{}
+	if _, err := db.Query(&bs, "SELECT * FROM payment_batches WHERE account_id=? ORDER BY id", accountID); err != nil {
+		return nil, err
+	}
+
+	ps := []payment{}
+	_, err := db.Query(&ps, `SELECT payments.* FROM payments JOIN payment_batches ON payment_batches.id=payments.batch_id
+		WHERE payment_batches.account_id=? ORDER BY payments.transaction_id`, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]model.PaymentBatch, len(bs))
+	for i := range bs {
+		out[i] = toPaymentBatch(bs[i], ps)
+	}
+
+	return out, nil
+}
+
+func (repo paymentRepo) CreateBatch(b *model.PaymentBatch, withdrawals []*model.Transaction) error {
+	executionDate, err := time.Parse(model.ExecutionDateLayout, b.ExecutionDate)
+	if err != nil {
+		return fmt.Errorf("execution_date[%v] %w", b.ExecutionDate, model.ErrInvalid)
+	}
+
+	created := paymentBatch{}
+	err = pgutil.DB().RunInTransaction(func(tx *pg.Tx) error {
+		if len(withdrawals) > 0 {
+			if err := insertWithdrawals(tx, b.AccountID, withdrawals); err != nil {
+				return err
+			}
+		}
+
+		if err := b.Pay(withdrawals); err != nil {
+			return err
+		}
+
+		_, err := tx.QueryOne(&created, "INSERT INTO payment_batches (user_id, account_id, debtor_account, execution_date) VALUES (?, ?, ?, ?) RETURNING *",
+			b.UserID, b.AccountID, b.DebtorAccount, executionDate)
+		if err != nil {
+			return fmt.Errorf("create payment batch fail: %v", err)
+		}
+
+		for _, p := range b.Payments {
+			res, err := tx.Exec(`INSERT INTO payments (transaction_id, batch_id, creditor_name, creditor_account, creditor_bank, remittance, status)
+				SELECT id, ?, ?, ?, ?, ?, ? FROM transactions WHERE id=?`,
+				created.ID, p.CreditorName, p.CreditorAccount, p.CreditorBank, p.Remittance, string(model.PaymentStatusPending), p.TransactionID)
+			if err != nil {
+				return fmt.Errorf("create payment fail: %v", err)
+			}
+
+			if res.RowsAffected() == 0 {
+				return fmt.Errorf("create payment batch: transaction[%v] %w", p.TransactionID, model.ErrNotFound)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	b.ID = created.ID
+	b.CreatedAt = created.CreatedAt.UTC().Format(model.CreatedAtLayout)
+	for i := range b.Payments {
+		b.Payments[i].BatchID = b.ID
+		b.Payments[i].Status = model.PaymentStatusPending
+		b.Payments[i].ConfirmedAt = ""
+	}
+
+	return nil
+}
+
+// insertWithdrawals inserts the withdrawals of the account in tx once its
+// balance covers them. The account row stays locked until tx ends, the
+// balance can't change in between.
+func insertWithdrawals(tx *pg.Tx, accountID int, withdrawals []*model.Transaction) error {
+	var id int
+	if _, err := tx.QueryOne(pg.Scan(&id), "SELECT id FROM accounts WHERE id=? FOR UPDATE", accountID); err != nil {
+		if err == pg.ErrNoRows {
+			return fmt.Errorf("account[%v] %w", accountID, model.ErrNotFound)
+		}
+
+		return fmt.Errorf("lock account fail: %v", err)
+	}
+
+	var balance decimal.Decimal
+	_, err := tx.QueryOne(pg.Scan(&balance), "SELECT COALESCE(SUM(CASE WHEN transaction_type=? THEN -amount ELSE amount END), 0) FROM transactions WHERE account_id=?",
+		string(model.TransactionTypeWithdraw), accountID)
+	if err != nil {
+		return fmt.Errorf("read balance fail: %v", err)
+	}
+
+	if err := model.CheckFunds(accountID, balance, withdrawals); err != nil {
+		return err
+	}
+
+	for _, t := range withdrawals {
+		if err := insertTransaction(tx, t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (repo paymentRepo) Confirm(transactionID int) (model.Payment, error) {
+	p := payment{}
+	_, err := pgutil.DB().QueryOne(&p, `UPDATE payments SET status=?, confirmed_at=COALESCE(confirmed_at, NOW())
+		WHERE transaction_id=? RETURNING *`, string(model.PaymentStatusConfirmed), transactionID)
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return model.Payment{}, fmt.Errorf("payment of transaction[%v] %w", transactionID, model.ErrNotFound)
+		}
+
+		return model.Payment{}, fmt.Errorf("confirm payment fail: %v", err)
+	}
+
+	return toPayment(p), nil
+}
@@ -1,22 +1,44 @@
 package postgre
 
 import (
+	"context"
+
+	"github.com/go-pg/pg/v9"
 	"github.com/pkg/errors"
 
 	"go-prj-skeleton/app/pgutil"
 )
 
+// uniqueViolation is the SQLSTATE of an insert or update breaking a unique
+// constraint
+const uniqueViolation = "23505"
+
+// db returns the connection running the queries of a repo built with ctx,
+// or the default one for repos built without any
+func db(ctx context.Context) *pg.DB {
+	if ctx == nil {
+		return pgutil.DB()
+	}
+
+	return pgutil.DB().WithContext(ctx)
+}
+
 type pgHelperStruct struct {
 }
 
 var pgHelper = pgHelperStruct{}
 
-func (helper pgHelperStruct) delete(model interface{}) error {
-	db := pgutil.DB()
-	err := db.Delete(model)
+func (helper pgHelperStruct) delete(ctx context.Context, model interface{}) error {
+	err := db(ctx).Delete(model)
 	if err != nil {
 		return errors.Wrap(err, "delete failed")
 	}
 
 	return nil
 }
+
+// isUniqueViolation reports whether err breaks a unique constraint
+func isUniqueViolation(err error) bool {
+	pgErr, ok := err.(pg.Error)
+	return ok && pgErr.Field('C') == uniqueViolation
+}
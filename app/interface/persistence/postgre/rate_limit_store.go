@@ -0,0 +1,89 @@
+package postgre
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-pg/pg/v9"
+
+	"go-prj-skeleton/app/ratelimit"
+)
+
+// rateLimitSweepInterval is how often the full buckets are deleted
+const rateLimitSweepInterval = time.Minute
+
+// rateLimitStore keeps the buckets in rate_limit_buckets, shared by the
+// replicas. The clock of the database times them all.
+type rateLimitStore struct {
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewRateLimitStore() ratelimit.Store {
+	return &rateLimitStore{}
+}
+
+func (s *rateLimitStore) Take(ctx context.Context, key string, l ratelimit.Limit) (ratelimit.Result, error) {
+	if err := s.sweep(ctx); err != nil {
+		return ratelimit.Result{}, err
+	}
+
+	interval := time.Duration(float64(time.Second) / l.Rate)
+
+	// the bucket only moves when the request is allowed, which the WHERE of
+	// the upsert tells in the same statement, so concurrent requests of the
+	// replicas can't both take the last token
+	var tat, now time.Time
+	_, err := db(ctx).QueryOne(pg.Scan(&tat, &now), `
+		INSERT INTO rate_limit_buckets AS b (key, tat) VALUES (?0, now() + ?1 * interval '1 microsecond')
+		ON CONFLICT (key) DO UPDATE SET tat = GREATEST(b.tat, now()) + ?1 * interval '1 microsecond'
+		WHERE GREATEST(b.tat, now()) + (?1 - ?2) * interval '1 microsecond' <= now()
+		RETURNING tat, now()`,
+		key, interval.Microseconds(), int64(l.Burst)*interval.Microseconds())
+	if err == nil {
+		_, res := ratelimit.Take(tat.Add(-interval), now, l)
+		return res, nil
+	}
+	if err != pg.ErrNoRows {
+		return ratelimit.Result{}, fmt.Errorf("take rate limit token: %w", err)
+	}
+
+	_, err = db(ctx).QueryOne(pg.Scan(&tat, &now), `SELECT tat, now() FROM rate_limit_buckets WHERE key = ?`, key)
+	if err != nil {
+		return ratelimit.Result{}, fmt.Errorf("read rate limit bucket: %w", err)
+	}
+
+	_, res := ratelimit.Take(tat, now, l)
+	return res, nil
+}
+
+func (s *rateLimitStore) Refund(ctx context.Context, key string, l ratelimit.Limit) error {
+	interval := time.Duration(float64(time.Second) / l.Rate)
+
+	_, err := db(ctx).Exec(`UPDATE rate_limit_buckets SET tat = tat - ? * interval '1 microsecond' WHERE key = ?`,
+		interval.Microseconds(), key)
+	if err != nil {
+		return fmt.Errorf("refund rate limit token: %w", err)
+	}
+
+	return nil
+}
+
+// sweep deletes the buckets full again, which a missing bucket is like
+func (s *rateLimitStore) sweep(ctx context.Context) error {
+	s.mu.Lock()
+	if time.Since(s.lastSweep) < rateLimitSweepInterval {
+		s.mu.Unlock()
+		return nil
+	}
+	s.lastSweep = time.Now()
+	s.mu.Unlock()
+
+	if _, err := db(ctx).Exec(`DELETE FROM rate_limit_buckets WHERE tat < now()`); err != nil {
+		return fmt.Errorf("sweep rate limit buckets: %w", err)
+	}
+
+	return nil
+}
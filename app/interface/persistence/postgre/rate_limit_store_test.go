@@ -0,0 +1,50 @@
+package postgre
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/pgutil"
+	"go-prj-skeleton/app/ratelimit"
+)
+
+// TestRateLimitStore runs against the database in POSTGRE_TEST_URL, like
+// TestRepos
+func TestRateLimitStore(t *testing.T) {
+	url := os.Getenv("POSTGRE_TEST_URL")
+	if url == "" {
+		t.Skip("POSTGRE_TEST_URL is not set")
+	}
+
+	pgutil.StartUp(pgutil.Configuration{URL: url, ApplicationName: "repotest"})
+	defer pgutil.Shutdown()
+
+	_, err := pgutil.DB().Exec("TRUNCATE rate_limit_buckets")
+	require.NoError(t, err)
+
+	s := NewRateLimitStore()
+	l := ratelimit.Limit{Rate: 1, Burst: 2}
+
+	res, err := s.Take(context.Background(), "api.ip.192.0.2.1", l)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 1, res.Remaining)
+
+	res, err = s.Take(context.Background(), "api.ip.192.0.2.1", l)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	res, err = s.Take(context.Background(), "api.ip.192.0.2.1", l)
+	require.NoError(t, err)
+	assert.False(t, res.Allowed)
+	assert.Greater(t, int64(res.RetryAfter), int64(0))
+
+	res, err = s.Take(context.Background(), "api.ip.192.0.2.2", l)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed, "buckets are per key")
+}
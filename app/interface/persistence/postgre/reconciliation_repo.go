@@ -0,0 +1,125 @@
+package postgre
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg/v9"
+	"github.com/go-pg/pg/v9/orm"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/pgutil"
+)
+
+type transactionReconciliation struct {
+	TransactionID int `json:"transaction_id"`
+	AccountID     int `json:"account_id"`
+
+	Status        model.ReconciliationStatus `json:"status"`
+	Reference     string                     `json:"reference"`
+	StatementDate time.Time                  `json:"statement_date"`
+	ValueDate     time.Time                  `json:"value_date"`
+	ReconciledAt  time.Time                  `json:"reconciled_at"`
+}
+
+func toReconciliation(r transactionReconciliation) model.Reconciliation {
+	out := model.Reconciliation{
+		TransactionID: r.TransactionID,
+		AccountID:     r.AccountID,
+		Status:        r.Status,
+		Reference:     r.Reference,
+		StatementDate: r.StatementDate.Format(model.StatementDateLayout),
+		ReconciledAt:  r.ReconciledAt.UTC().Format(model.CreatedAtLayout),
+	}
+
+	// value_date is NULL for statements without value dates
+	if !r.ValueDate.IsZero() {
+		out.ValueDate = r.ValueDate.Format(model.StatementDateLayout)
+	}
+
+	return out
+}
+
+type reconciliationRepo struct {
+}
+
+func NewReconciliationRepo() *reconciliationRepo {
+	return &reconciliationRepo{}
+}
+
+func (repo reconciliationRepo) FindByAccount(accountID int) ([]model.Reconciliation, error) {
+	rs := []transactionReconciliation{}
+
+	_, err := pgutil.DB().Query(&rs, "SELECT * FROM transaction_reconciliations WHERE account_id=? ORDER BY transaction_id", accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]model.Reconciliation, len(rs))
+	for i := range rs {
+		out[i] = toReconciliation(rs[i])
+	}
+
+	return out, nil
+}
+
+func (repo reconciliationRepo) Save(r *model.Reconciliation) error {
+	return saveReconciliation(pgutil.DB(), r)
+}
+
+// saveReconciliation saves r with db, the database or a transaction
+func saveReconciliation(db orm.DB, r *model.Reconciliation) error {
+	statementDate, err := time.Parse(model.StatementDateLayout, r.StatementDate)
+	if err != nil {
+		return fmt.Errorf("statement_date[%v] %w", r.StatementDate, model.ErrInvalid)
+	}
+
+	var valueDate *time.Time
+	if r.ValueDate != "" {
+		date, err := time.Parse(model.StatementDateLayout, r.ValueDate)
+		if err != nil {
+			return fmt.Errorf("value_date[%v] %w", r.ValueDate, model.ErrInvalid)
+		}
+		valueDate = &date
+	}
+
+	saved := transactionReconciliation{}
+	_, err = db.QueryOne(&saved, `INSERT INTO transaction_reconciliations (transaction_id, account_id, status, reference, statement_date, value_date)
+		SELECT id, account_id, ?, ?, ?, ? FROM transactions WHERE id=?
+		ON CONFLICT (transaction_id) DO UPDATE SET status=EXCLUDED.status, reference=EXCLUDED.reference, statement_date=EXCLUDED.statement_date, value_date=EXCLUDED.value_date, reconciled_at=NOW()
+		RETURNING *`, string(r.Status), r.Reference, statementDate, valueDate, r.TransactionID)
+	if err != nil {
+		if err == pg.ErrNoRows {
+			return fmt.Errorf("save reconciliation: transaction[%v] %w", r.TransactionID, model.ErrNotFound)
+		}
+
+		return fmt.Errorf("save reconciliation fail: %v", err)
+	}
+
+	*r = toReconciliation(saved)
+
+	return nil
+}
+
+func (repo reconciliationRepo) SaveStatement(saved []*model.Reconciliation, created []model.ReconciledTransaction) error {
+	return pgutil.DB().RunInTransaction(func(tx *pg.Tx) error {
+		for _, c := range created {
+			if err := insertTransaction(tx, c.Transaction); err != nil {
+				return err
+			}
+
+			c.Reconciliation.TransactionID = c.Transaction.ID
+			if err := saveReconciliation(tx, c.Reconciliation); err != nil {
+				return err
+			}
+		}
+
+		for _, r := range saved {
+			if err := saveReconciliation(tx, r); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,61 @@
+package postgre
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/domain/repo/repotest"
+	"go-prj-skeleton/app/pgutil"
+)
+
+// TestRepos runs the repository contract suite against the database in
+// POSTGRE_TEST_URL. All rows of that database are deleted, so point it at a
+// dedicated, migrated test database.
+func TestRepos(t *testing.T) {
+	url := os.Getenv("POSTGRE_TEST_URL")
+	if url == "" {
+		t.Skip("POSTGRE_TEST_URL is not set")
+	}
+
+	pgutil.StartUp(pgutil.Configuration{URL: url, ApplicationName: "repotest"})
+	defer pgutil.Shutdown()
+
+	repotest.Run(t, func(t *testing.T, fixture repotest.Fixture) repotest.Repos {
+		db := pgutil.DB()
+
+		_, err := db.Exec("TRUNCATE payments, payment_batches, transaction_reconciliations, transactions, accounts, users")
+		require.NoError(t, err)
+
+		for _, u := range fixture.Users {
+			require.NoError(t, db.Insert(&user{ID: u.ID, Name: u.Name}))
+		}
+		for _, acc := range fixture.Accounts {
+			require.NoError(t, db.Insert(&account{ID: acc.ID, UserID: acc.UserID, Name: acc.Name, Bank: acc.Bank, Number: acc.Number, IBAN: acc.IBAN}))
+		}
+
+		for _, tran := range fixture.Transactions {
+			createdAt, err := model.ParseCreatedAt(tran.CreatedAt)
+			require.NoError(t, err)
+			require.NoError(t, db.Insert(&transaction{
+				ID: tran.ID, UserID: tran.UserID, AccountID: tran.AccountID, Amount: tran.Amount, TransactionType: tran.TransactionType, CreatedAt: createdAt,
+			}))
+		}
+
+		// the fixture sets the ids, the sequences carry on after them
+		for _, table := range []string{"users", "accounts", "transactions"} {
+			_, err := db.Exec("SELECT setval(?, COALESCE((SELECT MAX(id) FROM "+table+"), 0) + 1, false)", table+"_id_seq")
+			require.NoError(t, err)
+		}
+
+		return repotest.Repos{
+			User:           NewUserRepo(),
+			Account:        NewAccountRepo(),
+			Transaction:    NewTransactionRepo(),
+			Reconciliation: NewReconciliationRepo(),
+			Payment:        NewPaymentRepo(),
+		}
+	})
+}
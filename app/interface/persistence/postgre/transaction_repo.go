@@ -1,15 +1,16 @@
 package postgre
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-pg/pg/v9"
+	"github.com/go-pg/pg/v9/orm"
 	"github.com/shopspring/decimal"
 
 	"go-prj-skeleton/app/domain/model"
-	"go-prj-skeleton/app/pgutil"
 )
 
 type transaction struct {
@@ -20,7 +21,7 @@ type transaction struct {
 
 	Amount          decimal.Decimal       `json:"amount"`
 	TransactionType model.TransactionType `json:"transaction_type"`
-	CreatedAt       string                `json:"created_at"`
+	CreatedAt       time.Time             `json:"created_at"`
 }
 
 func toTransaction(t transaction) model.Transaction {
@@ -30,21 +31,27 @@ func toTransaction(t transaction) model.Transaction {
 		AccountID:       t.AccountID,
 		Amount:          t.Amount,
 		TransactionType: t.TransactionType,
-		CreatedAt:       t.CreatedAt,
+		CreatedAt:       t.CreatedAt.UTC().Format(model.CreatedAtLayout),
 	}
 }
 
 type transactionRepo struct {
+	ctx context.Context
 }
 
 func NewTransactionRepo() *transactionRepo {
 	return &transactionRepo{}
 }
 
+// WithContext returns a copy of repo running its queries in ctx
+func (repo transactionRepo) WithContext(ctx context.Context) interface{} {
+	return &transactionRepo{ctx}
+}
+
 func (repo transactionRepo) FindByID(id int) (model.Transaction, error) {
 	tran := transaction{}
 
-	_, err := pgutil.DB().QueryOne(&tran, "SELECT * FROM transactions WHERE id=?", id)
+	_, err := db(repo.ctx).QueryOne(&tran, "SELECT * FROM transactions WHERE id=?", id)
 	if err != nil {
 		if err == pg.ErrNoRows {
 			return model.Transaction{}, model.ErrNotFound
@@ -59,7 +66,7 @@ func (repo transactionRepo) FindByID(id int) (model.Transaction, error) {
 func (repo transactionRepo) FindByUser(userID int) ([]model.Transaction, error) {
 	trans := []transaction{}
 
-	_, err := pgutil.DB().Query(&trans, "SELECT * FROM transactions WHERE user_id=?", userID)
+	_, err := db(repo.ctx).Query(&trans, "SELECT * FROM transactions WHERE user_id=? ORDER BY id", userID)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +82,7 @@ func (repo transactionRepo) FindByUser(userID int) ([]model.Transaction, error)
 func (repo transactionRepo) FindByUserAccount(userID, accountID int) ([]model.Transaction, error) {
 	trans := []transaction{}
 
-	_, err := pgutil.DB().Query(&trans, "SELECT t.* FROM transactions t INNER JOIN accounts a ON t.account_id = a.id INNER JOIN users u ON a.user_id = u.id WHERE u.id=? AND a.id=?", userID, accountID)
+	_, err := db(repo.ctx).Query(&trans, "SELECT t.* FROM transactions t INNER JOIN accounts a ON t.account_id = a.id INNER JOIN users u ON a.user_id = u.id WHERE u.id=? AND a.id=? ORDER BY t.id", userID, accountID)
 	if err != nil {
 		return nil, err
 	}
@@ -88,31 +95,81 @@ func (repo transactionRepo) FindByUserAccount(userID, accountID int) ([]model.Tr
 	return out, nil
 }
 
+// byUser selects into dst the transactions of userID, or of its account
+// accountID. go-pg aliases the transactions table as "transaction".
+func (repo transactionRepo) byUser(dst interface{}, userID int, accountID *int) *orm.Query {
+	if accountID == nil {
+		return db(repo.ctx).Model(dst).Where(`"transaction".user_id = ?`, userID)
+	}
+
+	return db(repo.ctx).Model(dst).
+		Join(`INNER JOIN accounts AS a ON a.id = "transaction".account_id`).
+		Where("a.user_id = ?", userID).
+		Where("a.id = ?", *accountID)
+}
+
+// EachByUser streams the rows, see orm.Query.ForEach
+func (repo transactionRepo) EachByUser(userID int, accountID *int, fn func(model.Transaction) error) error {
+	return repo.byUser((*transaction)(nil), userID, accountID).OrderExpr(`"transaction".created_at, "transaction".id`).ForEach(func(t *transaction) error {
+		return fn(toTransaction(*t))
+	})
+}
+
+func (repo transactionRepo) FindPage(userID int, accountID *int, after, limit int) ([]model.Transaction, error) {
+	trans := []transaction{}
+
+	q := repo.byUser(&trans, userID, accountID).Where(`"transaction".id > ?`, after).OrderExpr(`"transaction".id`)
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Select(); err != nil {
+		return nil, err
+	}
+
+	out := make([]model.Transaction, len(trans))
+	for i := range trans {
+		out[i] = toTransaction(trans[i])
+	}
+
+	return out, nil
+}
+
 func (repo transactionRepo) Create(t *model.Transaction) error {
+	return insertTransaction(db(repo.ctx), t)
+}
+
+// insertTransaction inserts t with db, the database or a transaction. A
+// zero created_at is left to its default, now.
+func insertTransaction(db orm.DB, t *model.Transaction) error {
 	tran := transaction{
-		ID:              int(time.Now().Unix()),
 		AccountID:       t.AccountID,
 		UserID:          t.UserID,
 		Amount:          t.Amount,
 		TransactionType: t.TransactionType,
 	}
 
-	now := time.Now().UTC().String()
-	tran.CreatedAt = now
-	if err := pgutil.DB().Insert(&tran); err != nil {
+	if t.CreatedAt != "" {
+		createdAt, err := model.ParseCreatedAt(t.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("created_at[%v] %w", t.CreatedAt, model.ErrInvalid)
+		}
+		tran.CreatedAt = createdAt
+	}
+
+	if err := db.Insert(&tran); err != nil {
 		return fmt.Errorf("exec Insert fail: %v", err)
 	}
 
-	t.CreatedAt = tran.CreatedAt
-	t.ID = tran.ID
+	created := toTransaction(tran)
+	t.CreatedAt = created.CreatedAt
+	t.ID = created.ID
 
 	return nil
 }
 
 func (repo transactionRepo) Update(t *model.Transaction) error {
-	db := pgutil.DB()
-
-	_, err := db.Model(&transaction{}).Set("amount=?", t.Amount).
+	_, err := db(repo.ctx).Model(&transaction{}).Set("amount=?", t.Amount).
 		Where("id=?", t.ID).Update()
 	if err != nil {
 		return fmt.Errorf("update transaction fail: %v", err)
@@ -135,7 +192,7 @@ func (repo transactionRepo) Delete(userID, tranID int) error {
 		return nil
 	}
 
-	if err := pgHelper.delete(&transaction{ID: tran.ID}); err != nil {
+	if err := pgHelper.delete(repo.ctx, &transaction{ID: tran.ID}); err != nil {
 		return err
 	}
 
@@ -1,10 +1,12 @@
 package postgre
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/go-pg/pg/v9"
 
 	"go-prj-skeleton/app/domain/model"
-	"go-prj-skeleton/app/pgutil"
 )
 
 type user struct {
@@ -20,16 +22,22 @@ func toUser(u user) model.User {
 }
 
 type userRepo struct {
+	ctx context.Context
 }
 
 func NewUserRepo() *userRepo {
 	return &userRepo{}
 }
 
+// WithContext returns a copy of repo running its queries in ctx
+func (repo userRepo) WithContext(ctx context.Context) interface{} {
+	return &userRepo{ctx}
+}
+
 func (repo userRepo) FindByID(id int) (model.User, error) {
 	u := user{}
 
-	_, err := pgutil.DB().QueryOne(&u, "SELECT * FROM users WHERE id=?", id)
+	_, err := db(repo.ctx).QueryOne(&u, "SELECT * FROM users WHERE id=?", id)
 	if err != nil {
 		if err == pg.ErrNoRows {
 			return model.User{}, model.ErrNotFound
@@ -40,3 +48,37 @@ func (repo userRepo) FindByID(id int) (model.User, error) {
 
 	return toUser(u), nil
 }
+
+func (repo userRepo) Create(u *model.User) error {
+	row := user{Name: u.Name}
+
+	if err := db(repo.ctx).Insert(&row); err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("user name[%v] %w", u.Name, model.ErrConflict)
+		}
+
+		return fmt.Errorf("create user fail: %v", err)
+	}
+
+	u.ID = row.ID
+
+	return nil
+}
+
+func (repo userRepo) Update(u *model.User) error {
+	res, err := db(repo.ctx).Model(&user{}).Set("name=?", u.Name).
+		Where("id=?", u.ID).Update()
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("user name[%v] %w", u.Name, model.ErrConflict)
+		}
+
+		return fmt.Errorf("update user fail: %v", err)
+	}
+
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("user[%v] %w", u.ID, model.ErrNotFound)
+	}
+
+	return nil
+}
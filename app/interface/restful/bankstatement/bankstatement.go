@@ -0,0 +1,101 @@
+// Package bankstatement reads the statements banks send for an account:
+// CSV files, whose columns depend on the bank, OFX 1 (SGML) or 2 (XML)
+// files, SWIFT MT940 messages and ISO 20022 camt.053 documents.
+package bankstatement
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+// Format is a file format of statements
+type Format struct {
+	Name string
+	// ByBank formats are laid out differently by each bank
+	ByBank bool
+
+	parse func(r io.Reader, bank string) ([]usecase.StatementEntry, error)
+}
+
+// Parse reads the entries of the statement r, issued by bank. Malformed
+// statements are ErrInvalid.
+func (f Format) Parse(r io.Reader, bank string) ([]usecase.StatementEntry, error) {
+	return f.parse(r, bank)
+}
+
+var (
+	CSV     = Format{Name: "csv", ByBank: true, parse: parseCSV}
+	OFX     = Format{Name: "ofx", parse: parseOFX}
+	MT940   = Format{Name: "mt940", parse: parseMT940}
+	CAMT053 = Format{Name: "camt053", parse: parseCAMT053}
+
+	// Formats are the supported formats
+	Formats = []Format{CSV, OFX, MT940, CAMT053}
+)
+
+// ByName returns the format named name, e.g. "mt940"
+func ByName(name string) (Format, bool) {
+	for _, f := range Formats {
+		if f.Name == name {
+			return f, true
+		}
+	}
+
+	return Format{}, false
+}
+
+// Names returns the names of the supported formats
+func Names() []string {
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = f.Name
+	}
+
+	return names
+}
+
+// numberFormat are the separators of the amounts of a statement, spaces
+// are always ignored
+type numberFormat struct {
+	thousands string
+	decimal   string
+}
+
+var pointDecimal = numberFormat{thousands: ",", decimal: "."}
+
+// parseAmount parses s, e.g. "-1,000.50" or "(1.000,50)". An empty s is 0.
+func (nf numberFormat) parseAmount(s string) (decimal.Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return decimal.Zero, nil
+	}
+
+	neg := false
+	if strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")") {
+		neg = true
+		s = s[1 : len(s)-1]
+	}
+
+	plain := strings.ReplaceAll(s, " ", "")
+	if nf.thousands != "" {
+		plain = strings.ReplaceAll(plain, nf.thousands, "")
+	}
+	plain = strings.Replace(plain, nf.decimal, ".", 1)
+
+	d, err := decimal.NewFromString(plain)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%q is not an amount: %w", s, model.ErrInvalid)
+	}
+
+	if neg {
+		d = d.Neg()
+	}
+
+	return d, nil
+}
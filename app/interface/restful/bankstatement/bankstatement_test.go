@@ -0,0 +1,252 @@
+package bankstatement
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+// summary renders entries as "date amount reference description" lines
+func summary(entries []usecase.StatementEntry) []string {
+	out := []string{}
+	for _, e := range entries {
+		out = append(out, fmt.Sprintf("%s %s %s %s", e.Date.Format("2006-01-02"), e.Amount.String(), e.Reference, e.Description))
+	}
+
+	return out
+}
+
+func TestParseCSV(t *testing.T) {
+	t.Parallel()
+
+	t.Run("VCB", func(t *testing.T) {
+		entries, err := CSV.Parse(strings.NewReader("\ufeffSAO KÊ TÀI KHOẢN\n"+
+			"Số tài khoản: 0011001234567,,,,\n"+
+			"Ngày giao dịch,Số tham chiếu,Số tiền ghi nợ,Số tiền ghi có,Mô tả\n"+
+			"05/01/2021,FT21005,\"1,000,000\",,Chuyen tien\n"+
+			"06/01/2021,FT21006,,250.50,Lai tien gui\n"+
+			",Tổng cộng,\"1,000,000\",250.50,\n"), "VCB")
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"2021-01-05 -1000000 FT21005 Chuyen tien",
+			"2021-01-06 250.5 FT21006 Lai tien gui",
+		}, summary(entries))
+	})
+
+	t.Run("ACB", func(t *testing.T) {
+		entries, err := CSV.Parse(strings.NewReader(
+			"Ngày hiệu lực;Số GD;Ghi nợ;Ghi có;Nội dung\n"+
+				"07/01/2021;5012;;1.500.000,00;Luong thang 1\n"), "ACB")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2021-01-07 1500000 5012 Luong thang 1"}, summary(entries))
+	})
+
+	t.Run("VIB", func(t *testing.T) {
+		entries, err := CSV.Parse(strings.NewReader(
+			"transaction date,Reference No,Amount,Description\n"+
+				"2021-01-08,VIB001,(20.00),Fee\n"+
+				"2021-01-09,VIB002,\"3,000\",Refund\n"), "VIB")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2021-01-08 -20 VIB001 Fee", "2021-01-09 3000 VIB002 Refund"}, summary(entries))
+	})
+
+	for name, tc := range map[string]struct {
+		bank string
+		body string
+		err  error
+	}{
+		"unknown bank":   {"XYZ", "", model.ErrInvalidBank},
+		"no header":      {"VIB", "a,b\n1,2\n", model.ErrInvalid},
+		"missing column": {"VIB", "Transaction Date,Amount\n", model.ErrInvalid},
+		"bad date":       {"VIB", "Transaction Date,Reference No,Amount,Description\n08/01/2021,A,1,B\n", model.ErrInvalid},
+		"bad amount":     {"VIB", "Transaction Date,Reference No,Amount,Description\n2021-01-08,A,one,B\n", model.ErrInvalid},
+	} {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := CSV.Parse(strings.NewReader(tc.body), tc.bank)
+			assert.True(t, errors.Is(err, tc.err), "got %v", err)
+		})
+	}
+}
+
+func TestParseOFX(t *testing.T) {
+	t.Parallel()
+
+	t.Run("SGML", func(t *testing.T) {
+		entries, err := OFX.Parse(strings.NewReader(`OFXHEADER:100
+DATA:OFXSGML
+VERSION:102
+
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+<DTSTART>20210101
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20210105120000.000[+7:ICT]
+<TRNAMT>-100.00
+<FITID>FT21005
+<NAME>Coffee &amp; Co
+<MEMO>Card 1234
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20210106
+<TRNAMT>250,5
+<FITID>FT21006
+</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`), "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"2021-01-05 -100 FT21005 Coffee & Co - Card 1234",
+			"2021-01-06 250.5 FT21006 ",
+		}, summary(entries))
+	})
+
+	t.Run("XML", func(t *testing.T) {
+		entries, err := OFX.Parse(strings.NewReader(`<?xml version="1.0" encoding="UTF-8"?>
+<?OFX OFXHEADER="200" VERSION="211"?>
+<OFX><BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+  <STMTTRN>
+    <TRNTYPE>CREDIT</TRNTYPE>
+    <DTPOSTED>20210107000000.000[+0:UTC]</DTPOSTED>
+    <TRNAMT>1500000.00</TRNAMT>
+    <FITID>5012</FITID>
+    <NAME>Salary</NAME>
+  </STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1></OFX>`), "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2021-01-07 1500000 5012 Salary"}, summary(entries))
+	})
+
+	t.Run("bad amount", func(t *testing.T) {
+		_, err := OFX.Parse(strings.NewReader("<STMTTRN><DTPOSTED>20210107<TRNAMT>ten</STMTTRN>"), "")
+		assert.True(t, errors.Is(err, model.ErrInvalid), "got %v", err)
+	})
+
+	t.Run("bad date", func(t *testing.T) {
+		_, err := OFX.Parse(strings.NewReader("<STMTTRN><DTPOSTED>2021<TRNAMT>10</STMTTRN>"), "")
+		assert.True(t, errors.Is(err, model.ErrInvalid), "got %v", err)
+	})
+}
+
+func TestParseMT940(t *testing.T) {
+	t.Parallel()
+
+	entries, err := MT940.Parse(strings.NewReader("{1:F01VCBVVNVXAXXX0000000000}{2:O9400000210108VCBVVNVXAXXX00000000002101080000N}{4:\r\n"+
+		":20:STMT2101\r\n"+
+		":25:0011001234567\r\n"+
+		":28C:1/1\r\n"+
+		":60F:C210104VND1000000,\r\n"+
+		":61:2101050105D100,NTRFFT21005//B21005\r\n"+
+		":86:Coffee\r\n"+
+		"Card 1234\r\n"+
+		":61:210106C250,5NINTNONREF//B21006\r\n"+
+		":61:210107RC30,NCHGNONREF\r\n"+
+		":86:Fee refund reversed\r\n"+
+		":62F:C210108VND1000120,5\r\n"+
+		"-}\r\n"), "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"2021-01-05 -100 FT21005 Coffee Card 1234",
+		"2021-01-06 250.5 B21006 ",
+		"2021-01-07 -30  Fee refund reversed",
+	}, summary(entries))
+
+	_, err = MT940.Parse(strings.NewReader(":61:2101XXC100,NTRF\n"), "")
+	assert.True(t, errors.Is(err, model.ErrInvalid), "got %v", err)
+
+	assert.Equal(t, time.UTC, entries[0].Date.Location())
+}
+
+func TestParseCAMT053(t *testing.T) {
+	t.Parallel()
+
+	// entry wraps the elements of an entry common to the cases
+	entry := func(amount, indicator, rest string) string {
+		return "<Ntry><Amt Ccy=\"VND\">" + amount + "</Amt><CdtDbtInd>" + indicator + "</CdtDbtInd>" + rest + "</Ntry>"
+	}
+	document := func(stmts ...string) string {
+		return `<?xml version="1.0" encoding="UTF-8"?>` +
+			`<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"><BkToCstmrStmt>` +
+			`<GrpHdr><MsgId>STMT2101</MsgId></GrpHdr>` + strings.Join(stmts, "") +
+			`</BkToCstmrStmt></Document>`
+	}
+	stmt := func(iban string, entries ...string) string {
+		return "<Stmt><Id>1</Id><Acct><Id><IBAN>" + iban + "</IBAN></Id><Ccy>VND</Ccy></Acct>" + strings.Join(entries, "") + "</Stmt>"
+	}
+
+	t.Run("entries", func(t *testing.T) {
+		entries, err := CAMT053.Parse(strings.NewReader(document(
+			stmt("VN12VCB0011001234567",
+				entry("100.00", "DBIT", "<Sts>BOOK</Sts><BookgDt><Dt>2021-01-05</Dt></BookgDt><ValDt><Dt>2021-01-04</Dt></ValDt>"+
+					"<AcctSvcrRef>B21005</AcctSvcrRef><NtryDtls><TxDtls><Refs><EndToEndId>E2E1</EndToEndId></Refs>"+
+					"<RmtInf><Ustrd>Coffee</Ustrd><Ustrd>Card  1234</Ustrd></RmtInf></TxDtls></NtryDtls>"),
+				entry("250.5", "CRDT", "<Sts><Cd>BOOK</Cd></Sts><BookgDt><DtTm>2021-01-06T23:30:00+07:00</DtTm></BookgDt>"+
+					"<NtryDtls><TxDtls><Refs><EndToEndId>E2E2</EndToEndId></Refs></TxDtls></NtryDtls><AddtlNtryInf>Interest</AddtlNtryInf>"),
+				entry("30", "CRDT", "<RvslInd>true</RvslInd><Sts>BOOK</Sts><BookgDt><Dt>2021-01-07</Dt></BookgDt><NtryRef>N3</NtryRef>"),
+				entry("999", "CRDT", "<Sts>PDNG</Sts><BookgDt><Dt>2021-01-08</Dt></BookgDt>"),
+			),
+			stmt("VN12VCB0011001234567",
+				entry("40", "DBIT", "<Sts>BOOK</Sts><BookgDt><Dt>2021-01-09</Dt></BookgDt><AcctSvcrRef>B21009</AcctSvcrRef>"),
+			),
+		)), "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"2021-01-05 -100 B21005 Coffee Card 1234",
+			"2021-01-06 250.5 E2E2 Interest",
+			"2021-01-07 -30 N3 ",
+			"2021-01-09 -40 B21009 ",
+		}, summary(entries))
+		assert.Equal(t, "2021-01-04", entries[0].ValueDate.Format("2006-01-02"))
+		assert.Equal(t, entries[1].Date, entries[1].ValueDate)
+	})
+
+	t.Run("invalid entries", func(t *testing.T) {
+		_, err := CAMT053.Parse(strings.NewReader(document(stmt("VN12VCB0011001234567",
+			entry("100", "DBIT", "<BookgDt><Dt>2021-01-05</Dt></BookgDt>"),
+			entry("1O0", "CRDT", "<BookgDt><Dt>05/01/2021</Dt></BookgDt>"),
+			"<Ntry><Amt Ccy=\"USD\">5</Amt><CdtDbtInd>CR</CdtDbtInd><BookgDt><Dt>2021-01-05</Dt></BookgDt></Ntry>",
+		))), "")
+		require.True(t, errors.Is(err, model.ErrInvalid), "got %v", err)
+
+		var fieldErrs model.FieldErrors
+		require.True(t, errors.As(err, &fieldErrs))
+		fields := []string{}
+		for _, fe := range fieldErrs {
+			fields = append(fields, fe.Field)
+		}
+		assert.Equal(t, []string{
+			"Stmt[1]/Ntry[2]/Amt",
+			"Stmt[1]/Ntry[2]/BookgDt",
+			"Stmt[1]/Ntry[3]/Amt/@Ccy",
+			"Stmt[1]/Ntry[3]/CdtDbtInd",
+		}, fields)
+	})
+
+	t.Run("several accounts", func(t *testing.T) {
+		booked := entry("10", "CRDT", "<Sts>BOOK</Sts><BookgDt><Dt>2021-01-05</Dt></BookgDt>")
+		entries, err := CAMT053.Parse(strings.NewReader(document(
+			stmt("VN12VCB0011001234567", booked),
+			"<Stmt><Id>2</Id><Acct><Id><Othr><Id> 0071000123456 </Id></Othr></Id></Acct>"+booked+"</Stmt>",
+		)), "")
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "VN12VCB0011001234567", entries[0].Account)
+		assert.Equal(t, "0071000123456", entries[1].Account)
+	})
+
+	t.Run("not XML", func(t *testing.T) {
+		_, err := CAMT053.Parse(strings.NewReader("<Document><BkToCstmrStmt>"), "")
+		assert.True(t, errors.Is(err, model.ErrInvalid), "got %v", err)
+	})
+}
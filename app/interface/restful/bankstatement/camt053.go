@@ -0,0 +1,214 @@
+package bankstatement
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/export"
+	"go-prj-skeleton/app/usecase"
+)
+
+// camt053 is a BkToCstmrStmt document, of any camt.053.001 version. Element
+// names match whatever the namespace.
+type camt053 struct {
+	Statements []camtStatement `xml:"BkToCstmrStmt>Stmt"`
+}
+
+type camtStatement struct {
+	IBAN    string      `xml:"Acct>Id>IBAN"`
+	Other   string      `xml:"Acct>Id>Othr>Id"`
+	Entries []camtEntry `xml:"Ntry"`
+}
+
+// account is the identification of the account of the statement
+func (s camtStatement) account() string {
+	if iban := strings.TrimSpace(s.IBAN); iban != "" {
+		return iban
+	}
+
+	return strings.TrimSpace(s.Other)
+}
+
+type camtEntry struct {
+	NtryRef      string     `xml:"NtryRef"`
+	Amount       camtAmount `xml:"Amt"`
+	CdtDbtInd    string     `xml:"CdtDbtInd"`
+	RvslInd      bool       `xml:"RvslInd"`
+	Status       camtStatus `xml:"Sts"`
+	BookingDate  camtDate   `xml:"BookgDt"`
+	ValueDate    camtDate   `xml:"ValDt"`
+	AcctSvcrRef  string     `xml:"AcctSvcrRef"`
+	EndToEndIDs  []string   `xml:"NtryDtls>TxDtls>Refs>EndToEndId"`
+	Unstructured []string   `xml:"NtryDtls>TxDtls>RmtInf>Ustrd"`
+	AddtlInf     string     `xml:"AddtlNtryInf"`
+}
+
+type camtAmount struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+// camtStatus is a code, e.g. BOOK, from version 08 on within a Cd element
+type camtStatus struct {
+	Value string `xml:",chardata"`
+	Code  string `xml:"Cd"`
+}
+
+func (s camtStatus) code() string {
+	if code := strings.TrimSpace(s.Code); code != "" {
+		return code
+	}
+
+	return strings.TrimSpace(s.Value)
+}
+
+// camtDate is either a date or a datetime, the day is the one of the
+// datetime's own offset
+type camtDate struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+func (d camtDate) empty() bool {
+	return strings.TrimSpace(d.Date) == "" && strings.TrimSpace(d.DateTime) == ""
+}
+
+func (d camtDate) parse() (time.Time, error) {
+	s := strings.TrimSpace(d.Date)
+	if s == "" {
+		s = strings.TrimSpace(d.DateTime)
+	}
+
+	if len(s) < len(model.StatementDateLayout) {
+		return time.Time{}, fmt.Errorf("%q is not a date: %w", s, model.ErrInvalid)
+	}
+
+	date, err := time.Parse(model.StatementDateLayout, s[:len(model.StatementDateLayout)])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not a date: %w", s, model.ErrInvalid)
+	}
+
+	return date, nil
+}
+
+// parseCAMT053 reads the booked entries of ISO 20022 bank to customer
+// statements, of any number of accounts. Pending and information only
+// entries are left out. Invalid
+// entries are reported all at once as model.FieldErrors, whose fields are
+// paths such as "Stmt[1]/Ntry[2]/Amt".
+func parseCAMT053(r io.Reader, bank string) ([]usecase.StatementEntry, error) {
+	var doc camt053
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("%v: %w", err, model.ErrInvalid)
+	}
+
+	if len(doc.Statements) == 0 {
+		return nil, fmt.Errorf("no BkToCstmrStmt/Stmt element: %w", model.ErrInvalid)
+	}
+
+	entries := []usecase.StatementEntry{}
+	var errs model.FieldErrors
+
+	for i, s := range doc.Statements {
+		for j, n := range s.Entries {
+			path := fmt.Sprintf("Stmt[%d]/Ntry[%d]", i+1, j+1)
+
+			if code := n.Status.code(); code != "" && code != "BOOK" {
+				continue
+			}
+
+			e, fieldErrs := camtEntryOf(path, n)
+			if len(fieldErrs) > 0 {
+				errs = append(errs, fieldErrs...)
+				continue
+			}
+
+			e.Account = s.account()
+			entries = append(entries, e)
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return entries, nil
+}
+
+func camtEntryOf(path string, n camtEntry) (usecase.StatementEntry, model.FieldErrors) {
+	var errs model.FieldErrors
+	invalid := func(field string, err error) {
+		errs = append(errs, &model.FieldError{Field: path + "/" + field, Err: err})
+	}
+
+	amount, err := pointDecimal.parseAmount(n.Amount.Value)
+	switch {
+	case err != nil:
+		invalid("Amt", err)
+	case strings.TrimSpace(n.Amount.Value) == "" || amount.IsNegative():
+		invalid("Amt", fmt.Errorf("%q is not a positive amount: %w", n.Amount.Value, model.ErrInvalidAmount))
+	}
+
+	if ccy := strings.TrimSpace(n.Amount.Currency); ccy != export.Currency {
+		invalid("Amt/@Ccy", fmt.Errorf("%q is not %s, the currency of accounts: %w", ccy, export.Currency, model.ErrInvalid))
+	}
+
+	credit := false
+	switch strings.TrimSpace(n.CdtDbtInd) {
+	case "CRDT":
+		credit = true
+	case "DBIT":
+	default:
+		invalid("CdtDbtInd", fmt.Errorf("%q is neither CRDT nor DBIT: %w", n.CdtDbtInd, model.ErrInvalid))
+	}
+
+	// a reversed credit takes money out of the account
+	if credit == n.RvslInd {
+		amount = amount.Neg()
+	}
+
+	booked, err := n.BookingDate.parse()
+	if err != nil {
+		invalid("BookgDt", err)
+	}
+
+	valued := booked
+	if !n.ValueDate.empty() {
+		if valued, err = n.ValueDate.parse(); err != nil {
+			invalid("ValDt", err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return usecase.StatementEntry{}, errs
+	}
+
+	// the reference of the bank is unique, the one of the entry may only be
+	// unique within the statement
+	reference := strings.TrimSpace(n.AcctSvcrRef)
+	if reference == "" {
+		reference = strings.TrimSpace(n.NtryRef)
+	}
+	for _, id := range n.EndToEndIDs {
+		if id = strings.TrimSpace(id); reference == "" && id != "" && id != "NOTPROVIDED" {
+			reference = id
+		}
+	}
+
+	description := strings.Join(strings.Fields(strings.Join(n.Unstructured, " ")), " ")
+	if description == "" {
+		description = strings.Join(strings.Fields(n.AddtlInf), " ")
+	}
+
+	return usecase.StatementEntry{
+		Date:        booked,
+		ValueDate:   valued,
+		Amount:      amount,
+		Reference:   reference,
+		Description: description,
+	}, nil
+}
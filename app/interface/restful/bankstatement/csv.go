@@ -0,0 +1,192 @@
+package bankstatement
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+// csvColumns names the columns of the CSV statements of a bank, headers are
+// matched case insensitively. Amounts are either signed, in Amount, or split
+// in Debit and Credit.
+type csvColumns struct {
+	Comma rune
+
+	Date        string
+	DateLayout  string
+	Reference   string
+	Description string
+
+	Amount string
+	Debit  string
+	Credit string
+
+	numberFormat
+}
+
+var csvColumnsByBank = map[string]csvColumns{
+	"VCB": {
+		Comma:        ',',
+		Date:         "Ngày giao dịch",
+		DateLayout:   "02/01/2006",
+		Reference:    "Số tham chiếu",
+		Description:  "Mô tả",
+		Debit:        "Số tiền ghi nợ",
+		Credit:       "Số tiền ghi có",
+		numberFormat: pointDecimal,
+	},
+	"ACB": {
+		Comma:        ';',
+		Date:         "Ngày hiệu lực",
+		DateLayout:   "02/01/2006",
+		Reference:    "Số GD",
+		Description:  "Nội dung",
+		Debit:        "Ghi nợ",
+		Credit:       "Ghi có",
+		numberFormat: numberFormat{thousands: ".", decimal: ","},
+	},
+	"VIB": {
+		Comma:        ',',
+		Date:         "Transaction Date",
+		DateLayout:   "2006-01-02",
+		Reference:    "Reference No",
+		Description:  "Description",
+		Amount:       "Amount",
+		numberFormat: pointDecimal,
+	},
+}
+
+// headers returns the headers of the columns, the date one first
+func (c csvColumns) headers() []string {
+	out := []string{c.Date, c.Reference, c.Description}
+	if c.Amount != "" {
+		return append(out, c.Amount)
+	}
+
+	return append(out, c.Debit, c.Credit)
+}
+
+// parseCSV skips the rows before the header, the first row naming the date
+// column, and the rows without a date after it, like totals.
+func parseCSV(r io.Reader, bank string) ([]usecase.StatementEntry, error) {
+	cols, ok := csvColumnsByBank[bank]
+	if !ok {
+		return nil, fmt.Errorf("no CSV layout for bank %s: %w", bank, model.ErrInvalidBank)
+	}
+
+	cr := csv.NewReader(r)
+	cr.Comma = cols.Comma
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+
+	var index map[string]int
+	entries := []usecase.StatementEntry{}
+	for row := 1; ; row++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v: %w", row, err, model.ErrInvalid)
+		}
+
+		if row == 1 && len(record) > 0 {
+			record[0] = strings.TrimPrefix(record[0], "\ufeff")
+		}
+
+		if index == nil {
+			index = headerIndex(record, cols.Date)
+			if index == nil {
+				continue
+			}
+
+			for _, h := range cols.headers() {
+				if _, ok := index[strings.ToLower(h)]; !ok {
+					return nil, fmt.Errorf("row %d: no %q column: %w", row, h, model.ErrInvalid)
+				}
+			}
+
+			continue
+		}
+
+		cell := func(header string) string {
+			if i := index[strings.ToLower(header)]; i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+
+			return ""
+		}
+
+		if cell(cols.Date) == "" {
+			continue
+		}
+
+		e, err := cols.entry(cell)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+
+		entries = append(entries, e)
+	}
+
+	if index == nil {
+		return nil, fmt.Errorf("no header row with a %q column: %w", cols.Date, model.ErrInvalid)
+	}
+
+	return entries, nil
+}
+
+// headerIndex returns the columns of record by lower cased name, or nil
+// when it has no date column
+func headerIndex(record []string, date string) map[string]int {
+	index := map[string]int{}
+	for i, h := range record {
+		index[strings.ToLower(strings.TrimSpace(h))] = i
+	}
+
+	if _, ok := index[strings.ToLower(date)]; !ok {
+		return nil
+	}
+
+	return index
+}
+
+func (c csvColumns) entry(cell func(string) string) (usecase.StatementEntry, error) {
+	date, err := time.Parse(c.DateLayout, cell(c.Date))
+	if err != nil {
+		return usecase.StatementEntry{}, fmt.Errorf("%q is not a %s date: %w", cell(c.Date), c.DateLayout, model.ErrInvalid)
+	}
+
+	var amount decimal.Decimal
+	if c.Amount != "" {
+		if amount, err = c.parseAmount(cell(c.Amount)); err != nil {
+			return usecase.StatementEntry{}, err
+		}
+	} else {
+		debit, err := c.parseAmount(cell(c.Debit))
+		if err != nil {
+			return usecase.StatementEntry{}, err
+		}
+
+		credit, err := c.parseAmount(cell(c.Credit))
+		if err != nil {
+			return usecase.StatementEntry{}, err
+		}
+
+		amount = credit.Sub(debit.Abs())
+	}
+
+	return usecase.StatementEntry{
+		Date:        date,
+		Amount:      amount,
+		Reference:   cell(c.Reference),
+		Description: cell(c.Description),
+	}, nil
+}
@@ -0,0 +1,106 @@
+package bankstatement
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+// mt940Field starts a field, e.g. ":61:2101050105D100,00NTRFFT21005"
+var mt940Field = regexp.MustCompile(`^:(\d{2}[A-Z]?):(.*)$`)
+
+// mt940Line is the statement line field 61: value date, optional entry date,
+// debit/credit mark (R for reversals), funds code, amount, transaction type,
+// reference for the account owner and reference of the bank
+var mt940Line = regexp.MustCompile(`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d*)([NSF][A-Z0-9]{3})([^/\n]*)(?://([^\n]*))?`)
+
+type mt940Tag struct {
+	tag   string
+	value string
+}
+
+// parseMT940 reads the statement lines, field 61, with the information to
+// the account owner, field 86, that follows them
+func parseMT940(r io.Reader, bank string) ([]usecase.StatementEntry, error) {
+	tags := []mt940Tag{}
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+
+		if m := mt940Field.FindStringSubmatch(line); m != nil {
+			tags = append(tags, mt940Tag{m[1], m[2]})
+			continue
+		}
+
+		// block headers and the end of a message
+		if strings.HasPrefix(line, "{") || strings.HasPrefix(line, "-") || len(tags) == 0 {
+			continue
+		}
+
+		tags[len(tags)-1].value += "\n" + line
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	entries := []usecase.StatementEntry{}
+	for i, t := range tags {
+		switch t.tag {
+		case "61":
+			e, err := mt940Entry(t.value)
+			if err != nil {
+				return nil, fmt.Errorf("statement line %d: %w", len(entries)+1, err)
+			}
+
+			entries = append(entries, e)
+
+		case "86":
+			if i > 0 && tags[i-1].tag == "61" {
+				entries[len(entries)-1].Description = strings.Join(strings.Fields(t.value), " ")
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func mt940Entry(value string) (usecase.StatementEntry, error) {
+	m := mt940Line.FindStringSubmatch(value)
+	if m == nil {
+		return usecase.StatementEntry{}, fmt.Errorf("%q is not a statement line: %w", value, model.ErrInvalid)
+	}
+
+	date, err := time.Parse("060102", m[1])
+	if err != nil {
+		return usecase.StatementEntry{}, fmt.Errorf("%q is not a YYMMDD date: %w", m[1], model.ErrInvalid)
+	}
+
+	amount, err := numberFormat{decimal: ","}.parseAmount(strings.TrimSuffix(m[5], ","))
+	if err != nil {
+		return usecase.StatementEntry{}, err
+	}
+
+	// reversals of a credit are debits and reversals of a debit credits
+	if m[3] == "D" || m[3] == "RC" {
+		amount = amount.Neg()
+	}
+
+	reference := strings.TrimSpace(m[7])
+	if reference == "" || reference == "NONREF" {
+		reference = strings.TrimSpace(m[8])
+	}
+
+	return usecase.StatementEntry{
+		Date:      date,
+		ValueDate: date,
+		Amount:    amount,
+		Reference: reference,
+	}, nil
+}
@@ -0,0 +1,106 @@
+package bankstatement
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+// ofxDateLayout is the date part of OFX datetimes, e.g.
+// 20210105120000.000[+7:ICT]
+const ofxDateLayout = "20060102"
+
+// parseOFX reads the STMTTRN aggregates. It scans tags rather than decoding
+// XML, so the SGML of OFX 1, whose elements are not closed, reads the same.
+func parseOFX(r io.Reader, bank string) ([]usecase.StatementEntry, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []usecase.StatementEntry{}
+	var fields map[string]string
+
+	s := string(data)
+	for {
+		start := strings.IndexByte(s, '<')
+		if start < 0 {
+			break
+		}
+		s = s[start+1:]
+
+		end := strings.IndexByte(s, '>')
+		if end < 0 {
+			break
+		}
+		tag := strings.ToUpper(strings.TrimSpace(s[:end]))
+		s = s[end+1:]
+
+		value := s
+		if next := strings.IndexByte(s, '<'); next >= 0 {
+			value = s[:next]
+		}
+		value = strings.TrimSpace(html.UnescapeString(value))
+
+		switch tag {
+		case "STMTTRN":
+			fields = map[string]string{}
+
+		case "/STMTTRN":
+			if fields == nil {
+				continue
+			}
+
+			e, err := ofxEntry(fields)
+			if err != nil {
+				return nil, fmt.Errorf("transaction %d: %w", len(entries)+1, err)
+			}
+
+			entries = append(entries, e)
+			fields = nil
+
+		case "DTPOSTED", "TRNAMT", "FITID", "NAME", "MEMO":
+			if fields != nil {
+				fields[tag] = value
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+func ofxEntry(fields map[string]string) (usecase.StatementEntry, error) {
+	posted := fields["DTPOSTED"]
+	if len(posted) < len(ofxDateLayout) {
+		return usecase.StatementEntry{}, fmt.Errorf("DTPOSTED %q is not a date: %w", posted, model.ErrInvalid)
+	}
+
+	date, err := time.Parse(ofxDateLayout, posted[:len(ofxDateLayout)])
+	if err != nil {
+		return usecase.StatementEntry{}, fmt.Errorf("DTPOSTED %q is not a date: %w", posted, model.ErrInvalid)
+	}
+
+	// OFX allows a comma as decimal separator
+	amount, err := numberFormat{decimal: ","}.parseAmount(fields["TRNAMT"])
+	if err != nil {
+		return usecase.StatementEntry{}, fmt.Errorf("TRNAMT: %w", err)
+	}
+
+	description := fields["NAME"]
+	if memo := fields["MEMO"]; memo != "" && memo != description {
+		description = strings.TrimPrefix(description+" - "+memo, " - ")
+	}
+
+	return usecase.StatementEntry{
+		Date:        date,
+		Amount:      amount,
+		Reference:   fields["FITID"],
+		Description: description,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+var csvHeader = []string{"id", "account_id", "bank", "transaction_type", "amount", "balance", "created_at"}
+
+// csvWriter writes one row per line, balance being the balance of the
+// account after it
+type csvWriter struct {
+	w       *csv.Writer
+	started bool
+}
+
+func newCSV(w io.Writer, s Statement) Writer {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) start() {
+	if !c.started {
+		c.started = true
+		c.w.Write(csvHeader)
+	}
+}
+
+func (c *csvWriter) Line(l usecase.StatementLine) error {
+	c.start()
+	c.w.Write([]string{
+		strconv.Itoa(l.ID),
+		strconv.Itoa(l.AccountID),
+		l.Bank,
+		string(l.TransactionType),
+		l.Amount.StringFixed(2),
+		l.Balance.StringFixed(2),
+		l.PostedAt.UTC().Format(model.CreatedAtLayout),
+	})
+
+	return c.w.Error()
+}
+
+func (c *csvWriter) Close(balances map[int]usecase.Balances) error {
+	c.start()
+	c.w.Flush()
+
+	return c.w.Error()
+}
@@ -0,0 +1,135 @@
+// Package export renders statements as CSV, OFX 2 or QIF files. Writers
+// render each line as it is read, so a statement never sits in memory, and
+// write nothing before their first line or Close: until then a failed
+// export can still be answered with an error.
+package export
+
+import (
+	"io"
+	"mime"
+	"strings"
+	"time"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+// Currency is the currency of every amount, accounts are held in Vietnam
+const Currency = "VND"
+
+// Statement describes what is exported
+type Statement struct {
+	// Account is the account of the statement, all the accounts of the user
+	// are exported when nil
+	Account *usecase.Account
+	// From and To bound the period, To is exclusive. They are zero when the
+	// period is open on that side.
+	From time.Time
+	To   time.Time
+	// Now is the time of the export
+	Now time.Time
+}
+
+// start returns the start of the period, the time of its first line when
+// open
+func (s Statement) start(first *usecase.StatementLine) time.Time {
+	switch {
+	case !s.From.IsZero():
+		return s.From
+	case first != nil:
+		return first.PostedAt
+	}
+
+	return s.Now
+}
+
+// end returns the end of the period, Now when open
+func (s Statement) end() time.Time {
+	if !s.To.IsZero() {
+		return s.To
+	}
+
+	return s.Now
+}
+
+// Writer renders a statement
+type Writer interface {
+	// Line renders the next line, in created_at then id order
+	Line(usecase.StatementLine) error
+	// Close renders what follows the last line, balances are by account id
+	Close(balances map[int]usecase.Balances) error
+}
+
+// Format is a file format of statements
+type Format struct {
+	Name        string
+	ContentType string
+	Extension   string
+	// PerAccount formats need Statement.Account
+	PerAccount bool
+
+	new func(w io.Writer, s Statement) Writer
+}
+
+// New returns a writer rendering s in the format to w
+func (f Format) New(w io.Writer, s Statement) Writer {
+	return f.new(w, s)
+}
+
+var (
+	CSV = Format{Name: "csv", ContentType: "text/csv; charset=utf-8", Extension: "csv", new: newCSV}
+	OFX = Format{Name: "ofx", ContentType: "application/x-ofx", Extension: "ofx", PerAccount: true, new: newOFX}
+	QIF = Format{Name: "qif", ContentType: "application/qif", Extension: "qif", PerAccount: true, new: newQIF}
+
+	// Formats are the supported formats, the first one is the default
+	Formats = []Format{CSV, OFX, QIF}
+)
+
+// ByName returns the format named name, e.g. "ofx"
+func ByName(name string) (Format, bool) {
+	for _, f := range Formats {
+		if f.Name == name {
+			return f, true
+		}
+	}
+
+	return Format{}, false
+}
+
+// Negotiate returns the first format of the Accept header value accept
+// that is supported, ignoring quality values
+func Negotiate(accept string) (Format, bool) {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		for _, f := range Formats {
+			if ct, _, _ := mime.ParseMediaType(f.ContentType); ct == mediaType {
+				return f, true
+			}
+		}
+	}
+
+	return Format{}, false
+}
+
+// Names returns the names of the supported formats
+func Names() []string {
+	names := make([]string, len(Formats))
+	for i, f := range Formats {
+		names[i] = f.Name
+	}
+
+	return names
+}
+
+// payee names the counterpart of a line, there is none but the type
+func payee(l usecase.StatementLine) string {
+	if l.TransactionType == model.TransactionTypeWithdraw {
+		return "Withdraw"
+	}
+
+	return "Deposit"
+}
@@ -0,0 +1,138 @@
+package export
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+var (
+	account = usecase.Account{ID: 7, UserID: 1, Name: "Alice", Bank: "VCB"}
+	from    = time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)
+	to      = time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	now     = time.Date(2021, 3, 5, 9, 0, 0, 0, time.UTC)
+
+	lines = []usecase.StatementLine{
+		{
+			Transaction: usecase.Transaction{ID: 3, AccountID: 7, Amount: decimal.NewFromInt(30), Bank: "VCB", TransactionType: model.TransactionTypeWithdraw},
+			PostedAt:    time.Date(2021, 2, 3, 10, 0, 0, 0, time.UTC),
+			Balance:     decimal.NewFromInt(70),
+		},
+		{
+			Transaction: usecase.Transaction{ID: 4, AccountID: 7, Amount: decimal.RequireFromString("20.5"), Bank: "VCB", TransactionType: model.TransactionTypeDeposit},
+			PostedAt:    time.Date(2021, 2, 20, 10, 0, 0, 0, time.UTC),
+			Balance:     decimal.RequireFromString("90.5"),
+		},
+	}
+
+	balances = map[int]usecase.Balances{7: {Opening: decimal.NewFromInt(100), Closing: decimal.RequireFromString("90.5")}}
+)
+
+func render(t *testing.T, f Format, lines []usecase.StatementLine) string {
+	b := &bytes.Buffer{}
+	w := f.New(b, Statement{Account: &account, From: from, To: to, Now: now})
+	for _, l := range lines {
+		require.NoError(t, w.Line(l))
+	}
+	require.NoError(t, w.Close(balances))
+
+	return b.String()
+}
+
+func TestCSV(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "id,account_id,bank,transaction_type,amount,balance,created_at\n"+
+		"3,7,VCB,withdraw,30.00,70.00,2021-02-03 10:00:00 +0000\n"+
+		"4,7,VCB,deposit,20.50,90.50,2021-02-20 10:00:00 +0000\n", render(t, CSV, lines))
+
+	assert.Equal(t, "id,account_id,bank,transaction_type,amount,balance,created_at\n", render(t, CSV, nil))
+}
+
+func TestOFX(t *testing.T) {
+	t.Parallel()
+
+	out := render(t, OFX, lines)
+	require.NoError(t, xml.Unmarshal([]byte(out), new(interface{})), "well formed")
+
+	doc := struct {
+		Statement struct {
+			Currency string `xml:"CURDEF"`
+			Account  struct {
+				BankID string `xml:"BANKID"`
+				AcctID string `xml:"ACCTID"`
+			} `xml:"BANKACCTFROM"`
+			List struct {
+				Start        string `xml:"DTSTART"`
+				End          string `xml:"DTEND"`
+				Transactions []struct {
+					Type   string `xml:"TRNTYPE"`
+					Posted string `xml:"DTPOSTED"`
+					Amount string `xml:"TRNAMT"`
+					FITID  string `xml:"FITID"`
+				} `xml:"STMTTRN"`
+			} `xml:"BANKTRANLIST"`
+			Ledger  string `xml:"LEDGERBAL>BALAMT"`
+			Opening string `xml:"BALLIST>BAL>VALUE"`
+		} `xml:"BANKMSGSRSV1>STMTTRNRS>STMTRS"`
+	}{}
+	require.NoError(t, xml.Unmarshal([]byte(out), &doc))
+
+	s := doc.Statement
+	assert.Equal(t, "VND", s.Currency)
+	assert.Equal(t, "VCB", s.Account.BankID)
+	assert.Equal(t, "7", s.Account.AcctID)
+	assert.Equal(t, "20210201000000.000[+0:UTC]", s.List.Start)
+	assert.Equal(t, "20210301000000.000[+0:UTC]", s.List.End)
+	require.Len(t, s.List.Transactions, 2)
+	assert.Equal(t, "DEBIT", s.List.Transactions[0].Type)
+	assert.Equal(t, "20210203100000.000[+0:UTC]", s.List.Transactions[0].Posted)
+	assert.Equal(t, "-30.00", s.List.Transactions[0].Amount)
+	assert.Equal(t, "3", s.List.Transactions[0].FITID)
+	assert.Equal(t, "CREDIT", s.List.Transactions[1].Type)
+	assert.Equal(t, "20.50", s.List.Transactions[1].Amount)
+	assert.Equal(t, "90.50", s.Ledger)
+	assert.Equal(t, "100.00", s.Opening)
+
+	empty := render(t, OFX, nil)
+	assert.NotContains(t, empty, "<STMTTRN>")
+	assert.Contains(t, empty, "<BALAMT>90.50</BALAMT>")
+}
+
+func TestQIF(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, strings.Join([]string{
+		"!Type:Bank",
+		"D02/01/2021", "T100.00", "POpening Balance", "L[VCB 7]", "^",
+		"D02/03/2021", "T-30.00", "N3", "PWithdraw", "^",
+		"D02/20/2021", "T20.50", "N4", "PDeposit", "^",
+	}, "\n")+"\n", render(t, QIF, lines))
+}
+
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+
+	f, ok := Negotiate("application/json, application/qif;q=0.9, text/csv")
+	assert.True(t, ok)
+	assert.Equal(t, "qif", f.Name)
+
+	f, ok = Negotiate("text/csv; charset=utf-8")
+	assert.True(t, ok)
+	assert.Equal(t, "csv", f.Name)
+
+	_, ok = Negotiate("*/*")
+	assert.False(t, ok)
+
+	_, ok = ByName("xls")
+	assert.False(t, ok)
+}
@@ -0,0 +1,155 @@
+package export
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+// ofxTimeLayout is the OFX datetime format, in UTC
+const ofxTimeLayout = "20060102150405.000[+0:UTC]"
+
+const ofxHeader = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="211" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+  <SIGNONMSGSRSV1>
+    <SONRS>
+      <STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+      <DTSERVER>%s</DTSERVER>
+      <LANGUAGE>ENG</LANGUAGE>
+    </SONRS>
+  </SIGNONMSGSRSV1>
+  <BANKMSGSRSV1>
+    <STMTTRNRS>
+      <TRNUID>0</TRNUID>
+      <STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+      <STMTRS>
+        <CURDEF>%s</CURDEF>
+        <BANKACCTFROM>
+          <BANKID>%s</BANKID>
+          <ACCTID>%s</ACCTID>
+          <ACCTTYPE>CHECKING</ACCTTYPE>
+        </BANKACCTFROM>
+        <BANKTRANLIST>
+          <DTSTART>%s</DTSTART>
+          <DTEND>%s</DTEND>
+`
+
+const ofxTransaction = `          <STMTTRN>
+            <TRNTYPE>%s</TRNTYPE>
+            <DTPOSTED>%s</DTPOSTED>
+            <TRNAMT>%s</TRNAMT>
+            <FITID>%d</FITID>
+            <NAME>%s</NAME>
+          </STMTTRN>
+`
+
+// ofxFooter closes the transaction list, the closing balance is the ledger
+// balance and the opening one is in the balance list
+const ofxFooter = `        </BANKTRANLIST>
+        <LEDGERBAL>
+          <BALAMT>%s</BALAMT>
+          <DTASOF>%s</DTASOF>
+        </LEDGERBAL>
+        <BALLIST>
+          <BAL>
+            <NAME>Opening balance</NAME>
+            <DESC>Balance at the start of the statement</DESC>
+            <BALTYPE>DOLLAR</BALTYPE>
+            <VALUE>%s</VALUE>
+            <DTASOF>%s</DTASOF>
+          </BAL>
+        </BALLIST>
+      </STMTRS>
+    </STMTTRNRS>
+  </BANKMSGSRSV1>
+</OFX>
+`
+
+// ofxWriter writes an OFX 2.1.1 bank statement of Statement.Account
+type ofxWriter struct {
+	w       *bufio.Writer
+	s       Statement
+	started bool
+	start   time.Time
+}
+
+func newOFX(w io.Writer, s Statement) Writer {
+	return &ofxWriter{w: bufio.NewWriter(w), s: s}
+}
+
+func ofxTime(t time.Time) string {
+	return t.UTC().Format(ofxTimeLayout)
+}
+
+func escapeXML(s string) string {
+	b := strings.Builder{}
+	xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
+
+func (o *ofxWriter) begin(first *usecase.StatementLine) error {
+	if o.started {
+		return nil
+	}
+	o.started = true
+	o.start = o.s.start(first)
+
+	_, err := fmt.Fprintf(o.w, ofxHeader,
+		ofxTime(o.s.Now),
+		Currency,
+		escapeXML(o.s.Account.Bank),
+		strconv.Itoa(o.s.Account.ID),
+		ofxTime(o.start),
+		ofxTime(o.s.end()),
+	)
+
+	return err
+}
+
+func (o *ofxWriter) Line(l usecase.StatementLine) error {
+	if err := o.begin(&l); err != nil {
+		return err
+	}
+
+	trnType := "CREDIT"
+	if l.TransactionType == model.TransactionTypeWithdraw {
+		trnType = "DEBIT"
+	}
+
+	_, err := fmt.Fprintf(o.w, ofxTransaction,
+		trnType,
+		ofxTime(l.PostedAt),
+		model.SignedAmount(l.TransactionType, l.Amount).StringFixed(2),
+		l.ID,
+		payee(l),
+	)
+
+	return err
+}
+
+func (o *ofxWriter) Close(balances map[int]usecase.Balances) error {
+	if err := o.begin(nil); err != nil {
+		return err
+	}
+
+	b := balances[o.s.Account.ID]
+	if _, err := fmt.Fprintf(o.w, ofxFooter,
+		b.Closing.StringFixed(2),
+		ofxTime(o.s.end()),
+		b.Opening.StringFixed(2),
+		ofxTime(o.start),
+	); err != nil {
+		return err
+	}
+
+	return o.w.Flush()
+}
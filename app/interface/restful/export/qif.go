@@ -0,0 +1,77 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+// qifDateLayout is the US date format most QIF readers expect
+const qifDateLayout = "01/02/2006"
+
+// qifWriter writes a QIF bank register of Statement.Account. By convention
+// its first entry is the opening balance, QIF has no closing balance: readers
+// add the entries up.
+type qifWriter struct {
+	w       *bufio.Writer
+	s       Statement
+	started bool
+}
+
+func newQIF(w io.Writer, s Statement) Writer {
+	return &qifWriter{w: bufio.NewWriter(w), s: s}
+}
+
+func qifDate(t time.Time) string {
+	return t.UTC().Format(qifDateLayout)
+}
+
+// begin writes the header and the opening balance, known from the first
+// line or else from the balances
+func (q *qifWriter) begin(first *usecase.StatementLine, balances map[int]usecase.Balances) error {
+	if q.started {
+		return nil
+	}
+	q.started = true
+
+	opening := balances[q.s.Account.ID].Opening
+	if first != nil {
+		opening = first.Balance.Sub(model.SignedAmount(first.TransactionType, first.Amount))
+	}
+
+	_, err := fmt.Fprintf(q.w, "!Type:Bank\nD%s\nT%s\nPOpening Balance\nL[%s %d]\n^\n",
+		qifDate(q.s.start(first)),
+		opening.StringFixed(2),
+		q.s.Account.Bank,
+		q.s.Account.ID,
+	)
+
+	return err
+}
+
+func (q *qifWriter) Line(l usecase.StatementLine) error {
+	if err := q.begin(&l, nil); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintf(q.w, "D%s\nT%s\nN%d\nP%s\n^\n",
+		qifDate(l.PostedAt),
+		model.SignedAmount(l.TransactionType, l.Amount).StringFixed(2),
+		l.ID,
+		payee(l),
+	)
+
+	return err
+}
+
+func (q *qifWriter) Close(balances map[int]usecase.Balances) error {
+	if err := q.begin(nil, balances); err != nil {
+		return err
+	}
+
+	return q.w.Flush()
+}
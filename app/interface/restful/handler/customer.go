@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"net/http"
+
+	"go-prj-skeleton/app/interface/restful/middleware"
+	"go-prj-skeleton/app/jsonutil"
+	"go-prj-skeleton/app/usecase"
+)
+
+type saveUser struct {
+	Name *string `json:"name" validate:"required,maxlen=300"`
+}
+
+type createAccount struct {
+	Name   *string `json:"name" validate:"maxlen=300"`
+	Bank   *string `json:"bank" validate:"required"`
+	Number *string `json:"number" validate:"maxlen=19"`
+	IBAN   *string `json:"iban" validate:"maxlen=34"`
+}
+
+type updateAccount struct {
+	Name   *string `json:"name" validate:"required,maxlen=300"`
+	Number *string `json:"number" validate:"maxlen=19"`
+	IBAN   *string `json:"iban" validate:"maxlen=34"`
+}
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type account struct {
+	ID     int    `json:"id"`
+	UserID int    `json:"user_id"`
+	Name   string `json:"name"`
+	Bank   string `json:"bank"`
+	Number string `json:"number,omitempty"`
+	IBAN   string `json:"iban,omitempty"`
+}
+
+type bank struct {
+	Code string `json:"code"`
+	BIC  string `json:"bic"`
+	BIN  string `json:"bin"`
+}
+
+func toUser(u usecase.User) user {
+	return user{
+		ID:   u.ID,
+		Name: u.Name,
+	}
+}
+
+// unmasked tells whether r may see account numbers in full, only the admin
+// is authenticated
+func unmasked(r *http.Request) bool {
+	return middleware.Principal(r.Context()) == middleware.PrincipalAdmin
+}
+
+// maskedNumber masks an account number or IBAN unless r is unmasked
+func maskedNumber(r *http.Request, number string) string {
+	if unmasked(r) {
+		return number
+	}
+
+	return usecase.Account{Number: number}.Masked().Number
+}
+
+// toAccount masks the number and IBAN of acc unless r is unmasked
+func toAccount(r *http.Request, acc usecase.Account) account {
+	if !unmasked(r) {
+		acc = acc.Masked()
+	}
+
+	return account{
+		ID:     acc.ID,
+		UserID: acc.UserID,
+		Name:   acc.Name,
+		Bank:   acc.Bank,
+		Number: acc.Number,
+		IBAN:   acc.IBAN,
+	}
+}
+
+// deref returns the string pointed to by s, or "" for a field left out
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+type customerHandler struct {
+	customerUsecase usecase.CustomerUsecase
+}
+
+func NewCustomerHandler(customerUsecase usecase.CustomerUsecase) *customerHandler {
+	return &customerHandler{
+		customerUsecase,
+	}
+}
+
+func (h customerHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	payl := saveUser{}
+	if err := decodeBody(r, &payl); err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	u, err := h.customerUsecase.CreateUser(r.Context(), *payl.Name)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(jsonutil.Marshal(toUser(*u)))
+}
+
+func (h customerHandler) RenameUser(w http.ResponseWriter, r *http.Request) {
+	userID, err := intParam(r, "user_id")
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	payl := saveUser{}
+	if err := decodeBody(r, &payl); err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	u, err := h.customerUsecase.RenameUser(r.Context(), userID, *payl.Name)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	w.Write(jsonutil.Marshal(toUser(*u)))
+}
+
+func (h customerHandler) CreateAccount(w http.ResponseWriter, r *http.Request) {
+	userID, err := intParam(r, "user_id")
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	payl := createAccount{}
+	if err := decodeBody(r, &payl); err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	acc, err := h.customerUsecase.CreateAccount(r.Context(), userID, usecase.CreateAccount{
+		Name:   deref(payl.Name),
+		Bank:   *payl.Bank,
+		Number: deref(payl.Number),
+		IBAN:   deref(payl.IBAN),
+	})
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(jsonutil.Marshal(toAccount(r, *acc)))
+}
+
+// UpdateAccount replaces the name, number and IBAN of an account, a number
+// or IBAN left out is removed
+func (h customerHandler) UpdateAccount(w http.ResponseWriter, r *http.Request) {
+	userID, err := intParam(r, "user_id")
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	accountID, err := intParam(r, "account_id")
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	payl := updateAccount{}
+	if err := decodeBody(r, &payl); err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	acc, err := h.customerUsecase.UpdateAccount(r.Context(), userID, accountID, usecase.UpdateAccount{
+		Name:   *payl.Name,
+		Number: deref(payl.Number),
+		IBAN:   deref(payl.IBAN),
+	})
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	w.Write(jsonutil.Marshal(toAccount(r, *acc)))
+}
+
+// Banks lists the banks the service accepts accounts of
+func (h customerHandler) Banks(w http.ResponseWriter, r *http.Request) {
+	banks := h.customerUsecase.Banks()
+
+	out := make([]bank, len(banks))
+	for i, b := range banks {
+		out[i] = bank{Code: b.Code, BIC: b.BIC, BIN: b.BIN}
+	}
+
+	w.Write(jsonutil.Marshal(out))
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goji "goji.io/v3"
+	"goji.io/v3/pat"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+	"go-prj-skeleton/app/interface/restful/middleware"
+	"go-prj-skeleton/app/interface/restful/problem"
+	"go-prj-skeleton/app/usecase"
+)
+
+func TestCustomerHandler(t *testing.T) {
+	t.Parallel()
+
+	store := memory.NewStore()
+	store.AddUser(model.User{ID: 1, Name: "Alice"})
+	store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB", Number: "0071000123456"})
+
+	h := NewCustomerHandler(usecase.NewCustomerUsecase(memory.NewUserRepo(store), memory.NewAccountRepo(store)))
+
+	mux := goji.NewMux()
+	mux.Use(middleware.AdminToken("secret"))
+	mux.HandleFunc(pat.Post("/users"), h.CreateUser)
+	mux.HandleFunc(pat.Put("/users/:user_id"), h.RenameUser)
+	mux.HandleFunc(pat.Post("/users/:user_id/accounts"), h.CreateAccount)
+	mux.HandleFunc(pat.Put("/users/:user_id/accounts/:account_id"), h.UpdateAccount)
+	mux.HandleFunc(pat.Get("/banks"), h.Banks)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		r.Header.Set("Authorization", "Bearer secret")
+		mux.ServeHTTP(w, r)
+
+		return w
+	}
+
+	codeOf := func(w *httptest.ResponseRecorder) model.ErrorCode {
+		p := problem.Problem{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+
+		return p.Code
+	}
+
+	t.Run("create and rename a user", func(t *testing.T) {
+		w := do(http.MethodPost, "/users", `{"name": "Bob"}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		u := user{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &u))
+		assert.Equal(t, 2, u.ID)
+		assert.Equal(t, "Bob", u.Name)
+
+		w = do(http.MethodPut, "/users/2", `{"name": "Robert"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.JSONEq(t, `{"id": 2, "name": "Robert"}`, w.Body.String())
+	})
+
+	t.Run("taken name", func(t *testing.T) {
+		w := do(http.MethodPost, "/users", `{"name": "Alice"}`)
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+		assert.Equal(t, model.CodeConflict, codeOf(w))
+	})
+
+	t.Run("rename missing user", func(t *testing.T) {
+		w := do(http.MethodPut, "/users/404", `{"name": "Nobody"}`)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("create and update an account", func(t *testing.T) {
+		w := do(http.MethodPost, "/users/1/accounts", `{"bank": "ACB", "number": "190124"}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		acc := account{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &acc))
+		assert.Equal(t, account{ID: acc.ID, UserID: 1, Name: "Alice", Bank: "ACB", Number: "190124"}, acc)
+
+		w = do(http.MethodPut, "/users/1/accounts/"+strconv.Itoa(acc.ID), `{"name": "Alice Savings"}`)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, model.Account{ID: acc.ID, UserID: 1, Name: "Alice Savings", Bank: "ACB"}, mustAccount(t, store, acc.ID))
+	})
+
+	t.Run("account masked without the admin principal", func(t *testing.T) {
+		unauthenticated := goji.NewMux()
+		unauthenticated.HandleFunc(pat.Put("/users/:user_id/accounts/:account_id"), h.UpdateAccount)
+
+		w := httptest.NewRecorder()
+		unauthenticated.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/users/1/accounts/1", strings.NewReader(`{"name": "Alice", "number": "0071000123456"}`)))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.JSONEq(t, `{"id": 1, "user_id": 1, "name": "Alice", "bank": "VCB", "number": "*********3456"}`, w.Body.String())
+	})
+
+	t.Run("number already used at the bank", func(t *testing.T) {
+		w := do(http.MethodPost, "/users/1/accounts", `{"bank": "VCB", "number": "0071000123456"}`)
+		require.Equal(t, http.StatusConflict, w.Code, w.Body.String())
+	})
+
+	t.Run("unknown bank", func(t *testing.T) {
+		w := do(http.MethodPost, "/users/1/accounts", `{"bank": "XYZ"}`)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Equal(t, model.CodeInvalidBank, codeOf(w))
+	})
+
+	t.Run("account of another user", func(t *testing.T) {
+		w := do(http.MethodPut, "/users/2/accounts/1", `{"name": "Bob"}`)
+		require.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+
+	t.Run("banks", func(t *testing.T) {
+		w := do(http.MethodGet, "/banks", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		banks := []bank{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &banks))
+		require.Len(t, banks, len(model.Banks))
+		assert.Equal(t, bank{Code: "VCB", BIC: "BFTVVNVX", BIN: "970436"}, banks[0])
+	})
+}
+
+func mustAccount(t *testing.T, store *memory.Store, id int) model.Account {
+	acc, err := memory.NewAccountRepo(store).FindByID(id)
+	require.NoError(t, err)
+
+	return acc
+}
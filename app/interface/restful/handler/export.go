@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/export"
+	"go-prj-skeleton/app/usecase"
+)
+
+// dateLayout is the layout of the date query parameters
+const dateLayout = "2006-01-02"
+
+func parseDate(name, s string) (time.Time, error) {
+	t, err := time.Parse(dateLayout, s)
+	if err != nil {
+		return time.Time{}, &model.FieldError{Field: name, Err: fmt.Errorf("%q is not a YYYY-MM-DD date: %w", s, model.ErrInvalid)}
+	}
+
+	return t, nil
+}
+
+// exportFormat returns the format of the format query parameter, else the
+// first supported one of the Accept header, else CSV
+func exportFormat(r *http.Request) (export.Format, error) {
+	if name := r.URL.Query().Get("format"); name != "" {
+		f, ok := export.ByName(name)
+		if !ok {
+			return export.Format{}, &model.FieldError{
+				Field: "format",
+				Err:   fmt.Errorf("%q is not one of %s: %w", name, strings.Join(export.Names(), ", "), model.ErrInvalid),
+			}
+		}
+
+		return f, nil
+	}
+
+	if f, ok := export.Negotiate(r.Header.Get("Accept")); ok {
+		return f, nil
+	}
+
+	return export.Formats[0], nil
+}
+
+// attachmentWriter sets the headers of the export on its first write, so
+// errors before it are answered as problems
+type attachmentWriter struct {
+	http.ResponseWriter
+	format   export.Format
+	filename string
+	wrote    bool
+}
+
+func (a *attachmentWriter) Write(p []byte) (int, error) {
+	if !a.wrote {
+		a.wrote = true
+		a.Header().Set("Content-Type", a.format.ContentType)
+		a.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, a.filename))
+	}
+
+	return a.ResponseWriter.Write(p)
+}
+
+// ExportTransactions streams the transactions of the user, or the statement
+// of one of its accounts, as a CSV, OFX or QIF file
+func (h userHandler) ExportTransactions(w http.ResponseWriter, r *http.Request) {
+	userID, err := intParam(r, "user_id")
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	query := r.URL.Query()
+	filter := usecase.StatementFilter{}
+	filename := fmt.Sprintf("transactions-%d", userID)
+
+	if s := query.Get("account_id"); s != "" {
+		accountID, err := parseInt("account_id", s)
+		if err != nil {
+			Error(w, r, err)
+			return
+		}
+
+		filter.AccountID = &accountID
+		filename = fmt.Sprintf("statement-%d-%d", userID, accountID)
+	}
+
+	if s := query.Get("from"); s != "" {
+		if filter.From, err = parseDate("from", s); err != nil {
+			Error(w, r, err)
+			return
+		}
+	}
+
+	// to is inclusive
+	if s := query.Get("to"); s != "" {
+		to, err := parseDate("to", s)
+		if err != nil {
+			Error(w, r, err)
+			return
+		}
+
+		filter.To = to.AddDate(0, 0, 1)
+	}
+
+	format, err := exportFormat(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	stmt := export.Statement{From: filter.From, To: filter.To, Now: time.Now().UTC()}
+	if format.PerAccount {
+		if filter.AccountID == nil {
+			Error(w, r, &model.FieldError{Field: "account_id", Err: fmt.Errorf("is required by %s: %w", format.Name, model.ErrInvalid)})
+			return
+		}
+
+		accs, err := h.userUsecase.FindAccounts(r.Context(), userID)
+		if err != nil {
+			Error(w, r, err)
+			return
+		}
+
+		for i := range accs {
+			if accs[i].ID == *filter.AccountID {
+				stmt.Account = &accs[i]
+			}
+		}
+
+		if stmt.Account == nil {
+			Error(w, r, fmt.Errorf("account[%v] %w", *filter.AccountID, model.ErrNotFound))
+			return
+		}
+	}
+
+	aw := &attachmentWriter{ResponseWriter: w, format: format, filename: filename + "." + format.Extension}
+	ew := format.New(aw, stmt)
+
+	balances, err := h.userUsecase.EachStatementLine(r.Context(), userID, filter, ew.Line)
+	if err == nil {
+		err = ew.Close(balances)
+	}
+
+	if err != nil {
+		if !aw.wrote {
+			Error(w, r, err)
+			return
+		}
+
+		// the status is sent, abort so the client sees a broken transfer
+		// rather than a truncated file
+		panic(http.ErrAbortHandler)
+	}
+}
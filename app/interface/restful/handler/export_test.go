@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserHandler_ExportTransactions(t *testing.T) {
+	t.Parallel()
+
+	mux := newTestMux()
+	for _, body := range []string{
+		`{"account_id":1,"amount":100,"transaction_type":"deposit"}`,
+		`{"account_id":1,"amount":30.5,"transaction_type":"withdraw"}`,
+	} {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users/1/transactions", strings.NewReader(body)))
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	}
+
+	export := func(query, accept string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/users/1/transactions/export"+query, nil)
+		if accept != "" {
+			r.Header.Set("Accept", accept)
+		}
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		return w
+	}
+
+	t.Run("csv by default", func(t *testing.T) {
+		w := export("", "")
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="transactions-1.csv"`, w.Header().Get("Content-Disposition"))
+
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.Equal(t, "id,account_id,bank,transaction_type,amount,balance,created_at", lines[0])
+		assert.True(t, strings.HasPrefix(lines[1], "1,1,VCB,deposit,100.00,100.00,"), lines[1])
+		assert.True(t, strings.HasPrefix(lines[2], "2,1,VCB,withdraw,30.50,69.50,"), lines[2])
+	})
+
+	t.Run("ofx negotiated", func(t *testing.T) {
+		w := export("?account_id=1", "application/json;q=0.5, application/x-ofx")
+		assert.Equal(t, "application/x-ofx", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="statement-1-1.ofx"`, w.Header().Get("Content-Disposition"))
+		assert.Contains(t, w.Body.String(), "<TRNAMT>-30.50</TRNAMT>")
+		assert.Contains(t, w.Body.String(), "<BALAMT>69.50</BALAMT>")
+	})
+
+	t.Run("qif of an empty period", func(t *testing.T) {
+		w := export("?format=qif&account_id=1&from=2999-01-01&to=2999-01-31", "")
+		assert.Equal(t, "!Type:Bank\nD01/01/2999\nT69.50\nPOpening Balance\nL[VCB 1]\n^\n", w.Body.String())
+	})
+}
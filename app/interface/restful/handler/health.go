@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+
+	"go-prj-skeleton/app/health"
+	"go-prj-skeleton/app/jsonutil"
+	"go-prj-skeleton/app/logger"
+)
+
+// statuses of the service and its dependencies
+const (
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+)
+
+type probe struct {
+	Status string `json:"status"`
+	// Failed names the dependencies the service is not ready without
+	Failed []string `json:"failed,omitempty"`
+}
+
+type dependencyStatus struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type serviceStatus struct {
+	Status        string             `json:"status"`
+	Version       string             `json:"version"`
+	Commit        string             `json:"commit"`
+	UptimeSeconds int64              `json:"uptime_seconds"`
+	Dependencies  []dependencyStatus `json:"dependencies"`
+}
+
+type healthHandler struct {
+	checker *health.Checker
+}
+
+func NewHealthHandler(checker *health.Checker) *healthHandler {
+	return &healthHandler{
+		checker,
+	}
+}
+
+// Healthz tells the process serves requests, whatever the state of its
+// dependencies
+func (h healthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	w.Write(jsonutil.Marshal(probe{Status: statusOK}))
+}
+
+// Readyz checks the dependencies, it is 503 until they all pass
+func (h healthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	results, ok := h.checker.Run(r.Context())
+
+	payl := probe{Status: statusOK}
+	if !ok {
+		payl.Status = statusUnavailable
+		for _, res := range results {
+			if res.Err != nil {
+				logger.FromContext(r.Context()).WithError(res.Err).WithField("dependency", res.Name).Warn("not ready")
+				payl.Failed = append(payl.Failed, res.Name)
+			}
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	w.Write(jsonutil.Marshal(payl))
+}
+
+// Status describes the build and checks the dependencies, it is 503 like
+// Readyz when one of them fails
+func (h healthHandler) Status(w http.ResponseWriter, r *http.Request) {
+	results, ok := h.checker.Run(r.Context())
+
+	payl := serviceStatus{
+		Status:        statusOK,
+		Version:       health.Version,
+		Commit:        health.Commit,
+		UptimeSeconds: int64(health.Uptime().Seconds()),
+		Dependencies:  make([]dependencyStatus, len(results)),
+	}
+
+	for i, res := range results {
+		d := dependencyStatus{
+			Name:      res.Name,
+			Status:    statusOK,
+			LatencyMs: float64(res.Latency.Microseconds()) / 1000,
+		}
+		if res.Err != nil {
+			d.Status = statusUnavailable
+			d.Error = res.Err.Error()
+		}
+
+		payl.Dependencies[i] = d
+	}
+
+	if !ok {
+		payl.Status = statusUnavailable
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	w.Write(jsonutil.Marshal(payl))
+}
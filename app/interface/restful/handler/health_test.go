@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/health"
+)
+
+func TestHealthHandler(t *testing.T) {
+	t.Parallel()
+
+	up := health.Check{Name: "database", Func: func(context.Context) error { return nil }}
+	down := health.Check{Name: "migrations", Func: func(context.Context) error { return errors.New("version 1, expected 2") }}
+
+	ready := NewHealthHandler(health.NewChecker(time.Second, up))
+	notReady := NewHealthHandler(health.NewChecker(time.Second, up, down))
+
+	t.Run("healthz", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		notReady.Healthz(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"status": "ok"}`, w.Body.String())
+	})
+
+	t.Run("readyz", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		ready.Readyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"status": "ok"}`, w.Body.String())
+
+		w = httptest.NewRecorder()
+		notReady.Readyz(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+		assert.JSONEq(t, `{"status": "unavailable", "failed": ["migrations"]}`, w.Body.String())
+	})
+
+	t.Run("status", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		notReady.Status(w, httptest.NewRequest(http.MethodGet, "/status", nil))
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+		status := serviceStatus{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &status))
+		assert.Equal(t, statusUnavailable, status.Status)
+		assert.Equal(t, health.Version, status.Version)
+		require.Len(t, status.Dependencies, 2)
+		assert.Equal(t, "database", status.Dependencies[0].Name)
+		assert.Equal(t, statusOK, status.Dependencies[0].Status)
+		assert.Equal(t, statusUnavailable, status.Dependencies[1].Status)
+		assert.Equal(t, "version 1, expected 2", status.Dependencies[1].Error)
+	})
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/jsonutil"
+	"go-prj-skeleton/app/usecase"
+)
+
+type integrityViolation struct {
+	Rule     model.Rule     `json:"rule"`
+	Severity model.Severity `json:"severity"`
+	Entity   string         `json:"entity"`
+	EntityID int            `json:"entity_id"`
+	Message  string         `json:"message"`
+	Fixable  bool           `json:"fixable"`
+	Repaired bool           `json:"repaired"`
+}
+
+// IntegrityReport is the JSON form of usecase.IntegrityReport, shared by
+// the admin endpoint and the `check` command.
+type IntegrityReport struct {
+	DryRun     bool                   `json:"dry_run"`
+	Total      int                    `json:"total"`
+	Repaired   int                    `json:"repaired"`
+	BySeverity map[model.Severity]int `json:"by_severity"`
+	Violations []integrityViolation   `json:"violations"`
+}
+
+func NewIntegrityReport(r usecase.IntegrityReport) IntegrityReport {
+	out := IntegrityReport{
+		DryRun:     r.DryRun,
+		Total:      len(r.Violations),
+		Repaired:   r.Repaired,
+		BySeverity: map[model.Severity]int{},
+		Violations: make([]integrityViolation, len(r.Violations)),
+	}
+
+	for i, v := range r.Violations {
+		out.BySeverity[v.Severity]++
+		out.Violations[i] = integrityViolation{
+			Rule:     v.Rule,
+			Severity: v.Severity,
+			Entity:   v.Entity,
+			EntityID: v.EntityID,
+			Message:  v.Message,
+			Fixable:  v.Fixable,
+			Repaired: v.Repaired,
+		}
+	}
+
+	return out
+}
+
+type integrityHandler struct {
+	integrityUsecase usecase.IntegrityUsecase
+}
+
+func NewIntegrityHandler(integrityUsecase usecase.IntegrityUsecase) *integrityHandler {
+	return &integrityHandler{
+		integrityUsecase,
+	}
+}
+
+// Check reports the broken invariants without changing any data.
+func (h integrityHandler) Check(w http.ResponseWriter, r *http.Request) {
+	report, err := h.integrityUsecase.Check(true)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	w.Write(jsonutil.Marshal(NewIntegrityReport(*report)))
+}
+
+// Repair fixes the fixable violations. It is a dry run unless the request
+// has dry_run=false.
+func (h integrityHandler) Repair(w http.ResponseWriter, r *http.Request) {
+	dryRun := true
+	if s := r.URL.Query().Get("dry_run"); s != "" {
+		parsed, err := strconv.ParseBool(s)
+		if err != nil {
+			err = &model.FieldError{Field: "dry_run", Err: fmt.Errorf("%q is not a boolean: %w", s, model.ErrInvalid)}
+			Error(w, r, err)
+			return
+		}
+
+		dryRun = parsed
+	}
+
+	report, err := h.integrityUsecase.Check(dryRun)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	w.Write(jsonutil.Marshal(NewIntegrityReport(*report)))
+}
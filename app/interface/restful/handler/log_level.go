@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"go-prj-skeleton/app/jsonutil"
+	"go-prj-skeleton/app/logger"
+)
+
+type logLevel struct {
+	Level *string `json:"level" validate:"required,oneof=trace debug info warn error"`
+}
+
+// LogLevel shows the lowest level the service logs
+func LogLevel(w http.ResponseWriter, r *http.Request) {
+	level := logger.Level()
+	w.Write(jsonutil.Marshal(logLevel{&level}))
+}
+
+// SetLogLevel changes the lowest level the service logs until it restarts,
+// e.g. to debug to log the SQL queries
+func SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	payl := logLevel{}
+	if err := decodeBody(r, &payl); err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	if err := logger.SetLevel(*payl.Level); err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	logger.FromContext(r.Context()).WithField("log_level", *payl.Level).Warn("log level changed")
+	LogLevel(w, r)
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-prj-skeleton/app/logger"
+)
+
+func TestLogLevel(t *testing.T) {
+	t.Cleanup(func() { logger.SetLevel("info") })
+
+	w := httptest.NewRecorder()
+	SetLogLevel(w, httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(`{"level": "debug"}`)))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"level": "debug"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	SetLogLevel(w, httptest.NewRequest(http.MethodPut, "/admin/log-level", strings.NewReader(`{"level": "fatal"}`)))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"/level"`)
+
+	w = httptest.NewRecorder()
+	LogLevel(w, httptest.NewRequest(http.MethodGet, "/admin/log-level", nil))
+	assert.JSONEq(t, `{"level": "debug"}`, w.Body.String())
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/interface/restful/openapi"
+)
+
+func TestDTOs_MatchOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.Parse()
+	require.NoError(t, err)
+
+	for schema, dto := range map[string]interface{}{
+		"Transaction":          transaction{},
+		"CreateTransaction":    createTransaction{},
+		"UpdateTransaction":    UpdateTransaction{},
+		"IntegrityReport":      IntegrityReport{},
+		"LogLevel":             logLevel{},
+		"Probe":                probe{},
+		"DependencyStatus":     dependencyStatus{},
+		"ServiceStatus":        serviceStatus{},
+		"Reconciliation":       reconciliation{},
+		"ReconciliationReport": reconciliationReport{},
+		"CreatePaymentBatch":   createPaymentBatch{},
+		"Payment":              payment{},
+		"PaymentBatch":         paymentBatch{},
+		"QRCode":               qrCode{},
+		"DecodeQR":             decodeQR{},
+		"QRTransaction":        qrTransaction{},
+	} {
+		s, ok := doc.Components.Schemas[schema]
+		require.True(t, ok, "schema %s is missing", schema)
+
+		fields, required := []string{}, []string{}
+		typ := reflect.TypeOf(dto)
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			name := strings.Split(f.Tag.Get("json"), ",")[0]
+			fields = append(fields, name)
+
+			if strings.Contains(f.Tag.Get("validate"), "required") {
+				required = append(required, name)
+			}
+		}
+
+		properties := []string{}
+		for name := range s.Properties {
+			properties = append(properties, name)
+		}
+
+		assert.ElementsMatch(t, fields, properties, "fields of schema %s", schema)
+
+		// required fields of request bodies come from their validate tags
+		if strings.HasPrefix(schema, "Create") || strings.HasPrefix(schema, "Update") || strings.HasPrefix(schema, "Decode") {
+			assert.ElementsMatch(t, required, s.Required, "required fields of schema %s", schema)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+// maxPageLimit caps the limit query parameter
+const maxPageLimit = 1000
+
+// page is the keyset pagination of a listing ordered by id: at most limit
+// items with an id greater than after. A zero limit returns every item.
+type page struct {
+	limit int
+	after int
+}
+
+func parsePage(r *http.Request) (page, error) {
+	p := page{}
+	query := r.URL.Query()
+
+	if s := query.Get("limit"); s != "" {
+		limit, err := parseInt("limit", s)
+		if err != nil {
+			return page{}, err
+		}
+
+		if limit < 1 || limit > maxPageLimit {
+			return page{}, &model.FieldError{Field: "limit", Err: fmt.Errorf("must be between 1 and %v: %w", maxPageLimit, model.ErrInvalid)}
+		}
+
+		p.limit = limit
+	}
+
+	if s := query.Get("after"); s != "" {
+		after, err := parseInt("after", s)
+		if err != nil {
+			return page{}, err
+		}
+
+		p.after = after
+	}
+
+	return p, nil
+}
+
+// next returns the Link header value of the page following lastID
+func (p page) next(r *http.Request, lastID int) string {
+	u := *r.URL
+	query := u.Query()
+	query.Set("after", strconv.Itoa(lastID))
+	query.Set("limit", strconv.Itoa(p.limit))
+	u.RawQuery = query.Encode()
+
+	return fmt.Sprintf(`<%s>; rel="next"`, u.RequestURI())
+}
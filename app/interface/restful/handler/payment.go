@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/pain001"
+	"go-prj-skeleton/app/jsonutil"
+	"go-prj-skeleton/app/usecase"
+)
+
+// createPayment fields are checked by the payment usecase, which reports
+// them by their JSON pointer within the batch
+type createPayment struct {
+	Amount          decimal.Decimal `json:"amount"`
+	CreditorName    string          `json:"creditor_name"`
+	CreditorAccount string          `json:"creditor_account"`
+	CreditorBank    string          `json:"creditor_bank"`
+	Remittance      string          `json:"remittance"`
+}
+
+type createPaymentBatch struct {
+	DebtorAccount *string         `json:"debtor_account" validate:"maxlen=34"`
+	ExecutionDate *string         `json:"execution_date"`
+	Payments      []createPayment `json:"payments" validate:"required"`
+}
+
+type payment struct {
+	TransactionID   int                 `json:"transaction_id"`
+	Amount          decimal.Decimal     `json:"amount"`
+	CreditorName    string              `json:"creditor_name"`
+	CreditorAccount string              `json:"creditor_account"`
+	CreditorBank    string              `json:"creditor_bank"`
+	Remittance      string              `json:"remittance"`
+	EndToEndID      string              `json:"end_to_end_id"`
+	Status          model.PaymentStatus `json:"status"`
+	ConfirmedAt     string              `json:"confirmed_at,omitempty"`
+}
+
+type paymentBatch struct {
+	ID            int             `json:"id"`
+	MessageID     string          `json:"message_id"`
+	AccountID     int             `json:"account_id"`
+	DebtorAccount string          `json:"debtor_account"`
+	ExecutionDate string          `json:"execution_date"`
+	CreatedAt     string          `json:"created_at"`
+	Total         decimal.Decimal `json:"total"`
+	Pending       int             `json:"pending"`
+	Confirmed     int             `json:"confirmed"`
+	Payments      []payment       `json:"payments"`
+}
+
+func toPayment(p usecase.Payment) payment {
+	return payment{
+		TransactionID:   p.TransactionID,
+		Amount:          p.Amount,
+		CreditorName:    p.CreditorName,
+		CreditorAccount: p.CreditorAccount,
+		CreditorBank:    p.CreditorBank,
+		Remittance:      p.Remittance,
+		EndToEndID:      p.EndToEndID,
+		Status:          p.Status,
+		ConfirmedAt:     p.ConfirmedAt,
+	}
+}
+
+// toPaymentBatch masks the debtor account like toAccount
+func toPaymentBatch(r *http.Request, b usecase.PaymentBatch) paymentBatch {
+	out := paymentBatch{
+		ID:            b.ID,
+		MessageID:     b.MessageID,
+		AccountID:     b.AccountID,
+		DebtorAccount: maskedNumber(r, b.DebtorAccount),
+		ExecutionDate: b.ExecutionDate,
+		CreatedAt:     b.CreatedAt,
+		Total:         b.Total,
+		Pending:       b.Count(model.PaymentStatusPending),
+		Confirmed:     b.Count(model.PaymentStatusConfirmed),
+		Payments:      make([]payment, len(b.Payments)),
+	}
+
+	for i, p := range b.Payments {
+		out.Payments[i] = toPayment(p)
+	}
+
+	return out
+}
+
+type paymentHandler struct {
+	paymentUsecase usecase.PaymentUsecase
+}
+
+func NewPaymentHandler(paymentUsecase usecase.PaymentUsecase) *paymentHandler {
+	return &paymentHandler{
+		paymentUsecase,
+	}
+}
+
+// userAccount returns the user_id and account_id path parameters
+func userAccount(r *http.Request) (int, int, error) {
+	userID, err := intParam(r, "user_id")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	accountID, err := intParam(r, "account_id")
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return userID, accountID, nil
+}
+
+// CreateBatch records the payments of the body as pending withdrawals of the
+// account
+func (h paymentHandler) CreateBatch(w http.ResponseWriter, r *http.Request) {
+	userID, accountID, err := userAccount(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	payl := createPaymentBatch{}
+	if err := decodeBody(r, &payl); err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	b := usecase.CreatePaymentBatch{
+		AccountID: accountID,
+		Payments:  make([]usecase.CreatePayment, len(payl.Payments)),
+	}
+	if payl.DebtorAccount != nil {
+		b.DebtorAccount = *payl.DebtorAccount
+	}
+	if payl.ExecutionDate != nil {
+		b.ExecutionDate = *payl.ExecutionDate
+	}
+
+	for i, p := range payl.Payments {
+		b.Payments[i] = usecase.CreatePayment{
+			Amount:          p.Amount,
+			CreditorName:    p.CreditorName,
+			CreditorAccount: p.CreditorAccount,
+			CreditorBank:    p.CreditorBank,
+			Remittance:      p.Remittance,
+		}
+	}
+
+	batch, err := h.paymentUsecase.CreateBatch(userID, b)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	w.Write(jsonutil.Marshal(toPaymentBatch(r, *batch)))
+}
+
+// FindBatches lists the payment batches of the account
+func (h paymentHandler) FindBatches(w http.ResponseWriter, r *http.Request) {
+	userID, accountID, err := userAccount(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	bs, err := h.paymentUsecase.FindBatches(userID, accountID)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	out := make([]paymentBatch, len(bs))
+	for i, b := range bs {
+		out[i] = toPaymentBatch(r, b)
+	}
+
+	w.Write(jsonutil.Marshal(out))
+}
+
+// ExportBatch downloads the pain.001 file of the batch, to upload to the
+// portal of the bank of the account
+func (h paymentHandler) ExportBatch(w http.ResponseWriter, r *http.Request) {
+	userID, accountID, err := userAccount(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	batchID, err := intParam(r, "batch_id")
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	b, err := h.paymentUsecase.FindBatch(userID, accountID, batchID)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	// rendered before the headers are written, a failure is still a problem
+	buf := &bytes.Buffer{}
+	if err := pain001.Write(buf, *b); err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", pain001.ContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.xml"`, b.MessageID))
+	w.Write(buf.Bytes())
+}
+
+// ConfirmPayment marks the payment of the withdrawal paid, for banks whose
+// statements are not imported
+func (h paymentHandler) ConfirmPayment(w http.ResponseWriter, r *http.Request) {
+	userID, accountID, err := userAccount(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	transactionID, err := intParam(r, "transaction_id")
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	p, err := h.paymentUsecase.ConfirmPayment(userID, accountID, transactionID)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	w.Write(jsonutil.Marshal(toPayment(*p)))
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goji "goji.io/v3"
+	"goji.io/v3/pat"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+	"go-prj-skeleton/app/interface/restful/problem"
+	"go-prj-skeleton/app/usecase"
+)
+
+func TestPaymentHandler(t *testing.T) {
+	t.Parallel()
+
+	store := memory.NewStore()
+	store.AddUser(model.User{ID: 1, Name: "Alice"})
+	store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB"})
+
+	userRepo, accountRepo, transRepo := memory.NewUserRepo(store), memory.NewAccountRepo(store), memory.NewTransactionRepo(store)
+	require.NoError(t, transRepo.Create(model.NewTransaction(1, 1, decimal.NewFromInt(1000), model.TransactionTypeDeposit)))
+
+	h := NewPaymentHandler(usecase.NewPaymentUsecase(userRepo, accountRepo, transRepo, memory.NewPaymentRepo(store)))
+
+	mux := goji.NewMux()
+	mux.HandleFunc(pat.Post("/users/:user_id/accounts/:account_id/payment-batches"), h.CreateBatch)
+	mux.HandleFunc(pat.Get("/users/:user_id/accounts/:account_id/payment-batches"), h.FindBatches)
+	mux.HandleFunc(pat.Get("/users/:user_id/accounts/:account_id/payment-batches/:batch_id/pain001"), h.ExportBatch)
+	mux.HandleFunc(pat.Post("/users/:user_id/accounts/:account_id/payments/:transaction_id/confirm"), h.ConfirmPayment)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+
+		return w
+	}
+
+	problemOf := func(w *httptest.ResponseRecorder) problem.Problem {
+		p := problem.Problem{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+
+		return p
+	}
+
+	t.Run("create, export then confirm", func(t *testing.T) {
+		w := do(http.MethodPost, "/users/1/accounts/1/payment-batches", `{
+			"debtor_account": "0071000123456",
+			"payments": [
+				{"amount": 300, "creditor_name": "Bob", "creditor_account": "190123", "creditor_bank": "ACB", "remittance": "Invoice 12"},
+				{"amount": "200.5", "creditor_name": "Carol", "creditor_account": "601704060012345", "creditor_bank": "VIB"}
+			]
+		}`)
+		require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+
+		b := paymentBatch{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &b))
+		assert.Equal(t, "500.5", b.Total.String())
+		assert.Equal(t, 2, b.Pending)
+		// the API does not authenticate the user, the number is masked
+		assert.Equal(t, "*********3456", b.DebtorAccount)
+		require.Len(t, b.Payments, 2)
+		assert.Equal(t, model.PaymentStatusPending, b.Payments[0].Status)
+
+		w = do(http.MethodGet, "/users/1/accounts/1/payment-batches", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		bs := []paymentBatch{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &bs))
+		require.Len(t, bs, 1)
+		assert.Equal(t, b.MessageID, bs[0].MessageID)
+		assert.Equal(t, "*********3456", bs[0].DebtorAccount)
+
+		w = do(http.MethodGet, "/users/1/accounts/1/payment-batches/"+strconv.Itoa(b.ID)+"/pain001", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "application/xml", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="`+b.MessageID+`.xml"`, w.Header().Get("Content-Disposition"))
+		require.NoError(t, xml.Unmarshal(w.Body.Bytes(), new(interface{})), "well formed")
+		assert.Contains(t, w.Body.String(), "<EndToEndId>"+b.Payments[1].EndToEndID+"</EndToEndId>")
+
+		w = do(http.MethodPost, "/users/1/accounts/1/payments/"+strconv.Itoa(b.Payments[0].TransactionID)+"/confirm", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		p := payment{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+		assert.Equal(t, model.PaymentStatusConfirmed, p.Status)
+		assert.NotEmpty(t, p.ConfirmedAt)
+	})
+
+	t.Run("invalid payments", func(t *testing.T) {
+		w := do(http.MethodPost, "/users/1/accounts/1/payment-batches", `{
+			"debtor_account": "0071000123456",
+			"payments": [{"amount": 10, "creditor_name": "Bob", "creditor_account": "19-01", "creditor_bank": "XYZ"}]
+		}`)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		fields := []string{}
+		for _, e := range problemOf(w).Errors {
+			fields = append(fields, e.Field)
+		}
+		assert.Equal(t, []string{"/payments/0/creditor_account", "/payments/0/creditor_bank"}, fields)
+	})
+
+	t.Run("missing payments", func(t *testing.T) {
+		w := do(http.MethodPost, "/users/1/accounts/1/payment-batches", `{"debtor_account": "0071000123456"}`)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Equal(t, "/payments", problemOf(w).Errors[0].Field)
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		w := do(http.MethodPost, "/users/1/accounts/1/payment-batches", `{
+			"debtor_account": "0071000123456",
+			"payments": [{"amount": 5000, "creditor_name": "Bob", "creditor_account": "190123", "creditor_bank": "ACB"}]
+		}`)
+		require.Equal(t, http.StatusUnprocessableEntity, w.Code, w.Body.String())
+		assert.Equal(t, model.CodeInsufficientFunds, problemOf(w).Code)
+	})
+
+	t.Run("batch of another account", func(t *testing.T) {
+		w := do(http.MethodGet, "/users/1/accounts/2/payment-batches/1/pain001", "")
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/shopspring/decimal"
+	qrcode "github.com/skip2/go-qrcode"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/vietqr"
+	"go-prj-skeleton/app/jsonutil"
+	"go-prj-skeleton/app/usecase"
+)
+
+const (
+	defaultQRSize = 256
+	minQRSize     = 128
+	maxQRSize     = 1024
+)
+
+type qrCode struct {
+	Payload       string          `json:"payload"`
+	Bank          string          `json:"bank"`
+	AccountNumber string          `json:"account_number"`
+	Amount        decimal.Decimal `json:"amount"`
+	Memo          string          `json:"memo"`
+}
+
+type decodeQR struct {
+	Payload *string `json:"payload" validate:"required,maxlen=512"`
+}
+
+// qrTransaction is a createTransaction body prefilled from a QR code, with
+// what the code tells about the account it pays
+type qrTransaction struct {
+	AccountID       int                   `json:"account_id"`
+	Amount          *decimal.Decimal      `json:"amount"`
+	TransactionType model.TransactionType `json:"transaction_type"`
+	Bank            string                `json:"bank"`
+	AccountNumber   string                `json:"account_number"`
+	Memo            string                `json:"memo"`
+}
+
+type qrHandler struct {
+	userUsecase usecase.UserUsecase
+}
+
+func NewQRHandler(userUsecase usecase.UserUsecase) *qrHandler {
+	return &qrHandler{
+		userUsecase,
+	}
+}
+
+// findAccount returns the account of the user
+func findAccount(ctx context.Context, u usecase.UserUsecase, userID, accountID int) (usecase.Account, error) {
+	accs, err := u.FindAccounts(ctx, userID)
+	if err != nil {
+		return usecase.Account{}, err
+	}
+
+	for _, acc := range accs {
+		if acc.ID == accountID {
+			return acc, nil
+		}
+	}
+
+	return usecase.Account{}, fmt.Errorf("account[%v] %w", accountID, model.ErrNotFound)
+}
+
+// qrSize parses the size query parameter, the width of the PNG in pixels
+func qrSize(r *http.Request) (int, error) {
+	s := r.URL.Query().Get("size")
+	if s == "" {
+		return defaultQRSize, nil
+	}
+
+	size, err := strconv.Atoi(s)
+	if err != nil || size < minQRSize || size > maxQRSize {
+		return 0, &model.FieldError{Field: "size", Err: fmt.Errorf("%q is not a number of pixels from %v to %v: %w", s, minQRSize, maxQRSize, model.ErrInvalid)}
+	}
+
+	return size, nil
+}
+
+// QR shares the VietQR code of a deposit into the account, as JSON or, with
+// format=png, as an image. The account_number query parameter names the
+// accounts without a number.
+func (h qrHandler) QR(w http.ResponseWriter, r *http.Request) {
+	userID, accountID, err := userAccount(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format != "" && format != "json" && format != "png" {
+		Error(w, r, &model.FieldError{Field: "format", Err: fmt.Errorf("%q is not one of json, png: %w", format, model.ErrInvalid)})
+		return
+	}
+
+	size, err := qrSize(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	acc, err := findAccount(r.Context(), h.userUsecase, userID, accountID)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	number, err := acc.ResolveNumber(query.Get("account_number"))
+	if err != nil {
+		Error(w, r, &model.FieldError{Field: "account_number", Err: err})
+		return
+	}
+
+	p := vietqr.Payload{Bank: acc.Bank, AccountNumber: number, Memo: query.Get("memo")}
+	if amount := query.Get("amount"); amount != "" {
+		if p.Amount, err = decimal.NewFromString(amount); err != nil {
+			Error(w, r, &model.FieldError{Field: "amount", Err: fmt.Errorf("%q is not a number: %w", amount, model.ErrInvalidAmount)})
+			return
+		}
+	}
+
+	payload, err := vietqr.Encode(p)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	if format != "png" {
+		w.Write(jsonutil.Marshal(qrCode{payload, p.Bank, p.AccountNumber, p.Amount, p.Memo}))
+		return
+	}
+
+	png, err := qrcode.Encode(payload, qrcode.Medium, size)
+	if err != nil {
+		Error(w, r, fmt.Errorf("render QR code: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/png")
+	w.Write(png)
+}
+
+// DecodeQR prefills the deposit into the account paid by scanning a VietQR
+// code, which has to pay the account: its bank and, when the account has
+// one, its number
+func (h qrHandler) DecodeQR(w http.ResponseWriter, r *http.Request) {
+	userID, accountID, err := userAccount(r)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	payl := decodeQR{}
+	if err := decodeBody(r, &payl); err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	acc, err := findAccount(r.Context(), h.userUsecase, userID, accountID)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	p, err := vietqr.Decode(*payl.Payload)
+	if err != nil {
+		Error(w, r, &model.FieldError{Field: "/payload", Err: err})
+		return
+	}
+
+	if p.Bank != acc.Bank {
+		Error(w, r, &model.FieldError{Field: "/payload", Err: fmt.Errorf("pays an account at %s, account[%v] is at %s: %w", p.Bank, acc.ID, acc.Bank, model.ErrInvalidBank)})
+		return
+	}
+
+	if _, err := acc.ResolveNumber(p.AccountNumber); err != nil {
+		Error(w, r, &model.FieldError{Field: "/payload", Err: fmt.Errorf("pays account %s, it %w", p.AccountNumber, err)})
+		return
+	}
+
+	t := p.CreateTransaction(acc.ID)
+	out := qrTransaction{
+		AccountID:       t.AccountID,
+		TransactionType: t.TransactionType,
+		Bank:            p.Bank,
+		AccountNumber:   maskedNumber(r, p.AccountNumber),
+		Memo:            p.Memo,
+	}
+	// the payer chooses the amount of a static code
+	if t.Amount.IsPositive() {
+		out.Amount = &t.Amount
+	}
+
+	w.Write(jsonutil.Marshal(out))
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goji "goji.io/v3"
+	"goji.io/v3/pat"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+	"go-prj-skeleton/app/interface/restful/problem"
+	"go-prj-skeleton/app/interface/restful/vietqr"
+	"go-prj-skeleton/app/usecase"
+)
+
+func TestQRHandler(t *testing.T) {
+	t.Parallel()
+
+	store := memory.NewStore()
+	store.AddUser(model.User{ID: 1, Name: "Alice"})
+	store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB", Number: "0071000123456"})
+	store.AddAccount(model.Account{ID: 2, UserID: 1, Name: "Alice", Bank: "ACB"})
+
+	h := NewQRHandler(usecase.NewUserUsecase(memory.NewUserRepo(store), memory.NewAccountRepo(store), memory.NewTransactionRepo(store)))
+
+	mux := goji.NewMux()
+	mux.HandleFunc(pat.Get("/users/:user_id/accounts/:account_id/qr"), h.QR)
+	mux.HandleFunc(pat.Post("/users/:user_id/accounts/:account_id/qr/decode"), h.DecodeQR)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+
+		return w
+	}
+
+	t.Run("share then decode", func(t *testing.T) {
+		w := do(http.MethodGet, "/users/1/accounts/1/qr?amount=50000&memo=Rent", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		code := qrCode{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &code))
+		assert.Contains(t, code.Payload, "0006970436")
+		assert.Equal(t, "50000", code.Amount.String())
+
+		body, err := json.Marshal(decodeQR{&code.Payload})
+		require.NoError(t, err)
+
+		w = do(http.MethodPost, "/users/1/accounts/1/qr/decode", string(body))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		tran := qrTransaction{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tran))
+		assert.Equal(t, 1, tran.AccountID)
+		require.NotNil(t, tran.Amount)
+		assert.Equal(t, "50000", tran.Amount.String())
+		assert.Equal(t, model.TransactionTypeDeposit, tran.TransactionType)
+		// the API does not authenticate the user, the number is masked
+		assert.Equal(t, "*********3456", tran.AccountNumber)
+		assert.Equal(t, "Rent", tran.Memo)
+
+		// a code of a VCB account does not pay into an ACB one
+		w = do(http.MethodPost, "/users/1/accounts/2/qr/decode", string(body))
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		// nor into another VCB account
+		other, err := vietqr.Encode(vietqr.Payload{Bank: "VCB", AccountNumber: "0071000999999"})
+		require.NoError(t, err)
+
+		body, err = json.Marshal(decodeQR{&other})
+		require.NoError(t, err)
+
+		w = do(http.MethodPost, "/users/1/accounts/1/qr/decode", string(body))
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "is not the number of account[1]")
+	})
+
+	t.Run("static code", func(t *testing.T) {
+		w := do(http.MethodGet, "/users/1/accounts/2/qr?account_number=190123", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		code := qrCode{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &code))
+
+		body, err := json.Marshal(decodeQR{&code.Payload})
+		require.NoError(t, err)
+
+		w = do(http.MethodPost, "/users/1/accounts/2/qr/decode", string(body))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), `"amount":null`)
+	})
+
+	t.Run("png", func(t *testing.T) {
+		w := do(http.MethodGet, "/users/1/accounts/1/qr?format=png&size=300", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "image/png", w.Header().Get("Content-Type"))
+
+		img, err := png.Decode(bytes.NewReader(w.Body.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, 300, img.Bounds().Dx())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		w := do(http.MethodGet, "/users/1/accounts/2/qr?account_number=19-01&amount=10.5&size=10", "")
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		p := problem.Problem{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+		assert.Equal(t, "size", p.Errors[0].Field)
+
+		w = do(http.MethodGet, "/users/1/accounts/2/qr?account_number=19-01&amount=10.5", "")
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		p = problem.Problem{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+		require.Len(t, p.Errors, 2)
+		assert.Equal(t, "account_number", p.Errors[0].Field)
+		assert.Equal(t, "amount", p.Errors[1].Field)
+
+		// the number of an account is its own
+		w = do(http.MethodGet, "/users/1/accounts/1/qr?account_number=0071000999999", "")
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = do(http.MethodPost, "/users/1/accounts/1/qr/decode", `{"payload": "000201"}`)
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		w = do(http.MethodGet, "/users/1/accounts/3/qr?account_number=190123", "")
+		assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	})
+}
@@ -0,0 +1,274 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/bankstatement"
+	"go-prj-skeleton/app/jsonutil"
+	"go-prj-skeleton/app/usecase"
+)
+
+// maxStatementBytes limits the size of imported statement files
+const maxStatementBytes = 4 << 20
+
+type reconciledEntry struct {
+	Line          int                 `json:"line"`
+	Date          string              `json:"date"`
+	ValueDate     string              `json:"value_date,omitempty"`
+	Amount        decimal.Decimal     `json:"amount"`
+	Reference     string              `json:"reference"`
+	Description   string              `json:"description"`
+	Status        usecase.EntryStatus `json:"status"`
+	TransactionID int                 `json:"transaction_id,omitempty"`
+}
+
+type unmatchedTransaction struct {
+	transaction
+	Duplicate bool `json:"duplicate"`
+}
+
+type reconciliationReport struct {
+	AccountID int                    `json:"account_id"`
+	From      string                 `json:"from"`
+	To        string                 `json:"to"`
+	Matched   int                    `json:"matched"`
+	Missing   int                    `json:"missing"`
+	Duplicate int                    `json:"duplicate"`
+	Created   int                    `json:"created"`
+	Confirmed []int                  `json:"confirmed"`
+	Entries   []reconciledEntry      `json:"entries"`
+	Unmatched []unmatchedTransaction `json:"unmatched"`
+}
+
+func toReconciliationReport(r usecase.ReconciliationReport) reconciliationReport {
+	out := reconciliationReport{
+		AccountID: r.AccountID,
+		From:      r.From.Format(dateLayout),
+		To:        r.To.Format(dateLayout),
+		Matched:   r.Count(usecase.EntryStatusMatched),
+		Missing:   r.Count(usecase.EntryStatusMissing),
+		Duplicate: r.Count(usecase.EntryStatusDuplicate),
+		Created:   r.Count(usecase.EntryStatusCreated),
+		Confirmed: r.Confirmed,
+		Entries:   make([]reconciledEntry, len(r.Entries)),
+		Unmatched: make([]unmatchedTransaction, len(r.Unmatched)),
+	}
+
+	for i, e := range r.Entries {
+		valueDate := ""
+		if !e.ValueDate.IsZero() {
+			valueDate = e.ValueDate.Format(dateLayout)
+		}
+
+		out.Entries[i] = reconciledEntry{
+			Line:          e.Line,
+			Date:          e.Date.Format(dateLayout),
+			ValueDate:     valueDate,
+			Amount:        e.Amount,
+			Reference:     e.Reference,
+			Description:   e.Description,
+			Status:        e.Status,
+			TransactionID: e.TransactionID,
+		}
+	}
+
+	for i, t := range r.Unmatched {
+		out.Unmatched[i] = unmatchedTransaction{toTransaction(t.Transaction), t.Duplicate}
+	}
+
+	return out
+}
+
+type reconciliation struct {
+	TransactionID int                        `json:"transaction_id"`
+	AccountID     int                        `json:"account_id"`
+	Status        model.ReconciliationStatus `json:"status"`
+	Reference     string                     `json:"reference"`
+	StatementDate string                     `json:"statement_date"`
+	ValueDate     string                     `json:"value_date,omitempty"`
+	ReconciledAt  string                     `json:"reconciled_at"`
+}
+
+func toReconciliations(s []usecase.Reconciliation) []reconciliation {
+	out := make([]reconciliation, len(s))
+
+	for i, r := range s {
+		out[i] = reconciliation{
+			TransactionID: r.TransactionID,
+			AccountID:     r.AccountID,
+			Status:        r.Status,
+			Reference:     r.Reference,
+			StatementDate: r.StatementDate,
+			ValueDate:     r.ValueDate,
+			ReconciledAt:  r.ReconciledAt,
+		}
+	}
+
+	return out
+}
+
+type reconciliationHandler struct {
+	userUsecase           usecase.UserUsecase
+	reconciliationUsecase usecase.ReconciliationUsecase
+}
+
+func NewReconciliationHandler(userUsecase usecase.UserUsecase, reconciliationUsecase usecase.ReconciliationUsecase) *reconciliationHandler {
+	return &reconciliationHandler{
+		userUsecase,
+		reconciliationUsecase,
+	}
+}
+
+// parseLines parses the comma separated line numbers of the create query
+// parameter
+func parseLines(s string) ([]int, error) {
+	lines := []int{}
+	for _, part := range strings.Split(s, ",") {
+		line, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, &model.FieldError{Field: "create", Err: fmt.Errorf("%q is not a line number: %w", part, model.ErrInvalid)}
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// statementBank returns the bank query parameter, else the bank of the
+// account. It is required without an account.
+func (h reconciliationHandler) statementBank(r *http.Request, userID, accountID int) (string, error) {
+	if bank := r.URL.Query().Get("bank"); bank != "" {
+		return bank, nil
+	}
+
+	if accountID == 0 {
+		return "", &model.FieldError{Field: "bank", Err: fmt.Errorf("is required without an account: %w", model.ErrInvalid)}
+	}
+
+	acc, err := findAccount(r.Context(), h.userUsecase, userID, accountID)
+	if err != nil {
+		return "", err
+	}
+
+	return acc.Bank, nil
+}
+
+// ImportStatement reconciles the statement file in the body with the
+// transactions of the account. The missing entries listed in the create
+// query parameter are recorded as transactions.
+func (h reconciliationHandler) ImportStatement(w http.ResponseWriter, r *http.Request) {
+	userID, err := intParam(r, "user_id")
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	accountID, err := intParam(r, "account_id")
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	h.importStatement(w, r, userID, accountID)
+}
+
+// ImportUserStatement reconciles the statement file in the body with the
+// transactions of the account of the user it names by IBAN or number, as
+// camt.053 statements do
+func (h reconciliationHandler) ImportUserStatement(w http.ResponseWriter, r *http.Request) {
+	userID, err := intParam(r, "user_id")
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	h.importStatement(w, r, userID, 0)
+}
+
+// importStatement imports the statement of the account, 0 for the one the
+// statement names
+func (h reconciliationHandler) importStatement(w http.ResponseWriter, r *http.Request, userID, accountID int) {
+	var err error
+	query := r.URL.Query()
+	format, ok := bankstatement.ByName(query.Get("format"))
+	if !ok {
+		Error(w, r, &model.FieldError{
+			Field: "format",
+			Err:   fmt.Errorf("%q is not one of %s: %w", query.Get("format"), strings.Join(bankstatement.Names(), ", "), model.ErrInvalid),
+		})
+		return
+	}
+
+	s := usecase.ReconcileStatement{AccountID: accountID}
+	if create := query.Get("create"); create != "" {
+		if s.Create, err = parseLines(create); err != nil {
+			Error(w, r, err)
+			return
+		}
+	}
+
+	bank := ""
+	if format.ByBank {
+		if bank, err = h.statementBank(r, userID, accountID); err != nil {
+			Error(w, r, err)
+			return
+		}
+	}
+
+	body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxStatementBytes+1))
+	if err != nil {
+		Error(w, r, fmt.Errorf("read body: %w", err))
+		return
+	}
+
+	if len(body) > maxStatementBytes {
+		Error(w, r, fmt.Errorf("statement is larger than %v bytes: %w", maxStatementBytes, model.ErrPayloadTooLarge))
+		return
+	}
+
+	if s.Entries, err = format.Parse(bytes.NewReader(body), bank); err != nil {
+		Error(w, r, fmt.Errorf("%s statement: %w", format.Name, err))
+		return
+	}
+
+	report, err := h.reconciliationUsecase.Reconcile(userID, s)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	w.Write(jsonutil.Marshal(toReconciliationReport(*report)))
+}
+
+// FindReconciliations lists the reconciled transactions of the account
+func (h reconciliationHandler) FindReconciliations(w http.ResponseWriter, r *http.Request) {
+	userID, err := intParam(r, "user_id")
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	accountID, err := intParam(r, "account_id")
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	rs, err := h.reconciliationUsecase.FindReconciliations(userID, accountID)
+	if err != nil {
+		Error(w, r, err)
+		return
+	}
+
+	w.Write(jsonutil.Marshal(toReconciliations(rs)))
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goji "goji.io/v3"
+	"goji.io/v3/pat"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+	"go-prj-skeleton/app/interface/restful/problem"
+	"go-prj-skeleton/app/usecase"
+)
+
+func TestReconciliationHandler(t *testing.T) {
+	t.Parallel()
+
+	store := memory.NewStore()
+	store.AddUser(model.User{ID: 1, Name: "Alice"})
+	store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VIB"})
+	store.AddAccount(model.Account{ID: 2, UserID: 1, Name: "Alice", Bank: "VCB"})
+	store.AddAccount(model.Account{ID: 3, UserID: 1, Name: "Alice", Bank: "VCB", Number: "0071000123456"})
+
+	userRepo, accountRepo, transRepo := memory.NewUserRepo(store), memory.NewAccountRepo(store), memory.NewTransactionRepo(store)
+	h := NewReconciliationHandler(
+		usecase.NewUserUsecase(userRepo, accountRepo, transRepo),
+		usecase.NewReconciliationUsecase(userRepo, accountRepo, transRepo, memory.NewReconciliationRepo(store), memory.NewPaymentRepo(store)),
+	)
+
+	mux := goji.NewMux()
+	mux.HandleFunc(pat.Post("/users/:user_id/accounts/:account_id/statements"), h.ImportStatement)
+	mux.HandleFunc(pat.Post("/users/:user_id/statements"), h.ImportUserStatement)
+	mux.HandleFunc(pat.Get("/users/:user_id/accounts/:account_id/reconciliations"), h.FindReconciliations)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+
+		return w
+	}
+
+	statement := "Transaction Date,Reference No,Amount,Description\n" +
+		"2021-01-08,VIB001,-20.00,Fee\n" +
+		"2021-01-09,VIB002,3000,Refund\n" +
+		"2021-01-09,VIB002,3000,Refund\n"
+
+	t.Run("review then create", func(t *testing.T) {
+		w := do(http.MethodPost, "/users/1/accounts/1/statements?format=csv", statement)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		report := reconciliationReport{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.Equal(t, 2, report.Missing)
+		assert.Equal(t, 1, report.Duplicate)
+		assert.Equal(t, "2021-01-08", report.From)
+		assert.Equal(t, "2021-01-09", report.To)
+		assert.Equal(t, "-20", report.Entries[0].Amount.String())
+
+		w = do(http.MethodPost, "/users/1/accounts/1/statements?format=csv&bank=VIB&create=1,2", statement)
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		report = reconciliationReport{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.Equal(t, 2, report.Created)
+		assert.NotZero(t, report.Entries[1].TransactionID)
+
+		w = do(http.MethodGet, "/users/1/accounts/1/reconciliations", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		rs := []reconciliation{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rs))
+		require.Len(t, rs, 2)
+		assert.Equal(t, model.ReconciliationStatusCreated, rs[0].Status)
+		assert.Equal(t, "VIB001", rs[0].Reference)
+		assert.Equal(t, "2021-01-08", rs[0].StatementDate)
+	})
+
+	t.Run("camt053 imported twice", func(t *testing.T) {
+		camt := `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"><BkToCstmrStmt><Stmt>` +
+			`<Acct><Id><IBAN>VN12VCB0011001234567</IBAN></Id></Acct>` +
+			`<Ntry><NtryRef>1</NtryRef><Amt Ccy="VND">500000</Amt><CdtDbtInd>CRDT</CdtDbtInd><Sts>BOOK</Sts>` +
+			`<BookgDt><Dt>2021-01-05</Dt></BookgDt><ValDt><Dt>2021-01-04</Dt></ValDt><AcctSvcrRef>VCB0105</AcctSvcrRef></Ntry>` +
+			`</Stmt></BkToCstmrStmt></Document>`
+
+		ids := []int{}
+		for i := 0; i < 2; i++ {
+			w := do(http.MethodPost, "/users/1/accounts/2/statements?format=camt053&create=1", camt)
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+			report := reconciliationReport{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+			require.Len(t, report.Entries, 1)
+			assert.Equal(t, "2021-01-04", report.Entries[0].ValueDate)
+			ids = append(ids, report.Entries[0].TransactionID)
+		}
+		assert.NotZero(t, ids[0])
+		assert.Equal(t, ids[0], ids[1])
+
+		w := do(http.MethodPost, "/users/1/accounts/2/statements?format=camt053", strings.Replace(camt, "CRDT", "CR", 1))
+		require.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		p := problem.Problem{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+		require.Len(t, p.Errors, 1)
+		assert.Equal(t, "Stmt[1]/Ntry[1]/CdtDbtInd", p.Errors[0].Field)
+	})
+
+	camtOf := func(account string) string {
+		return `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"><BkToCstmrStmt><Stmt>` +
+			`<Acct><Id><Othr><Id>` + account + `</Id></Othr></Id></Acct>` +
+			`<Ntry><Amt Ccy="VND">20000</Amt><CdtDbtInd>DBIT</CdtDbtInd><Sts>BOOK</Sts>` +
+			`<BookgDt><Dt>2021-01-06</Dt></BookgDt><ValDt><Dt>2021-01-07</Dt></ValDt><AcctSvcrRef>VCB0106</AcctSvcrRef></Ntry>` +
+			`</Stmt></BkToCstmrStmt></Document>`
+	}
+
+	t.Run("camt053 of the account it names", func(t *testing.T) {
+		w := do(http.MethodPost, "/users/1/statements?format=camt053&create=1", camtOf("0071000123456"))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		report := reconciliationReport{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+		assert.Equal(t, 3, report.AccountID)
+		assert.Equal(t, 1, report.Created)
+
+		w = do(http.MethodGet, "/users/1/accounts/3/reconciliations", "")
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+		rs := []reconciliation{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &rs))
+		require.Len(t, rs, 1)
+		assert.Equal(t, "2021-01-06", rs[0].StatementDate)
+		assert.Equal(t, "2021-01-07", rs[0].ValueDate)
+	})
+
+	for _, tc := range []struct {
+		name   string
+		path   string
+		body   string
+		status int
+		field  string
+	}{
+		{"unknown format", "/users/1/accounts/1/statements?format=pdf", statement, http.StatusBadRequest, "format"},
+		{"bad create", "/users/1/accounts/1/statements?format=csv&create=one", statement, http.StatusBadRequest, "create"},
+		{"not a missing entry", "/users/1/accounts/1/statements?format=csv&create=3", statement, http.StatusBadRequest, "create"},
+		{"unknown bank", "/users/1/accounts/1/statements?format=csv&bank=XYZ", statement, http.StatusBadRequest, ""},
+		{"malformed", "/users/1/accounts/1/statements?format=csv", "Transaction Date,Amount\n", http.StatusBadRequest, ""},
+		{"unknown account", "/users/1/accounts/404/statements?format=ofx", "", http.StatusNotFound, ""},
+		{"reconciliations of an unknown account", "/users/1/accounts/404/reconciliations", "", http.StatusNotFound, ""},
+		{"statement of an unknown account", "/users/1/statements?format=camt053", camtOf("0071000999999"), http.StatusNotFound, ""},
+		{"statement naming no account", "/users/1/statements?format=csv&bank=VIB", statement, http.StatusBadRequest, ""},
+		{"csv without an account", "/users/1/statements?format=csv", statement, http.StatusBadRequest, "bank"},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			method := http.MethodPost
+			if strings.HasSuffix(tc.path, "reconciliations") {
+				method = http.MethodGet
+			}
+
+			w := do(method, tc.path, tc.body)
+			assert.Equal(t, tc.status, w.Code, w.Body.String())
+			assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+			if tc.field != "" {
+				p := problem.Problem{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+				require.Len(t, p.Errors, 1)
+				assert.Equal(t, tc.field, p.Errors[0].Field)
+			}
+		})
+	}
+}
@@ -1,28 +1,32 @@
 package handler
 
 import (
-	"encoding/json"
-	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/shopspring/decimal"
 	"goji.io/v3/pat"
 
 	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/problem"
+	"go-prj-skeleton/app/interface/restful/validate"
 	"go-prj-skeleton/app/jsonutil"
 	"go-prj-skeleton/app/usecase"
 )
 
+// maxBodyBytes limits the size of request bodies
+const maxBodyBytes = 64 << 10
+
 type createTransaction struct {
-	AccountID       int                   `json:"account_id"`
-	Amount          decimal.Decimal       `json:"amount"`
-	TransactionType model.TransactionType `json:"transaction_type"`
+	AccountID       *int                   `json:"account_id" validate:"required,min=1"`
+	Amount          *decimal.Decimal       `json:"amount" validate:"required,gt=0,max=99999999999999999.99,scale=2"`
+	TransactionType *model.TransactionType `json:"transaction_type" validate:"required,oneof=withdraw deposit"`
 }
 
 type UpdateTransaction struct {
-	Amount decimal.Decimal `json:"amount"`
+	Amount *decimal.Decimal `json:"amount" validate:"required,gt=0,max=99999999999999999.99,scale=2"`
 }
 
 type transaction struct {
@@ -65,165 +69,154 @@ func NewUserHandler(userUsecase usecase.UserUsecase) *userHandler {
 	}
 }
 
-type erroMessage struct {
-	Error string `json:"error"`
+// Error renders err as an application/problem+json response, see package
+// problem for the mapping of errors to statuses.
+func Error(w http.ResponseWriter, r *http.Request, err error) {
+	problem.Write(w, r, err)
 }
 
-func Error(w http.ResponseWriter, err error) {
-	msg := erroMessage{
-		err.Error(),
-	}
+// NotFound answers requests that match no route
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	Error(w, r, fmt.Errorf("route %s %s: %w", r.Method, r.URL.Path, model.ErrNotFound))
+}
 
-	code := http.StatusInternalServerError
-	switch true {
-	case errors.Is(err, model.ErrInvalid):
-		code = http.StatusBadRequest
-	case errors.Is(err, model.ErrNotFound):
-		code = http.StatusNotFound
-	case errors.Is(err, model.ErrTransactionTypeInvalid):
-		code = http.StatusBadRequest
+// MethodNotAllowed answers requests to a route with a method it doesn't
+// serve, allowed are the methods it does
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request, allowed []string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	Error(w, r, fmt.Errorf("route %s %s: %w", r.Method, r.URL.Path, model.ErrMethodNotAllowed))
+}
+
+// intParam parses the path parameter name as an int
+func intParam(r *http.Request, name string) (int, error) {
+	return parseInt(name, pat.Param(r, name))
+}
+
+func parseInt(name, s string) (int, error) {
+	i, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return 0, &model.FieldError{Field: name, Err: fmt.Errorf("%q is not an integer: %w", s, model.ErrInvalid)}
 	}
 
-	w.Header().Set("X-Content-Type-Options", "nosniff")
-	w.WriteHeader(code)
-	fmt.Fprintln(w, string(jsonutil.Marshal(msg)))
+	return int(i), nil
+}
+
+// decodeBody decodes the JSON body of r into the DTO pointed to by v and
+// validates it, see package validate
+func decodeBody(r *http.Request, v interface{}) error {
+	return validate.DecodeJSON(r.Body, maxBodyBytes, v)
 }
 
 func (h userHandler) FindTransactions(w http.ResponseWriter, r *http.Request) {
-	strUserID := pat.Param(r, "user_id")
-	userID, err := strconv.ParseInt(strUserID, 10, 32)
+	userID, err := intParam(r, "user_id")
 	if err != nil {
-		Error(w, err)
+		Error(w, r, err)
 		return
 	}
 
 	var accountID *int
 	strAccountID := r.URL.Query().Get("account_id")
 	if strAccountID != "" {
-		accID, err := strconv.ParseInt(strAccountID, 10, 32)
+		parsedID, err := parseInt("account_id", strAccountID)
 		if err != nil {
-			Error(w, err)
+			Error(w, r, err)
 			return
 		}
 
-		parsedID := int(accID)
 		accountID = &parsedID
 	}
 
-	trans, err := h.userUsecase.FindTransactions(int(userID), accountID)
+	page, err := parsePage(r)
 	if err != nil {
-		Error(w, err)
+		Error(w, r, err)
 		return
 	}
 
-	bytes, err := json.Marshal(toTransactions(trans))
+	trans, more, err := h.userUsecase.FindTransactionPage(r.Context(), userID, accountID, usecase.Page{After: page.after, Limit: page.limit})
 	if err != nil {
-		Error(w, err)
+		Error(w, r, err)
 		return
 	}
 
-	w.Write(bytes)
+	if more {
+		w.Header().Set("Link", page.next(r, trans[len(trans)-1].ID))
+	}
+
+	w.Write(jsonutil.Marshal(toTransactions(trans)))
 }
 
 func (h userHandler) CreateTransaction(w http.ResponseWriter, r *http.Request) {
-	strUserID := pat.Param(r, "user_id")
-	pUserID, err := strconv.ParseInt(strUserID, 10, 32)
-	userID := int(pUserID)
+	userID, err := intParam(r, "user_id")
 	if err != nil {
-		Error(w, err)
+		Error(w, r, err)
 		return
 	}
 
 	payl := createTransaction{}
-	if err := json.NewDecoder(r.Body).Decode(&payl); err != nil {
-		Error(w, err)
+	if err := decodeBody(r, &payl); err != nil {
+		Error(w, r, err)
 		return
 	}
 
-	createdTran, err := h.userUsecase.CreateTransaction(userID, usecase.CreateTransaction{
-		AccountID:       payl.AccountID,
-		Amount:          payl.Amount,
-		TransactionType: payl.TransactionType,
+	createdTran, err := h.userUsecase.CreateTransaction(r.Context(), userID, usecase.CreateTransaction{
+		AccountID:       *payl.AccountID,
+		Amount:          *payl.Amount,
+		TransactionType: *payl.TransactionType,
 	})
 	if err != nil {
-		Error(w, err)
-		return
-	}
-
-	bytes, err := json.Marshal(toTransaction(*createdTran))
-	if err != nil {
-		Error(w, err)
+		Error(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusCreated)
-	w.Write(bytes)
+	w.Write(jsonutil.Marshal(toTransaction(*createdTran)))
 }
 
 func (h userHandler) UpdateTransaction(w http.ResponseWriter, r *http.Request) {
-	strUserID := pat.Param(r, "user_id")
-	userID, err := strconv.ParseInt(strUserID, 10, 32)
+	userID, err := intParam(r, "user_id")
 	if err != nil {
-		w.Write(jsonutil.Marshal(erroMessage{err.Error()}))
-		w.WriteHeader(http.StatusBadRequest)
+		Error(w, r, err)
 		return
 	}
 
-	strTranID := pat.Param(r, "transaction_id")
-	tranID, err := strconv.ParseInt(strTranID, 10, 32)
+	tranID, err := intParam(r, "transaction_id")
 	if err != nil {
-		w.Write(jsonutil.Marshal(erroMessage{err.Error()}))
-		w.WriteHeader(http.StatusBadRequest)
+		Error(w, r, err)
 		return
 	}
 
 	payl := UpdateTransaction{}
-	if err := json.NewDecoder(r.Body).Decode(&payl); err != nil {
-		w.Write(jsonutil.Marshal(erroMessage{err.Error()}))
-		w.WriteHeader(http.StatusBadRequest)
+	if err := decodeBody(r, &payl); err != nil {
+		Error(w, r, err)
 		return
 	}
 
-	updatedTran, err := h.userUsecase.UpdateTransaction(int(userID), int(tranID), usecase.UpdateTransaction{
-		Amount: payl.Amount,
+	updatedTran, err := h.userUsecase.UpdateTransaction(r.Context(), userID, tranID, usecase.UpdateTransaction{
+		Amount: *payl.Amount,
 	})
 	if err != nil {
-		w.Write(jsonutil.Marshal(erroMessage{err.Error()}))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	bytes, err := json.Marshal(toTransaction(*updatedTran))
-	if err != nil {
-		w.Write(jsonutil.Marshal(erroMessage{err.Error()}))
-		w.WriteHeader(http.StatusInternalServerError)
+		Error(w, r, err)
 		return
 	}
 
-	w.Write(bytes)
+	w.Write(jsonutil.Marshal(toTransaction(*updatedTran)))
 }
 
 func (h userHandler) DeleteTransaction(w http.ResponseWriter, r *http.Request) {
-	strUserID := pat.Param(r, "user_id")
-	userID, err := strconv.ParseInt(strUserID, 10, 32)
+	userID, err := intParam(r, "user_id")
 	if err != nil {
-		w.Write(jsonutil.Marshal(erroMessage{err.Error()}))
-		w.WriteHeader(http.StatusBadRequest)
+		Error(w, r, err)
 		return
 	}
 
-	strTranID := pat.Param(r, "transaction_id")
-	tranID, err := strconv.ParseInt(strTranID, 10, 32)
+	tranID, err := intParam(r, "transaction_id")
 	if err != nil {
-		w.Write(jsonutil.Marshal(erroMessage{err.Error()}))
-		w.WriteHeader(http.StatusBadRequest)
+		Error(w, r, err)
 		return
 	}
 
-	err = h.userUsecase.DeleteTransaction(int(userID), int(tranID))
-	if err != nil {
-		w.Write(jsonutil.Marshal(erroMessage{err.Error()}))
-		w.WriteHeader(http.StatusInternalServerError)
+	if err := h.userUsecase.DeleteTransaction(r.Context(), userID, tranID); err != nil {
+		Error(w, r, err)
 		return
 	}
 }
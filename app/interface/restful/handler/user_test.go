@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goji "goji.io/v3"
+	"goji.io/v3/pat"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+	"go-prj-skeleton/app/interface/restful/problem"
+	"go-prj-skeleton/app/usecase"
+)
+
+func newTestMux() *goji.Mux {
+	store := memory.NewStore()
+	store.AddUser(model.User{ID: 1, Name: "Alice"})
+	store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB"})
+
+	h := NewUserHandler(usecase.NewUserUsecase(
+		memory.NewUserRepo(store),
+		memory.NewAccountRepo(store),
+		memory.NewTransactionRepo(store),
+	))
+
+	mux := goji.NewMux()
+	mux.HandleFunc(pat.Get("/users/:user_id/transactions"), h.FindTransactions)
+	mux.HandleFunc(pat.Get("/users/:user_id/transactions/export"), h.ExportTransactions)
+	mux.HandleFunc(pat.Post("/users/:user_id/transactions"), h.CreateTransaction)
+	mux.HandleFunc(pat.Put("/users/:user_id/transactions/:transaction_id"), h.UpdateTransaction)
+	mux.HandleFunc(pat.Delete("/users/:user_id/transactions/:transaction_id"), h.DeleteTransaction)
+
+	return mux
+}
+
+func TestUserHandler_Problems(t *testing.T) {
+	t.Parallel()
+
+	mux := newTestMux()
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		code   model.ErrorCode
+		fields []string
+	}{
+		{"find with invalid user id", http.MethodGet, "/users/abc/transactions", "", http.StatusBadRequest, model.CodeInvalid, []string{"user_id"}},
+		{"find with invalid account id", http.MethodGet, "/users/1/transactions?account_id=x", "", http.StatusBadRequest, model.CodeInvalid, []string{"account_id"}},
+		{"find with invalid limit", http.MethodGet, "/users/1/transactions?limit=0", "", http.StatusBadRequest, model.CodeInvalid, []string{"limit"}},
+		{"find with invalid after", http.MethodGet, "/users/1/transactions?after=x", "", http.StatusBadRequest, model.CodeInvalid, []string{"after"}},
+		{"find for unknown user", http.MethodGet, "/users/2/transactions", "", http.StatusNotFound, model.CodeNotFound, nil},
+		{"export with unknown format", http.MethodGet, "/users/1/transactions/export?format=xls", "", http.StatusBadRequest, model.CodeInvalid, []string{"format"}},
+		{"export with invalid date", http.MethodGet, "/users/1/transactions/export?from=2021-13-01", "", http.StatusBadRequest, model.CodeInvalid, []string{"from"}},
+		{"export ofx without account", http.MethodGet, "/users/1/transactions/export?format=ofx", "", http.StatusBadRequest, model.CodeInvalid, []string{"account_id"}},
+		{"export account of another user", http.MethodGet, "/users/1/transactions/export?format=qif&account_id=2", "", http.StatusNotFound, model.CodeNotFound, nil},
+		{"export for unknown user", http.MethodGet, "/users/2/transactions/export", "", http.StatusNotFound, model.CodeNotFound, nil},
+		{"create with malformed body", http.MethodPost, "/users/1/transactions", "{", http.StatusBadRequest, model.CodeInvalid, []string{""}},
+		{"create with invalid type", http.MethodPost, "/users/1/transactions", `{"account_id":1,"amount":1,"transaction_type":"refund"}`, http.StatusBadRequest, model.CodeInvalid, []string{"/transaction_type"}},
+		{"create with unknown field", http.MethodPost, "/users/1/transactions", `{"account_id":1,"amount":1,"transaction_type":"deposit","bank":"VCB"}`, http.StatusBadRequest, model.CodeInvalid, []string{"/bank"}},
+		{"create for account of another user", http.MethodPost, "/users/1/transactions", `{"account_id":2,"amount":1,"transaction_type":"deposit"}`, http.StatusBadRequest, model.CodeInvalid, nil},
+		{"update without amount", http.MethodPut, "/users/1/transactions/1", `{}`, http.StatusBadRequest, model.CodeInvalid, []string{"/amount"}},
+		{"update with invalid transaction id", http.MethodPut, "/users/1/transactions/x", `{"amount":1}`, http.StatusBadRequest, model.CodeInvalid, []string{"transaction_id"}},
+		{"update unknown transaction", http.MethodPut, "/users/1/transactions/404", `{"amount":1}`, http.StatusNotFound, model.CodeNotFound, nil},
+		{"delete with invalid user id", http.MethodDelete, "/users/x/transactions/1", "", http.StatusBadRequest, model.CodeInvalid, []string{"user_id"}},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := httptest.NewRecorder()
+			mux.ServeHTTP(w, httptest.NewRequest(c.method, c.path, strings.NewReader(c.body)))
+
+			assert.Equal(t, c.status, w.Code)
+			assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+			p := problem.Problem{}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+			assert.Equal(t, c.status, p.Status)
+			assert.Equal(t, c.code, p.Code)
+			assert.Equal(t, problem.TypePrefix+string(c.code), p.Type)
+
+			fields := []string{}
+			for _, fe := range p.Errors {
+				fields = append(fields, fe.Field)
+			}
+			assert.ElementsMatch(t, c.fields, fields)
+		})
+	}
+}
+
+func TestUserHandler_CreateTransaction(t *testing.T) {
+	t.Parallel()
+
+	mux := newTestMux()
+
+	t.Run("created", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users/1/transactions",
+			strings.NewReader(`{"account_id":1,"amount":"100.50","transaction_type":"deposit"}`)))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+
+		tran := transaction{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tran))
+		assert.Equal(t, 1, tran.AccountID)
+		assert.Equal(t, "100.5", tran.Amount.String())
+		assert.Equal(t, "VCB", tran.Bank)
+	})
+
+	t.Run("every missing field is reported", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users/1/transactions", strings.NewReader(`{}`)))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		p := problem.Problem{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+		assert.Equal(t, []problem.FieldProblem{
+			{Field: "/account_id", Code: model.CodeInvalid, Detail: "is required: invalid"},
+			{Field: "/amount", Code: model.CodeInvalid, Detail: "is required: invalid"},
+			{Field: "/transaction_type", Code: model.CodeInvalid, Detail: "is required: invalid"},
+		}, p.Errors)
+	})
+}
+
+func TestUserHandler_FindTransactions_Pages(t *testing.T) {
+	t.Parallel()
+
+	mux := newTestMux()
+	for i := 0; i < 5; i++ {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/users/1/transactions",
+			strings.NewReader(`{"account_id":1,"amount":"1","transaction_type":"deposit"}`)))
+		require.Equal(t, http.StatusCreated, w.Code)
+	}
+
+	ids := []int{}
+	path := "/users/1/transactions?account_id=1&limit=2"
+	for path != "" {
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		trans := []transaction{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &trans))
+		assert.LessOrEqual(t, len(trans), 2)
+		for _, tran := range trans {
+			ids = append(ids, tran.ID)
+		}
+
+		path = ""
+		if link := w.Header().Get("Link"); link != "" {
+			path = strings.TrimSuffix(strings.TrimPrefix(link, "<"), `>; rel="next"`)
+			assert.Contains(t, path, "account_id=1")
+		}
+	}
+
+	assert.Len(t, ids, 5)
+	for i := 1; i < len(ids); i++ {
+		assert.Greater(t, ids[i], ids[i-1])
+	}
+}
@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	gojimw "goji.io/v3/middleware"
+	"goji.io/v3/pat"
+
+	"go-prj-skeleton/app/logger"
+)
+
+type accessKey struct{}
+
+// access is what the muxes tell the access log about the request they route
+type access struct {
+	route  string
+	userID string
+}
+
+// AccessLog logs every request once it is served, as one line with its
+// method, route pattern, status, latency and user. A request whose handler
+// panicked, e.g. aborted with http.ErrAbortHandler, is logged as aborted as
+// the panic goes on. It goes right after RequestID, Route fills the route in.
+func AccessLog(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		a := &access{}
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		served := false
+
+		defer func() {
+			l := logger.FromContext(r.Context()).WithFields(map[string]interface{}{
+				"method":     r.Method,
+				"route":      a.route,
+				"path":       r.URL.Path,
+				"status":     rec.status,
+				"bytes":      rec.bytes,
+				"latency_ms": float64(time.Since(start).Microseconds()) / 1000,
+			})
+			if a.userID != "" {
+				l = l.WithField("user_id", a.userID)
+			}
+
+			if !served {
+				l.WithField("aborted", true).Error("request")
+				return
+			}
+
+			if rec.status >= http.StatusInternalServerError {
+				l.Error("request")
+				return
+			}
+
+			l.Info("request")
+		}()
+
+		h.ServeHTTP(rec, r.WithContext(context.WithValue(r.Context(), accessKey{}, a)))
+		served = true
+	})
+}
+
+// Route records the pattern the mux matched, appended to the one of the
+// parent mux, and the user_id parameter of the route. The logger of the
+// request logs the user.
+func Route(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a, ok := r.Context().Value(accessKey{}).(*access)
+		p, matched := gojimw.Pattern(r.Context()).(*pat.Pattern)
+		if !ok || !matched {
+			h.ServeHTTP(w, r)
+			return
+		}
+
+		a.route = strings.TrimSuffix(a.route, "/*") + p.String()
+		if strings.Contains(p.String(), ":user_id") {
+			a.userID = pat.Param(r, "user_id")
+			l := logger.FromContext(r.Context()).WithField("user_id", a.userID)
+			r = r.WithContext(logger.NewContext(r.Context(), l))
+		}
+
+		h.ServeHTTP(w, r)
+	})
+}
+
+// routeOf returns the pattern Route recorded for r, "unmatched" if none
+func routeOf(r *http.Request) string {
+	if a, ok := r.Context().Value(accessKey{}).(*access); ok && a.route != "" {
+		return a.route
+	}
+
+	return "unmatched"
+}
+
+// statusRecorder keeps the status and size of the response
+type statusRecorder struct {
+	http.ResponseWriter
+
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
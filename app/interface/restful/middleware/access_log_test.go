@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goji "goji.io/v3"
+	"goji.io/v3/pat"
+
+	"go-prj-skeleton/app/logger"
+	"go-prj-skeleton/app/requestid"
+)
+
+func TestAccessLog(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger.SetOutput(out)
+	t.Cleanup(func() { logger.SetOutput(os.Stdout) })
+
+	mux := goji.NewMux()
+	mux.Use(RequestID)
+	mux.Use(AccessLog)
+	mux.Use(Route)
+
+	api := goji.SubMux()
+	api.Use(Route)
+	api.HandleFunc(pat.Get("/users/:user_id/transactions"), func(w http.ResponseWriter, r *http.Request) {
+		logger.FromContext(r.Context()).Info("handled")
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte("tea"))
+	})
+	mux.Handle(pat.New("/api/*"), api)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/users/7/transactions?account_id=2", nil)
+	r.Header.Set(requestid.Header, "abc")
+	mux.ServeHTTP(httptest.NewRecorder(), r)
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	handled := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(lines[0], &handled))
+	assert.Equal(t, "7", handled["user_id"])
+	assert.Equal(t, "abc", handled["request_id"])
+
+	access := map[string]interface{}{}
+	require.NoError(t, json.Unmarshal(lines[1], &access))
+	assert.Equal(t, "request", access["msg"])
+	assert.Equal(t, "GET", access["method"])
+	assert.Equal(t, "/api/users/:user_id/transactions", access["route"])
+	assert.Equal(t, "/api/users/7/transactions", access["path"])
+	assert.Equal(t, float64(http.StatusTeapot), access["status"])
+	assert.Equal(t, float64(3), access["bytes"])
+	assert.Equal(t, "7", access["user_id"])
+	assert.Equal(t, "abc", access["request_id"])
+	assert.Contains(t, access, "latency_ms")
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/problem"
+)
+
+type principalKey struct{}
+
+// PrincipalAdmin is the principal of the requests carrying the admin token
+const PrincipalAdmin = "admin"
+
+// Principal returns the principal an authentication middleware
+// authenticated the request of ctx as, empty if none
+func Principal(ctx context.Context) string {
+	p, _ := ctx.Value(principalKey{}).(string)
+	return p
+}
+
+// AdminToken only lets through requests carrying "Authorization: Bearer <token>",
+// as PrincipalAdmin
+func AdminToken(token string) func(http.Handler) http.Handler {
+	expected := []byte("Bearer " + token)
+
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got := []byte(r.Header.Get("Authorization"))
+			if subtle.ConstantTimeCompare(got, expected) != 1 {
+				problem.Write(w, r, fmt.Errorf("admin token: %w", model.ErrUnauthorized))
+				return
+			}
+
+			h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, PrincipalAdmin)))
+		})
+	}
+}
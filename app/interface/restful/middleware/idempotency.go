@@ -0,0 +1,277 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/problem"
+	"go-prj-skeleton/app/ratelimit"
+	"go-prj-skeleton/app/requestid"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client chosen key of a request, retries
+	// of the request send the same key
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set on responses replayed from the store
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+
+	// maxFingerprintBytes is how much of the body is read to tell requests
+	// reusing a key apart, the limit of the JSON bodies of the handlers. The
+	// fingerprint of a larger body, a statement, covers its length and its
+	// first maxFingerprintBytes.
+	maxFingerprintBytes = 64 << 10
+
+	// maxIdempotentEntries bounds the responses a store keeps
+	maxIdempotentEntries = 10000
+
+	// maxIdempotentBodyBytes bounds the response body kept for a replay, a
+	// retry of a request with a larger response is a conflict
+	maxIdempotentBodyBytes = 64 << 10
+)
+
+// IdempotencyStore keeps the responses of requests carrying an
+// Idempotency-Key for ttl, at most maxIdempotentEntries of them: once full,
+// the response expiring first makes room. It lives in memory, so a retry is
+// only recognized by the replica which served the first attempt.
+type IdempotencyStore struct {
+	ttl time.Duration
+
+	mu        sync.Mutex
+	entries   map[string]*idempotentEntry
+	lastSweep time.Time
+}
+
+type idempotentEntry struct {
+	fingerprint string
+	done        chan struct{}
+	expires     time.Time
+
+	status int
+	header http.Header
+	body   []byte
+	// truncated is a response whose body was too large to keep
+	truncated bool
+}
+
+func NewIdempotencyStore(ttl time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{
+		ttl:     ttl,
+		entries: map[string]*idempotentEntry{},
+	}
+}
+
+// claim returns the entry of key, created is true when the caller must
+// serve the request and complete the entry. It is ErrRateLimited when the
+// store is full of requests still being served.
+func (s *IdempotencyStore) claim(key, fingerprint string) (entry *idempotentEntry, created bool, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) > time.Minute {
+		for k, e := range s.entries {
+			if !e.expires.IsZero() && now.After(e.expires) {
+				delete(s.entries, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	if e, ok := s.entries[key]; ok {
+		return e, false, nil
+	}
+
+	if len(s.entries) >= maxIdempotentEntries && !s.evict() {
+		return nil, false, fmt.Errorf("%v requests with an %s are being served: %w", len(s.entries), IdempotencyKeyHeader, model.ErrRateLimited)
+	}
+
+	e := &idempotentEntry{
+		fingerprint: fingerprint,
+		done:        make(chan struct{}),
+	}
+	s.entries[key] = e
+
+	return e, true, nil
+}
+
+// evict forgets the completed response expiring first, it is false when
+// every entry is still being served. s.mu is held.
+func (s *IdempotencyStore) evict() bool {
+	oldest := ""
+	for k, e := range s.entries {
+		if e.expires.IsZero() {
+			continue
+		}
+
+		if oldest == "" || e.expires.Before(s.entries[oldest].expires) {
+			oldest = k
+		}
+	}
+
+	if oldest == "" {
+		return false
+	}
+
+	delete(s.entries, oldest)
+	return true
+}
+
+// complete stores the response of entry, server errors are forgotten so a
+// retry runs the request again
+func (s *IdempotencyStore) complete(key string, e *idempotentEntry, rec *recorder) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rec.status >= http.StatusInternalServerError {
+		delete(s.entries, key)
+	} else {
+		e.status = rec.status
+		e.header = rec.Header().Clone()
+		e.body = rec.body.Bytes()
+		e.truncated = rec.truncated
+		e.expires = time.Now().Add(s.ttl)
+	}
+
+	close(e.done)
+}
+
+// Idempotency replays the stored response of a non safe request whose
+// Idempotency-Key its client already sent, instead of running it again.
+// Keys are scoped by the client RateLimit identified: its API key,
+// principal or IP. Requests without the header are served as usual.
+func Idempotency(store *IdempotencyStore) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			if len(key) > maxIdempotencyKeyLength {
+				problem.Write(w, r, &model.FieldError{
+					Field: IdempotencyKeyHeader,
+					Err:   fmt.Errorf("must have at most %v characters: %w", maxIdempotencyKeyLength, model.ErrInvalid),
+				})
+				return
+			}
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxFingerprintBytes))
+			if err != nil {
+				problem.Write(w, r, fmt.Errorf("read body: %w", err))
+				return
+			}
+			r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+
+			sum := sha256.Sum256(body)
+			fingerprint := fmt.Sprintf("%s %s %d %s", r.Method, r.URL.RequestURI(), r.ContentLength, hex.EncodeToString(sum[:]))
+
+			key = idempotencyClient(r) + " " + key
+			entry, created, err := store.claim(key, fingerprint)
+			if err != nil {
+				problem.Write(w, r, err)
+				return
+			}
+
+			if !created {
+				if entry.fingerprint != fingerprint {
+					problem.Write(w, r, &model.FieldError{
+						Field: IdempotencyKeyHeader,
+						Err:   fmt.Errorf("was already used for a different request: %w", model.ErrInvalid),
+					})
+					return
+				}
+
+				select {
+				case <-entry.done:
+				case <-r.Context().Done():
+					return
+				}
+
+				if entry.header == nil {
+					// the first attempt failed with a server error, run it again
+					Idempotency(store)(h).ServeHTTP(w, r)
+					return
+				}
+
+				if entry.truncated {
+					problem.Write(w, r, fmt.Errorf("the request of this %s was served, its response was too large to keep: %w", IdempotencyKeyHeader, model.ErrConflict))
+					return
+				}
+
+				for k, v := range entry.header {
+					if k != requestid.Header {
+						w.Header()[k] = v
+					}
+				}
+				w.Header().Set(IdempotentReplayedHeader, "true")
+				w.WriteHeader(entry.status)
+				w.Write(entry.body)
+				return
+			}
+
+			rec := &recorder{ResponseWriter: w, status: http.StatusOK}
+			defer func() {
+				if p := recover(); p != nil {
+					rec.status = http.StatusInternalServerError
+					store.complete(key, entry, rec)
+					panic(p)
+				}
+
+				store.complete(key, entry, rec)
+			}()
+
+			h.ServeHTTP(rec, r)
+		})
+	}
+}
+
+// idempotencyClient is the client the keys of r are scoped by
+func idempotencyClient(r *http.Request) string {
+	client := ratelimit.FromContext(r.Context())
+	for _, scope := range []string{ratelimit.ScopeKey, ratelimit.ScopePrincipal, ratelimit.ScopeIP} {
+		if id := client[scope]; id != "" {
+			return scope + ":" + id
+		}
+	}
+
+	return ""
+}
+
+// recorder writes the response through to the client and keeps a copy of
+// its first maxIdempotentBodyBytes
+type recorder struct {
+	http.ResponseWriter
+
+	status    int
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	if r.body.Len()+len(b) > maxIdempotentBodyBytes {
+		r.truncated = true
+		r.body.Reset()
+	}
+	if !r.truncated {
+		r.body.Write(b)
+	}
+
+	return r.ResponseWriter.Write(b)
+}
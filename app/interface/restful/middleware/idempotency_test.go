@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-prj-skeleton/app/ratelimit"
+)
+
+func TestIdempotency(t *testing.T) {
+	t.Parallel()
+
+	var calls, failures int32
+	h := Idempotency(NewIdempotencyStore(time.Hour))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" && atomic.AddInt32(&failures, 1) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		n := atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(string(body) + strings.Repeat("!", int(n))))
+	}))
+
+	do := func(method, path, key, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, strings.NewReader(body))
+		if key != "" {
+			r.Header.Set(IdempotencyKeyHeader, key)
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		return w
+	}
+
+	t.Run("replays the first response", func(t *testing.T) {
+		first := do(http.MethodPost, "/a", "k1", "x")
+		second := do(http.MethodPost, "/a", "k1", "x")
+
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+		assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+	})
+
+	t.Run("rejects a key reused for another request", func(t *testing.T) {
+		do(http.MethodPost, "/a", "k2", "x")
+		w := do(http.MethodPost, "/a", "k2", "y")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), IdempotencyKeyHeader)
+	})
+
+	t.Run("keys are scoped by client", func(t *testing.T) {
+		as := func(client ratelimit.Client, body string) *httptest.ResponseRecorder {
+			r := httptest.NewRequest(http.MethodPost, "/a", strings.NewReader(body))
+			r.Header.Set(IdempotencyKeyHeader, "k5")
+			r = r.WithContext(ratelimit.NewContext(r.Context(), client))
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			return w
+		}
+
+		alice := as(ratelimit.Client{ratelimit.ScopeIP: "192.0.2.1"}, "x")
+		bob := as(ratelimit.Client{ratelimit.ScopeIP: "192.0.2.2"}, "y")
+		assert.Equal(t, http.StatusCreated, bob.Code)
+		assert.Empty(t, bob.Header().Get(IdempotentReplayedHeader))
+
+		again := as(ratelimit.Client{ratelimit.ScopeIP: "192.0.2.1"}, "x")
+		assert.Equal(t, alice.Body.String(), again.Body.String())
+	})
+
+	t.Run("large responses are not replayed", func(t *testing.T) {
+		large := Idempotency(NewIdempotencyStore(time.Hour))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(strings.Repeat("x", maxIdempotentBodyBytes+1)))
+		}))
+
+		for _, code := range []int{http.StatusCreated, http.StatusConflict} {
+			r := httptest.NewRequest(http.MethodPost, "/a", nil)
+			r.Header.Set(IdempotencyKeyHeader, "k6")
+			w := httptest.NewRecorder()
+			large.ServeHTTP(w, r)
+			assert.Equal(t, code, w.Code)
+		}
+	})
+
+	t.Run("runs again after a server error", func(t *testing.T) {
+		assert.Equal(t, http.StatusInternalServerError, do(http.MethodPost, "/fail", "k3", "x").Code)
+		assert.Equal(t, http.StatusCreated, do(http.MethodPost, "/fail", "k3", "x").Code)
+	})
+
+	t.Run("ignores requests without key and safe methods", func(t *testing.T) {
+		a := do(http.MethodPost, "/a", "", "x")
+		b := do(http.MethodPost, "/a", "", "x")
+		assert.NotEqual(t, a.Body.String(), b.Body.String())
+
+		a = do(http.MethodGet, "/a", "k4", "")
+		b = do(http.MethodGet, "/a", "k4", "")
+		assert.NotEqual(t, a.Body.String(), b.Body.String())
+	})
+}
+
+func TestIdempotencyStore_Bounded(t *testing.T) {
+	t.Parallel()
+
+	s := NewIdempotencyStore(time.Hour)
+	rec := &recorder{ResponseWriter: httptest.NewRecorder(), status: http.StatusCreated}
+	for i := 0; i < maxIdempotentEntries; i++ {
+		e, created, err := s.claim(strconv.Itoa(i), "f")
+		assert.True(t, created)
+		assert.NoError(t, err)
+		if i > 0 {
+			s.complete(strconv.Itoa(i), e, rec)
+		}
+	}
+
+	// the completed response expiring first makes room
+	_, created, err := s.claim("new", "f")
+	assert.True(t, created)
+	assert.NoError(t, err)
+	assert.Len(t, s.entries, maxIdempotentEntries)
+	assert.NotContains(t, s.entries, "1")
+	assert.Contains(t, s.entries, "0")
+}
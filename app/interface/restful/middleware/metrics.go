@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"go-prj-skeleton/app/metrics"
+)
+
+// Metrics counts every request and observes its latency by route pattern
+// and status, an aborted one too. It goes after AccessLog, whose record
+// Route fills in.
+func Metrics(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+		defer func() {
+			metrics.ObserveRequest(r.Method, routeOf(r), rec.status, time.Since(start))
+		}()
+
+		h.ServeHTTP(rec, r)
+	})
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/problem"
+	"go-prj-skeleton/app/logger"
+	"go-prj-skeleton/app/metrics"
+	"go-prj-skeleton/app/ratelimit"
+)
+
+// APIKeyHeader carries the API key identifying the client of a request
+const APIKeyHeader = "X-API-Key"
+
+// ForwardedForHeader carries the addresses a request was forwarded for by
+// the proxies it went through
+const ForwardedForHeader = "X-Forwarded-For"
+
+// RateLimit takes a token for every group the route of a request is in,
+// from the bucket of each scope of its client: its principal, its API key
+// when clients knows it and its IP, forwarded for by the trusted proxies of
+// clients. The request is rejected with 429 and Retry-After as soon as one
+// is empty. The RateLimit-* headers describe the bucket closest to empty.
+// The client goes in the request context for the handlers limiting
+// operations themselves, see Limit. It goes after Route, and after the
+// authentication for the principal scope. A failing store lets the requests
+// through.
+func RateLimit(limiter *ratelimit.Limiter, clients *ratelimit.Clients, groups ...ratelimit.Group) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := routeOf(r)
+			client := clients.Identify(Principal(r.Context()), r.Header.Get(APIKeyHeader), r.RemoteAddr, r.Header.Values(ForwardedForHeader))
+
+			var tightest *ratelimit.Result
+			for _, g := range groups {
+				if !g.Match(r.Method, route) {
+					continue
+				}
+
+				res, scope, ok, err := limiter.TakeClient(r.Context(), g.Name, client)
+				if err != nil {
+					logger.FromContext(r.Context()).WithError(err).Error("rate limit")
+				}
+				if !ok {
+					continue
+				}
+
+				if !res.Allowed {
+					metrics.ObserveRateLimited(g.Name, scope)
+					setRateLimitHeaders(w, res)
+					w.Header().Set("Retry-After", strconv.Itoa(seconds(res.RetryAfter.Seconds())))
+					problem.Write(w, r, fmt.Errorf("%s requests by %s: %w", g.Name, scope, model.ErrRateLimited))
+					return
+				}
+
+				if tightest == nil || res.Remaining < tightest.Remaining {
+					tightest = &res
+				}
+			}
+
+			if tightest != nil {
+				setRateLimitHeaders(w, *tightest)
+			}
+
+			h.ServeHTTP(w, r.WithContext(ratelimit.NewContext(r.Context(), client)))
+		})
+	}
+}
+
+// Limit takes a token for group from the buckets of client, for the
+// operations limited past RateLimit, e.g. a GraphQL mutation, or out of
+// HTTP. It is ErrRateLimited when one is empty, a failing store lets the
+// operation through.
+func Limit(ctx context.Context, limiter *ratelimit.Limiter, group string, client ratelimit.Client) error {
+	res, scope, ok, err := limiter.TakeClient(ctx, group, client)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Error("rate limit")
+	}
+
+	if ok && !res.Allowed {
+		metrics.ObserveRateLimited(group, scope)
+		return fmt.Errorf("%s requests by %s, retry in %vs: %w", group, scope, seconds(res.RetryAfter.Seconds()), model.ErrRateLimited)
+	}
+
+	return nil
+}
+
+// setRateLimitHeaders sets the RateLimit-* headers of the IETF draft
+func setRateLimitHeaders(w http.ResponseWriter, res ratelimit.Result) {
+	w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+	w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+	w.Header().Set("RateLimit-Reset", strconv.Itoa(seconds(res.Reset.Seconds())))
+}
+
+// seconds rounds s up, the headers count whole seconds
+func seconds(s float64) int {
+	return int(math.Ceil(s))
+}
@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goji "goji.io/v3"
+	"goji.io/v3/pat"
+
+	"go-prj-skeleton/app/interface/restful/problem"
+	"go-prj-skeleton/app/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	limits, err := ratelimit.ParseLimits("transactions.ip=1/m:2,transactions.key=1/m:3,admin.ip=1/m:1")
+	require.NoError(t, err)
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), limits)
+	clients, err := ratelimit.ParseClients("partner", "10.0.0.0/8")
+	require.NoError(t, err)
+	groups := []ratelimit.Group{
+		{Name: "transactions", Methods: []string{http.MethodPost}, Prefix: "/api/users/:user_id/transactions"},
+		{Name: "admin", Prefix: "/admin/"},
+	}
+
+	mux := goji.NewMux()
+	mux.Use(AccessLog)
+	mux.Use(Route)
+
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+	api := goji.SubMux()
+	api.Use(Route)
+	api.Use(RateLimit(limiter, clients, groups...))
+	api.HandleFunc(pat.Post("/users/:user_id/transactions"), ok)
+	api.HandleFunc(pat.Get("/users/:user_id/transactions"), ok)
+	mux.Handle(pat.New("/api/*"), api)
+
+	admin := goji.SubMux()
+	admin.Use(Route)
+	admin.Use(RateLimit(limiter, clients, groups...))
+	admin.Use(AdminToken("secret"))
+	admin.HandleFunc(pat.Get("/integrity"), ok)
+	mux.Handle(pat.New("/admin/*"), admin)
+
+	serve := func(method, path, remoteAddr, key string, forwardedFor ...string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, nil)
+		r.RemoteAddr = remoteAddr
+		if key != "" {
+			r.Header.Set(APIKeyHeader, key)
+		}
+		for _, f := range forwardedFor {
+			r.Header.Add(ForwardedForHeader, f)
+		}
+		r.Header.Set("Authorization", "Bearer secret")
+
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("ip", func(t *testing.T) {
+		w := serve(http.MethodPost, "/api/users/1/transactions", "192.0.2.1:1234", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, "60", w.Header().Get("RateLimit-Reset"))
+
+		w = serve(http.MethodPost, "/api/users/1/transactions", "192.0.2.1:1235", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+		w = serve(http.MethodPost, "/api/users/1/transactions", "192.0.2.1:1236", "")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "60", w.Header().Get("Retry-After"))
+		assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Body.String(), `"code":"rate_limited"`)
+
+		// the routes out of the group and the other clients are not limited
+		w = serve(http.MethodGet, "/api/users/1/transactions", "192.0.2.1:1237", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("RateLimit-Limit"))
+
+		w = serve(http.MethodPost, "/api/users/1/transactions", "192.0.2.2:1234", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("key", func(t *testing.T) {
+		// the headers describe the bucket closest to empty
+		w := serve(http.MethodPost, "/api/users/1/transactions", "192.0.2.3:1234", "partner")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+
+		// another IP does not escape the limit of the key
+		serve(http.MethodPost, "/api/users/1/transactions", "192.0.2.4:1234", "partner")
+		w = serve(http.MethodPost, "/api/users/1/transactions", "192.0.2.5:1234", "partner")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "3", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "0", w.Header().Get("RateLimit-Remaining"))
+
+		w = serve(http.MethodPost, "/api/users/1/transactions", "192.0.2.6:1234", "partner")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		// only the ip bucket counts
+		w := serve(http.MethodPost, "/api/users/1/transactions", "192.0.2.9:1234", "forged")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "2", w.Header().Get("RateLimit-Limit"))
+		assert.Equal(t, "1", w.Header().Get("RateLimit-Remaining"))
+	})
+
+	t.Run("forwarded for", func(t *testing.T) {
+		// a trusted proxy forwards for its clients
+		for _, proxy := range []string{"10.0.0.1:1234", "10.0.0.2:1234"} {
+			w := serve(http.MethodPost, "/api/users/1/transactions", proxy, "", "192.0.2.20, 10.0.0.3")
+			assert.Equal(t, http.StatusOK, w.Code)
+		}
+
+		w := serve(http.MethodPost, "/api/users/1/transactions", "10.0.0.1:1234", "", "192.0.2.20")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+		w = serve(http.MethodPost, "/api/users/1/transactions", "10.0.0.1:1234", "", "192.0.2.21")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		// a client can't forge the header to escape its limit
+		for _, forged := range []string{"192.0.2.22", "192.0.2.23"} {
+			serve(http.MethodPost, "/api/users/1/transactions", "192.0.2.30:1234", "", forged)
+		}
+		w = serve(http.MethodPost, "/api/users/1/transactions", "192.0.2.30:1234", "", "192.0.2.24")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+
+	t.Run("admin", func(t *testing.T) {
+		w := serve(http.MethodGet, "/admin/integrity", "192.0.2.7:1234", "")
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		w = serve(http.MethodGet, "/admin/integrity", "192.0.2.7:1235", "")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+		// a wrong token spends a token too, not to guess it unlimited
+		r := httptest.NewRequest(http.MethodGet, "/admin/integrity", nil)
+		r.RemoteAddr = "192.0.2.8:1234"
+		r.Header.Set("Authorization", "Bearer guess")
+		w = httptest.NewRecorder()
+		mux.ServeHTTP(w, r)
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+		w = serve(http.MethodGet, "/admin/integrity", "192.0.2.8:1235", "")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	})
+}
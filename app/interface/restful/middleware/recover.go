@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"strings"
+
+	"go-prj-skeleton/app/interface/restful/problem"
+	"go-prj-skeleton/app/logger"
+	"go-prj-skeleton/app/metrics"
+)
+
+// Recover turns a panic of the handler into a 500 problem, logs it with its
+// stack and counts it. The response tells nothing of the panic unless stack
+// is true, for development, which adds the stack to it. It goes after
+// AccessLog, Metrics and Trace so they record the 500.
+func Recover(stack bool) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &headerRecorder{ResponseWriter: w}
+
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+
+				// the server aborts the response on purpose
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+
+				trace := string(debug.Stack())
+				metrics.ObservePanic(routeOf(r))
+				logger.FromContext(r.Context()).WithField("stack", trace).Errorf("panic: %v", v)
+
+				// the client got part of a response, it can't be a problem
+				if rec.wroteHeader {
+					return
+				}
+
+				err := fmt.Errorf("panic: %v", v)
+				p := problem.New(r, err)
+				if stack {
+					p.Detail = err.Error()
+					p.Stack = strings.Split(strings.TrimSpace(trace), "\n")
+				}
+				problem.Render(w, p)
+			}()
+
+			h.ServeHTTP(rec, r)
+		})
+	}
+}
+
+// headerRecorder tells whether the response was started
+type headerRecorder struct {
+	http.ResponseWriter
+
+	wroteHeader bool
+}
+
+func (r *headerRecorder) WriteHeader(status int) {
+	r.wroteHeader = true
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *headerRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,128 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goji "goji.io/v3"
+	"goji.io/v3/pat"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/problem"
+	"go-prj-skeleton/app/logger"
+	"go-prj-skeleton/app/metrics"
+	"go-prj-skeleton/app/requestid"
+)
+
+func TestRecover(t *testing.T) {
+	out := &bytes.Buffer{}
+	logger.SetOutput(out)
+	t.Cleanup(func() { logger.SetOutput(os.Stdout) })
+
+	newMux := func(stack bool) *goji.Mux {
+		mux := goji.NewMux()
+		mux.Use(RequestID)
+		mux.Use(AccessLog)
+		mux.Use(Metrics)
+		mux.Use(Recover(stack))
+		mux.Use(Route)
+		mux.HandleFunc(pat.Get("/nil"), func(w http.ResponseWriter, r *http.Request) {
+			var account *model.Account
+			w.Write([]byte(account.Name))
+		})
+		mux.HandleFunc(pat.Get("/partial"), func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("["))
+			panic("encode failed")
+		})
+		mux.HandleFunc(pat.Get("/abort"), func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("date,amount\n"))
+			panic(http.ErrAbortHandler)
+		})
+		return mux
+	}
+
+	t.Run("hides the panic", func(t *testing.T) {
+		out.Reset()
+		r := httptest.NewRequest(http.MethodGet, "/nil", nil)
+		r.Header.Set(requestid.Header, "abc")
+		w := httptest.NewRecorder()
+		newMux(false).ServeHTTP(w, r)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, problem.ContentType, w.Header().Get("Content-Type"))
+
+		p := problem.Problem{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+		assert.Equal(t, model.CodeInternal, p.Code)
+		assert.Equal(t, "abc", p.RequestID)
+		assert.NotContains(t, p.Detail, "nil pointer")
+		assert.Empty(t, p.Stack)
+
+		lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+		require.Len(t, lines, 2)
+
+		panicked := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(lines[0], &panicked))
+		assert.Contains(t, panicked["msg"], "nil pointer")
+		assert.Contains(t, panicked["stack"], "recover_test.go")
+		assert.Equal(t, "abc", panicked["request_id"])
+
+		access := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(lines[1], &access))
+		assert.Equal(t, float64(http.StatusInternalServerError), access["status"])
+
+		count, err := testutil.GatherAndCount(metrics.Registry, "http_panics_total")
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("stack in development", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newMux(true).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/nil", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+		p := problem.Problem{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+		assert.Contains(t, p.Detail, "nil pointer")
+		assert.NotEmpty(t, p.Stack)
+	})
+
+	t.Run("started response", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newMux(false).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/partial", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "[", w.Body.String())
+	})
+	t.Run("aborted response", func(t *testing.T) {
+		out.Reset()
+		before, err := testutil.GatherAndCount(metrics.Registry, "http_requests_total")
+		require.NoError(t, err)
+
+		// the server drops the connection, the request is logged and counted
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			newMux(false).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/abort", nil))
+		})
+
+		lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+		require.Len(t, lines, 1)
+
+		access := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(lines[0], &access))
+		assert.Equal(t, "request", access["msg"])
+		assert.Equal(t, "/abort", access["route"])
+		assert.Equal(t, true, access["aborted"])
+
+		after, err := testutil.GatherAndCount(metrics.Registry, "http_requests_total")
+		require.NoError(t, err)
+		assert.Equal(t, before+1, after)
+	})
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go-prj-skeleton/app/requestid"
+)
+
+// maxRequestIDLength caps IDs propagated from clients
+const maxRequestIDLength = 128
+
+// RequestID propagates the X-Request-ID header of the request, or generates
+// one, and stores it in the request context and the response headers.
+func RequestID(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestid.Header)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = requestid.New()
+		}
+
+		w.Header().Set(requestid.Header, id)
+		h.ServeHTTP(w, r.WithContext(requestid.NewContext(r.Context(), id)))
+	})
+}
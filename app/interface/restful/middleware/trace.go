@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-prj-skeleton/app/logger"
+	"go-prj-skeleton/app/requestid"
+	"go-prj-skeleton/app/tracing"
+)
+
+// Trace serves every request within a span, child of the one of the W3C
+// traceparent header of the request, and named after its route pattern once
+// served. The logger of the request logs the trace ID. It goes after
+// AccessLog, whose record Route fills in.
+func Trace(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		ctx, span := tracing.Tracer().Start(ctx, "HTTP "+r.Method,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPMethodKey.String(r.Method),
+				semconv.HTTPTargetKey.String(r.URL.Path),
+				semconv.HTTPUserAgentKey.String(r.UserAgent()),
+			))
+		defer span.End()
+
+		if id := requestid.FromContext(ctx); id != "" {
+			span.SetAttributes(attribute.String("request.id", id))
+		}
+		if sc := span.SpanContext(); sc.IsValid() {
+			ctx = logger.NewContext(ctx, logger.FromContext(ctx).WithField("trace_id", sc.TraceID().String()))
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		h.ServeHTTP(rec, r.WithContext(ctx))
+
+		if a, ok := r.Context().Value(accessKey{}).(*access); ok && a.route != "" {
+			span.SetName(r.Method + " " + a.route)
+			span.SetAttributes(semconv.HTTPRouteKey.String(a.route))
+		}
+
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(rec.status))
+		if rec.status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(rec.status))
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+	goji "goji.io/v3"
+	"goji.io/v3/pat"
+)
+
+func TestTrace(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	mux := goji.NewMux()
+	mux.Use(AccessLog)
+	mux.Use(Trace)
+	mux.Use(Route)
+
+	var handled trace.SpanContext
+	api := goji.SubMux()
+	api.Use(Route)
+	api.HandleFunc(pat.Get("/users/:user_id/transactions"), func(w http.ResponseWriter, r *http.Request) {
+		handled = trace.SpanContextFromContext(r.Context())
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	mux.Handle(pat.New("/api/*"), api)
+
+	r := httptest.NewRequest(http.MethodGet, "/api/users/7/transactions", nil)
+	r.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	mux.ServeHTTP(httptest.NewRecorder(), r)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 1)
+
+	span := ended[0]
+	assert.Equal(t, "GET /api/users/:user_id/transactions", span.Name())
+	assert.Equal(t, trace.SpanKindServer, span.SpanKind())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+	assert.Equal(t, span.SpanContext(), handled)
+	assert.Contains(t, span.Attributes(), semconv.HTTPRouteKey.String("/api/users/:user_id/transactions"))
+	assert.Contains(t, span.Attributes(), semconv.HTTPStatusCodeKey.Int(http.StatusInternalServerError))
+	assert.Equal(t, codes.Error, span.Status().Code)
+}
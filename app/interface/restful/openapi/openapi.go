@@ -0,0 +1,73 @@
+// Package openapi serves the OpenAPI 3 document of the REST API and a
+// Swagger UI page to browse it.
+//
+// openapi.json is maintained by hand next to the routes and DTOs, tests in
+// the restful and handler packages fail when they drift apart.
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+)
+
+//go:embed openapi.json
+var spec []byte
+
+// Spec returns the raw OpenAPI document
+func Spec() []byte {
+	return spec
+}
+
+// Document is the subset of an OpenAPI document the drift tests look at
+type Document struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Components struct {
+		Schemas map[string]Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Schema is the subset of a schema object the drift tests look at
+type Schema struct {
+	Required   []string                   `json:"required"`
+	Properties map[string]json.RawMessage `json:"properties"`
+}
+
+// Parse decodes the embedded document
+func Parse() (Document, error) {
+	doc := Document{}
+	err := json.Unmarshal(spec, &doc)
+
+	return doc, err
+}
+
+// JSON serves the OpenAPI document
+func JSON(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(spec)
+}
+
+// UI serves a Swagger UI page loading the document from specURL
+func UI(specURL string) http.HandlerFunc {
+	page := []byte(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>HRM API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "` + specURL + `", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(page)
+	}
+}
@@ -0,0 +1,165 @@
+// Package pain001 renders payment batches as ISO 20022 customer credit
+// transfer initiation files, pain.001.001.03, the version bank portals
+// accept for batch uploads.
+package pain001
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/export"
+	"go-prj-skeleton/app/usecase"
+)
+
+// Namespace is the namespace of the documents
+const Namespace = "urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"
+
+// ContentType is the media type of the documents
+const ContentType = "application/xml"
+
+// isoDateTimeLayout is an ISODateTime, in UTC
+const isoDateTimeLayout = "2006-01-02T15:04:05Z"
+
+// The types below follow the element order of the XML schema, which
+// validators enforce.
+
+type document struct {
+	XMLName xml.Name   `xml:"urn:iso:std:iso:20022:tech:xsd:pain.001.001.03 Document"`
+	Initn   initiation `xml:"CstmrCdtTrfInitn"`
+}
+
+type initiation struct {
+	GrpHdr groupHeader `xml:"GrpHdr"`
+	PmtInf paymentInfo `xml:"PmtInf"`
+}
+
+type groupHeader struct {
+	MsgID    string `xml:"MsgId"`
+	CreDtTm  string `xml:"CreDtTm"`
+	NbOfTxs  int    `xml:"NbOfTxs"`
+	CtrlSum  string `xml:"CtrlSum"`
+	InitgPty party  `xml:"InitgPty"`
+}
+
+type paymentInfo struct {
+	PmtInfID    string           `xml:"PmtInfId"`
+	PmtMtd      string           `xml:"PmtMtd"`
+	BtchBookg   bool             `xml:"BtchBookg"`
+	NbOfTxs     int              `xml:"NbOfTxs"`
+	CtrlSum     string           `xml:"CtrlSum"`
+	ReqdExctnDt string           `xml:"ReqdExctnDt"`
+	Dbtr        party            `xml:"Dbtr"`
+	DbtrAcct    cashAccount      `xml:"DbtrAcct"`
+	DbtrAgt     agent            `xml:"DbtrAgt"`
+	ChrgBr      string           `xml:"ChrgBr"`
+	CdtTrfTxInf []creditTransfer `xml:"CdtTrfTxInf"`
+}
+
+type party struct {
+	Nm string `xml:"Nm"`
+}
+
+type cashAccount struct {
+	ID  string `xml:"Id>Othr>Id"`
+	Ccy string `xml:"Ccy,omitempty"`
+}
+
+type agent struct {
+	BIC string `xml:"FinInstnId>BIC"`
+}
+
+type amount struct {
+	Ccy   string `xml:"Ccy,attr"`
+	Value string `xml:",chardata"`
+}
+
+type creditTransfer struct {
+	EndToEndID string      `xml:"PmtId>EndToEndId"`
+	InstdAmt   amount      `xml:"Amt>InstdAmt"`
+	CdtrAgt    agent       `xml:"CdtrAgt"`
+	Cdtr       party       `xml:"Cdtr"`
+	CdtrAcct   cashAccount `xml:"CdtrAcct"`
+	RmtInf     *remittance `xml:"RmtInf"`
+}
+
+type remittance struct {
+	Ustrd string `xml:"Ustrd"`
+}
+
+// Write renders b as one payment information block, booked as a batch by
+// the bank of the debtor, each party bearing the charges of its own bank.
+// Banks reject a message id twice, b is meant to be uploaded once.
+func Write(w io.Writer, b usecase.PaymentBatch) error {
+	createdAt, err := model.ParseCreatedAt(b.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("payment batch[%v] created_at[%v]: %w", b.ID, b.CreatedAt, err)
+	}
+
+	debtorBIC, ok := model.BankBICs[b.Bank]
+	if !ok {
+		return fmt.Errorf("bank[%v] of account[%v] has no BIC: %w", b.Bank, b.AccountID, model.ErrInvalidBank)
+	}
+
+	info := paymentInfo{
+		PmtInfID:    b.MessageID,
+		PmtMtd:      "TRF",
+		BtchBookg:   true,
+		NbOfTxs:     len(b.Payments),
+		CtrlSum:     b.Total.String(),
+		ReqdExctnDt: b.ExecutionDate,
+		Dbtr:        party{b.DebtorName},
+		DbtrAcct:    cashAccount{ID: b.DebtorAccount, Ccy: export.Currency},
+		DbtrAgt:     agent{debtorBIC},
+		ChrgBr:      "SLEV",
+	}
+
+	for _, p := range b.Payments {
+		creditorBIC, ok := model.BankBICs[p.CreditorBank]
+		if !ok {
+			return fmt.Errorf("bank[%v] of transaction[%v] has no BIC: %w", p.CreditorBank, p.TransactionID, model.ErrInvalidBank)
+		}
+
+		tx := creditTransfer{
+			EndToEndID: p.EndToEndID,
+			InstdAmt:   amount{export.Currency, p.Amount.String()},
+			CdtrAgt:    agent{creditorBIC},
+			Cdtr:       party{p.CreditorName},
+			CdtrAcct:   cashAccount{ID: p.CreditorAccount},
+		}
+		// an empty RmtInf element is invalid
+		if p.Remittance != "" {
+			tx.RmtInf = &remittance{p.Remittance}
+		}
+
+		info.CdtTrfTxInf = append(info.CdtTrfTxInf, tx)
+	}
+
+	doc := document{
+		Initn: initiation{
+			GrpHdr: groupHeader{
+				MsgID:    b.MessageID,
+				CreDtTm:  createdAt.UTC().Format(isoDateTimeLayout),
+				NbOfTxs:  len(b.Payments),
+				CtrlSum:  b.Total.String(),
+				InitgPty: party{b.DebtorName},
+			},
+			PmtInf: info,
+		},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+
+	return err
+}
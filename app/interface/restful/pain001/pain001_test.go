@@ -0,0 +1,127 @@
+package pain001
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+var batch = usecase.PaymentBatch{
+	ID:            3,
+	MessageID:     "PB00000003",
+	AccountID:     7,
+	DebtorName:    "Alice",
+	DebtorAccount: "0071000123456",
+	Bank:          "VCB",
+	ExecutionDate: "2021-03-08",
+	CreatedAt:     "2021-03-05 16:00:00 +0700",
+	Total:         decimal.RequireFromString("1500.5"),
+	Payments: []usecase.Payment{
+		{TransactionID: 11, Amount: decimal.NewFromInt(1000), CreditorName: "Bob", CreditorAccount: "190123", CreditorBank: "ACB", Remittance: "Invoice 12", EndToEndID: "TX11"},
+		{TransactionID: 12, Amount: decimal.RequireFromString("500.5"), CreditorName: "Carol", CreditorAccount: "200456", CreditorBank: "VIB", EndToEndID: "TX12"},
+	},
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	t.Run("batch", func(t *testing.T) {
+		t.Parallel()
+
+		b := &bytes.Buffer{}
+		require.NoError(t, Write(b, batch))
+		assert.True(t, strings.HasPrefix(b.String(), xml.Header))
+
+		doc := struct {
+			XMLName xml.Name
+			GrpHdr  struct {
+				MsgID   string `xml:"MsgId"`
+				CreDtTm string `xml:"CreDtTm"`
+				NbOfTxs int    `xml:"NbOfTxs"`
+				CtrlSum string `xml:"CtrlSum"`
+				Nm      string `xml:"InitgPty>Nm"`
+			} `xml:"CstmrCdtTrfInitn>GrpHdr"`
+			PmtInf struct {
+				PmtMtd      string `xml:"PmtMtd"`
+				ReqdExctnDt string `xml:"ReqdExctnDt"`
+				DbtrAcct    string `xml:"DbtrAcct>Id>Othr>Id"`
+				Ccy         string `xml:"DbtrAcct>Ccy"`
+				DbtrBIC     string `xml:"DbtrAgt>FinInstnId>BIC"`
+				Txs         []struct {
+					EndToEndID string `xml:"PmtId>EndToEndId"`
+					Amount     struct {
+						Ccy   string `xml:"Ccy,attr"`
+						Value string `xml:",chardata"`
+					} `xml:"Amt>InstdAmt"`
+					BIC        string `xml:"CdtrAgt>FinInstnId>BIC"`
+					Name       string `xml:"Cdtr>Nm"`
+					Account    string `xml:"CdtrAcct>Id>Othr>Id"`
+					Remittance string `xml:"RmtInf>Ustrd"`
+				} `xml:"CdtTrfTxInf"`
+			} `xml:"CstmrCdtTrfInitn>PmtInf"`
+		}{}
+		require.NoError(t, xml.Unmarshal(b.Bytes(), &doc))
+
+		assert.Equal(t, xml.Name{Space: Namespace, Local: "Document"}, doc.XMLName)
+		assert.Equal(t, "PB00000003", doc.GrpHdr.MsgID)
+		assert.Equal(t, "2021-03-05T09:00:00Z", doc.GrpHdr.CreDtTm)
+		assert.Equal(t, 2, doc.GrpHdr.NbOfTxs)
+		assert.Equal(t, "1500.5", doc.GrpHdr.CtrlSum)
+		assert.Equal(t, "Alice", doc.GrpHdr.Nm)
+
+		assert.Equal(t, "TRF", doc.PmtInf.PmtMtd)
+		assert.Equal(t, "2021-03-08", doc.PmtInf.ReqdExctnDt)
+		assert.Equal(t, "0071000123456", doc.PmtInf.DbtrAcct)
+		assert.Equal(t, "VND", doc.PmtInf.Ccy)
+		assert.Equal(t, "BFTVVNVX", doc.PmtInf.DbtrBIC)
+
+		require.Len(t, doc.PmtInf.Txs, 2)
+		tx := doc.PmtInf.Txs[0]
+		assert.Equal(t, "TX11", tx.EndToEndID)
+		assert.Equal(t, "VND", tx.Amount.Ccy)
+		assert.Equal(t, "1000", tx.Amount.Value)
+		assert.Equal(t, "ASCBVNVX", tx.BIC)
+		assert.Equal(t, "Bob", tx.Name)
+		assert.Equal(t, "190123", tx.Account)
+		assert.Equal(t, "Invoice 12", tx.Remittance)
+
+		assert.Equal(t, "500.5", doc.PmtInf.Txs[1].Amount.Value)
+		assert.Equal(t, "VNIBVNVX", doc.PmtInf.Txs[1].BIC)
+		assert.Equal(t, 1, strings.Count(b.String(), "<RmtInf>"), "no remittance, no element")
+	})
+
+	t.Run("schema order", func(t *testing.T) {
+		t.Parallel()
+
+		b := &bytes.Buffer{}
+		require.NoError(t, Write(b, batch))
+
+		order := []string{"<PmtInfId>", "<PmtMtd>", "<BtchBookg>", "<NbOfTxs>", "<CtrlSum>", "<ReqdExctnDt>", "<Dbtr>", "<DbtrAcct>", "<DbtrAgt>", "<ChrgBr>", "<CdtTrfTxInf>"}
+		pmtInf := b.String()[strings.Index(b.String(), "<PmtInf>"):]
+		last := -1
+		for _, tag := range order {
+			i := strings.Index(pmtInf, tag)
+			assert.Greater(t, i, last, tag)
+			last = i
+		}
+	})
+
+	t.Run("bank without BIC", func(t *testing.T) {
+		t.Parallel()
+
+		b := batch
+		b.Payments = []usecase.Payment{{TransactionID: 11, Amount: decimal.NewFromInt(1), CreditorBank: "XYZ"}}
+
+		err := Write(&bytes.Buffer{}, b)
+		assert.True(t, errors.Is(err, model.ErrInvalidBank), err)
+	})
+}
@@ -0,0 +1,143 @@
+// Package problem renders errors as RFC 7807 application/problem+json
+// responses.
+//
+// Domain errors are mapped to a stable code and an HTTP status:
+//
+//	code                      status  error
+//	invalid                   400     model.ErrInvalid, model.FieldErrors, malformed JSON or parameters
+//	invalid_amount            400     model.ErrInvalidAmount
+//	invalid_bank              400     model.ErrInvalidBank
+//	invalid_transaction_type  400     model.ErrTransactionTypeInvalid
+//	unauthorized              401     model.ErrUnauthorized
+//	not_found                 404     model.ErrNotFound
+//	method_not_allowed        405     model.ErrMethodNotAllowed
+//	conflict                  409     model.ErrConflict
+//	payload_too_large         413     model.ErrPayloadTooLarge
+//	insufficient_funds        422     model.ErrInsufficientFunds
+//	rate_limited              429     model.ErrRateLimited
+//	internal                  500     any other error, its message is not exposed
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/jsonutil"
+	"go-prj-skeleton/app/logger"
+	"go-prj-skeleton/app/requestid"
+)
+
+// ContentType is the media type of problem responses
+const ContentType = "application/problem+json"
+
+// TypePrefix prefixes the code in the type URI of a problem
+const TypePrefix = "urn:go-prj-skeleton:problem:"
+
+var statuses = map[model.ErrorCode]int{
+	model.CodeInvalid:                http.StatusBadRequest,
+	model.CodeInvalidAmount:          http.StatusBadRequest,
+	model.CodeInvalidBank:            http.StatusBadRequest,
+	model.CodeInvalidTransactionType: http.StatusBadRequest,
+	model.CodeUnauthorized:           http.StatusUnauthorized,
+	model.CodeNotFound:               http.StatusNotFound,
+	model.CodeMethodNotAllowed:       http.StatusMethodNotAllowed,
+	model.CodeConflict:               http.StatusConflict,
+	model.CodePayloadTooLarge:        http.StatusRequestEntityTooLarge,
+	model.CodeInsufficientFunds:      http.StatusUnprocessableEntity,
+	model.CodeRateLimited:            http.StatusTooManyRequests,
+	model.CodeInternal:               http.StatusInternalServerError,
+}
+
+// FieldProblem is one invalid field of the request
+type FieldProblem struct {
+	Field  string          `json:"field"`
+	Code   model.ErrorCode `json:"code"`
+	Detail string          `json:"detail"`
+}
+
+// Problem is an RFC 7807 problem details object
+type Problem struct {
+	Type      string          `json:"type"`
+	Title     string          `json:"title"`
+	Status    int             `json:"status"`
+	Detail    string          `json:"detail,omitempty"`
+	Instance  string          `json:"instance,omitempty"`
+	Code      model.ErrorCode `json:"code"`
+	RequestID string          `json:"request_id,omitempty"`
+	Errors    []FieldProblem  `json:"errors,omitempty"`
+	// Stack is the stack of the goroutine that panicked, only exposed in
+	// development
+	Stack []string `json:"stack,omitempty"`
+}
+
+// Status returns the HTTP status of code
+func Status(code model.ErrorCode) int {
+	if status, ok := statuses[code]; ok {
+		return status
+	}
+
+	return http.StatusInternalServerError
+}
+
+// New builds the problem describing err for request r.
+func New(r *http.Request, err error) Problem {
+	code := model.ErrorCodeOf(err)
+	status := Status(code)
+
+	p := Problem{
+		Type:      TypePrefix + string(code),
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    err.Error(),
+		Instance:  r.URL.Path,
+		Code:      code,
+		RequestID: requestid.FromContext(r.Context()),
+	}
+
+	if status >= http.StatusInternalServerError {
+		p.Detail = "the server could not process the request, quote the request_id when reporting it"
+	}
+
+	var fieldErrs model.FieldErrors
+	if errors.As(err, &fieldErrs) {
+		for _, fe := range fieldErrs {
+			p.Errors = append(p.Errors, FieldProblem{
+				Field:  fe.Field,
+				Code:   model.ErrorCodeOf(fe.Err),
+				Detail: fe.Err.Error(),
+			})
+		}
+	}
+
+	var fieldErr *model.FieldError
+	if len(p.Errors) == 0 && errors.As(err, &fieldErr) {
+		p.Errors = append(p.Errors, FieldProblem{
+			Field:  fieldErr.Field,
+			Code:   model.ErrorCodeOf(fieldErr.Err),
+			Detail: fieldErr.Err.Error(),
+		})
+	}
+
+	return p
+}
+
+// Write renders err as a problem response. Server errors, hidden from the
+// client, are logged with the request ID it is told to quote.
+func Write(w http.ResponseWriter, r *http.Request, err error) {
+	p := New(r, err)
+	if p.Status >= http.StatusInternalServerError {
+		logger.FromContext(r.Context()).WithError(err).Error("request failed")
+	}
+
+	Render(w, p)
+}
+
+// Render writes p as the response, without logging it
+func Render(w http.ResponseWriter, p Problem) {
+	w.Header().Set("Content-Type", ContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(p.Status)
+	fmt.Fprintln(w, string(jsonutil.Marshal(p)))
+}
@@ -0,0 +1,50 @@
+package problem
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/requestid"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	r := httptest.NewRequest(http.MethodPost, "/api/users/1/transactions", nil)
+	r = r.WithContext(requestid.NewContext(r.Context(), "req-1"))
+
+	t.Run("field errors", func(t *testing.T) {
+		err := fmt.Errorf("create: %w", model.FieldErrors{
+			{Field: "/account_id", Err: fmt.Errorf("is required: %w", model.ErrInvalid)},
+			{Field: "/amount", Err: model.ErrInvalidAmount},
+		})
+
+		assert.Equal(t, Problem{
+			Type:      TypePrefix + "invalid",
+			Title:     "Bad Request",
+			Status:    http.StatusBadRequest,
+			Detail:    "create: /account_id: is required: invalid; /amount: invalid amount",
+			Instance:  "/api/users/1/transactions",
+			Code:      model.CodeInvalid,
+			RequestID: "req-1",
+			Errors: []FieldProblem{
+				{Field: "/account_id", Code: model.CodeInvalid, Detail: "is required: invalid"},
+				{Field: "/amount", Code: model.CodeInvalidAmount, Detail: "invalid amount"},
+			},
+		}, New(r, err))
+	})
+
+	t.Run("internal errors are not exposed", func(t *testing.T) {
+		p := New(r, errors.New("pq: password authentication failed"))
+
+		assert.Equal(t, http.StatusInternalServerError, p.Status)
+		assert.Equal(t, model.CodeInternal, p.Code)
+		assert.NotContains(t, p.Detail, "password")
+	})
+}
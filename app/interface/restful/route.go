@@ -1,34 +1,238 @@
 package restful
 
 import (
-	// log "github.com/sirupsen/logrus"
+	"context"
 	"net/http"
+	"sort"
+	"time"
 
 	goji "goji.io/v3"
 	"goji.io/v3/pat"
 
+	"go-prj-skeleton/app/health"
+	"go-prj-skeleton/app/interface/gql"
 	"go-prj-skeleton/app/interface/restful/handler"
 	"go-prj-skeleton/app/interface/restful/middleware"
+	"go-prj-skeleton/app/interface/restful/openapi"
 	"go-prj-skeleton/app/jsonutil"
+	"go-prj-skeleton/app/ratelimit"
 	"go-prj-skeleton/app/registry"
+	"go-prj-skeleton/app/setting"
 	"go-prj-skeleton/app/usecase"
 )
 
-// Handlers ...
-func Handlers(ctn *registry.Container) *goji.Mux {
+// idempotencyTTL is how long the responses of requests carrying an
+// Idempotency-Key are replayed
+const idempotencyTTL = 24 * time.Hour
+
+// checkTimeout bounds each dependency check of the readiness and status
+// probes
+const checkTimeout = 2 * time.Second
+
+// rateLimitGroups are the routes sharing rate limits, SETTING_RATE_LIMITS
+// sets the limits of each group by client scope
+var rateLimitGroups = []ratelimit.Group{
+	{Name: "api", Prefix: "/api/"},
+	{Name: ratelimit.GroupTransactions, Methods: []string{http.MethodPost, http.MethodPut, http.MethodDelete}, Prefix: "/api/users/:user_id/transactions"},
+	{Name: "admin", Prefix: "/admin/"},
+}
+
+// route is one endpoint, Pattern is relative to the prefix of its sub mux
+type route struct {
+	Method  string
+	Pattern string
+	Handler http.HandlerFunc
+}
+
+type userRoutes interface {
+	FindTransactions(http.ResponseWriter, *http.Request)
+	ExportTransactions(http.ResponseWriter, *http.Request)
+	CreateTransaction(http.ResponseWriter, *http.Request)
+	UpdateTransaction(http.ResponseWriter, *http.Request)
+	DeleteTransaction(http.ResponseWriter, *http.Request)
+}
+
+type reconciliationRoutes interface {
+	ImportStatement(http.ResponseWriter, *http.Request)
+	ImportUserStatement(http.ResponseWriter, *http.Request)
+	FindReconciliations(http.ResponseWriter, *http.Request)
+}
+
+type paymentRoutes interface {
+	CreateBatch(http.ResponseWriter, *http.Request)
+	FindBatches(http.ResponseWriter, *http.Request)
+	ExportBatch(http.ResponseWriter, *http.Request)
+	ConfirmPayment(http.ResponseWriter, *http.Request)
+}
+
+type qrRoutes interface {
+	QR(http.ResponseWriter, *http.Request)
+	DecodeQR(http.ResponseWriter, *http.Request)
+}
+
+type healthRoutes interface {
+	Healthz(http.ResponseWriter, *http.Request)
+	Readyz(http.ResponseWriter, *http.Request)
+	Status(http.ResponseWriter, *http.Request)
+}
+
+type customerRoutes interface {
+	CreateUser(http.ResponseWriter, *http.Request)
+	RenameUser(http.ResponseWriter, *http.Request)
+	CreateAccount(http.ResponseWriter, *http.Request)
+	UpdateAccount(http.ResponseWriter, *http.Request)
+	Banks(http.ResponseWriter, *http.Request)
+}
+
+type integrityRoutes interface {
+	Check(http.ResponseWriter, *http.Request)
+	Repair(http.ResponseWriter, *http.Request)
+}
+
+// rootRoutes lists the routes outside of /api, for the clients and
+// orchestrators to probe the service. They are documented in openapi.json.
+func rootRoutes(healthHandler healthRoutes) []route {
+	return []route{
+		{http.MethodGet, "/", Info},
+		{http.MethodGet, "/healthz", healthHandler.Healthz},
+		{http.MethodGet, "/readyz", healthHandler.Readyz},
+		{http.MethodGet, "/status", healthHandler.Status},
+	}
+}
+
+// apiRoutes lists the routes under /api, they are documented in openapi.json
+func apiRoutes(userHandler userRoutes, customerHandler customerRoutes, reconciliationHandler reconciliationRoutes, paymentHandler paymentRoutes, qrHandler qrRoutes, graphqlHandler http.Handler) []route {
+	return []route{
+		{http.MethodGet, "/openapi.json", openapi.JSON},
+		{http.MethodGet, "/docs", openapi.UI("/api/openapi.json")},
+		{http.MethodGet, "/banks", customerHandler.Banks},
+		{http.MethodGet, "/users/:user_id/transactions", userHandler.FindTransactions},
+		{http.MethodGet, "/users/:user_id/transactions/export", userHandler.ExportTransactions},
+		{http.MethodPost, "/users/:user_id/transactions", userHandler.CreateTransaction},
+		{http.MethodPut, "/users/:user_id/transactions/:transaction_id", userHandler.UpdateTransaction},
+		{http.MethodDelete, "/users/:user_id/transactions/:transaction_id", userHandler.DeleteTransaction},
+		{http.MethodPost, "/users/:user_id/accounts/:account_id/statements", reconciliationHandler.ImportStatement},
+		{http.MethodPost, "/users/:user_id/statements", reconciliationHandler.ImportUserStatement},
+		{http.MethodGet, "/users/:user_id/accounts/:account_id/reconciliations", reconciliationHandler.FindReconciliations},
+		{http.MethodPost, "/users/:user_id/accounts/:account_id/payment-batches", paymentHandler.CreateBatch},
+		{http.MethodGet, "/users/:user_id/accounts/:account_id/payment-batches", paymentHandler.FindBatches},
+		{http.MethodGet, "/users/:user_id/accounts/:account_id/payment-batches/:batch_id/pain001", paymentHandler.ExportBatch},
+		{http.MethodPost, "/users/:user_id/accounts/:account_id/payments/:transaction_id/confirm", paymentHandler.ConfirmPayment},
+		{http.MethodGet, "/users/:user_id/accounts/:account_id/qr", qrHandler.QR},
+		{http.MethodPost, "/users/:user_id/accounts/:account_id/qr/decode", qrHandler.DecodeQR},
+		{http.MethodPost, "/graphql", graphqlHandler.ServeHTTP},
+	}
+}
+
+// adminRoutes lists the routes under /admin, they are documented in openapi.json
+func adminRoutes(customerHandler customerRoutes, integrityHandler integrityRoutes) []route {
+	return []route{
+		{http.MethodPost, "/users", customerHandler.CreateUser},
+		{http.MethodPut, "/users/:user_id", customerHandler.RenameUser},
+		{http.MethodPost, "/users/:user_id/accounts", customerHandler.CreateAccount},
+		{http.MethodPut, "/users/:user_id/accounts/:account_id", customerHandler.UpdateAccount},
+		{http.MethodGet, "/integrity", integrityHandler.Check},
+		{http.MethodPost, "/integrity/repair", integrityHandler.Repair},
+		{http.MethodGet, "/log-level", handler.LogLevel},
+		{http.MethodPut, "/log-level", handler.SetLogLevel},
+	}
+}
+
+func handle(mux *goji.Mux, routes []route) {
+	for _, r := range routes {
+		p := pat.NewWithMethods(r.Pattern, r.Method)
+		if r.Method == http.MethodGet {
+			p = pat.Get(r.Pattern)
+		}
+
+		mux.HandleFunc(p, r.Handler)
+	}
+}
+
+// fallback answers the requests that match none of routes: 405 with the
+// allowed methods when the path is one of a route, 404 otherwise
+func fallback(routes []route) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		methods := map[string]bool{}
+		for _, rt := range routes {
+			if pat.New(rt.Pattern).Match(r) == nil {
+				continue
+			}
+
+			methods[rt.Method] = true
+			if rt.Method == http.MethodGet {
+				methods[http.MethodHead] = true
+			}
+		}
+
+		if len(methods) == 0 {
+			handler.NotFound(w, r)
+			return
+		}
+
+		allowed := make([]string, 0, len(methods))
+		for m := range methods {
+			allowed = append(allowed, m)
+		}
+		sort.Strings(allowed)
+
+		handler.MethodNotAllowed(w, r, allowed)
+	}
+}
+
+// Handlers serves the API of the use cases of ctn. The readiness and status
+// probes check that ctn builds them, and run checks, e.g. of the database.
+func Handlers(ctn *registry.Container, checks ...health.Check) *goji.Mux {
 	mux := goji.NewMux()
+	mux.Use(middleware.RequestID)
+	mux.Use(middleware.AccessLog)
+	mux.Use(middleware.Metrics)
+	mux.Use(middleware.Trace)
+	mux.Use(middleware.Recover(setting.ProjectEnvSettings.Env == setting.EnvDevelopment && setting.ProjectEnvSettings.PanicStack))
+	mux.Use(middleware.Route)
 	mux.Use(middleware.JSON)
 
-	mux.HandleFunc(pat.Get("/"), Info)
+	container := health.Check{Name: "container", Func: func(context.Context) error { return ctn.Check() }}
+	checker := health.NewChecker(checkTimeout, append([]health.Check{container}, checks...)...)
+	root := rootRoutes(handler.NewHealthHandler(checker))
+	handle(mux, root)
+
+	rateLimiter := ctn.Resolve("rate-limiter").(*ratelimit.Limiter)
+	rateLimitClients := ctn.Resolve("rate-limit-clients").(*ratelimit.Clients)
+
 	apiRoute := goji.SubMux()
+	apiRoute.Use(middleware.Route)
+	apiRoute.Use(middleware.RateLimit(rateLimiter, rateLimitClients, rateLimitGroups...))
+	apiRoute.Use(middleware.Idempotency(middleware.NewIdempotencyStore(idempotencyTTL)))
 	mux.Handle(pat.New("/api/*"), apiRoute)
 
-	userHandler := handler.NewUserHandler(ctn.Resolve("user-usecase").(usecase.UserUsecase))
+	userUsecase := ctn.Resolve("user-usecase").(usecase.UserUsecase)
+	userHandler := handler.NewUserHandler(userUsecase)
+	reconciliationHandler := handler.NewReconciliationHandler(userUsecase, ctn.Resolve("reconciliation-usecase").(usecase.ReconciliationUsecase))
+
+	paymentHandler := handler.NewPaymentHandler(ctn.Resolve("payment-usecase").(usecase.PaymentUsecase))
+	customerHandler := handler.NewCustomerHandler(ctn.Resolve("customer-usecase").(usecase.CustomerUsecase))
+
+	api := apiRoutes(userHandler, customerHandler, reconciliationHandler, paymentHandler, handler.NewQRHandler(userUsecase), gql.NewHandler(userUsecase, rateLimiter))
+	handle(apiRoute, api)
+	apiRoute.HandleFunc(pat.New("/*"), fallback(api))
+
+	// admin routes are only served when a token is configured
+	if token := setting.ProjectEnvSettings.AdminToken; token != "" {
+		adminRoute := goji.SubMux()
+		adminRoute.Use(middleware.Route)
+		adminRoute.Use(middleware.RateLimit(rateLimiter, rateLimitClients, rateLimitGroups...))
+		adminRoute.Use(middleware.AdminToken(token))
+		mux.Handle(pat.New("/admin/*"), adminRoute)
+
+		integrityHandler := handler.NewIntegrityHandler(ctn.Resolve("integrity-usecase").(usecase.IntegrityUsecase))
+
+		admin := adminRoutes(customerHandler, integrityHandler)
+		handle(adminRoute, admin)
+		adminRoute.HandleFunc(pat.New("/*"), fallback(admin))
+	}
 
-	apiRoute.HandleFunc(pat.Get("/users/:user_id/transactions"), userHandler.FindTransactions)
-	apiRoute.HandleFunc(pat.Post("/users/:user_id/transactions"), userHandler.CreateTransaction)
-	apiRoute.HandleFunc(pat.Put("/users/:user_id/transactions/:transaction_id"), userHandler.UpdateTransaction)
-	apiRoute.HandleFunc(pat.Delete("/users/:user_id/transactions/:transaction_id"), userHandler.DeleteTransaction)
+	mux.HandleFunc(pat.New("/*"), fallback(root))
 
 	return mux
 }
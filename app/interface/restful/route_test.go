@@ -0,0 +1,176 @@
+package restful
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	goji "goji.io/v3"
+	"goji.io/v3/pat"
+
+	"go-prj-skeleton/app/interface/gql"
+	"go-prj-skeleton/app/interface/restful/handler"
+	"go-prj-skeleton/app/interface/restful/openapi"
+	"go-prj-skeleton/app/registry"
+	"go-prj-skeleton/app/setting"
+)
+
+var (
+	paramRegexp = regexp.MustCompile(`:(\w+)`)
+	braceRegexp = regexp.MustCompile(`{\w+}`)
+)
+
+func TestRoutes_MatchOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	doc, err := openapi.Parse()
+	require.NoError(t, err)
+
+	served := []string{}
+	for prefix, routes := range map[string][]route{
+		"":       rootRoutes(handler.NewHealthHandler(nil)),
+		"/api":   apiRoutes(handler.NewUserHandler(nil), handler.NewCustomerHandler(nil), handler.NewReconciliationHandler(nil, nil), handler.NewPaymentHandler(nil), handler.NewQRHandler(nil), gql.NewHandler(nil, nil)),
+		"/admin": adminRoutes(handler.NewCustomerHandler(nil), handler.NewIntegrityHandler(nil)),
+	} {
+		for _, r := range routes {
+			path := prefix + paramRegexp.ReplaceAllString(r.Pattern, "{$1}")
+			served = append(served, strings.ToLower(r.Method)+" "+path)
+		}
+	}
+
+	documented := []string{}
+	for path, item := range doc.Paths {
+		for method := range item {
+			if method == "parameters" {
+				continue
+			}
+
+			documented = append(documented, method+" "+path)
+		}
+	}
+
+	sort.Strings(served)
+	sort.Strings(documented)
+	assert.Equal(t, served, documented, "routes and openapi.json paths differ")
+}
+
+// TestHandlers_ServeOpenAPI walks the mux of Handlers: every documented
+// operation reaches a handler, and other methods of a documented path are
+// refused with the documented ones in Allow.
+func TestHandlers_ServeOpenAPI(t *testing.T) {
+	setting.EnvSettingsInit(nil)
+	setting.ProjectEnvSettings.StorageBackend = setting.StorageBackendMemory
+	setting.ProjectEnvSettings.AdminToken = "secret"
+	// the walk requests every route from one address
+	setting.ProjectEnvSettings.RateLimits = ""
+
+	ctn, err := registry.NewContainer()
+	require.NoError(t, err)
+	defer ctn.Clean()
+
+	mux := Handlers(ctn)
+
+	doc, err := openapi.Parse()
+	require.NoError(t, err)
+
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, target, nil)
+		r.Header.Set("Authorization", "Bearer secret")
+
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, r)
+
+		return rec
+	}
+
+	for path, item := range doc.Paths {
+		target := braceRegexp.ReplaceAllString(path, "1")
+
+		allowed := []string{}
+		for method := range item {
+			if method == "parameters" {
+				continue
+			}
+
+			method = strings.ToUpper(method)
+			allowed = append(allowed, method)
+			if method == http.MethodGet {
+				allowed = append(allowed, http.MethodHead)
+			}
+
+			rec := serve(method, target)
+
+			// the fallback details start with the route, no handler's do
+			var p struct{ Detail string }
+			_ = json.Unmarshal(rec.Body.Bytes(), &p)
+			assert.NotEqual(t, http.StatusMethodNotAllowed, rec.Code, method+" "+path)
+			assert.False(t, strings.HasPrefix(p.Detail, "route "), "%s %s is not served: %s", method, path, p.Detail)
+		}
+
+		// a parameter also matches literal segments, so Allow may hold the
+		// methods of a sibling path too
+		rec := serve(http.MethodPatch, target)
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code, "PATCH "+path)
+		assert.Subset(t, strings.Split(rec.Header().Get("Allow"), ", "), allowed, path)
+	}
+}
+
+func TestOpenAPI_Served(t *testing.T) {
+	t.Parallel()
+
+	mux := goji.NewMux()
+	handle(mux, apiRoutes(handler.NewUserHandler(nil), handler.NewCustomerHandler(nil), handler.NewReconciliationHandler(nil, nil), handler.NewPaymentHandler(nil), handler.NewQRHandler(nil), gql.NewHandler(nil, nil)))
+
+	t.Run("document", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+		var doc map[string]interface{}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+		assert.Equal(t, "3.0.3", doc["openapi"])
+	})
+
+	t.Run("swagger ui", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/docs", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Header().Get("Content-Type"), "text/html")
+		assert.Contains(t, rec.Body.String(), `url: "/api/openapi.json"`)
+	})
+}
+
+func TestFallback(t *testing.T) {
+	t.Parallel()
+
+	routes := apiRoutes(handler.NewUserHandler(nil), handler.NewCustomerHandler(nil), handler.NewReconciliationHandler(nil, nil), handler.NewPaymentHandler(nil), handler.NewQRHandler(nil), gql.NewHandler(nil, nil))
+	mux := goji.NewMux()
+	handle(mux, routes)
+	mux.HandleFunc(pat.New("/*"), fallback(routes))
+
+	for _, tc := range []struct {
+		method, path string
+		status       int
+		allow        string
+	}{
+		{http.MethodGet, "/unknown", http.StatusNotFound, ""},
+		{http.MethodPost, "/openapi.json", http.StatusMethodNotAllowed, "GET, HEAD"},
+		{http.MethodDelete, "/users/1/transactions", http.StatusMethodNotAllowed, "GET, HEAD, POST"},
+		{http.MethodGet, "/users/1/transactions/2/extra", http.StatusNotFound, ""},
+	} {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.path, nil))
+
+		assert.Equal(t, tc.status, rec.Code, tc.method+" "+tc.path)
+		assert.Equal(t, tc.allow, rec.Header().Get("Allow"), tc.method+" "+tc.path)
+	}
+}
@@ -0,0 +1,337 @@
+// Package validate decodes and validates request DTOs declared with struct
+// tags, reporting every invalid field at once.
+//
+// Fields are validated by a `validate` tag holding comma separated rules:
+//
+//	required     the field is present in the body; use a pointer field to tell a missing field from a zero value
+//	min=N        number >= N
+//	max=N        number <= N
+//	gt=N         number > N
+//	scale=N      number with at most N decimal places
+//	oneof=A B C  value is one of the space separated values
+//	maxlen=N     string with at most N characters
+//
+// Only the top level fields of a JSON object are supported. Errors are
+// model.FieldErrors whose Field is a JSON pointer, e.g. "/amount".
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+// DecodeJSON reads a JSON object of at most maxBytes from r into the struct
+// pointed to by v, rejecting unknown fields, then validates it.
+func DecodeJSON(r io.Reader, maxBytes int64, v interface{}) error {
+	body, err := ioutil.ReadAll(io.LimitReader(r, maxBytes+1))
+	if err != nil {
+		return fmt.Errorf("read body: %w", err)
+	}
+
+	if int64(len(body)) > maxBytes {
+		return fmt.Errorf("body is larger than %v bytes: %w", maxBytes, model.ErrPayloadTooLarge)
+	}
+
+	raw := map[string]json.RawMessage{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return model.FieldErrors{
+			{Field: "", Err: fmt.Errorf("must be a JSON object: %w", model.ErrInvalid)},
+		}
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	fields := jsonFields(rv.Type())
+
+	errs := model.FieldErrors{}
+	for _, name := range sortedKeys(raw) {
+		if _, ok := fields[name]; !ok {
+			errs = append(errs, fieldError(name, "is not a known field"))
+		}
+	}
+
+	decoded := map[string]bool{}
+	for name, idx := range fields {
+		value, ok := raw[name]
+		if !ok {
+			continue
+		}
+
+		field := rv.Field(idx)
+		if err := json.Unmarshal(value, field.Addr().Interface()); err != nil {
+			field.Set(reflect.Zero(field.Type()))
+			errs = append(errs, fieldError(name, "must be %s", describe(field.Type())))
+			continue
+		}
+
+		decoded[name] = true
+	}
+
+	errs = append(errs, check(rv, raw, decoded)...)
+
+	if len(errs) > 0 {
+		sortFieldErrors(errs, rv.Type())
+		return errs
+	}
+
+	return nil
+}
+
+// Struct validates the fields of the struct pointed to by v. A nil pointer
+// field is treated as missing.
+func Struct(v interface{}) error {
+	rv := reflect.ValueOf(v).Elem()
+
+	present := map[string]json.RawMessage{}
+	decoded := map[string]bool{}
+	for name, idx := range jsonFields(rv.Type()) {
+		field := rv.Field(idx)
+		if field.Kind() == reflect.Ptr && field.IsNil() {
+			continue
+		}
+
+		present[name] = nil
+		decoded[name] = true
+	}
+
+	errs := check(rv, present, decoded)
+	if len(errs) > 0 {
+		sortFieldErrors(errs, rv.Type())
+		return errs
+	}
+
+	return nil
+}
+
+func check(rv reflect.Value, present map[string]json.RawMessage, decoded map[string]bool) model.FieldErrors {
+	errs := model.FieldErrors{}
+
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name := jsonName(sf)
+		tag := sf.Tag.Get("validate")
+		if name == "" || tag == "" {
+			continue
+		}
+
+		raw, ok := present[name]
+		isNull := ok && string(raw) == "null"
+		for _, rule := range strings.Split(tag, ",") {
+			if rule == "required" && (!ok || isNull) {
+				errs = append(errs, fieldError(name, "is required"))
+				break
+			}
+
+			if !decoded[name] || isNull || rule == "required" {
+				continue
+			}
+
+			if fe := checkRule(name, rule, rv.Field(i)); fe != nil {
+				errs = append(errs, fe)
+				break
+			}
+		}
+	}
+
+	return errs
+}
+
+func checkRule(name, rule string, field reflect.Value) *model.FieldError {
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil
+		}
+
+		field = field.Elem()
+	}
+
+	key, param := rule, ""
+	if i := strings.Index(rule, "="); i >= 0 {
+		key, param = rule[:i], rule[i+1:]
+	}
+
+	switch key {
+	case "min", "max", "gt":
+		limit, err := decimal.NewFromString(param)
+		if err != nil {
+			panic(fmt.Sprintf("validate: invalid %s rule on %s: %v", key, name, err))
+		}
+
+		n, ok := number(field)
+		if !ok {
+			panic(fmt.Sprintf("validate: %s rule on non numeric field %s", key, name))
+		}
+
+		switch {
+		case key == "min" && n.LessThan(limit):
+			return fieldError(name, "must be greater than or equal to %s", param)
+		case key == "max" && n.GreaterThan(limit):
+			return fieldError(name, "must be less than or equal to %s", param)
+		case key == "gt" && n.LessThanOrEqual(limit):
+			return fieldError(name, "must be greater than %s", param)
+		}
+
+	case "scale":
+		places, err := strconv.Atoi(param)
+		if err != nil {
+			panic(fmt.Sprintf("validate: invalid scale rule on %s: %v", name, err))
+		}
+
+		n, ok := number(field)
+		if !ok {
+			panic(fmt.Sprintf("validate: scale rule on non numeric field %s", name))
+		}
+
+		if !n.Equal(n.Truncate(int32(places))) {
+			return fieldError(name, "must have at most %v decimal places", places)
+		}
+
+	case "oneof":
+		values := strings.Fields(param)
+		s := fmt.Sprint(field.Interface())
+		for _, v := range values {
+			if s == v {
+				return nil
+			}
+		}
+
+		return fieldError(name, "must be one of %s", strings.Join(values, ", "))
+
+	case "maxlen":
+		max, err := strconv.Atoi(param)
+		if err != nil {
+			panic(fmt.Sprintf("validate: invalid maxlen rule on %s: %v", name, err))
+		}
+
+		if field.Kind() == reflect.String && utf8.RuneCountInString(field.String()) > max {
+			return fieldError(name, "must have at most %v characters", max)
+		}
+
+	default:
+		panic(fmt.Sprintf("validate: unknown rule %q on %s", rule, name))
+	}
+
+	return nil
+}
+
+func number(v reflect.Value) (decimal.Decimal, bool) {
+	if d, ok := v.Interface().(decimal.Decimal); ok {
+		return d, true
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return decimal.NewFromInt(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return decimal.NewFromInt(int64(v.Uint())), true
+	case reflect.Float32, reflect.Float64:
+		return decimal.NewFromFloat(v.Float()), true
+	}
+
+	return decimal.Decimal{}, false
+}
+
+func describe(t reflect.Type) string {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == reflect.TypeOf(decimal.Decimal{}) {
+		return "a decimal number"
+	}
+
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+
+	return "an object"
+}
+
+func fieldError(name, format string, args ...interface{}) *model.FieldError {
+	return &model.FieldError{
+		Field: "/" + escapePointer(name),
+		Err:   fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), model.ErrInvalid),
+	}
+}
+
+// escapePointer escapes a JSON pointer reference token, see RFC 6901
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+func jsonName(sf reflect.StructField) string {
+	if sf.PkgPath != "" {
+		return ""
+	}
+
+	name := strings.Split(sf.Tag.Get("json"), ",")[0]
+	if name == "-" {
+		return ""
+	}
+
+	if name == "" {
+		return sf.Name
+	}
+
+	return name
+}
+
+// jsonFields maps the JSON names of t's fields to their index
+func jsonFields(t reflect.Type) map[string]int {
+	out := map[string]int{}
+	for i := 0; i < t.NumField(); i++ {
+		if name := jsonName(t.Field(i)); name != "" {
+			out[name] = i
+		}
+	}
+
+	return out
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// sortFieldErrors orders errs by the declaration order of the fields in t,
+// unknown fields last
+func sortFieldErrors(errs model.FieldErrors, t reflect.Type) {
+	fields := jsonFields(t)
+	rank := func(fe *model.FieldError) int {
+		if idx, ok := fields[strings.TrimPrefix(fe.Field, "/")]; ok {
+			return idx
+		}
+
+		return t.NumField()
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return rank(errs[i]) < rank(errs[j])
+	})
+}
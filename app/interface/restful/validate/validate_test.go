@@ -0,0 +1,126 @@
+package validate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+type payload struct {
+	AccountID *int             `json:"account_id" validate:"required,min=1"`
+	Amount    *decimal.Decimal `json:"amount" validate:"required,gt=0,max=1000,scale=2"`
+	Type      *string          `json:"transaction_type" validate:"required,oneof=withdraw deposit"`
+	Memo      string           `json:"memo" validate:"maxlen=5"`
+}
+
+func fields(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	var errs model.FieldErrors
+	require.True(t, errors.As(err, &errs), "got %v", err)
+
+	out := map[string]string{}
+	for _, fe := range errs {
+		assert.True(t, errors.Is(fe, model.ErrInvalid))
+		out[fe.Field] = strings.TrimSuffix(fe.Err.Error(), ": invalid")
+	}
+
+	return out
+}
+
+func TestDecodeJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		p := payload{}
+		err := DecodeJSON(strings.NewReader(`{"account_id":2,"amount":"100.50","transaction_type":"deposit","memo":"rent"}`), 1024, &p)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, *p.AccountID)
+		assert.Equal(t, "100.5", p.Amount.String())
+		assert.Equal(t, "deposit", *p.Type)
+		assert.Equal(t, "rent", p.Memo)
+	})
+
+	t.Run("every violation at once", func(t *testing.T) {
+		p := payload{}
+		err := DecodeJSON(strings.NewReader(`{"amount":-5,"transaction_type":"refund","memo":"too long","bank":"VCB","a/b":1}`), 1024, &p)
+
+		assert.Equal(t, map[string]string{
+			"/account_id":       "is required",
+			"/amount":           "must be greater than 0",
+			"/transaction_type": "must be one of withdraw, deposit",
+			"/memo":             "must have at most 5 characters",
+			"/bank":             "is not a known field",
+			"/a~1b":             "is not a known field",
+		}, fields(t, err))
+	})
+
+	t.Run("errors follow field order", func(t *testing.T) {
+		err := DecodeJSON(strings.NewReader(`{"zzz":1,"memo":"too long"}`), 1024, &payload{})
+
+		var errs model.FieldErrors
+		require.True(t, errors.As(err, &errs))
+		got := []string{}
+		for _, fe := range errs {
+			got = append(got, fe.Field)
+		}
+		assert.Equal(t, []string{"/account_id", "/amount", "/transaction_type", "/memo", "/zzz"}, got)
+	})
+
+	t.Run("wrong types and null", func(t *testing.T) {
+		err := DecodeJSON(strings.NewReader(`{"account_id":"two","amount":null,"transaction_type":1}`), 1024, &payload{})
+
+		assert.Equal(t, map[string]string{
+			"/account_id":       "must be an integer",
+			"/amount":           "is required",
+			"/transaction_type": "must be a string",
+		}, fields(t, err))
+	})
+
+	t.Run("ranges", func(t *testing.T) {
+		err := DecodeJSON(strings.NewReader(`{"account_id":0,"amount":1000.01,"transaction_type":"deposit"}`), 1024, &payload{})
+
+		assert.Equal(t, map[string]string{
+			"/account_id": "must be greater than or equal to 1",
+			"/amount":     "must be less than or equal to 1000",
+		}, fields(t, err))
+
+		err = DecodeJSON(strings.NewReader(`{"account_id":1,"amount":10.123,"transaction_type":"deposit"}`), 1024, &payload{})
+		assert.Equal(t, map[string]string{
+			"/amount": "must have at most 2 decimal places",
+		}, fields(t, err))
+	})
+
+	t.Run("not an object", func(t *testing.T) {
+		err := DecodeJSON(strings.NewReader(`[1, 2]`), 1024, &payload{})
+
+		assert.Equal(t, map[string]string{
+			"": "must be a JSON object",
+		}, fields(t, err))
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		err := DecodeJSON(strings.NewReader(`{"memo":"0123456789"}`), 10, &payload{})
+
+		assert.True(t, errors.Is(err, model.ErrPayloadTooLarge))
+	})
+}
+
+func TestStruct(t *testing.T) {
+	t.Parallel()
+
+	id := 1
+	amount := decimal.NewFromInt(-1)
+
+	assert.Equal(t, map[string]string{
+		"/amount":           "must be greater than 0",
+		"/transaction_type": "is required",
+	}, fields(t, Struct(&payload{AccountID: &id, Amount: &amount})))
+}
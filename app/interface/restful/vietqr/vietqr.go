@@ -0,0 +1,274 @@
+// Package vietqr encodes and decodes the EMVCo QR payloads of VietQR, the
+// NAPAS standard for transfers to bank accounts, so that a deposit into an
+// account can be shared as a QR code.
+package vietqr
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+// Data object ids of the payload, per EMVCo merchant presented mode
+const (
+	idFormat         = "00"
+	idInitiation     = "01"
+	idMerchant       = "38"
+	idCurrency       = "53"
+	idAmount         = "54"
+	idCountry        = "58"
+	idAdditionalData = "62"
+	idCRC            = "63"
+
+	// within the merchant account information
+	idGUID        = "00"
+	idBeneficiary = "01"
+	idService     = "02"
+
+	// within the beneficiary organization
+	idBIN     = "00"
+	idAccount = "01"
+
+	// within the additional data
+	idPurpose = "08"
+)
+
+const (
+	formatIndicator = "01"
+	// static codes leave the amount to the payer, dynamic ones have it
+	initiationStatic  = "11"
+	initiationDynamic = "12"
+	napasGUID         = "A000000727"
+	// serviceToAccount is a transfer to an account number, rather than to a
+	// card number
+	serviceToAccount = "QRIBFTTA"
+	currencyVND      = "704"
+	countryVN        = "VN"
+)
+
+// MaxMemoLength is the longest purpose of transaction of a payload
+const MaxMemoLength = 25
+
+var (
+	accountNumber = regexp.MustCompile(`^[0-9A-Za-z]{1,19}$`)
+	// memo is the printable ASCII banking apps accept
+	memo = regexp.MustCompile(`^[ -~]*$`)
+	// maxAmount fits the 13 characters of the amount field
+	maxAmount = decimal.New(9999999999999, 0)
+)
+
+// Payload is a transfer to an account. Amount is zero when the payer
+// chooses it.
+type Payload struct {
+	Bank          string
+	AccountNumber string
+	Amount        decimal.Decimal
+	Memo          string
+}
+
+// Validate reports every invalid field of p, Field is the name of the query
+// parameter of the field
+func (p Payload) Validate() error {
+	errs := model.FieldErrors{}
+	invalid := func(field string, err error) {
+		errs = append(errs, &model.FieldError{Field: field, Err: err})
+	}
+
+	if _, ok := model.BankBINs[p.Bank]; !ok {
+		invalid("bank", fmt.Errorf("%s has no NAPAS BIN: %w", p.Bank, model.ErrInvalidBank))
+	}
+
+	if !accountNumber.MatchString(p.AccountNumber) {
+		invalid("account_number", fmt.Errorf("must be 1 to 19 letters and digits: %w", model.ErrInvalid))
+	}
+
+	switch {
+	case p.Amount.IsNegative():
+		invalid("amount", fmt.Errorf("%v is negative: %w", p.Amount, model.ErrInvalidAmount))
+	case !p.Amount.Equal(p.Amount.Truncate(0)):
+		invalid("amount", fmt.Errorf("%v is not a whole number of VND: %w", p.Amount, model.ErrInvalidAmount))
+	case p.Amount.GreaterThan(maxAmount):
+		invalid("amount", fmt.Errorf("%v is over %v: %w", p.Amount, maxAmount, model.ErrInvalidAmount))
+	}
+
+	if len(p.Memo) > MaxMemoLength || !memo.MatchString(p.Memo) {
+		invalid("memo", fmt.Errorf("must be at most %d ASCII characters, without diacritics: %w", MaxMemoLength, model.ErrInvalid))
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// field renders a data object: its id, the length of its value on two
+// digits, then the value
+func field(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+// Encode renders p, which has to be valid, as a VietQR payload ending with
+// its CRC
+func Encode(p Payload) (string, error) {
+	if err := p.Validate(); err != nil {
+		return "", err
+	}
+
+	initiation := initiationStatic
+	if p.Amount.IsPositive() {
+		initiation = initiationDynamic
+	}
+
+	b := &strings.Builder{}
+	b.WriteString(field(idFormat, formatIndicator))
+	b.WriteString(field(idInitiation, initiation))
+	b.WriteString(field(idMerchant,
+		field(idGUID, napasGUID)+
+			field(idBeneficiary, field(idBIN, model.BankBINs[p.Bank])+field(idAccount, p.AccountNumber))+
+			field(idService, serviceToAccount)))
+	b.WriteString(field(idCurrency, currencyVND))
+	if p.Amount.IsPositive() {
+		b.WriteString(field(idAmount, p.Amount.String()))
+	}
+	b.WriteString(field(idCountry, countryVN))
+	if p.Memo != "" {
+		b.WriteString(field(idAdditionalData, field(idPurpose, p.Memo)))
+	}
+
+	// the CRC covers its own id and length
+	b.WriteString(idCRC + "04")
+	b.WriteString(fmt.Sprintf("%04X", crc16(b.String())))
+
+	return b.String(), nil
+}
+
+// crc16 is the CRC-16/CCITT-FALSE of s: polynomial 0x1021, initial value
+// 0xFFFF
+func crc16(s string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+// fields splits s into its data objects by id
+func fields(s string) (map[string]string, error) {
+	out := map[string]string{}
+	for len(s) > 0 {
+		if len(s) < 4 {
+			return nil, fmt.Errorf("%q is not a data object: %w", s, model.ErrInvalid)
+		}
+
+		id := s[:2]
+		n, err := strconv.Atoi(s[2:4])
+		if err != nil || n > len(s)-4 {
+			return nil, fmt.Errorf("data object %s has an invalid length %q: %w", id, s[2:4], model.ErrInvalid)
+		}
+
+		out[id] = s[4 : 4+n]
+		s = s[4+n:]
+	}
+
+	return out, nil
+}
+
+// Decode reads a VietQR payload of a transfer to an account of one of the
+// banks, checking its CRC
+func Decode(s string) (Payload, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 8 || s[len(s)-8:len(s)-4] != idCRC+"04" {
+		return Payload{}, fmt.Errorf("no CRC at the end of the payload: %w", model.ErrInvalid)
+	}
+
+	crc, err := strconv.ParseUint(s[len(s)-4:], 16, 16)
+	if err != nil || uint16(crc) != crc16(s[:len(s)-4]) {
+		return Payload{}, fmt.Errorf("CRC %q does not match the payload: %w", s[len(s)-4:], model.ErrInvalid)
+	}
+
+	top, err := fields(s[:len(s)-8])
+	if err != nil {
+		return Payload{}, err
+	}
+
+	if top[idFormat] != formatIndicator {
+		return Payload{}, fmt.Errorf("payload format indicator %q is not %s: %w", top[idFormat], formatIndicator, model.ErrInvalid)
+	}
+
+	merchant, err := fields(top[idMerchant])
+	if err != nil {
+		return Payload{}, err
+	}
+
+	if merchant[idGUID] != napasGUID {
+		return Payload{}, fmt.Errorf("not a VietQR payload, merchant account information %q: %w", merchant[idGUID], model.ErrInvalid)
+	}
+
+	if service := merchant[idService]; service != serviceToAccount {
+		return Payload{}, fmt.Errorf("service %q is not a transfer to an account: %w", service, model.ErrInvalid)
+	}
+
+	beneficiary, err := fields(merchant[idBeneficiary])
+	if err != nil {
+		return Payload{}, err
+	}
+
+	p := Payload{AccountNumber: beneficiary[idAccount]}
+	for bank, bin := range model.BankBINs {
+		if bin == beneficiary[idBIN] {
+			p.Bank = bank
+		}
+	}
+	if p.Bank == "" {
+		return Payload{}, fmt.Errorf("BIN %q is not one of a known bank: %w", beneficiary[idBIN], model.ErrInvalidBank)
+	}
+
+	if ccy, ok := top[idCurrency]; ok && ccy != currencyVND {
+		return Payload{}, fmt.Errorf("currency %q is not VND: %w", ccy, model.ErrInvalid)
+	}
+
+	if amount, ok := top[idAmount]; ok {
+		if p.Amount, err = decimal.NewFromString(amount); err != nil {
+			return Payload{}, fmt.Errorf("amount %q is not a number: %w", amount, model.ErrInvalidAmount)
+		}
+	}
+
+	if data, ok := top[idAdditionalData]; ok {
+		additional, err := fields(data)
+		if err != nil {
+			return Payload{}, err
+		}
+		p.Memo = additional[idPurpose]
+	}
+
+	if err := p.Validate(); err != nil {
+		return Payload{}, fmt.Errorf("%v: %w", err, model.ErrInvalid)
+	}
+
+	return p, nil
+}
+
+// CreateTransaction prefills the deposit into accountID paid by scanning
+// p. Amount is zero when the payer has to choose it.
+func (p Payload) CreateTransaction(accountID int) usecase.CreateTransaction {
+	return usecase.CreateTransaction{
+		AccountID:       accountID,
+		Amount:          p.Amount,
+		TransactionType: model.TransactionTypeDeposit,
+	}
+}
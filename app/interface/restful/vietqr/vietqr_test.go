@@ -0,0 +1,128 @@
+package vietqr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+func TestCRC16(t *testing.T) {
+	t.Parallel()
+
+	// check value of CRC-16/CCITT-FALSE
+	assert.Equal(t, uint16(0x29B1), crc16("123456789"))
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("dynamic", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := Encode(Payload{Bank: "VCB", AccountNumber: "0071000123456", Amount: decimal.NewFromInt(50000), Memo: "Invoice 12"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "000201"+"010212"+
+			"3857"+"0010A000000727"+"0127"+"0006970436"+"01130071000123456"+"0208QRIBFTTA"+
+			"5303704"+"540550000"+"5802VN"+"6214"+"0810Invoice 12"+"6304", s[:len(s)-4])
+		assert.Regexp(t, `^[0-9A-F]{4}$`, s[len(s)-4:])
+	})
+
+	t.Run("static", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := Encode(Payload{Bank: "ACB", AccountNumber: "190123"})
+		require.NoError(t, err)
+
+		assert.Contains(t, s, "010211")
+		assert.Contains(t, s, "0006970416")
+		assert.NotContains(t, s, "5405")
+		assert.NotContains(t, s, "6208")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Encode(Payload{Bank: "XYZ", AccountNumber: "19-01", Amount: decimal.RequireFromString("10.5"), Memo: "Tiền nhà"})
+
+		fields := []string{}
+		errs := model.FieldErrors{}
+		require.True(t, errors.As(err, &errs), err)
+		for _, e := range errs {
+			fields = append(fields, e.Field)
+		}
+		assert.Equal(t, []string{"bank", "account_number", "amount", "memo"}, fields)
+	})
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		for _, p := range []Payload{
+			{Bank: "VIB", AccountNumber: "200456", Amount: decimal.NewFromInt(120000), Memo: "Rent"},
+			{Bank: "VCB", AccountNumber: "0071000123456"},
+		} {
+			s, err := Encode(p)
+			require.NoError(t, err)
+
+			got, err := Decode(s)
+			require.NoError(t, err)
+			assert.Equal(t, p.Bank, got.Bank)
+			assert.Equal(t, p.AccountNumber, got.AccountNumber)
+			assert.True(t, p.Amount.Equal(got.Amount), got.Amount)
+			assert.Equal(t, p.Memo, got.Memo)
+		}
+	})
+
+	t.Run("prefilled transaction", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := Encode(Payload{Bank: "VCB", AccountNumber: "0071000123456", Amount: decimal.NewFromInt(50000)})
+		require.NoError(t, err)
+
+		p, err := Decode(s)
+		require.NoError(t, err)
+
+		tran := p.CreateTransaction(7)
+		assert.Equal(t, 7, tran.AccountID)
+		assert.Equal(t, "50000", tran.Amount.String())
+		assert.Equal(t, model.TransactionTypeDeposit, tran.TransactionType)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Parallel()
+
+		valid, err := Encode(Payload{Bank: "VCB", AccountNumber: "0071000123456"})
+		require.NoError(t, err)
+
+		// the BIN of a bank the service does not know, with a valid CRC
+		unknown := "00020101021138540010A00000072701240006970422011000123456780208QRIBFTTA53037045802VN6304"
+		unknown += fmtCRC(unknown)
+
+		for name, s := range map[string]string{
+			"empty":        "",
+			"wrong crc":    valid[:len(valid)-4] + "0000",
+			"altered":      "000202" + valid[6:],
+			"not vietqr":   "hello",
+			"unknown bank": unknown,
+			"bad length":   "000201019912" + "6304" + fmtCRC("000201019912"+"6304"),
+		} {
+			_, err := Decode(s)
+			assert.Error(t, err, name)
+			assert.True(t, errors.Is(err, model.ErrInvalid) || errors.Is(err, model.ErrInvalidBank), "%s: %v", name, err)
+		}
+	})
+}
+
+func fmtCRC(s string) string {
+	return fmt.Sprintf("%04X", crc16(s))
+}
@@ -0,0 +1,123 @@
+// Package rpc serves the use cases over gRPC, next to the REST API of
+// package restful. Errors carry the same stable codes as the REST problems.
+package rpc
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+
+	"go-prj-skeleton/app/interface/restful/middleware"
+	"go-prj-skeleton/app/interface/rpc/transactionpb"
+	"go-prj-skeleton/app/ratelimit"
+	"go-prj-skeleton/app/registry"
+	"go-prj-skeleton/app/requestid"
+	"go-prj-skeleton/app/usecase"
+)
+
+// maxRequestIDLength caps IDs propagated from clients
+const maxRequestIDLength = 128
+
+// maxMessageBytes limits the size of request messages, like the REST body limit
+const maxMessageBytes = 64 << 10
+
+// rateLimitGroups are the rate limit groups of the methods taking a token,
+// those writing transactions like the REST routes of the group
+var rateLimitGroups = map[string]string{
+	"/skeleton.transaction.v1.TransactionService/CreateTransaction": ratelimit.GroupTransactions,
+	"/skeleton.transaction.v1.TransactionService/UpdateTransaction": ratelimit.GroupTransactions,
+	"/skeleton.transaction.v1.TransactionService/DeleteTransaction": ratelimit.GroupTransactions,
+}
+
+// Server builds the gRPC server of the services resolved from ctn
+func Server(ctn *registry.Container) *grpc.Server {
+	return newServer(
+		ctn.Resolve("user-usecase").(usecase.UserUsecase),
+		ctn.Resolve("rate-limiter").(*ratelimit.Limiter),
+		ctn.Resolve("rate-limit-clients").(*ratelimit.Clients),
+	)
+}
+
+func newServer(userUsecase usecase.UserUsecase, limiter *ratelimit.Limiter, clients *ratelimit.Clients) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.MaxRecvMsgSize(maxMessageBytes),
+		grpc.ChainUnaryInterceptor(unaryRequestID, unaryRateLimit(limiter, clients)),
+		grpc.StreamInterceptor(streamRequestID),
+	)
+
+	transactionpb.RegisterTransactionServiceServer(server, NewTransactionServer(userUsecase))
+	reflection.Register(server)
+
+	return server
+}
+
+// withRequestID propagates the x-request-id metadata of the call, or
+// generates one, and sends it back in the response header.
+func withRequestID(ctx context.Context) context.Context {
+	id := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(requestid.Header); len(ids) > 0 {
+			id = ids[0]
+		}
+	}
+
+	if id == "" || len(id) > maxRequestIDLength {
+		id = requestid.New()
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(strings.ToLower(requestid.Header), id))
+
+	return requestid.NewContext(ctx, id)
+}
+
+func unaryRequestID(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	return handler(withRequestID(ctx), req)
+}
+
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s requestIDStream) Context() context.Context {
+	return s.ctx
+}
+
+func streamRequestID(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, requestIDStream{ss, withRequestID(ss.Context())})
+}
+
+// unaryRateLimit takes a token for the methods of rateLimitGroups from the
+// buckets of the client, identified by its x-api-key and x-forwarded-for
+// metadata like the REST clients by their headers. A call over a limit is
+// ResourceExhausted.
+func unaryRateLimit(limiter *ratelimit.Limiter, clients *ratelimit.Clients) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		group, ok := rateLimitGroups[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		key := ""
+		if keys := md.Get(middleware.APIKeyHeader); len(keys) > 0 {
+			key = keys[0]
+		}
+
+		remoteAddr := ""
+		if p, ok := peer.FromContext(ctx); ok {
+			remoteAddr = p.Addr.String()
+		}
+
+		client := clients.Identify("", key, remoteAddr, md.Get(middleware.ForwardedForHeader))
+		if err := middleware.Limit(ctx, limiter, group, client); err != nil {
+			return nil, Error(ctx, err)
+		}
+
+		return handler(ctx, req)
+	}
+}
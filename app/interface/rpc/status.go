@@ -0,0 +1,103 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/logger"
+	"go-prj-skeleton/app/requestid"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail attached to errors
+const ErrorDomain = "go-prj-skeleton"
+
+// grpcCodes maps the error codes to gRPC codes, like package problem maps them
+// to HTTP statuses
+var grpcCodes = map[model.ErrorCode]codes.Code{
+	model.CodeInvalid:                codes.InvalidArgument,
+	model.CodeInvalidAmount:          codes.InvalidArgument,
+	model.CodeInvalidBank:            codes.InvalidArgument,
+	model.CodeInvalidTransactionType: codes.InvalidArgument,
+	model.CodeUnauthorized:           codes.Unauthenticated,
+	model.CodeNotFound:               codes.NotFound,
+	model.CodeMethodNotAllowed:       codes.Unimplemented,
+	model.CodeConflict:               codes.AlreadyExists,
+	model.CodePayloadTooLarge:        codes.ResourceExhausted,
+	model.CodeInsufficientFunds:      codes.FailedPrecondition,
+	model.CodeRateLimited:            codes.ResourceExhausted,
+	model.CodeInternal:               codes.Internal,
+}
+
+// Code returns the gRPC code of code
+func Code(code model.ErrorCode) codes.Code {
+	if c, ok := grpcCodes[code]; ok {
+		return c
+	}
+
+	return codes.Internal
+}
+
+// Error converts err to a gRPC status error. The stable error code and the
+// request ID travel in an ErrorInfo detail, invalid fields in a BadRequest
+// detail named after the proto fields.
+func Error(ctx context.Context, err error) error {
+	code := model.ErrorCodeOf(err)
+	grpcCode := Code(code)
+
+	msg := err.Error()
+	if grpcCode == codes.Internal {
+		logger.FromContext(ctx).WithError(err).Error("call failed")
+		msg = "the server could not process the request, quote the request_id when reporting it"
+	}
+
+	info := &errdetails.ErrorInfo{
+		Reason:   string(code),
+		Domain:   ErrorDomain,
+		Metadata: map[string]string{},
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		info.Metadata["request_id"] = id
+	}
+
+	st, detailErr := status.New(grpcCode, msg).WithDetails(info)
+	if detailErr != nil {
+		return status.Error(grpcCode, msg)
+	}
+
+	if violations := fieldViolations(err); len(violations) > 0 {
+		if withFields, detailErr := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations}); detailErr == nil {
+			st = withFields
+		}
+	}
+
+	return st.Err()
+}
+
+func fieldViolations(err error) []*errdetails.BadRequest_FieldViolation {
+	var fieldErrs model.FieldErrors
+	if !errors.As(err, &fieldErrs) {
+		var fieldErr *model.FieldError
+		if !errors.As(err, &fieldErr) {
+			return nil
+		}
+
+		fieldErrs = model.FieldErrors{fieldErr}
+	}
+
+	out := make([]*errdetails.BadRequest_FieldViolation, len(fieldErrs))
+	for i, fe := range fieldErrs {
+		out[i] = &errdetails.BadRequest_FieldViolation{
+			// validate reports JSON pointers, proto fields have no leading slash
+			Field:       strings.TrimPrefix(fe.Field, "/"),
+			Description: fe.Err.Error(),
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,275 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/restful/validate"
+	"go-prj-skeleton/app/interface/rpc/transactionpb"
+	"go-prj-skeleton/app/usecase"
+)
+
+// createTransaction and updateTransaction carry the same validation rules
+// as the REST request bodies, a nil field is a missing one
+type createTransaction struct {
+	AccountID       *int                   `json:"account_id" validate:"required,min=1"`
+	Amount          *decimal.Decimal       `json:"amount" validate:"required,gt=0,max=99999999999999999.99,scale=2"`
+	TransactionType *model.TransactionType `json:"transaction_type" validate:"required,oneof=withdraw deposit"`
+}
+
+type updateTransaction struct {
+	Amount *decimal.Decimal `json:"amount" validate:"required,gt=0,max=99999999999999999.99,scale=2"`
+}
+
+var transactionTypes = map[transactionpb.TransactionType]model.TransactionType{
+	transactionpb.TransactionType_TRANSACTION_TYPE_WITHDRAW: model.TransactionTypeWithdraw,
+	transactionpb.TransactionType_TRANSACTION_TYPE_DEPOSIT:  model.TransactionTypeDeposit,
+}
+
+type transactionServer struct {
+	transactionpb.UnimplementedTransactionServiceServer
+
+	userUsecase usecase.UserUsecase
+}
+
+func NewTransactionServer(userUsecase usecase.UserUsecase) *transactionServer {
+	return &transactionServer{
+		userUsecase: userUsecase,
+	}
+}
+
+func (s *transactionServer) ListTransactions(ctx context.Context, req *transactionpb.ListTransactionsRequest) (*transactionpb.ListTransactionsResponse, error) {
+	trans, err := s.findTransactions(ctx, req)
+	if err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	out := &transactionpb.ListTransactionsResponse{
+		Transactions: make([]*transactionpb.Transaction, len(trans)),
+	}
+	for i := range trans {
+		if out.Transactions[i], err = toTransaction(trans[i]); err != nil {
+			return nil, Error(ctx, err)
+		}
+	}
+
+	return out, nil
+}
+
+func (s *transactionServer) StreamTransactions(req *transactionpb.ListTransactionsRequest, stream transactionpb.TransactionService_StreamTransactionsServer) error {
+	ctx := stream.Context()
+
+	userID, accountID, err := listParams(req)
+	if err != nil {
+		return Error(ctx, err)
+	}
+
+	// the transactions are sent as they are read, the stream error is
+	// returned as is rather than as a domain error
+	var sendErr error
+	_, err = s.userUsecase.EachStatementLine(ctx, userID, usecase.StatementFilter{AccountID: accountID}, func(line usecase.StatementLine) error {
+		t, err := toTransaction(line.Transaction)
+		if err != nil {
+			return err
+		}
+
+		if sendErr = stream.Send(t); sendErr != nil {
+			return sendErr
+		}
+
+		return nil
+	})
+	if sendErr != nil {
+		return sendErr
+	}
+	if err != nil {
+		return Error(ctx, err)
+	}
+
+	return nil
+}
+
+func (s *transactionServer) CreateTransaction(ctx context.Context, req *transactionpb.CreateTransactionRequest) (*transactionpb.Transaction, error) {
+	userID, err := toInt("user_id", req.UserId)
+	if err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	payl := createTransaction{}
+	if req.AccountId != 0 {
+		accountID, err := toInt("account_id", req.AccountId)
+		if err != nil {
+			return nil, Error(ctx, err)
+		}
+
+		payl.AccountID = &accountID
+	}
+
+	if payl.Amount, err = parseAmount(req.Amount); err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	if req.TransactionType != transactionpb.TransactionType_TRANSACTION_TYPE_UNSPECIFIED {
+		tt, ok := transactionTypes[req.TransactionType]
+		if !ok {
+			tt = model.TransactionType(req.TransactionType.String())
+		}
+
+		payl.TransactionType = &tt
+	}
+
+	if err := validate.Struct(&payl); err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	created, err := s.userUsecase.CreateTransaction(ctx, userID, usecase.CreateTransaction{
+		AccountID:       *payl.AccountID,
+		Amount:          *payl.Amount,
+		TransactionType: *payl.TransactionType,
+	})
+	if err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	t, err := toTransaction(*created)
+	if err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	return t, nil
+}
+
+func (s *transactionServer) UpdateTransaction(ctx context.Context, req *transactionpb.UpdateTransactionRequest) (*transactionpb.Transaction, error) {
+	userID, err := toInt("user_id", req.UserId)
+	if err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	tranID, err := toInt("transaction_id", req.TransactionId)
+	if err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	payl := updateTransaction{}
+	if payl.Amount, err = parseAmount(req.Amount); err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	if err := validate.Struct(&payl); err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	updated, err := s.userUsecase.UpdateTransaction(ctx, userID, tranID, usecase.UpdateTransaction{
+		Amount: *payl.Amount,
+	})
+	if err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	t, err := toTransaction(*updated)
+	if err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	return t, nil
+}
+
+func (s *transactionServer) DeleteTransaction(ctx context.Context, req *transactionpb.DeleteTransactionRequest) (*transactionpb.DeleteTransactionResponse, error) {
+	userID, err := toInt("user_id", req.UserId)
+	if err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	tranID, err := toInt("transaction_id", req.TransactionId)
+	if err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	if err := s.userUsecase.DeleteTransaction(ctx, userID, tranID); err != nil {
+		return nil, Error(ctx, err)
+	}
+
+	return &transactionpb.DeleteTransactionResponse{}, nil
+}
+
+func (s *transactionServer) findTransactions(ctx context.Context, req *transactionpb.ListTransactionsRequest) ([]usecase.Transaction, error) {
+	userID, accountID, err := listParams(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.userUsecase.FindTransactions(ctx, userID, accountID)
+}
+
+// listParams returns the user and the optional account of req
+func listParams(req *transactionpb.ListTransactionsRequest) (int, *int, error) {
+	userID, err := toInt("user_id", req.UserId)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var accountID *int
+	if req.AccountId != 0 {
+		id, err := toInt("account_id", req.AccountId)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		accountID = &id
+	}
+
+	return userID, accountID, nil
+}
+
+// toInt checks that the id field name fits the 32 bit ids of the REST API
+func toInt(name string, v int64) (int, error) {
+	if v < math.MinInt32 || v > math.MaxInt32 {
+		return 0, &model.FieldError{Field: name, Err: fmt.Errorf("%v is out of range: %w", v, model.ErrInvalid)}
+	}
+
+	return int(v), nil
+}
+
+// parseAmount parses a decimal amount, an empty string is a missing amount
+func parseAmount(s string) (*decimal.Decimal, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return nil, model.FieldErrors{
+			{Field: "/amount", Err: fmt.Errorf("must be a decimal number: %w", model.ErrInvalid)},
+		}
+	}
+
+	return &d, nil
+}
+
+func toTransaction(t usecase.Transaction) (*transactionpb.Transaction, error) {
+	createdAt, err := model.ParseCreatedAt(t.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("transaction[%v] created_at: %w", t.ID, err)
+	}
+
+	tt := transactionpb.TransactionType_TRANSACTION_TYPE_UNSPECIFIED
+	for k, v := range transactionTypes {
+		if v == t.TransactionType {
+			tt = k
+		}
+	}
+
+	return &transactionpb.Transaction{
+		Id:              int64(t.ID),
+		AccountId:       int64(t.AccountID),
+		Amount:          t.Amount.StringFixed(2),
+		Bank:            t.Bank,
+		TransactionType: tt,
+		CreatedAt:       timestamppb.New(createdAt),
+	}, nil
+}
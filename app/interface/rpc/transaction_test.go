@@ -0,0 +1,226 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+	"go-prj-skeleton/app/interface/rpc/transactionpb"
+	"go-prj-skeleton/app/ratelimit"
+	"go-prj-skeleton/app/usecase"
+)
+
+// newTestClient serves the transactions of a memory store, limited by
+// limits for the clients of the API key "partner"
+func newTestClient(t *testing.T, limits string) transactionpb.TransactionServiceClient {
+	store := memory.NewStore()
+	store.AddUser(model.User{ID: 1, Name: "Alice"})
+	store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB"})
+
+	l, err := ratelimit.ParseLimits(limits)
+	require.NoError(t, err)
+
+	server := newServer(usecase.NewUserUsecase(
+		memory.NewUserRepo(store),
+		memory.NewAccountRepo(store),
+		memory.NewTransactionRepo(store),
+	), ratelimit.NewLimiter(ratelimit.NewMemoryStore(), l), ratelimit.NewClients([]string{"partner"}, nil))
+
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return transactionpb.NewTransactionServiceClient(conn)
+}
+
+func TestTransactionServer(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, "")
+	ctx := context.Background()
+
+	created, err := client.CreateTransaction(ctx, &transactionpb.CreateTransactionRequest{
+		UserId:          1,
+		AccountId:       1,
+		Amount:          "100.5",
+		TransactionType: transactionpb.TransactionType_TRANSACTION_TYPE_DEPOSIT,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "100.50", created.Amount)
+	assert.Equal(t, "VCB", created.Bank)
+	assert.Equal(t, transactionpb.TransactionType_TRANSACTION_TYPE_DEPOSIT, created.TransactionType)
+	assert.NotNil(t, created.CreatedAt)
+
+	updated, err := client.UpdateTransaction(ctx, &transactionpb.UpdateTransactionRequest{
+		UserId:        1,
+		TransactionId: created.Id,
+		Amount:        "20",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "20.00", updated.Amount)
+
+	list, err := client.ListTransactions(ctx, &transactionpb.ListTransactionsRequest{UserId: 1, AccountId: 1})
+	require.NoError(t, err)
+	require.Len(t, list.Transactions, 1)
+	assert.Equal(t, created.Id, list.Transactions[0].Id)
+
+	stream, err := client.StreamTransactions(ctx, &transactionpb.ListTransactionsRequest{UserId: 1})
+	require.NoError(t, err)
+
+	streamed := []*transactionpb.Transaction{}
+	for {
+		tran, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		streamed = append(streamed, tran)
+	}
+	require.Len(t, streamed, 1)
+	assert.Equal(t, "20.00", streamed[0].Amount)
+
+	_, err = client.DeleteTransaction(ctx, &transactionpb.DeleteTransactionRequest{UserId: 1, TransactionId: created.Id})
+	require.NoError(t, err)
+
+	list, err = client.ListTransactions(ctx, &transactionpb.ListTransactionsRequest{UserId: 1})
+	require.NoError(t, err)
+	assert.Empty(t, list.Transactions)
+}
+
+func TestTransactionServer_RateLimit(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, "transactions.key=1/m:1")
+	req := &transactionpb.CreateTransactionRequest{
+		UserId:          1,
+		AccountId:       1,
+		Amount:          "10",
+		TransactionType: transactionpb.TransactionType_TRANSACTION_TYPE_DEPOSIT,
+	}
+
+	partner := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "partner")
+	_, err := client.CreateTransaction(partner, req)
+	require.NoError(t, err)
+
+	_, err = client.CreateTransaction(partner, req)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err), err)
+
+	// reads are not limited, nor the clients of unknown keys
+	_, err = client.ListTransactions(partner, &transactionpb.ListTransactionsRequest{UserId: 1})
+	assert.NoError(t, err)
+
+	unknown := metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "forged")
+	_, err = client.CreateTransaction(unknown, req)
+	assert.NoError(t, err)
+}
+
+func TestTransactionServer_Errors(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, "")
+
+	cases := []struct {
+		name   string
+		call   func(ctx context.Context) error
+		code   codes.Code
+		reason model.ErrorCode
+		fields []string
+	}{
+		{"list for unknown user", func(ctx context.Context) error {
+			_, err := client.ListTransactions(ctx, &transactionpb.ListTransactionsRequest{UserId: 2})
+			return err
+		}, codes.NotFound, model.CodeNotFound, nil},
+		{"stream for unknown user", func(ctx context.Context) error {
+			stream, err := client.StreamTransactions(ctx, &transactionpb.ListTransactionsRequest{UserId: 2})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return err
+		}, codes.NotFound, model.CodeNotFound, nil},
+		{"stream for unknown account", func(ctx context.Context) error {
+			stream, err := client.StreamTransactions(ctx, &transactionpb.ListTransactionsRequest{UserId: 1, AccountId: 99})
+			if err != nil {
+				return err
+			}
+			_, err = stream.Recv()
+			return err
+		}, codes.NotFound, model.CodeNotFound, nil},
+		{"create with missing fields", func(ctx context.Context) error {
+			_, err := client.CreateTransaction(ctx, &transactionpb.CreateTransactionRequest{UserId: 1})
+			return err
+		}, codes.InvalidArgument, model.CodeInvalid, []string{"account_id", "amount", "transaction_type"}},
+		{"create with malformed amount", func(ctx context.Context) error {
+			_, err := client.CreateTransaction(ctx, &transactionpb.CreateTransactionRequest{
+				UserId: 1, AccountId: 1, Amount: "ten", TransactionType: transactionpb.TransactionType_TRANSACTION_TYPE_DEPOSIT,
+			})
+			return err
+		}, codes.InvalidArgument, model.CodeInvalid, []string{"amount"}},
+		{"create with unknown type", func(ctx context.Context) error {
+			_, err := client.CreateTransaction(ctx, &transactionpb.CreateTransactionRequest{
+				UserId: 1, AccountId: 1, Amount: "10", TransactionType: 7,
+			})
+			return err
+		}, codes.InvalidArgument, model.CodeInvalid, []string{"transaction_type"}},
+		{"update with out of range id", func(ctx context.Context) error {
+			_, err := client.UpdateTransaction(ctx, &transactionpb.UpdateTransactionRequest{UserId: 1, TransactionId: 1 << 40, Amount: "1"})
+			return err
+		}, codes.InvalidArgument, model.CodeInvalid, []string{"transaction_id"}},
+		{"update unknown transaction", func(ctx context.Context) error {
+			_, err := client.UpdateTransaction(ctx, &transactionpb.UpdateTransactionRequest{UserId: 1, TransactionId: 10, Amount: "1"})
+			return err
+		}, codes.NotFound, model.CodeNotFound, nil},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-"+c.name)
+			err := c.call(ctx)
+			require.Error(t, err)
+
+			st := status.Convert(err)
+			assert.Equal(t, c.code, st.Code())
+
+			fields := []string{}
+			for _, d := range st.Details() {
+				switch d := d.(type) {
+				case *errdetails.ErrorInfo:
+					assert.Equal(t, string(c.reason), d.Reason)
+					assert.Equal(t, ErrorDomain, d.Domain)
+					assert.Equal(t, "req-"+c.name, d.Metadata["request_id"])
+				case *errdetails.BadRequest:
+					for _, v := range d.FieldViolations {
+						fields = append(fields, v.Field)
+					}
+				}
+			}
+			assert.ElementsMatch(t, c.fields, fields)
+		})
+	}
+}
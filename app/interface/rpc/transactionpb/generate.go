@@ -0,0 +1,5 @@
+// Package transactionpb holds the protobuf messages and gRPC stubs of
+// TransactionService, generated from transaction.proto.
+package transactionpb
+
+//go:generate protoc --go_out=paths=source_relative:. --go-grpc_out=paths=source_relative:. transaction.proto
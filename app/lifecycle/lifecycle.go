@@ -0,0 +1,154 @@
+// Package lifecycle starts the subsystems of the service in the order they
+// depend on each other and stops them in reverse order on SIGINT or SIGTERM,
+// also when it is received while they start.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"go-prj-skeleton/app/logger"
+)
+
+// Hook starts and stops one subsystem, every func is optional
+type Hook struct {
+	Name string
+
+	// OnStart prepares the subsystem, e.g. opens a pool or a listener. The
+	// next hooks start once it returns.
+	OnStart func(ctx context.Context) error
+
+	// Serve runs in its own goroutine until OnStop makes it return, e.g. a
+	// server accepting connections. An error stops the service.
+	Serve func() error
+
+	// OnStop stops the subsystem, draining its work until ctx is done
+	OnStop func(ctx context.Context) error
+}
+
+// Manager runs the hooks registered with it
+type Manager struct {
+	timeout time.Duration
+	hooks   []Hook
+	started int
+	errs    chan error
+	signals chan os.Signal
+}
+
+// New returns a Manager giving the OnStop hooks timeout to run
+func New(timeout time.Duration) *Manager {
+	return &Manager{
+		timeout: timeout,
+		errs:    make(chan error, 1),
+		signals: make(chan os.Signal, 1),
+	}
+}
+
+// Register appends h, it starts after the hooks registered before and
+// stops before them
+func (m *Manager) Register(h Hook) {
+	m.hooks = append(m.hooks, h)
+}
+
+// Run starts the hooks, waits for SIGINT, SIGTERM, ctx to be done or a
+// Serve hook to fail, then stops the started hooks. A signal received while
+// the hooks start stops those started so far. It returns the error that
+// stopped the service, if any, or else the first one of the OnStop hooks.
+func (m *Manager) Run(ctx context.Context) error {
+	signal.Notify(m.signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(m.signals)
+
+	interrupted, err := m.start(ctx)
+	if err == nil && !interrupted {
+		err = m.wait(ctx)
+	}
+
+	if stopErr := m.stop(); err == nil {
+		err = stopErr
+	}
+
+	return err
+}
+
+// start runs the OnStart hooks in order, interrupted tells a signal was
+// received before they all ran
+func (m *Manager) start(ctx context.Context) (interrupted bool, err error) {
+	for _, h := range m.hooks {
+		select {
+		case sig := <-m.signals:
+			logger.L().Infof("received %v while starting, shutting down", sig)
+			return true, nil
+		default:
+		}
+
+		if h.OnStart != nil {
+			if err := h.OnStart(ctx); err != nil {
+				return false, fmt.Errorf("start %s: %w", h.Name, err)
+			}
+		}
+		m.started++
+
+		if h.Serve != nil {
+			go m.serve(h)
+		}
+
+		logger.L().WithField("hook", h.Name).Debug("started")
+	}
+
+	return false, nil
+}
+
+func (m *Manager) serve(h Hook) {
+	if err := h.Serve(); err != nil {
+		select {
+		case m.errs <- fmt.Errorf("%s: %w", h.Name, err):
+		default:
+			// the service is already stopping
+			logger.L().WithError(err).WithField("hook", h.Name).Error("serve")
+		}
+	}
+}
+
+func (m *Manager) wait(ctx context.Context) error {
+	select {
+	case err := <-m.errs:
+		return err
+	case sig := <-m.signals:
+		logger.L().Infof("received %v, shutting down", sig)
+	case <-ctx.Done():
+	}
+
+	return nil
+}
+
+// stop runs the OnStop hooks of the started hooks in reverse order. They
+// all run even once the timeout is over, to release what they hold.
+func (m *Manager) stop() error {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	var first error
+	for i := m.started - 1; i >= 0; i-- {
+		h := m.hooks[i]
+		if h.OnStop == nil {
+			continue
+		}
+
+		if err := h.OnStop(ctx); err != nil {
+			logger.L().WithError(err).WithField("hook", h.Name).Error("stop")
+			if first == nil {
+				first = fmt.Errorf("stop %s: %w", h.Name, err)
+			}
+			continue
+		}
+
+		logger.L().WithField("hook", h.Name).Debug("stopped")
+	}
+	m.started = 0
+
+	return first
+}
@@ -0,0 +1,113 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder records the calls of the hooks in order
+type recorder []string
+
+func (r *recorder) hook(name string, startErr error) Hook {
+	return Hook{
+		Name: name,
+		OnStart: func(context.Context) error {
+			*r = append(*r, "start "+name)
+			return startErr
+		},
+		OnStop: func(context.Context) error {
+			*r = append(*r, "stop "+name)
+			return nil
+		},
+	}
+}
+
+func TestManager_Run(t *testing.T) {
+	t.Run("stops in reverse order", func(t *testing.T) {
+		calls := recorder{}
+		m := New(time.Second)
+		m.Register(calls.hook("db", nil))
+		m.Register(calls.hook("container", nil))
+		m.Register(calls.hook("server", nil))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		require.NoError(t, m.Run(ctx))
+		assert.Equal(t, recorder{"start db", "start container", "start server", "stop server", "stop container", "stop db"}, calls)
+	})
+
+	t.Run("stops the started hooks when one fails to start", func(t *testing.T) {
+		calls := recorder{}
+		m := New(time.Second)
+		m.Register(calls.hook("db", nil))
+		m.Register(calls.hook("container", errors.New("unknown backend")))
+		m.Register(calls.hook("server", nil))
+
+		err := m.Run(context.Background())
+		assert.EqualError(t, err, "start container: unknown backend")
+		assert.Equal(t, recorder{"start db", "start container", "stop db"}, calls)
+	})
+
+	t.Run("a signal while starting stops the started hooks", func(t *testing.T) {
+		calls := recorder{}
+		m := New(time.Second)
+		m.Register(calls.hook("db", nil))
+		m.Register(Hook{
+			Name: "container",
+			OnStart: func(context.Context) error {
+				calls = append(calls, "start container")
+				m.signals <- syscall.SIGTERM
+				return nil
+			},
+		})
+		m.Register(calls.hook("server", nil))
+
+		require.NoError(t, m.Run(context.Background()))
+		assert.Equal(t, recorder{"start db", "start container", "stop db"}, calls)
+	})
+
+	t.Run("a failed serve stops the service", func(t *testing.T) {
+		stopped := make(chan struct{})
+		m := New(time.Second)
+		m.Register(Hook{
+			Name:  "server",
+			Serve: func() error { return errors.New("address already in use") },
+			OnStop: func(context.Context) error {
+				close(stopped)
+				return nil
+			},
+		})
+
+		err := m.Run(context.Background())
+		assert.EqualError(t, err, "server: address already in use")
+		select {
+		case <-stopped:
+		default:
+			t.Error("the server was not stopped")
+		}
+	})
+
+	t.Run("stop hooks drain within the timeout", func(t *testing.T) {
+		m := New(10 * time.Millisecond)
+		m.Register(Hook{
+			Name: "server",
+			OnStop: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := m.Run(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/sirupsen/logrus"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/requestid"
+)
+
+type ctxKey struct{}
+
+// base writes every log line of the service as JSON, its level can change
+// while the service runs
+var base = &logrus.Logger{
+	Out:       os.Stdout,
+	Formatter: &logrus.JSONFormatter{TimestampFormat: "2006-01-02T15:04:05.000Z07:00"},
+	Hooks:     logrus.LevelHooks{},
+	Level:     logrus.InfoLevel,
+	ExitFunc:  os.Exit,
+}
+
+// Levels are the accepted log levels, from the most to the least verbose
+var Levels = []string{"trace", "debug", "info", "warn", "error"}
+
+// SetLevel sets the lowest level logged, one of Levels
+func SetLevel(level string) error {
+	l, err := logrus.ParseLevel(level)
+	if err != nil || l < logrus.ErrorLevel {
+		return fmt.Errorf("log level %q is not one of %v: %w", level, Levels, model.ErrInvalid)
+	}
+
+	base.SetLevel(l)
+	return nil
+}
+
+// Level returns the lowest level logged
+func Level() string {
+	return base.GetLevel().String()
+}
+
+// Enabled tells whether lines of level are logged, to skip building costly ones
+func Enabled(level logrus.Level) bool {
+	return base.IsLevelEnabled(level)
+}
+
+// SetOutput redirects the log lines, tests use it to read them
+func SetOutput(w io.Writer) {
+	base.SetOutput(w)
+}
+
+// Std returns a standard library logger writing its lines at level, for the
+// libraries that only accept one
+func Std(level logrus.Level) *log.Logger {
+	return log.New(base.WriterLevel(level), "", 0)
+}
+
+// L returns the logger of the service, for code outside of a request
+func L() *logrus.Entry {
+	return logrus.NewEntry(base)
+}
+
+// NewContext returns a copy of ctx carrying l, the logger the code handling
+// it logs with
+func NewContext(ctx context.Context, l *logrus.Entry) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns the logger carried by ctx, else the logger of the
+// service. Both log the request ID of ctx.
+func FromContext(ctx context.Context) *logrus.Entry {
+	l, ok := ctx.Value(ctxKey{}).(*logrus.Entry)
+	if !ok {
+		l = L()
+	}
+
+	if id := requestid.FromContext(ctx); id != "" {
+		if _, ok := l.Data["request_id"]; !ok {
+			l = l.WithField("request_id", id)
+		}
+	}
+
+	return l.WithContext(ctx)
+}
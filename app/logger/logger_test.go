@@ -0,0 +1,71 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/requestid"
+)
+
+func TestLogger(t *testing.T) {
+	out := &bytes.Buffer{}
+	SetOutput(out)
+	t.Cleanup(func() {
+		SetOutput(os.Stdout)
+		SetLevel("info")
+	})
+
+	line := func() map[string]interface{} {
+		m := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &m))
+		out.Reset()
+		return m
+	}
+
+	t.Run("logs the request ID of the context", func(t *testing.T) {
+		ctx := requestid.NewContext(context.Background(), "abc")
+		FromContext(ctx).WithField("k", "v").Info("hello")
+
+		m := line()
+		assert.Equal(t, "hello", m["msg"])
+		assert.Equal(t, "abc", m["request_id"])
+		assert.Equal(t, "v", m["k"])
+		assert.Equal(t, "info", m["level"])
+	})
+
+	t.Run("carries the fields of the context logger", func(t *testing.T) {
+		ctx := NewContext(context.Background(), L().WithField("user_id", "1"))
+		FromContext(requestid.NewContext(ctx, "abc")).Info("hello")
+
+		m := line()
+		assert.Equal(t, "1", m["user_id"])
+		assert.Equal(t, "abc", m["request_id"])
+	})
+
+	t.Run("level changes at runtime", func(t *testing.T) {
+		L().Debug("hidden")
+		assert.Empty(t, out.String())
+
+		require.NoError(t, SetLevel("debug"))
+		assert.Equal(t, "debug", Level())
+		assert.True(t, Enabled(logrus.DebugLevel))
+
+		L().Debug("shown")
+		assert.Equal(t, "shown", line()["msg"])
+
+		for _, level := range []string{"verbose", "fatal", "panic"} {
+			err := SetLevel(level)
+			assert.True(t, errors.Is(err, model.ErrInvalid), "%s: got %v", level, err)
+		}
+		assert.Equal(t, "debug", Level())
+	})
+}
@@ -0,0 +1,135 @@
+// Package metrics holds the Prometheus metrics of the service, served by
+// Handler on the admin listener.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-pg/pg/v9"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Registry holds every metric of the service, with the Go runtime and
+// process ones
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests served, by route pattern and status.",
+	}, []string{"method", "route", "status"})
+
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Latency of the HTTP requests, by route pattern and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	httpPanics = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_panics_total",
+		Help: "Panics recovered while serving HTTP requests, by route pattern.",
+	}, []string{"route"})
+
+	httpRateLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_rate_limited_total",
+		Help: "Requests rejected over a rate limit, by route group and scope.",
+	}, []string{"group", "scope"})
+
+	transactionsCreated = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "transactions_created_total",
+		Help: "Transactions created, by type and source: api, payment or statement.",
+	}, []string{"type", "source"})
+
+	transactionsRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "transactions_rejected_total",
+		Help: "Transaction creations that failed, by error code and source.",
+	}, []string{"reason", "source"})
+
+	transactionAmount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "transaction_amount_total",
+		Help: "Amount of the created transactions in VND, by type and source.",
+	}, []string{"type", "source"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpDuration,
+		httpPanics,
+		httpRateLimited,
+		transactionsCreated,
+		transactionsRejected,
+		transactionAmount,
+	)
+}
+
+// Handler serves the metrics in the Prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{})
+}
+
+// ObserveRequest counts a served HTTP request, route is the pattern it matched
+func ObserveRequest(method, route string, status int, elapsed time.Duration) {
+	s := strconv.Itoa(status)
+	httpRequests.WithLabelValues(method, route, s).Inc()
+	httpDuration.WithLabelValues(method, route, s).Observe(elapsed.Seconds())
+}
+
+// ObservePanic counts a panic recovered while serving a request of route
+func ObservePanic(route string) {
+	httpPanics.WithLabelValues(route).Inc()
+}
+
+// ObserveRateLimited counts a request rejected over the limit of scope for
+// the route group
+func ObserveRateLimited(group, scope string) {
+	httpRateLimited.WithLabelValues(group, scope).Inc()
+}
+
+// poolCollector reads the stats of a go-pg connection pool when scraped
+type poolCollector struct {
+	stats func() *pg.PoolStats
+
+	hits, misses, timeouts, stale *prometheus.Desc
+	idle, total                   *prometheus.Desc
+}
+
+// NewPoolCollector collects the pool stats returned by stats, e.g.
+// pgutil.PoolStats
+func NewPoolCollector(stats func() *pg.PoolStats) prometheus.Collector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc("pg_pool_"+name, help, nil, nil)
+	}
+
+	return &poolCollector{
+		stats:    stats,
+		hits:     desc("hits_total", "Times a free connection was found in the pool."),
+		misses:   desc("misses_total", "Times a free connection was not found in the pool."),
+		timeouts: desc("timeouts_total", "Times waiting for a connection timed out."),
+		stale:    desc("stale_connections_total", "Stale connections removed from the pool."),
+		idle:     desc("idle_connections", "Idle connections in the pool."),
+		total:    desc("connections", "Connections in the pool."),
+	}
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{c.hits, c.misses, c.timeouts, c.stale, c.idle, c.total} {
+		ch <- d
+	}
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	s := c.stats()
+	ch <- prometheus.MustNewConstMetric(c.hits, prometheus.CounterValue, float64(s.Hits))
+	ch <- prometheus.MustNewConstMetric(c.misses, prometheus.CounterValue, float64(s.Misses))
+	ch <- prometheus.MustNewConstMetric(c.timeouts, prometheus.CounterValue, float64(s.Timeouts))
+	ch <- prometheus.MustNewConstMetric(c.stale, prometheus.CounterValue, float64(s.StaleConns))
+	ch <- prometheus.MustNewConstMetric(c.idle, prometheus.GaugeValue, float64(s.IdleConns))
+	ch <- prometheus.MustNewConstMetric(c.total, prometheus.GaugeValue, float64(s.TotalConns))
+}
@@ -0,0 +1,65 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-pg/pg/v9"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+	"go-prj-skeleton/app/usecase"
+)
+
+func TestUserUsecase(t *testing.T) {
+	store := memory.NewStore()
+	store.AddUser(model.User{ID: 1, Name: "Alice"})
+	store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB"})
+	u := NewUserUsecase(usecase.NewUserUsecase(memory.NewUserRepo(store), memory.NewAccountRepo(store), memory.NewTransactionRepo(store)))
+
+	deposits := testutil.ToFloat64(transactionsCreated.WithLabelValues("deposit", sourceAPI))
+	amount := testutil.ToFloat64(transactionAmount.WithLabelValues("deposit", sourceAPI))
+	invalid := testutil.ToFloat64(transactionsRejected.WithLabelValues("invalid", sourceAPI))
+
+	_, err := u.CreateTransaction(context.Background(), 1, usecase.CreateTransaction{AccountID: 1, Amount: decimal.NewFromInt(1500), TransactionType: model.TransactionTypeDeposit})
+	require.NoError(t, err)
+	_, err = u.CreateTransaction(context.Background(), 1, usecase.CreateTransaction{AccountID: 2, Amount: decimal.NewFromInt(1), TransactionType: model.TransactionTypeDeposit})
+	require.Error(t, err)
+
+	assert.Equal(t, deposits+1, testutil.ToFloat64(transactionsCreated.WithLabelValues("deposit", sourceAPI)))
+	assert.Equal(t, amount+1500, testutil.ToFloat64(transactionAmount.WithLabelValues("deposit", sourceAPI)))
+	assert.Equal(t, invalid+1, testutil.ToFloat64(transactionsRejected.WithLabelValues("invalid", sourceAPI)))
+}
+
+func TestHandler(t *testing.T) {
+	ObserveRequest(http.MethodGet, "/api/users/:user_id/transactions", http.StatusOK, 20*time.Millisecond)
+
+	pool := NewPoolCollector(func() *pg.PoolStats {
+		return &pg.PoolStats{Hits: 7, Misses: 2, TotalConns: 3, IdleConns: 1}
+	})
+	assert.Equal(t, 6, testutil.CollectAndCount(pool))
+	require.NoError(t, testutil.CollectAndCompare(pool, strings.NewReader(`
+# HELP pg_pool_hits_total Times a free connection was found in the pool.
+# TYPE pg_pool_hits_total counter
+pg_pool_hits_total 7
+# HELP pg_pool_connections Connections in the pool.
+# TYPE pg_pool_connections gauge
+pg_pool_connections 3
+`), "pg_pool_hits_total", "pg_pool_connections"))
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/api/users/:user_id/transactions",status="200"}`)
+	assert.Contains(t, body, `http_request_duration_seconds_bucket{method="GET",route="/api/users/:user_id/transactions",status="200",le="0.025"}`)
+	assert.Contains(t, body, "go_goroutines")
+}
@@ -0,0 +1,102 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+// sources of the created transactions
+const (
+	sourceAPI       = "api"
+	sourcePayment   = "payment"
+	sourceStatement = "statement"
+)
+
+func created(source string, t model.TransactionType, amount decimal.Decimal) {
+	transactionsCreated.WithLabelValues(string(t), source).Inc()
+	f, _ := amount.Float64()
+	transactionAmount.WithLabelValues(string(t), source).Add(f)
+}
+
+func rejected(source string, err error) {
+	transactionsRejected.WithLabelValues(string(model.ErrorCodeOf(err)), source).Inc()
+}
+
+type userUsecase struct {
+	usecase.UserUsecase
+}
+
+// NewUserUsecase counts the transactions created and rejected through u
+func NewUserUsecase(u usecase.UserUsecase) usecase.UserUsecase {
+	return userUsecase{u}
+}
+
+func (u userUsecase) CreateTransaction(ctx context.Context, userID int, t usecase.CreateTransaction) (*usecase.Transaction, error) {
+	tran, err := u.UserUsecase.CreateTransaction(ctx, userID, t)
+	if err != nil {
+		rejected(sourceAPI, err)
+		return nil, err
+	}
+
+	created(sourceAPI, tran.TransactionType, tran.Amount)
+	return tran, nil
+}
+
+type paymentUsecase struct {
+	usecase.PaymentUsecase
+}
+
+// NewPaymentUsecase counts the withdrawals of the payment batches created
+// through u, and the batches rejected
+func NewPaymentUsecase(u usecase.PaymentUsecase) usecase.PaymentUsecase {
+	return paymentUsecase{u}
+}
+
+func (u paymentUsecase) CreateBatch(userID int, b usecase.CreatePaymentBatch) (*usecase.PaymentBatch, error) {
+	batch, err := u.PaymentUsecase.CreateBatch(userID, b)
+	if err != nil {
+		rejected(sourcePayment, err)
+		return nil, err
+	}
+
+	for _, p := range batch.Payments {
+		created(sourcePayment, model.TransactionTypeWithdraw, p.Amount)
+	}
+
+	return batch, nil
+}
+
+type reconciliationUsecase struct {
+	usecase.ReconciliationUsecase
+}
+
+// NewReconciliationUsecase counts the transactions recorded from the
+// missing entries of the statements reconciled through u
+func NewReconciliationUsecase(u usecase.ReconciliationUsecase) usecase.ReconciliationUsecase {
+	return reconciliationUsecase{u}
+}
+
+func (u reconciliationUsecase) Reconcile(userID int, s usecase.ReconcileStatement) (*usecase.ReconciliationReport, error) {
+	report, err := u.ReconciliationUsecase.Reconcile(userID, s)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range report.Entries {
+		if e.Status != usecase.EntryStatusCreated {
+			continue
+		}
+
+		t := model.TransactionTypeDeposit
+		if e.Amount.IsNegative() {
+			t = model.TransactionTypeWithdraw
+		}
+		created(sourceStatement, t, e.Amount.Abs())
+	}
+
+	return report, nil
+}
@@ -0,0 +1,61 @@
+package migration
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/db"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"000002_add_users.up.sql":     {Data: []byte("INSERT")},
+			"000002_add_users.down.sql":   {Data: []byte("DELETE")},
+			"000001_create_users.up.sql":  {Data: []byte("CREATE")},
+			"000010_no_down.up.sql":       {Data: []byte("ALTER")},
+			"README.md":                   {Data: []byte("ignored")},
+			"000001_create_users.down.sq": {Data: []byte("ignored")},
+		}
+
+		migrations, err := Load(fsys)
+		require.NoError(t, err)
+		assert.Equal(t, []Migration{
+			{Version: 1, Name: "create_users", Up: "CREATE"},
+			{Version: 2, Name: "add_users", Up: "INSERT", Down: "DELETE"},
+			{Version: 10, Name: "no_down", Up: "ALTER"},
+		}, migrations)
+	})
+
+	t.Run("missing up", func(t *testing.T) {
+		_, err := Load(fstest.MapFS{
+			"000001_create_users.down.sql": {Data: []byte("DROP")},
+		})
+		assert.EqualError(t, err, "migration 1_create_users has no up file")
+	})
+
+	t.Run("conflicting names", func(t *testing.T) {
+		_, err := Load(fstest.MapFS{
+			"000001_a.up.sql": {Data: []byte("CREATE")},
+			"000001_b.up.sql": {Data: []byte("CREATE")},
+		})
+		assert.EqualError(t, err, "migration 1 has two names: a and b")
+	})
+
+	t.Run("embedded", func(t *testing.T) {
+		for name, fsys := range map[string]fs.FS{
+			"postgres": db.PostgresMigrations(),
+			"mysql":    db.MySQLMigrations(),
+		} {
+			migrations, err := Load(fsys)
+			require.NoError(t, err, name)
+			assert.NotEmpty(t, migrations, name)
+		}
+	})
+}
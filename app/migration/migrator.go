@@ -0,0 +1,277 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// NilVersion is the version of a database without any applied migration.
+const NilVersion = -1
+
+var (
+	ErrDirty    = errors.New("database is dirty, fix it and force a version")
+	ErrLock     = errors.New("could not acquire migration lock")
+	ErrOutdated = errors.New("database is older than the latest migration")
+)
+
+// Dialect holds the statements that differ between database engines. The
+// version table is compatible with golang-migrate so databases migrated by
+// its CLI keep working.
+type Dialect struct {
+	Name string
+
+	CreateTable   string
+	InsertVersion string
+	Lock          string
+	Unlock        string
+}
+
+var (
+	Postgres = Dialect{
+		Name:          "postgres",
+		CreateTable:   "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)",
+		InsertVersion: "INSERT INTO schema_migrations (version, dirty) VALUES ($1, $2)",
+		Lock:          "SELECT pg_advisory_lock(1701870426)",
+		Unlock:        "SELECT pg_advisory_unlock(1701870426)",
+	}
+
+	MySQL = Dialect{
+		Name:          "mysql",
+		CreateTable:   "CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)",
+		InsertVersion: "INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)",
+		Lock:          "SELECT GET_LOCK('schema_migrations', 300)",
+		Unlock:        "SELECT RELEASE_LOCK('schema_migrations')",
+	}
+)
+
+// Status describes the schema version of a database.
+type Status struct {
+	Version int
+	Dirty   bool
+	Pending []Migration
+}
+
+// Migrator applies migrations while holding a database-wide lock, so
+// replicas starting at the same time don't race each other.
+type Migrator struct {
+	db         *sql.DB
+	dialect    Dialect
+	migrations []Migration
+}
+
+func NewMigrator(db *sql.DB, dialect Dialect, migrations []Migration) *Migrator {
+	return &Migrator{
+		db,
+		dialect,
+		migrations,
+	}
+}
+
+// Up applies all pending migrations and returns how many ran.
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if dirty {
+			return fmt.Errorf("version %v: %w", version, ErrDirty)
+		}
+
+		for _, mig := range m.migrations {
+			if mig.Version <= version {
+				continue
+			}
+
+			if err := m.run(ctx, conn, mig.Version, mig.Up, mig.Version); err != nil {
+				return fmt.Errorf("migrate up %v_%s: %w", mig.Version, mig.Name, err)
+			}
+
+			applied++
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// Down rolls back the last steps applied migrations and returns how many ran.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+
+	err := m.withLock(ctx, func(conn *sql.Conn) error {
+		version, dirty, err := m.version(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		if dirty {
+			return fmt.Errorf("version %v: %w", version, ErrDirty)
+		}
+
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			mig := m.migrations[i]
+			if mig.Version > version {
+				continue
+			}
+
+			prev := NilVersion
+			if i > 0 {
+				prev = m.migrations[i-1].Version
+			}
+
+			if err := m.run(ctx, conn, mig.Version, mig.Down, prev); err != nil {
+				return fmt.Errorf("migrate down %v_%s: %w", mig.Version, mig.Name, err)
+			}
+
+			reverted++
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// Status returns the current version and the migrations not applied yet.
+// Like Check it doesn't take the lock, so it answers while a migration runs.
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	if _, err := m.db.ExecContext(ctx, m.dialect.CreateTable); err != nil {
+		return Status{}, fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	version, dirty, err := m.version(ctx, m.db)
+	if err != nil {
+		return Status{}, err
+	}
+
+	status := Status{
+		Version: version,
+		Dirty:   dirty,
+	}
+	for _, mig := range m.migrations {
+		if mig.Version > version {
+			status.Pending = append(status.Pending, mig)
+		}
+	}
+
+	return status, nil
+}
+
+// Check fails unless the database is clean and at least at the version of
+// the last migration: a database migrated further by a newer release keeps
+// the replicas of the previous one ready during a rolling deploy. It reads
+// the version without taking the lock.
+func (m *Migrator) Check(ctx context.Context) error {
+	version, dirty, err := m.version(ctx, m.db)
+	if err != nil {
+		return err
+	}
+
+	if dirty {
+		return fmt.Errorf("version %v: %w", version, ErrDirty)
+	}
+
+	latest := NilVersion
+	if len(m.migrations) > 0 {
+		latest = m.migrations[len(m.migrations)-1].Version
+	}
+	if version < latest {
+		return fmt.Errorf("version %v, expected %v: %w", version, latest, ErrOutdated)
+	}
+
+	return nil
+}
+
+// Force sets the version without running any migration and clears the
+// dirty flag. Use NilVersion to mark the database as empty.
+func (m *Migrator) Force(ctx context.Context, version int) error {
+	return m.withLock(ctx, func(conn *sql.Conn) error {
+		return m.setVersion(ctx, conn, version, false)
+	})
+}
+
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, m.dialect.Lock).Scan(&locked); err != nil {
+		return fmt.Errorf("%v: %w", err, ErrLock)
+	}
+
+	// pg_advisory_lock returns void, GET_LOCK returns 1 on success
+	if locked.Valid && locked.Int64 != 1 {
+		return ErrLock
+	}
+
+	defer conn.ExecContext(context.Background(), m.dialect.Unlock)
+
+	if _, err := conn.ExecContext(ctx, m.dialect.CreateTable); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	return fn(conn)
+}
+
+// queryer is a *sql.DB or a *sql.Conn
+type queryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+func (m *Migrator) version(ctx context.Context, conn queryer) (int, bool, error) {
+	var (
+		version int
+		dirty   bool
+	)
+
+	err := conn.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return NilVersion, false, nil
+		}
+
+		return 0, false, fmt.Errorf("read schema version: %w", err)
+	}
+
+	return version, dirty, nil
+}
+
+func (m *Migrator) setVersion(ctx context.Context, conn *sql.Conn, version int, dirty bool) error {
+	if _, err := conn.ExecContext(ctx, "DELETE FROM schema_migrations"); err != nil {
+		return fmt.Errorf("write schema version: %w", err)
+	}
+
+	if version == NilVersion {
+		return nil
+	}
+
+	if _, err := conn.ExecContext(ctx, m.dialect.InsertVersion, version, dirty); err != nil {
+		return fmt.Errorf("write schema version: %w", err)
+	}
+
+	return nil
+}
+
+// run marks the database dirty at version, executes query and records
+// next as the clean version. A failure leaves the dirty flag set.
+func (m *Migrator) run(ctx context.Context, conn *sql.Conn, version int, query string, next int) error {
+	if err := m.setVersion(ctx, conn, version, true); err != nil {
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, query); err != nil {
+		return err
+	}
+
+	return m.setVersion(ctx, conn, next, false)
+}
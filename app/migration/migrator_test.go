@@ -0,0 +1,156 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "create_migrator_test", Up: "CREATE TABLE migrator_test (id INTEGER)", Down: "DROP TABLE migrator_test"},
+	{Version: 3, Name: "add_name", Up: "ALTER TABLE migrator_test ADD COLUMN name VARCHAR(10)", Down: "ALTER TABLE migrator_test DROP COLUMN name"},
+}
+
+// TestMigrator runs the migrator against the databases in
+// POSTGRE_MIGRATION_TEST_URL and MYSQL_MIGRATION_TEST_URL. Its tables
+// schema_migrations and migrator_test are dropped, so point them at scratch
+// databases rather than the migrated ones of the repository tests.
+func TestMigrator(t *testing.T) {
+	for _, tc := range []struct {
+		env     string
+		driver  string
+		dialect Dialect
+	}{
+		{"POSTGRE_MIGRATION_TEST_URL", "postgres", Postgres},
+		{"MYSQL_MIGRATION_TEST_URL", "mysql", MySQL},
+	} {
+		tc := tc
+		t.Run(tc.dialect.Name, func(t *testing.T) {
+			url := os.Getenv(tc.env)
+			if url == "" {
+				t.Skipf("%s is not set", tc.env)
+			}
+
+			db, err := sql.Open(tc.driver, url)
+			require.NoError(t, err)
+			defer db.Close()
+
+			testMigrator(t, db, tc.dialect)
+		})
+	}
+}
+
+func testMigrator(t *testing.T, db *sql.DB, dialect Dialect) {
+	ctx := context.Background()
+
+	reset := func(t *testing.T) {
+		for _, table := range []string{"migrator_test", "schema_migrations"} {
+			_, err := db.Exec("DROP TABLE IF EXISTS " + table)
+			require.NoError(t, err)
+		}
+	}
+
+	assertVersion := func(t *testing.T, m *Migrator, version int, dirty bool, pending int) {
+		status, err := m.Status(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, version, status.Version)
+		assert.Equal(t, dirty, status.Dirty)
+		assert.Len(t, status.Pending, pending)
+	}
+
+	t.Run("up and down", func(t *testing.T) {
+		reset(t)
+		m := NewMigrator(db, dialect, testMigrations)
+
+		assertVersion(t, m, NilVersion, false, 2)
+		assert.ErrorIs(t, m.Check(ctx), ErrOutdated)
+
+		applied, err := m.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, applied)
+		assertVersion(t, m, 3, false, 0)
+		assert.NoError(t, m.Check(ctx))
+
+		_, err = db.Exec("SELECT id, name FROM migrator_test")
+		assert.NoError(t, err)
+
+		// the replicas of a release knowing fewer migrations stay ready
+		assert.NoError(t, NewMigrator(db, dialect, testMigrations[:1]).Check(ctx))
+
+		applied, err = m.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 0, applied)
+
+		reverted, err := m.Down(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 1, reverted)
+		assertVersion(t, m, 1, false, 1)
+
+		reverted, err = m.Down(ctx, 5)
+		require.NoError(t, err)
+		assert.Equal(t, 1, reverted)
+		assertVersion(t, m, NilVersion, false, 2)
+
+		_, err = db.Exec("SELECT id FROM migrator_test")
+		assert.Error(t, err)
+	})
+
+	t.Run("failure leaves dirty until forced", func(t *testing.T) {
+		reset(t)
+		m := NewMigrator(db, dialect, append(testMigrations[:1:1], Migration{
+			Version: 2, Name: "broken", Up: "SELECT * FROM migrator_missing",
+		}))
+
+		_, err := m.Up(ctx)
+		assert.Error(t, err)
+		assertVersion(t, m, 2, true, 0)
+		assert.ErrorIs(t, m.Check(ctx), ErrDirty)
+
+		_, err = m.Up(ctx)
+		assert.ErrorIs(t, err, ErrDirty)
+		_, err = m.Down(ctx, 1)
+		assert.ErrorIs(t, err, ErrDirty)
+
+		require.NoError(t, m.Force(ctx, 1))
+		assertVersion(t, m, 1, false, 1)
+
+		require.NoError(t, m.Force(ctx, NilVersion))
+		assertVersion(t, m, NilVersion, false, 2)
+	})
+
+	t.Run("lock", func(t *testing.T) {
+		reset(t)
+		m := NewMigrator(db, dialect, testMigrations)
+
+		conn, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var locked sql.NullInt64
+		require.NoError(t, conn.QueryRowContext(ctx, dialect.Lock).Scan(&locked))
+
+		timeout, cancel := context.WithTimeout(ctx, 500*time.Millisecond)
+		defer cancel()
+
+		_, err = m.Up(timeout)
+		assert.ErrorIs(t, err, ErrLock)
+
+		// Status and Check don't wait for the lock
+		assertVersion(t, m, NilVersion, false, 2)
+		assert.ErrorIs(t, m.Check(ctx), ErrOutdated)
+
+		_, err = conn.ExecContext(ctx, dialect.Unlock)
+		require.NoError(t, err)
+
+		applied, err := m.Up(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 2, applied)
+	})
+}
@@ -0,0 +1,68 @@
+package mysqlutil
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	// This is needed to have the driver to acess MySQL server
+	"github.com/go-sql-driver/mysql"
+)
+
+// Configuration struct
+type Configuration struct {
+	URL            string
+	Host           string
+	Port           string
+	Database       string
+	User           string
+	Password       string
+	MaxConnections int
+}
+
+var dbSession *sql.DB
+
+// DB : return connection to DB
+func DB() *sql.DB {
+	return dbSession
+}
+
+// DSN returns the go-sql-driver connection string of config
+func DSN(config Configuration) string {
+	if config.URL != "" {
+		return config.URL
+	}
+
+	cfg := mysql.NewConfig()
+	cfg.Net = "tcp"
+	cfg.Addr = fmt.Sprintf("%s:%s", config.Host, config.Port)
+	cfg.User = config.User
+	cfg.Passwd = config.Password
+	cfg.DBName = config.Database
+
+	return cfg.FormatDSN()
+}
+
+// Ping tells the server is reachable
+func Ping(ctx context.Context) error {
+	return dbSession.PingContext(ctx)
+}
+
+// StartUp ...
+func StartUp(config Configuration) {
+	db, err := sql.Open("mysql", DSN(config))
+	if err != nil {
+		panic(err)
+	}
+
+	if config.MaxConnections > 0 {
+		db.SetMaxOpenConns(config.MaxConnections)
+	}
+
+	dbSession = db
+}
+
+// Shutdown ...
+func Shutdown() {
+	dbSession.Close()
+}
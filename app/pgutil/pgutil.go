@@ -1,17 +1,16 @@
 package pgutil
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
-	"log"
-	"os"
 
 	// This is needed to have the driver to acess PG server
 	"github.com/go-pg/pg/v9"
 	_ "github.com/lib/pq"
+	"github.com/sirupsen/logrus"
 
-	"github.com/golang/glog"
-
+	"go-prj-skeleton/app/logger"
 	"go-prj-skeleton/app/setting"
 )
 
@@ -41,25 +40,46 @@ func ConnectPG(query string) (*sql.Rows, error) {
 	db, err := sql.Open("postgres", dbinfo)
 	defer db.Close()
 	if err != nil {
-		glog.Errorf("Cannot connect to DB, error: %s", err)
+		logger.L().WithError(err).Error("cannot connect to DB")
 		return nil, err
 	}
 	rows, err := db.Query(query)
 	if err != nil {
-		glog.Errorf("Cannot query, error: %s", err)
+		logger.L().WithError(err).Error("cannot query")
 		return nil, err
 	}
 	return rows, nil
 }
 
+// DSN returns the lib/pq connection string of config
+func DSN(config Configuration) string {
+	if config.URL != "" {
+		return config.URL
+	}
+
+	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
+		config.Host, config.Port, config.User, config.Password, config.Database)
+}
+
 // DB : return connection to DB
 func DB() *pg.DB {
 	return dbSession
 }
 
+// Ping runs a trivial query to tell the server is reachable
+func Ping(ctx context.Context) error {
+	_, err := dbSession.ExecContext(ctx, "SELECT 1")
+	return err
+}
+
+// PoolStats returns the stats of the connection pool
+func PoolStats() *pg.PoolStats {
+	return dbSession.PoolStats()
+}
+
 // StartUp ...
 func StartUp(config Configuration) {
-	pg.SetLogger(log.New(os.Stdout, "", log.LstdFlags))
+	pg.SetLogger(logger.Std(logrus.WarnLevel))
 
 	if config.URL != "" {
 		options, err := pg.ParseURL(config.URL)
@@ -68,6 +88,7 @@ func StartUp(config Configuration) {
 		}
 		options.ApplicationName = config.ApplicationName
 		dbSession = pg.Connect(options)
+		addQueryHooks(dbSession)
 		return
 	}
 
@@ -79,6 +100,13 @@ func StartUp(config Configuration) {
 		TLSConfig:       nil,
 		ApplicationName: config.ApplicationName,
 	})
+	addQueryHooks(dbSession)
+}
+
+// addQueryHooks traces and logs the queries of db
+func addQueryHooks(db *pg.DB) {
+	db.AddQueryHook(queryTracer{})
+	db.AddQueryHook(queryLogger{})
 }
 
 // Shutdown ...
@@ -0,0 +1,61 @@
+package pgutil
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pg/pg/v9"
+	"github.com/sirupsen/logrus"
+
+	"go-prj-skeleton/app/logger"
+)
+
+// redacted replaces the query parameters that may be personal data
+const redacted = "[redacted]"
+
+// queryLogger logs every query at debug level with its duration. The query
+// is logged without its values, the parameters of raw queries are, except
+// the strings, which may be names or account numbers.
+type queryLogger struct{}
+
+func (queryLogger) BeforeQuery(ctx context.Context, e *pg.QueryEvent) (context.Context, error) {
+	return ctx, nil
+}
+
+func (queryLogger) AfterQuery(ctx context.Context, e *pg.QueryEvent) error {
+	if !logger.Enabled(logrus.DebugLevel) {
+		return nil
+	}
+
+	query, err := e.UnformattedQuery()
+	if err != nil {
+		query = err.Error()
+	}
+
+	l := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"query":       query,
+		"params":      redact(e.Params),
+		"duration_ms": float64(time.Since(e.StartTime).Microseconds()) / 1000,
+	})
+	if e.Err != nil {
+		l = l.WithError(e.Err)
+	}
+
+	l.Debug("sql")
+	return nil
+}
+
+// redact keeps the numbers, booleans and NULLs of params
+func redact(params []interface{}) []interface{} {
+	out := make([]interface{}, len(params))
+	for i, p := range params {
+		switch p.(type) {
+		case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			out[i] = p
+		default:
+			out[i] = redacted
+		}
+	}
+
+	return out
+}
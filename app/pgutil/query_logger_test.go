@@ -0,0 +1,16 @@
+package pgutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	params := []interface{}{1, int64(2), true, nil, "0071000123456", decimal.NewFromInt(10), time.Now()}
+	assert.Equal(t, []interface{}{1, int64(2), true, nil, redacted, redacted, redacted}, redact(params))
+}
@@ -0,0 +1,51 @@
+package pgutil
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-pg/pg/v9"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-prj-skeleton/app/tracing"
+)
+
+// queryTracer runs every query within a span, child of the span of the
+// context of the query. The statement is recorded without its values.
+type queryTracer struct{}
+
+func (queryTracer) BeforeQuery(ctx context.Context, e *pg.QueryEvent) (context.Context, error) {
+	ctx, _ = tracing.Tracer().Start(ctx, "SQL",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.DBSystemPostgreSQL))
+
+	return ctx, nil
+}
+
+func (queryTracer) AfterQuery(ctx context.Context, e *pg.QueryEvent) error {
+	span := trace.SpanFromContext(ctx)
+	defer span.End()
+
+	if !span.IsRecording() {
+		return nil
+	}
+
+	if query, err := e.UnformattedQuery(); err == nil {
+		span.SetAttributes(semconv.DBStatementKey.String(query))
+		if fields := strings.Fields(query); len(fields) > 0 {
+			op := strings.ToUpper(fields[0])
+			span.SetName("SQL " + op)
+			span.SetAttributes(semconv.DBOperationKey.String(op))
+		}
+	}
+
+	// no rows is how lookups report a missing row
+	if e.Err != nil && e.Err != pg.ErrNoRows {
+		span.RecordError(e.Err)
+		span.SetStatus(codes.Error, e.Err.Error())
+	}
+
+	return nil
+}
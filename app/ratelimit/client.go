@@ -0,0 +1,162 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+// Client is who a request comes from, by its id in each scope it could be
+// identified in
+type Client map[string]string
+
+type clientKey struct{}
+
+// NewContext returns ctx carrying the client of its request
+func NewContext(ctx context.Context, c Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, c)
+}
+
+// FromContext returns the client NewContext stored in ctx, nil if none
+func FromContext(ctx context.Context) Client {
+	c, _ := ctx.Value(clientKey{}).(Client)
+	return c
+}
+
+// Clients identifies the clients of requests. An API key only identifies
+// one when it is a configured key, and the address is read from
+// X-Forwarded-For only past the configured trusted proxies. The zero value
+// knows no key and trusts no proxy.
+type Clients struct {
+	keys    map[[sha256.Size]byte]bool
+	proxies []*net.IPNet
+}
+
+// NewClients returns the Clients knowing keys and trusting proxies
+func NewClients(keys []string, proxies []*net.IPNet) *Clients {
+	c := &Clients{keys: map[[sha256.Size]byte]bool{}, proxies: proxies}
+	for _, key := range keys {
+		c.keys[sha256.Sum256([]byte(key))] = true
+	}
+
+	return c
+}
+
+// ParseClients parses comma separated API keys and comma separated trusted
+// proxies, each an address or a CIDR, e.g. "10.0.0.0/8,192.0.2.10"
+func ParseClients(keys, proxies string) (*Clients, error) {
+	var nets []*net.IPNet
+	for _, p := range strings.Split(proxies, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		if !strings.Contains(p, "/") {
+			if ip := net.ParseIP(p); ip != nil && ip.To4() != nil {
+				p += "/32"
+			} else {
+				p += "/128"
+			}
+		}
+
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q is not an address or a CIDR: %w", p, model.ErrInvalid)
+		}
+		nets = append(nets, n)
+	}
+
+	var known []string
+	for _, key := range strings.Split(keys, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			known = append(known, key)
+		}
+	}
+
+	return NewClients(known, nets), nil
+}
+
+// Identify returns the client of a request authenticated as principal,
+// carrying key, from remoteAddr, forwarded for the X-Forwarded-For values
+// forwardedFor
+func (c *Clients) Identify(principal, key, remoteAddr string, forwardedFor []string) Client {
+	client := Client{}
+	if principal != "" {
+		client[ScopePrincipal] = principal
+	}
+
+	if id := c.keyID(key); id != "" {
+		client[ScopeKey] = id
+	}
+
+	if ip := c.ip(remoteAddr, forwardedFor); ip != "" {
+		client[ScopeIP] = ip
+	}
+
+	return client
+}
+
+// keyID is the id of the bucket of key, empty unless it is a known key. It
+// is hashed, not to keep the key in the buckets.
+func (c *Clients) keyID(key string) string {
+	if key == "" || c == nil {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	if !c.keys[sum] {
+		return ""
+	}
+
+	return hex.EncodeToString(sum[:16])
+}
+
+// ip walks X-Forwarded-For back from the address of the connection, as long
+// as the address reached is a trusted proxy
+func (c *Clients) ip(remoteAddr string, forwardedFor []string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	var hops []string
+	for _, v := range forwardedFor {
+		for _, hop := range strings.Split(v, ",") {
+			hops = append(hops, strings.TrimSpace(hop))
+		}
+	}
+
+	for i := len(hops) - 1; i >= 0 && c.trusted(host); i-- {
+		if net.ParseIP(hops[i]) == nil {
+			break
+		}
+		host = hops[i]
+	}
+
+	return host
+}
+
+func (c *Clients) trusted(host string) bool {
+	if c == nil {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range c.proxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the full buckets are dropped
+const sweepInterval = time.Minute
+
+// MemoryStore keeps the buckets in the process, so each replica limits the
+// requests it serves on its own
+type MemoryStore struct {
+	mu        sync.Mutex
+	tats      map[string]time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		tats: map[string]time.Time{},
+	}
+}
+
+func (s *MemoryStore) Take(ctx context.Context, key string, l Limit) (Result, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(now)
+
+	tat, res := Take(s.tats[key], now, l)
+	s.tats[key] = tat
+
+	return res, nil
+}
+
+func (s *MemoryStore) Refund(ctx context.Context, key string, l Limit) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if tat, ok := s.tats[key]; ok {
+		s.tats[key] = tat.Add(-l.interval())
+	}
+
+	return nil
+}
+
+// sweep drops the buckets full again, which a missing bucket is like
+func (s *MemoryStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, tat := range s.tats {
+		if tat.Before(now) {
+			delete(s.tats, key)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+// Package ratelimit limits the requests of each client with token buckets.
+// A bucket is kept as the theoretical arrival time of its next request
+// (GCRA), which a single timestamp stores and a single statement updates.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+// Scopes identify the client a bucket belongs to
+const (
+	// ScopePrincipal is the principal an authentication middleware set
+	ScopePrincipal = "principal"
+	// ScopeKey is the API key of the request
+	ScopeKey = "key"
+	// ScopeIP is the address the request comes from
+	ScopeIP = "ip"
+)
+
+// GroupTransactions is the group of the operations writing transactions,
+// whichever API they come through
+const GroupTransactions = "transactions"
+
+// Scopes are checked from the most to the least specific client
+var Scopes = []string{ScopePrincipal, ScopeKey, ScopeIP}
+
+// Limit refills a bucket of Burst tokens at Rate tokens per second
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+func (l Limit) interval() time.Duration {
+	return time.Duration(float64(time.Second) / l.Rate)
+}
+
+// Result tells whether a request may proceed and how full its bucket is
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is when the next token is there, for a request not allowed
+	RetryAfter time.Duration
+	// Reset is when the bucket is full again
+	Reset time.Duration
+}
+
+// Take takes a token at now from the bucket whose next request was expected
+// at tat, it returns the tat to store when the request is allowed
+func Take(tat, now time.Time, l Limit) (time.Time, Result) {
+	t := l.interval()
+	if tat.Before(now) {
+		tat = now
+	}
+
+	next := tat.Add(t)
+	allowAt := next.Add(-time.Duration(l.Burst) * t)
+	if now.Before(allowAt) {
+		return tat, Result{
+			Limit:      l.Burst,
+			RetryAfter: allowAt.Sub(now),
+			Reset:      tat.Sub(now),
+		}
+	}
+
+	return next, Result{
+		Allowed:   true,
+		Limit:     l.Burst,
+		Remaining: int(now.Sub(allowAt) / t),
+		Reset:     next.Sub(now),
+	}
+}
+
+// Store keeps the buckets, in memory for a single node or in a database
+// shared by the replicas
+type Store interface {
+	// Take takes a token from the bucket of key
+	Take(ctx context.Context, key string, l Limit) (Result, error)
+
+	// Refund puts back a token Take took from the bucket of key, for a
+	// request another bucket rejected
+	Refund(ctx context.Context, key string, l Limit) error
+}
+
+// Limits are the limits of the route groups by scope, e.g.
+// Limits{"transactions": {ScopeIP: {Rate: 5, Burst: 20}}}
+type Limits map[string]map[string]Limit
+
+var units = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// ParseLimits parses comma separated <group>.<scope>=<n>/<s|m|h>[:<burst>],
+// e.g. "api.ip=20/s:40,transactions.key=300/m". The burst defaults to n.
+func ParseLimits(s string) (Limits, error) {
+	limits := Limits{}
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, value, ok := strings.Cut(entry, "=")
+		group, scope, ok2 := strings.Cut(name, ".")
+		if !ok || !ok2 || group == "" {
+			return nil, fmt.Errorf("rate limit %q is not <group>.<scope>=<n>/<unit>[:<burst>]: %w", entry, model.ErrInvalid)
+		}
+
+		if scope != ScopePrincipal && scope != ScopeKey && scope != ScopeIP {
+			return nil, fmt.Errorf("rate limit %q: scope is not one of %s, %s, %s: %w", entry, ScopePrincipal, ScopeKey, ScopeIP, model.ErrInvalid)
+		}
+
+		l, err := parseLimit(value)
+		if err != nil {
+			return nil, fmt.Errorf("rate limit %q: %w", entry, err)
+		}
+
+		if limits[group] == nil {
+			limits[group] = map[string]Limit{}
+		}
+		limits[group][scope] = l
+	}
+
+	return limits, nil
+}
+
+func parseLimit(s string) (Limit, error) {
+	rate, burst, hasBurst := strings.Cut(s, ":")
+	count, unit, ok := strings.Cut(rate, "/")
+	per, known := units[unit]
+	n, err := strconv.Atoi(count)
+	if !ok || !known || err != nil || n <= 0 {
+		return Limit{}, fmt.Errorf("%q is not <n>/<s|m|h> with n > 0: %w", rate, model.ErrInvalid)
+	}
+
+	l := Limit{Rate: float64(n) / per.Seconds(), Burst: n}
+	if hasBurst {
+		b, err := strconv.Atoi(burst)
+		if err != nil || b <= 0 {
+			return Limit{}, fmt.Errorf("burst %q is not a positive integer: %w", burst, model.ErrInvalid)
+		}
+		l.Burst = b
+	}
+
+	return l, nil
+}
+
+// Group is a set of routes sharing limits: those whose pattern starts with
+// Prefix, requested with one of Methods or any method when it is empty
+type Group struct {
+	Name    string
+	Methods []string
+	Prefix  string
+}
+
+// Match tells whether a request of method for route pattern is in g
+func (g Group) Match(method, route string) bool {
+	if !strings.HasPrefix(route, g.Prefix) {
+		return false
+	}
+
+	if len(g.Methods) == 0 {
+		return true
+	}
+
+	for _, m := range g.Methods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Limiter takes the tokens of the requests from the buckets of a Store
+type Limiter struct {
+	store  Store
+	limits Limits
+}
+
+func NewLimiter(store Store, limits Limits) *Limiter {
+	return &Limiter{
+		store,
+		limits,
+	}
+}
+
+// Take takes a token from the bucket of the client id in scope for group,
+// ok is false when group has no limit in scope
+func (l *Limiter) Take(ctx context.Context, group, scope, id string) (_ Result, ok bool, err error) {
+	limit, ok := l.limits[group][scope]
+	if !ok {
+		return Result{}, false, nil
+	}
+
+	res, err := l.store.Take(ctx, group+"."+scope+"."+id, limit)
+	return res, true, err
+}
+
+// TakeClient takes a token for group from the bucket of c in each of
+// Scopes and stops at the first empty one, whose result and scope it
+// returns after refunding the tokens taken from the buckets before, so a
+// rejected request spends none. Else it returns the result of the bucket
+// closest to empty; ok is false when group limits none of the scopes of c.
+// A failing store is skipped, its error is returned along with the result
+// of the others.
+func (l *Limiter) TakeClient(ctx context.Context, group string, c Client) (res Result, scope string, ok bool, err error) {
+	var taken []string
+	for _, s := range Scopes {
+		id := c[s]
+		if id == "" {
+			continue
+		}
+
+		r, limited, takeErr := l.Take(ctx, group, s, id)
+		if takeErr != nil {
+			err = takeErr
+			continue
+		}
+		if !limited {
+			continue
+		}
+
+		if !r.Allowed {
+			if refundErr := l.refund(ctx, group, c, taken); refundErr != nil {
+				err = refundErr
+			}
+			return r, s, true, err
+		}
+		taken = append(taken, s)
+
+		if !ok || r.Remaining < res.Remaining {
+			res, scope, ok = r, s, true
+		}
+	}
+
+	return res, scope, ok, err
+}
+
+// refund puts back the tokens of group taken from the buckets of c in
+// scopes, it returns the last error of the store
+func (l *Limiter) refund(ctx context.Context, group string, c Client, scopes []string) (err error) {
+	for _, s := range scopes {
+		if refundErr := l.store.Refund(ctx, group+"."+s+"."+c[s], l.limits[group][s]); refundErr != nil {
+			err = refundErr
+		}
+	}
+
+	return err
+}
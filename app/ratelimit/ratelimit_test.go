@@ -0,0 +1,146 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+func TestTake(t *testing.T) {
+	t.Parallel()
+
+	l := Limit{Rate: 1, Burst: 3}
+	now := time.Date(2022, 11, 1, 9, 0, 0, 0, time.UTC)
+
+	// a missing bucket is full
+	tat, res := Take(time.Time{}, now, l)
+	assert.Equal(t, Result{Allowed: true, Limit: 3, Remaining: 2, Reset: time.Second}, res)
+
+	tat, res = Take(tat, now, l)
+	assert.Equal(t, 1, res.Remaining)
+	tat, res = Take(tat, now, l)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, 3*time.Second, res.Reset)
+
+	denied, res := Take(tat, now.Add(500*time.Millisecond), l)
+	assert.Equal(t, tat, denied, "a denied request takes no token")
+	assert.Equal(t, Result{Limit: 3, RetryAfter: 500 * time.Millisecond, Reset: 2500 * time.Millisecond}, res)
+
+	// a token is back every second
+	_, res = Take(tat, now.Add(time.Second), l)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+
+	_, res = Take(tat, now.Add(time.Hour), l)
+	assert.Equal(t, 2, res.Remaining)
+}
+
+func TestParseLimits(t *testing.T) {
+	t.Parallel()
+
+	limits, err := ParseLimits("api.ip=20/s:40, transactions.key=300/m,transactions.ip=10/h")
+	require.NoError(t, err)
+	assert.Equal(t, Limits{
+		"api":          {ScopeIP: {Rate: 20, Burst: 40}},
+		"transactions": {ScopeKey: {Rate: 5, Burst: 300}, ScopeIP: {Rate: 10.0 / 3600, Burst: 10}},
+	}, limits)
+
+	limits, err = ParseLimits("")
+	require.NoError(t, err)
+	assert.Empty(t, limits)
+
+	for _, s := range []string{"api=20/s", "api.user=20/s", "api.ip=20", "api.ip=0/s", "api.ip=20/d", "api.ip=20/s:-1", ".ip=1/s"} {
+		_, err := ParseLimits(s)
+		assert.ErrorIs(t, err, model.ErrInvalid, s)
+	}
+}
+
+func TestClients(t *testing.T) {
+	t.Parallel()
+
+	c, err := ParseClients("partner, other", "10.0.0.0/8, 2001:db8::1")
+	require.NoError(t, err)
+
+	client := c.Identify("admin", "partner", "192.0.2.1:1234", nil)
+	assert.Equal(t, "admin", client[ScopePrincipal])
+	assert.Len(t, client[ScopeKey], 32)
+	assert.NotContains(t, client[ScopeKey], "partner")
+	assert.Equal(t, "192.0.2.1", client[ScopeIP])
+
+	// unknown keys and the X-Forwarded-For of untrusted addresses are ignored
+	assert.Equal(t, Client{ScopeIP: "192.0.2.1"}, c.Identify("", "forged", "192.0.2.1:1234", []string{"198.51.100.1"}))
+
+	// the trusted proxies are skipped from the right
+	for _, tc := range []struct {
+		remoteAddr   string
+		forwardedFor []string
+		ip           string
+	}{
+		{"10.0.0.1:1234", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"10.0.0.1:1234", []string{"198.51.100.2, 198.51.100.1", "10.1.1.1"}, "198.51.100.1"},
+		{"[2001:db8::1]:1234", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"10.0.0.1:1234", []string{"10.1.1.1"}, "10.1.1.1"},
+		{"10.0.0.1:1234", []string{"unknown"}, "10.0.0.1"},
+		{"10.0.0.1:1234", nil, "10.0.0.1"},
+	} {
+		assert.Equal(t, tc.ip, c.Identify("", "", tc.remoteAddr, tc.forwardedFor)[ScopeIP], "%v", tc)
+	}
+
+	// the zero value knows nothing
+	var none *Clients
+	assert.Equal(t, Client{ScopeIP: "10.0.0.1"}, none.Identify("", "partner", "10.0.0.1:1234", []string{"198.51.100.1"}))
+
+	_, err = ParseClients("", "10.0.0.0/33")
+	assert.ErrorIs(t, err, model.ErrInvalid)
+	_, err = ParseClients("", "proxy")
+	assert.ErrorIs(t, err, model.ErrInvalid)
+}
+
+func TestMemoryStore(t *testing.T) {
+	t.Parallel()
+
+	s := NewMemoryStore()
+	l := Limit{Rate: 1, Burst: 2}
+
+	for _, allowed := range []bool{true, true, false} {
+		res, err := s.Take(context.Background(), "api.ip.192.0.2.1", l)
+		require.NoError(t, err)
+		assert.Equal(t, allowed, res.Allowed)
+	}
+
+	res, err := s.Take(context.Background(), "api.ip.192.0.2.2", l)
+	require.NoError(t, err)
+	assert.True(t, res.Allowed, "buckets are per key")
+}
+
+func TestLimiter_TakeClient(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(NewMemoryStore(), Limits{"g": {
+		ScopeKey: {Rate: 1.0 / 3600, Burst: 3},
+		ScopeIP:  {Rate: 1.0 / 3600, Burst: 1},
+	}})
+	c := Client{ScopeKey: "k", ScopeIP: "192.0.2.1"}
+
+	res, scope, ok, err := l.TakeClient(context.Background(), "g", c)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, ScopeIP, scope, "the bucket closest to empty")
+
+	res, scope, ok, err = l.TakeClient(context.Background(), "g", c)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.False(t, res.Allowed)
+	assert.Equal(t, ScopeIP, scope)
+
+	res, _, err = l.Take(context.Background(), "g", ScopeKey, "k")
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.Remaining, "the rejected request spent no token of the key")
+}
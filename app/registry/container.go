@@ -1,9 +1,18 @@
 package registry
 
 import (
+	"fmt"
+
 	"github.com/sarulabs/di"
 
+	"go-prj-skeleton/app/domain/repo"
+	"go-prj-skeleton/app/interface/persistence/memory"
+	"go-prj-skeleton/app/interface/persistence/mysql"
 	"go-prj-skeleton/app/interface/persistence/postgre"
+	"go-prj-skeleton/app/metrics"
+	"go-prj-skeleton/app/ratelimit"
+	"go-prj-skeleton/app/setting"
+	"go-prj-skeleton/app/tracing"
 	"go-prj-skeleton/app/usecase"
 )
 
@@ -18,10 +27,42 @@ func NewContainer() (*Container, error) {
 	}
 
 	if err := builder.Add([]di.Def{
+		{
+			Name:  "memory-store",
+			Build: buildMemoryStore,
+		},
+		{
+			Name:  "repos",
+			Build: buildRepos,
+		},
 		{
 			Name:  "user-usecase",
 			Build: buildUserUsecase,
 		},
+		{
+			Name:  "customer-usecase",
+			Build: buildCustomerUsecase,
+		},
+		{
+			Name:  "integrity-usecase",
+			Build: buildIntegrityUsecase,
+		},
+		{
+			Name:  "reconciliation-usecase",
+			Build: buildReconciliationUsecase,
+		},
+		{
+			Name:  "payment-usecase",
+			Build: buildPaymentUsecase,
+		},
+		{
+			Name:  "rate-limiter",
+			Build: buildRateLimiter,
+		},
+		{
+			Name:  "rate-limit-clients",
+			Build: buildRateLimitClients,
+		},
 	}...); err != nil {
 		return nil, err
 	}
@@ -35,13 +76,122 @@ func (c *Container) Resolve(name string) interface{} {
 	return c.ctn.Get(name)
 }
 
+// Check builds the use cases and the rate limiter and its clients, it fails
+// when one of them can't be
+func (c *Container) Check() error {
+	for _, name := range []string{"user-usecase", "customer-usecase", "integrity-usecase", "reconciliation-usecase", "payment-usecase", "rate-limiter", "rate-limit-clients"} {
+		if _, err := c.ctn.SafeGet(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (c *Container) Clean() error {
 	return c.ctn.Clean()
 }
 
+// repos holds the repositories of the configured storage backend
+type repos struct {
+	user           repo.UserRepo
+	account        repo.AccountRepo
+	transaction    repo.TransactionRepo
+	integrity      repo.IntegrityRepo
+	reconciliation repo.ReconciliationRepo
+	payment        repo.PaymentRepo
+}
+
+// buildMemoryStore builds the store of the memory backend, tests resolve it
+// to seed users and accounts
+func buildMemoryStore(ctn di.Container) (interface{}, error) {
+	return memory.NewStore(), nil
+}
+
+func buildRepos(ctn di.Container) (interface{}, error) {
+	switch setting.ProjectEnvSettings.StorageBackend {
+	case setting.StorageBackendMemory:
+		store := ctn.Get("memory-store").(*memory.Store)
+		return &repos{
+			user:           memory.NewUserRepo(store),
+			account:        memory.NewAccountRepo(store),
+			transaction:    memory.NewTransactionRepo(store),
+			integrity:      memory.NewIntegrityRepo(store),
+			reconciliation: memory.NewReconciliationRepo(store),
+			payment:        memory.NewPaymentRepo(store),
+		}, nil
+	case setting.StorageBackendMySQL:
+		return &repos{
+			user:           mysql.NewUserRepo(),
+			account:        mysql.NewAccountRepo(),
+			transaction:    mysql.NewTransactionRepo(),
+			integrity:      mysql.NewIntegrityRepo(),
+			reconciliation: mysql.NewReconciliationRepo(),
+			payment:        mysql.NewPaymentRepo(),
+		}, nil
+	case setting.StorageBackendPostgres:
+		return &repos{
+			user:           postgre.NewUserRepo(),
+			account:        postgre.NewAccountRepo(),
+			transaction:    postgre.NewTransactionRepo(),
+			integrity:      postgre.NewIntegrityRepo(),
+			reconciliation: postgre.NewReconciliationRepo(),
+			payment:        postgre.NewPaymentRepo(),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", setting.ProjectEnvSettings.StorageBackend)
+	}
+}
+
 func buildUserUsecase(ctn di.Container) (interface{}, error) {
-	userRepo := postgre.NewUserRepo()
-	accountRepo := postgre.NewAccountRepo()
-	tranRepo := postgre.NewTransactionRepo()
-	return usecase.NewUserUsecase(userRepo, accountRepo, tranRepo), nil
+	r := ctn.Get("repos").(*repos)
+	return metrics.NewUserUsecase(tracing.NewUserUsecase(usecase.NewUserUsecase(r.user, r.account, r.transaction))), nil
+}
+
+func buildCustomerUsecase(ctn di.Container) (interface{}, error) {
+	r := ctn.Get("repos").(*repos)
+	return usecase.NewCustomerUsecase(r.user, r.account), nil
+}
+
+func buildIntegrityUsecase(ctn di.Container) (interface{}, error) {
+	r := ctn.Get("repos").(*repos)
+	return usecase.NewIntegrityUsecase(r.integrity), nil
+}
+
+func buildReconciliationUsecase(ctn di.Container) (interface{}, error) {
+	r := ctn.Get("repos").(*repos)
+	return metrics.NewReconciliationUsecase(usecase.NewReconciliationUsecase(r.user, r.account, r.transaction, r.reconciliation, r.payment)), nil
+}
+
+func buildPaymentUsecase(ctn di.Container) (interface{}, error) {
+	r := ctn.Get("repos").(*repos)
+	return metrics.NewPaymentUsecase(usecase.NewPaymentUsecase(r.user, r.account, r.transaction, r.payment)), nil
+}
+
+func buildRateLimiter(ctn di.Container) (interface{}, error) {
+	limits, err := ratelimit.ParseLimits(setting.ProjectEnvSettings.RateLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	switch backend := setting.ProjectEnvSettings.RateLimitBackend; backend {
+	case setting.RateLimitBackendMemory:
+		return ratelimit.NewLimiter(ratelimit.NewMemoryStore(), limits), nil
+	case setting.RateLimitBackendPostgres:
+		if setting.ProjectEnvSettings.StorageBackend != setting.StorageBackendPostgres {
+			return nil, fmt.Errorf("the %s rate limit backend needs the %s storage backend", backend, setting.StorageBackendPostgres)
+		}
+		return ratelimit.NewLimiter(postgre.NewRateLimitStore(), limits), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend %q", backend)
+	}
+}
+
+func buildRateLimitClients(ctn di.Container) (interface{}, error) {
+	clients, err := ratelimit.ParseClients(setting.ProjectEnvSettings.APIKeys, setting.ProjectEnvSettings.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	return clients, nil
 }
@@ -4,10 +4,11 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/kelseyhightower/envconfig"
-	log "github.com/sirupsen/logrus"
 
+	"go-prj-skeleton/app/logger"
 	"go-prj-skeleton/app/strutil"
 )
 
@@ -16,15 +17,90 @@ type envSettings struct {
 
 	PrintEnvs string `envconfig:"gohelpers_print_envs" default:""`
 
+	// StorageBackend selects the persistence implementation: "postgres", "mysql"
+	// or "memory", which keeps the data in the process and is meant for tests
+	StorageBackend string `envconfig:"storage_backend" default:"postgres"`
+
+	// AutoMigrate applies pending migrations before `serve` starts listening
+	AutoMigrate bool `envconfig:"auto_migrate" default:"false"`
+
+	// GRPCPort is the port of the gRPC server, empty disables it
+	GRPCPort string `envconfig:"grpc_port" default:"50051"`
+
+	// MetricsPort is the port of the admin listener serving /metrics, empty
+	// disables it. It is not meant to be exposed publicly.
+	MetricsPort string `envconfig:"metrics_port" default:"9090"`
+
+	// TraceExporter exports the OpenTelemetry spans: "otlp" to OTLPEndpoint,
+	// "stdout" or "file" to TraceFile. Empty disables the export.
+	TraceExporter string `envconfig:"trace_exporter" default:""`
+	OTLPEndpoint  string `envconfig:"otlp_endpoint" default:"localhost:4317"`
+	OTLPInsecure  bool   `envconfig:"otlp_insecure" default:"false"`
+	TraceFile     string `envconfig:"trace_file" default:"traces.json"`
+
+	// ShutdownTimeout bounds how long the in-flight requests may take to
+	// finish once SIGINT or SIGTERM is received
+	ShutdownTimeout time.Duration `envconfig:"shutdown_timeout" default:"15s"`
+
+	// PanicStack adds the stack of a recovered panic to the 500 response, it
+	// is only honored in development
+	PanicStack bool `envconfig:"panic_stack" default:"false"`
+
+	// RateLimits are the token buckets of the route groups by client scope,
+	// comma separated <group>.<scope>=<n>/<s|m|h>[:<burst>]. Empty disables
+	// the rate limiting.
+	RateLimits string `envconfig:"rate_limits" default:"transactions.ip=10/s:50,admin.ip=1/s:10"`
+
+	// RateLimitBackend keeps the buckets "memory", per replica, or in
+	// "postgres", shared by the replicas of the postgres storage backend
+	RateLimitBackend string `envconfig:"rate_limit_backend" default:"memory"`
+
+	// APIKeys are the comma separated API keys of the clients, only these
+	// identify a client by its X-API-Key in the key scope of the rate limits
+	APIKeys string `envconfig:"api_keys" default:"" secret:"true"`
+
+	// TrustedProxies are the comma separated addresses or CIDRs of the
+	// proxies whose X-Forwarded-For gives the client address the ip scope
+	// of the rate limits uses
+	TrustedProxies string `envconfig:"trusted_proxies" default:""`
+
+	// AdminToken enables the /admin routes, guarded by this bearer token
+	AdminToken string `envconfig:"admin_token" default:"" secret:"true"`
+
+	// LogLevel is the lowest level logged until PUT /admin/log-level changes it
+	LogLevel string `envconfig:"log_level" default:"info"`
+
 	// PostgreSql
 	PostgreHost           string `envconfig:"postgre_host" default:"db"`
 	PostgrePort           string `envconfig:"postgre_port" default:"5432"`
 	PostgreUser           string `envconfig:"postgre_user" default:"admin"`
-	PostgrePassword       string `envconfig:"postgre_password" default:"moneyforward@123"`
+	PostgrePassword       string `envconfig:"postgre_password" default:"moneyforward@123" secret:"true"`
 	PostgreDatabaseName   string `envconfig:"postgre_database_name" default:"postgres"`
 	PostgreMaxConnections int    `envconfig:"postgre_max_connections" default:"16"`
+
+	// MySQL
+	MySQLHost           string `envconfig:"mysql_host" default:"mysql"`
+	MySQLPort           string `envconfig:"mysql_port" default:"3306"`
+	MySQLUser           string `envconfig:"mysql_user" default:"admin"`
+	MySQLPassword       string `envconfig:"mysql_password" default:"moneyforward@123" secret:"true"`
+	MySQLDatabaseName   string `envconfig:"mysql_database_name" default:"bank"`
+	MySQLMaxConnections int    `envconfig:"mysql_max_connections" default:"16"`
 }
 
+// EnvDevelopment is the Env of a local setup
+const EnvDevelopment = "development"
+
+const (
+	RateLimitBackendMemory   = "memory"
+	RateLimitBackendPostgres = "postgres"
+)
+
+const (
+	StorageBackendPostgres = "postgres"
+	StorageBackendMySQL    = "mysql"
+	StorageBackendMemory   = "memory"
+)
+
 // ProjectEnvSettings is the singeton hold all the env vars
 var ProjectEnvSettings *envSettings
 
@@ -45,14 +121,14 @@ func EnvSettingsInit(filterKeys []string) {
 	ProjectEnvSettings = &envSettings{}
 
 	if err := ProjectEnvSettings.readEnvironmentVariables(); err != nil {
-		log.WithError(err).Errorln("error while read env vars for project settings")
+		logger.L().WithError(err).Errorln("error while read env vars for project settings")
 	}
 
 	if ProjectEnvSettings.PrintEnvs != "" {
 		filterKeys = strutil.CleanEmpty(strings.Split(ProjectEnvSettings.PrintEnvs, ","))
 	}
 
-	log.Println("setting env vars:")
+	logger.L().Println("setting env vars:")
 	PrintEnvs("setting", ProjectEnvSettings, filterKeys)
 }
 
@@ -67,6 +143,7 @@ func PrintEnvs(prefix string, spec interface{}, filterKeys []string) {
 	for i := 0; i < s.NumField(); i++ {
 		f := s.Field(i)
 
+		secret := typeOfSpec.Field(i).Tag.Get("secret") == "true"
 		alt := typeOfSpec.Field(i).Tag.Get("envconfig")
 		fieldName := typeOfSpec.Field(i).Name
 		if alt != "" {
@@ -76,7 +153,7 @@ func PrintEnvs(prefix string, spec interface{}, filterKeys []string) {
 		key := strings.ToUpper(fmt.Sprintf("%s_%s", prefix, fieldName))
 		value := f.Interface()
 		if noFilter {
-			if strings.Contains(key, "SECRET") || strings.Contains(key, "PASSWORD") { // not to print secrets if not asked to
+			if secret || strings.Contains(key, "SECRET") || strings.Contains(key, "PASSWORD") || strings.Contains(key, "TOKEN") { // not to print secrets if not asked to
 				continue
 			}
 
@@ -0,0 +1,98 @@
+// Package tracing sets up the OpenTelemetry tracer of the service and
+// instruments the use cases with it.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ServiceName names the service in the exported spans
+const ServiceName = "go-prj-skeleton"
+
+// Exporters
+const (
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+// Config tells where the spans are exported
+type Config struct {
+	// Exporter is one of ExporterOTLP, ExporterStdout and ExporterFile, empty
+	// disables the export
+	Exporter string
+	// OTLPEndpoint is the host:port of the OTLP gRPC collector
+	OTLPEndpoint string
+	// OTLPInsecure sends the spans to the collector without TLS
+	OTLPInsecure bool
+	// File is the path of the JSON file ExporterFile appends the spans to
+	File string
+}
+
+// Tracer starts the spans of the service
+func Tracer() trace.Tracer {
+	return otel.Tracer(ServiceName)
+}
+
+// Init propagates the W3C trace context of the requests and exports the
+// spans as cfg tells. The returned function flushes the spans left and
+// stops the export.
+func Init(ctx context.Context, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	closeFile := func() error { return nil }
+
+	var exporter sdktrace.SpanExporter
+	var err error
+	switch cfg.Exporter {
+	case "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.OTLPEndpoint)}
+		if cfg.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterFile:
+		f, openErr := os.OpenFile(cfg.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if openErr != nil {
+			return nil, fmt.Errorf("open trace file: %w", openErr)
+		}
+		closeFile = f.Close
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, fmt.Errorf("trace exporter %q is not one of %s, %s, %s", cfg.Exporter, ExporterOTLP, ExporterStdout, ExporterFile)
+	}
+	if err != nil {
+		closeFile()
+		return nil, fmt.Errorf("%s trace exporter: %w", cfg.Exporter, err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closeErr := closeFile(); err == nil {
+			err = closeErr
+		}
+
+		return err
+	}, nil
+}
@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/usecase"
+)
+
+// start starts the span of a use case method called for userID
+func start(ctx context.Context, name string, userID int, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs, attribute.Int("user.id", userID))
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// end records err and ends span. Only internal errors fail the span, the
+// others are the caller's.
+func end(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		if model.ErrorCodeOf(err) == model.CodeInternal {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+
+	span.End()
+}
+
+type userUsecase struct {
+	usecase.UserUsecase
+}
+
+// NewUserUsecase traces every method of u, the queries of its repos are
+// traced within their span
+func NewUserUsecase(u usecase.UserUsecase) usecase.UserUsecase {
+	return userUsecase{u}
+}
+
+func (u userUsecase) FindUser(ctx context.Context, userID int) (_ *usecase.User, err error) {
+	ctx, span := start(ctx, "UserUsecase.FindUser", userID)
+	defer func() { end(span, err) }()
+
+	return u.UserUsecase.FindUser(ctx, userID)
+}
+
+func (u userUsecase) FindAccounts(ctx context.Context, userID int) (_ []usecase.Account, err error) {
+	ctx, span := start(ctx, "UserUsecase.FindAccounts", userID)
+	defer func() { end(span, err) }()
+
+	return u.UserUsecase.FindAccounts(ctx, userID)
+}
+
+func (u userUsecase) FindAccountByNumber(ctx context.Context, userID int, bank, number string) (_ *usecase.Account, err error) {
+	ctx, span := start(ctx, "UserUsecase.FindAccountByNumber", userID, attribute.String("account.bank", bank))
+	defer func() { end(span, err) }()
+
+	return u.UserUsecase.FindAccountByNumber(ctx, userID, bank, number)
+}
+
+func (u userUsecase) FindTransactions(ctx context.Context, userID int, accountID *int) (_ []usecase.Transaction, err error) {
+	var attrs []attribute.KeyValue
+	if accountID != nil {
+		attrs = append(attrs, attribute.Int("account.id", *accountID))
+	}
+
+	ctx, span := start(ctx, "UserUsecase.FindTransactions", userID, attrs...)
+	defer func() { end(span, err) }()
+
+	return u.UserUsecase.FindTransactions(ctx, userID, accountID)
+}
+
+func (u userUsecase) FindTransactionPage(ctx context.Context, userID int, accountID *int, p usecase.Page) (_ []usecase.Transaction, _ bool, err error) {
+	attrs := []attribute.KeyValue{attribute.Int("page.after", p.After), attribute.Int("page.limit", p.Limit)}
+	if accountID != nil {
+		attrs = append(attrs, attribute.Int("account.id", *accountID))
+	}
+
+	ctx, span := start(ctx, "UserUsecase.FindTransactionPage", userID, attrs...)
+	defer func() { end(span, err) }()
+
+	return u.UserUsecase.FindTransactionPage(ctx, userID, accountID, p)
+}
+
+func (u userUsecase) EachStatementLine(ctx context.Context, userID int, f usecase.StatementFilter, fn func(usecase.StatementLine) error) (_ map[int]usecase.Balances, err error) {
+	var attrs []attribute.KeyValue
+	if f.AccountID != nil {
+		attrs = append(attrs, attribute.Int("account.id", *f.AccountID))
+	}
+
+	ctx, span := start(ctx, "UserUsecase.EachStatementLine", userID, attrs...)
+	defer func() { end(span, err) }()
+
+	return u.UserUsecase.EachStatementLine(ctx, userID, f, fn)
+}
+
+func (u userUsecase) CreateTransaction(ctx context.Context, userID int, t usecase.CreateTransaction) (_ *usecase.Transaction, err error) {
+	ctx, span := start(ctx, "UserUsecase.CreateTransaction", userID,
+		attribute.Int("account.id", t.AccountID), attribute.String("transaction.type", string(t.TransactionType)))
+	defer func() { end(span, err) }()
+
+	return u.UserUsecase.CreateTransaction(ctx, userID, t)
+}
+
+func (u userUsecase) UpdateTransaction(ctx context.Context, userID, tranID int, t usecase.UpdateTransaction) (_ *usecase.Transaction, err error) {
+	ctx, span := start(ctx, "UserUsecase.UpdateTransaction", userID, attribute.Int("transaction.id", tranID))
+	defer func() { end(span, err) }()
+
+	return u.UserUsecase.UpdateTransaction(ctx, userID, tranID, t)
+}
+
+func (u userUsecase) DeleteTransaction(ctx context.Context, userID, tranID int) (err error) {
+	ctx, span := start(ctx, "UserUsecase.DeleteTransaction", userID, attribute.Int("transaction.id", tranID))
+	defer func() { end(span, err) }()
+
+	return u.UserUsecase.DeleteTransaction(ctx, userID, tranID)
+}
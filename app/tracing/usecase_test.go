@@ -0,0 +1,53 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+	"go-prj-skeleton/app/usecase"
+)
+
+func TestUserUsecase(t *testing.T) {
+	spans := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)))
+
+	store := memory.NewStore()
+	store.AddUser(model.User{ID: 1, Name: "Alice"})
+	store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB"})
+	u := NewUserUsecase(usecase.NewUserUsecase(memory.NewUserRepo(store), memory.NewAccountRepo(store), memory.NewTransactionRepo(store)))
+
+	ctx, parent := Tracer().Start(context.Background(), "parent")
+	_, err := u.CreateTransaction(ctx, 1, usecase.CreateTransaction{AccountID: 1, Amount: decimal.NewFromInt(1500), TransactionType: model.TransactionTypeDeposit})
+	require.NoError(t, err)
+	_, err = u.FindUser(ctx, 2)
+	require.Error(t, err)
+	parent.End()
+
+	ended := spans.Ended()
+	require.Len(t, ended, 3)
+
+	created := ended[0]
+	assert.Equal(t, "UserUsecase.CreateTransaction", created.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), created.Parent().SpanID())
+	assert.Contains(t, created.Attributes(), attribute.Int("user.id", 1))
+	assert.Contains(t, created.Attributes(), attribute.Int("account.id", 1))
+	assert.Equal(t, codes.Unset, created.Status().Code)
+
+	// a missing user is the caller's error, it is recorded without failing the span
+	notFound := ended[1]
+	assert.Equal(t, "UserUsecase.FindUser", notFound.Name())
+	assert.Equal(t, codes.Unset, notFound.Status().Code)
+	require.Len(t, notFound.Events(), 1)
+	assert.Equal(t, "exception", notFound.Events()[0].Name)
+}
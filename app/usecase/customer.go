@@ -0,0 +1,42 @@
+package usecase
+
+import "go-prj-skeleton/app/domain/model"
+
+type CreateAccount struct {
+	// Name is the holder of the account, the name of its user when empty
+	Name   string
+	Bank   string
+	Number string
+	IBAN   string
+}
+
+// UpdateAccount replaces the name, number and IBAN of an account, its bank
+// never changes
+type UpdateAccount struct {
+	Name   string
+	Number string
+	IBAN   string
+}
+
+// Bank is a bank the service accepts accounts of
+type Bank struct {
+	Code string
+	// BIC is the SWIFT code of the bank in payment files
+	BIC string
+	// BIN is the NAPAS bank identification number in VietQR codes
+	BIN string
+}
+
+func toBanks() []Bank {
+	out := make([]Bank, len(model.Banks))
+
+	for i, code := range model.Banks {
+		out[i] = Bank{
+			Code: code,
+			BIC:  model.BankBICs[code],
+			BIN:  model.BankBINs[code],
+		}
+	}
+
+	return out
+}
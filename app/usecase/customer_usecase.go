@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/domain/repo"
+)
+
+// CustomerUsecase manages the users, their accounts and lists the banks.
+// Banks are defined in code with their identifiers and number validators,
+// so they are read only.
+type CustomerUsecase interface {
+	// CreateUser creates the user named name, a taken name is
+	// model.ErrConflict
+	CreateUser(ctx context.Context, name string) (*User, error)
+	RenameUser(ctx context.Context, userID int, name string) (*User, error)
+	// CreateAccount opens an account of the user, a number already used at
+	// its bank is model.ErrConflict
+	CreateAccount(ctx context.Context, userID int, a CreateAccount) (*Account, error)
+	UpdateAccount(ctx context.Context, userID, accountID int, a UpdateAccount) (*Account, error)
+	Banks() []Bank
+}
+
+type customerUsecase struct {
+	userRepo    repo.UserRepo
+	accountRepo repo.AccountRepo
+}
+
+func NewCustomerUsecase(userRepo repo.UserRepo, accountRepo repo.AccountRepo) *customerUsecase {
+	return &customerUsecase{
+		userRepo,
+		accountRepo,
+	}
+}
+
+// withContext returns a copy of u whose repos run their queries in ctx
+func (u *customerUsecase) withContext(ctx context.Context) *customerUsecase {
+	out := *u
+	if r, ok := repo.WithContext(ctx, u.userRepo).(repo.UserRepo); ok {
+		out.userRepo = r
+	}
+	if r, ok := repo.WithContext(ctx, u.accountRepo).(repo.AccountRepo); ok {
+		out.accountRepo = r
+	}
+
+	return &out
+}
+
+func (u *customerUsecase) CreateUser(ctx context.Context, name string) (*User, error) {
+	u = u.withContext(ctx)
+
+	user := model.User{Name: strings.TrimSpace(name)}
+	if user.Name == "" {
+		return nil, &model.FieldError{Field: "/name", Err: fmt.Errorf("is required: %w", model.ErrInvalid)}
+	}
+
+	if err := u.userRepo.Create(&user); err != nil {
+		return nil, err
+	}
+
+	return &User{
+		ID:   user.ID,
+		Name: user.Name,
+	}, nil
+}
+
+func (u *customerUsecase) RenameUser(ctx context.Context, userID int, name string) (*User, error) {
+	u = u.withContext(ctx)
+
+	user := model.User{ID: userID, Name: strings.TrimSpace(name)}
+	if user.Name == "" {
+		return nil, &model.FieldError{Field: "/name", Err: fmt.Errorf("is required: %w", model.ErrInvalid)}
+	}
+
+	if err := u.userRepo.Update(&user); err != nil {
+		return nil, err
+	}
+
+	return &User{
+		ID:   user.ID,
+		Name: user.Name,
+	}, nil
+}
+
+func (u *customerUsecase) CreateAccount(ctx context.Context, userID int, a CreateAccount) (*Account, error) {
+	u = u.withContext(ctx)
+
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	acc := model.Account{
+		UserID: userID,
+		Name:   strings.TrimSpace(a.Name),
+		Bank:   a.Bank,
+		Number: a.Number,
+		IBAN:   a.IBAN,
+	}
+	if acc.Name == "" {
+		acc.Name = user.Name
+	}
+
+	if err := model.ValidateBank(acc.Bank); err != nil {
+		return nil, &model.FieldError{Field: "/bank", Err: err}
+	}
+
+	if err := acc.ValidateNumbers(); err != nil {
+		return nil, err
+	}
+
+	if err := u.accountRepo.Create(&acc); err != nil {
+		return nil, err
+	}
+
+	out := toAccount(acc)
+	return &out, nil
+}
+
+func (u *customerUsecase) UpdateAccount(ctx context.Context, userID, accountID int, a UpdateAccount) (*Account, error) {
+	u = u.withContext(ctx)
+
+	acc, err := u.accountRepo.FindByID(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if acc.UserID != userID {
+		return nil, fmt.Errorf("account[%v] of user[%v] %w", accountID, userID, model.ErrNotFound)
+	}
+
+	acc.Name = strings.TrimSpace(a.Name)
+	acc.Number = a.Number
+	acc.IBAN = a.IBAN
+	if acc.Name == "" {
+		return nil, &model.FieldError{Field: "/name", Err: fmt.Errorf("is required: %w", model.ErrInvalid)}
+	}
+
+	if err := acc.ValidateNumbers(); err != nil {
+		return nil, err
+	}
+
+	if err := u.accountRepo.Update(&acc); err != nil {
+		return nil, err
+	}
+
+	out := toAccount(acc)
+	return &out, nil
+}
+
+func (u *customerUsecase) Banks() []Bank {
+	return toBanks()
+}
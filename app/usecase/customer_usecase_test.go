@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+)
+
+func TestCustomerUsecase(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	newUsecase := func() *customerUsecase {
+		store := memory.NewStore()
+		store.AddUser(model.User{ID: 1, Name: "Alice"})
+		store.AddUser(model.User{ID: 2, Name: "Bob"})
+		store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB", Number: "0071000123456"})
+
+		return NewCustomerUsecase(memory.NewUserRepo(store), memory.NewAccountRepo(store))
+	}
+
+	t.Run("create and rename a user", func(t *testing.T) {
+		uc := newUsecase()
+
+		u, err := uc.CreateUser(ctx, "  Carol ")
+		require.NoError(t, err)
+		assert.Equal(t, &User{ID: 3, Name: "Carol"}, u)
+
+		u, err = uc.RenameUser(ctx, 3, "Caroline")
+		require.NoError(t, err)
+		assert.Equal(t, &User{ID: 3, Name: "Caroline"}, u)
+
+		_, err = uc.RenameUser(ctx, 3, "Bob")
+		assert.True(t, errors.Is(err, model.ErrConflict), "got %v", err)
+
+		_, err = uc.CreateUser(ctx, " ")
+		assert.True(t, errors.Is(err, model.ErrInvalid), "got %v", err)
+	})
+
+	t.Run("create an account named after its user", func(t *testing.T) {
+		uc := newUsecase()
+
+		acc, err := uc.CreateAccount(ctx, 2, CreateAccount{Bank: "ACB", Number: "190123", IBAN: "GB82WEST12345698765432"})
+		require.NoError(t, err)
+		assert.Equal(t, &Account{ID: 2, UserID: 2, Name: "Bob", Bank: "ACB", Number: "190123", IBAN: "GB82WEST12345698765432"}, acc)
+
+		_, err = uc.CreateAccount(ctx, 404, CreateAccount{Bank: "ACB"})
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+
+	t.Run("invalid accounts", func(t *testing.T) {
+		uc := newUsecase()
+
+		for _, a := range []CreateAccount{
+			{Bank: "XYZ"},
+			{Bank: "VCB", Number: "123"},
+			{Bank: "VCB", IBAN: "GB00WEST12345698765432"},
+		} {
+			_, err := uc.CreateAccount(ctx, 2, a)
+			assert.True(t, errors.Is(err, model.ErrInvalid) || errors.Is(err, model.ErrInvalidBank), "%+v: got %v", a, err)
+		}
+
+		_, err := uc.CreateAccount(ctx, 2, CreateAccount{Bank: "VCB", Number: "0071000123456"})
+		assert.True(t, errors.Is(err, model.ErrConflict), "got %v", err)
+	})
+
+	t.Run("update an account", func(t *testing.T) {
+		uc := newUsecase()
+
+		acc, err := uc.UpdateAccount(ctx, 1, 1, UpdateAccount{Name: "Alice Nguyen", Number: "0071000654321"})
+		require.NoError(t, err)
+		assert.Equal(t, &Account{ID: 1, UserID: 1, Name: "Alice Nguyen", Bank: "VCB", Number: "0071000654321"}, acc)
+
+		_, err = uc.UpdateAccount(ctx, 2, 1, UpdateAccount{Name: "Bob"})
+		assert.True(t, errors.Is(err, model.ErrNotFound), "the account of another user, got %v", err)
+
+		_, err = uc.UpdateAccount(ctx, 1, 1, UpdateAccount{Name: "Alice", Number: "12"})
+		assert.True(t, errors.Is(err, model.ErrInvalid), "got %v", err)
+	})
+
+	t.Run("banks", func(t *testing.T) {
+		banks := newUsecase().Banks()
+		require.Len(t, banks, len(model.Banks))
+		assert.Equal(t, Bank{Code: "ACB", BIC: "ASCBVNVX", BIN: "970416"}, banks[1])
+	})
+}
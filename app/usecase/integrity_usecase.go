@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"fmt"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/domain/repo"
+)
+
+type IntegrityViolation struct {
+	model.Violation
+	Repaired bool
+}
+
+type IntegrityReport struct {
+	DryRun     bool
+	Violations []IntegrityViolation
+	Repaired   int
+}
+
+// Unrepaired counts the violations left in the database.
+func (r IntegrityReport) Unrepaired() int {
+	return len(r.Violations) - r.Repaired
+}
+
+type IntegrityUsecase interface {
+	// Check scans the database for broken invariants. Fixable violations
+	// are repaired only when dryRun is false.
+	Check(dryRun bool) (*IntegrityReport, error)
+}
+
+type integrityUsecase struct {
+	integrityRepo repo.IntegrityRepo
+}
+
+func NewIntegrityUsecase(integrityRepo repo.IntegrityRepo) *integrityUsecase {
+	return &integrityUsecase{
+		integrityRepo,
+	}
+}
+
+func (u *integrityUsecase) Check(dryRun bool) (*IntegrityReport, error) {
+	report := &IntegrityReport{
+		DryRun:     dryRun,
+		Violations: []IntegrityViolation{},
+	}
+
+	accounts := map[int]model.Account{}
+	err := u.integrityRepo.EachAccount(func(acc model.Account) error {
+		accounts[acc.ID] = acc
+		for _, v := range model.CheckAccount(acc) {
+			report.Violations = append(report.Violations, IntegrityViolation{Violation: v})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan accounts: %w", err)
+	}
+
+	var trans []model.Transaction
+	err = u.integrityRepo.EachTransaction(func(t model.Transaction) error {
+		violations := model.CheckTransaction(t, accounts)
+		for _, v := range violations {
+			report.Violations = append(report.Violations, IntegrityViolation{Violation: v})
+		}
+
+		if len(violations) > 0 {
+			trans = append(trans, t)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan transactions: %w", err)
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	byID := map[int]model.Transaction{}
+	for _, t := range trans {
+		byID[t.ID] = t
+	}
+
+	for i := range report.Violations {
+		v := &report.Violations[i]
+		if !v.Fixable {
+			continue
+		}
+
+		switch v.Rule {
+		case model.RuleTransactionOwnerMismatch:
+			t := byID[v.EntityID]
+			if err := u.integrityRepo.SetTransactionUser(t.ID, accounts[t.AccountID].UserID); err != nil {
+				return nil, fmt.Errorf("repair transaction[%v]: %w", t.ID, err)
+			}
+		default:
+			continue
+		}
+
+		v.Repaired = true
+		report.Repaired++
+	}
+
+	return report, nil
+}
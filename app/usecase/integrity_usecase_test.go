@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+)
+
+func TestIntegrityUsecase_Check(t *testing.T) {
+	t.Parallel()
+
+	newStore := func() *memory.Store {
+		store := memory.NewStore()
+		store.AddUser(model.User{ID: 1, Name: "Alice"})
+		store.AddUser(model.User{ID: 2, Name: "Bob"})
+		store.AddAccount(model.Account{ID: 1, UserID: 1, Bank: "VCB"})
+		store.AddAccount(model.Account{ID: 2, UserID: 2, Bank: "XYZ"})
+		store.AddTransaction(model.Transaction{
+			ID:              1,
+			UserID:          1,
+			AccountID:       1,
+			Amount:          decimal.NewFromInt(100),
+			TransactionType: model.TransactionTypeDeposit,
+			CreatedAt:       "2020-02-10 20:00:00 +0700",
+		})
+		store.AddTransaction(model.Transaction{
+			ID:              2,
+			UserID:          2,
+			AccountID:       1,
+			Amount:          decimal.NewFromInt(100),
+			TransactionType: model.TransactionTypeDeposit,
+			CreatedAt:       "not a date",
+		})
+
+		return store
+	}
+
+	rules := func(report *IntegrityReport) map[model.Rule]bool {
+		out := map[model.Rule]bool{}
+		for _, v := range report.Violations {
+			out[v.Rule] = v.Repaired
+		}
+
+		return out
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		store := newStore()
+		uc := NewIntegrityUsecase(memory.NewIntegrityRepo(store))
+
+		report, err := uc.Check(true)
+		require.NoError(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, map[model.Rule]bool{
+			model.RuleAccountUnknownBank:          false,
+			model.RuleTransactionOwnerMismatch:    false,
+			model.RuleTransactionInvalidCreatedAt: false,
+		}, rules(report))
+		assert.Equal(t, 3, report.Unrepaired())
+
+		tran, err := memory.NewTransactionRepo(store).FindByID(2)
+		require.NoError(t, err)
+		assert.Equal(t, 2, tran.UserID)
+	})
+
+	t.Run("repair", func(t *testing.T) {
+		store := newStore()
+		uc := NewIntegrityUsecase(memory.NewIntegrityRepo(store))
+
+		report, err := uc.Check(false)
+		require.NoError(t, err)
+		assert.Equal(t, map[model.Rule]bool{
+			model.RuleAccountUnknownBank:          false,
+			model.RuleTransactionOwnerMismatch:    true,
+			model.RuleTransactionInvalidCreatedAt: false,
+		}, rules(report))
+		assert.Equal(t, 1, report.Repaired)
+		assert.Equal(t, 2, report.Unrepaired())
+
+		tran, err := memory.NewTransactionRepo(store).FindByID(2)
+		require.NoError(t, err)
+		assert.Equal(t, 1, tran.UserID)
+
+		report, err = uc.Check(true)
+		require.NoError(t, err)
+		assert.Len(t, report.Violations, 2)
+	})
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+// CreatePayment is a payment of a batch: a withdrawal of Amount paid to the
+// creditor account at CreditorBank
+type CreatePayment struct {
+	Amount          decimal.Decimal
+	CreditorName    string
+	CreditorAccount string
+	CreditorBank    string
+	Remittance      string
+}
+
+// CreatePaymentBatch pays withdrawals of one account from a payment
+// initiation file. DebtorAccount is the number of the account, required only
+// for accounts without one. ExecutionDate is a YYYY-MM-DD date, today when
+// empty.
+type CreatePaymentBatch struct {
+	AccountID     int
+	DebtorAccount string
+	ExecutionDate string
+	Payments      []CreatePayment
+}
+
+type Payment struct {
+	TransactionID   int
+	Amount          decimal.Decimal
+	CreditorName    string
+	CreditorAccount string
+	CreditorBank    string
+	Remittance      string
+	EndToEndID      string
+	Status          model.PaymentStatus
+	ConfirmedAt     string
+}
+
+// PaymentBatch is a batch with what its payment initiation file needs to
+// know about the paying account
+type PaymentBatch struct {
+	ID            int
+	MessageID     string
+	AccountID     int
+	DebtorName    string
+	DebtorAccount string
+	Bank          string
+	ExecutionDate string
+	CreatedAt     string
+	Total         decimal.Decimal
+	Payments      []Payment
+}
+
+// Count returns the number of payments of status s
+func (b PaymentBatch) Count(s model.PaymentStatus) int {
+	n := 0
+	for _, p := range b.Payments {
+		if p.Status == s {
+			n++
+		}
+	}
+
+	return n
+}
+
+// toPaymentBatch needs the amounts of the payments, by transaction id
+func toPaymentBatch(b model.PaymentBatch, acc model.Account, amounts map[int]decimal.Decimal) PaymentBatch {
+	out := PaymentBatch{
+		ID:            b.ID,
+		MessageID:     b.MessageID(),
+		AccountID:     b.AccountID,
+		DebtorName:    acc.Name,
+		DebtorAccount: b.DebtorAccount,
+		Bank:          acc.Bank,
+		ExecutionDate: b.ExecutionDate,
+		CreatedAt:     b.CreatedAt,
+		Total:         decimal.Zero,
+		Payments:      make([]Payment, len(b.Payments)),
+	}
+
+	for i, p := range b.Payments {
+		out.Payments[i] = toPayment(p, amounts[p.TransactionID])
+		out.Total = out.Total.Add(out.Payments[i].Amount)
+	}
+
+	return out
+}
+
+func toPayment(p model.Payment, amount decimal.Decimal) Payment {
+	return Payment{
+		TransactionID:   p.TransactionID,
+		Amount:          amount,
+		CreditorName:    p.CreditorName,
+		CreditorAccount: p.CreditorAccount,
+		CreditorBank:    p.CreditorBank,
+		Remittance:      p.Remittance,
+		EndToEndID:      p.EndToEndID(),
+		Status:          p.Status,
+		ConfirmedAt:     p.ConfirmedAt,
+	}
+}
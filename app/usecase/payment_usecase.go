@@ -0,0 +1,270 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+	"unicode/utf8"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/domain/repo"
+)
+
+// bankAccountNumber is an account identification of a payment file, at most
+// 34 letters and digits
+var bankAccountNumber = regexp.MustCompile(`^[0-9A-Za-z]{1,34}$`)
+
+type PaymentUsecase interface {
+	// CreateBatch records the payments of b as pending withdrawals of its
+	// account, once checked against the balance and the payment limits.
+	CreateBatch(userID int, b CreatePaymentBatch) (*PaymentBatch, error)
+	FindBatches(userID, accountID int) ([]PaymentBatch, error)
+	FindBatch(userID, accountID, batchID int) (*PaymentBatch, error)
+	// ConfirmPayment marks the payment of the withdrawal paid, for banks
+	// whose statements are not imported
+	ConfirmPayment(userID, accountID, transactionID int) (*Payment, error)
+}
+
+type paymentUsecase struct {
+	userRepo    repo.UserRepo
+	accountRepo repo.AccountRepo
+	transRepo   repo.TransactionRepo
+	paymentRepo repo.PaymentRepo
+}
+
+func NewPaymentUsecase(userRepo repo.UserRepo, accountRepo repo.AccountRepo, transRepo repo.TransactionRepo, paymentRepo repo.PaymentRepo) *paymentUsecase {
+	return &paymentUsecase{
+		userRepo,
+		accountRepo,
+		transRepo,
+		paymentRepo,
+	}
+}
+
+func (u *paymentUsecase) account(userID, accountID int) (model.Account, error) {
+	if _, err := u.userRepo.FindByID(userID); err != nil {
+		return model.Account{}, err
+	}
+
+	acc, err := u.accountRepo.FindByID(accountID)
+	if err != nil {
+		return model.Account{}, fmt.Errorf("account[%v] %w", accountID, err)
+	}
+
+	if acc.UserID != userID {
+		return model.Account{}, fmt.Errorf("account[%v] %w", accountID, model.ErrNotFound)
+	}
+
+	return acc, nil
+}
+
+// amounts returns the amounts of the transactions of the account by id and
+// the balance of the account
+func (u *paymentUsecase) amounts(userID, accountID int) (map[int]decimal.Decimal, decimal.Decimal, error) {
+	trans, err := u.transRepo.FindByUserAccount(userID, accountID)
+	if err != nil {
+		return nil, decimal.Zero, err
+	}
+
+	amounts := map[int]decimal.Decimal{}
+	balance := decimal.Zero
+	for _, tran := range trans {
+		amounts[tran.ID] = tran.Amount
+		balance = balance.Add(model.SignedAmount(tran.TransactionType, tran.Amount))
+	}
+
+	return amounts, balance, nil
+}
+
+// validateBatch reports every invalid field of b, by JSON pointer. today is
+// the earliest execution date.
+func validateBatch(b CreatePaymentBatch, today string) model.FieldErrors {
+	errs := model.FieldErrors{}
+	invalid := func(field string, err error) {
+		errs = append(errs, &model.FieldError{Field: field, Err: err})
+	}
+
+	if b.DebtorAccount != "" && !bankAccountNumber.MatchString(b.DebtorAccount) {
+		invalid("/debtor_account", fmt.Errorf("must be 1 to 34 letters and digits: %w", model.ErrInvalid))
+	}
+
+	if b.ExecutionDate != "" {
+		if _, err := time.Parse(model.ExecutionDateLayout, b.ExecutionDate); err != nil {
+			invalid("/execution_date", fmt.Errorf("%q is not a YYYY-MM-DD date: %w", b.ExecutionDate, model.ErrInvalid))
+		} else if b.ExecutionDate < today {
+			invalid("/execution_date", fmt.Errorf("%v is in the past: %w", b.ExecutionDate, model.ErrInvalid))
+		}
+	}
+
+	if len(b.Payments) == 0 || len(b.Payments) > model.MaxBatchPayments {
+		invalid("/payments", fmt.Errorf("must have 1 to %v payments: %w", model.MaxBatchPayments, model.ErrInvalid))
+	}
+
+	for i, p := range b.Payments {
+		field := func(name string) string {
+			return fmt.Sprintf("/payments/%d/%s", i, name)
+		}
+
+		switch {
+		case !p.Amount.IsPositive():
+			invalid(field("amount"), fmt.Errorf("%v is not positive: %w", p.Amount, model.ErrInvalidAmount))
+		case p.Amount.GreaterThan(model.MaxPaymentAmount):
+			invalid(field("amount"), fmt.Errorf("%v is over the limit of %v: %w", p.Amount, model.MaxPaymentAmount, model.ErrInvalidAmount))
+		case !p.Amount.Equal(p.Amount.Round(2)):
+			invalid(field("amount"), fmt.Errorf("%v has more than 2 decimal places: %w", p.Amount, model.ErrInvalidAmount))
+		}
+
+		if p.CreditorName == "" || utf8.RuneCountInString(p.CreditorName) > 70 {
+			invalid(field("creditor_name"), fmt.Errorf("must be 1 to 70 characters: %w", model.ErrInvalid))
+		}
+
+		// the format of a number depends on the bank, the number of an
+		// unknown bank is only checked to fit the file
+		if model.ValidateBank(p.CreditorBank) == nil {
+			if err := model.ValidateAccountNumber(p.CreditorBank, p.CreditorAccount); err != nil {
+				invalid(field("creditor_account"), err)
+			}
+		} else if !bankAccountNumber.MatchString(p.CreditorAccount) {
+			invalid(field("creditor_account"), fmt.Errorf("must be 1 to 34 letters and digits: %w", model.ErrInvalid))
+		}
+
+		if err := model.ValidateBank(p.CreditorBank); err != nil {
+			invalid(field("creditor_bank"), err)
+		}
+
+		if utf8.RuneCountInString(p.Remittance) > 140 {
+			invalid(field("remittance"), fmt.Errorf("must be at most 140 characters: %w", model.ErrInvalid))
+		}
+	}
+
+	return errs
+}
+
+func (u *paymentUsecase) CreateBatch(userID int, b CreatePaymentBatch) (*PaymentBatch, error) {
+	today := time.Now().UTC().Format(model.ExecutionDateLayout)
+	if errs := validateBatch(b, today); len(errs) > 0 {
+		return nil, errs
+	}
+
+	acc, err := u.account(userID, b.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	debtor, err := acc.ResolveNumber(b.DebtorAccount)
+	if err != nil {
+		return nil, &model.FieldError{Field: "/debtor_account", Err: err}
+	}
+
+	batch := &model.PaymentBatch{
+		UserID:        userID,
+		AccountID:     acc.ID,
+		DebtorAccount: debtor,
+		ExecutionDate: b.ExecutionDate,
+	}
+	if batch.ExecutionDate == "" {
+		batch.ExecutionDate = today
+	}
+
+	withdrawals := make([]*model.Transaction, len(b.Payments))
+	for i, p := range b.Payments {
+		withdrawals[i] = model.NewTransaction(userID, acc.ID, p.Amount, model.TransactionTypeWithdraw)
+		batch.Payments = append(batch.Payments, model.Payment{
+			CreditorName:    p.CreditorName,
+			CreditorAccount: p.CreditorAccount,
+			CreditorBank:    p.CreditorBank,
+			Remittance:      p.Remittance,
+		})
+	}
+
+	// the repository checks the balance covers the withdrawals as it
+	// inserts them, concurrent batches can't both spend it
+	if err := u.paymentRepo.CreateBatch(batch, withdrawals); err != nil {
+		return nil, err
+	}
+
+	amounts := map[int]decimal.Decimal{}
+	for _, tran := range withdrawals {
+		amounts[tran.ID] = tran.Amount
+	}
+
+	out := toPaymentBatch(*batch, acc, amounts)
+
+	return &out, nil
+}
+
+func (u *paymentUsecase) FindBatches(userID, accountID int) ([]PaymentBatch, error) {
+	acc, err := u.account(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := u.paymentRepo.FindByAccount(acc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	amounts, _, err := u.amounts(userID, acc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]PaymentBatch, len(bs))
+	for i, b := range bs {
+		out[i] = toPaymentBatch(b, acc, amounts)
+	}
+
+	return out, nil
+}
+
+func (u *paymentUsecase) FindBatch(userID, accountID, batchID int) (*PaymentBatch, error) {
+	acc, err := u.account(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := u.paymentRepo.FindBatch(batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.AccountID != acc.ID {
+		return nil, fmt.Errorf("payment batch[%v] %w", batchID, model.ErrNotFound)
+	}
+
+	amounts, _, err := u.amounts(userID, acc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := toPaymentBatch(b, acc, amounts)
+
+	return &out, nil
+}
+
+func (u *paymentUsecase) ConfirmPayment(userID, accountID, transactionID int) (*Payment, error) {
+	acc, err := u.account(userID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	tran, err := u.transRepo.FindByID(transactionID)
+	if err != nil {
+		return nil, fmt.Errorf("transaction[%v] %w", transactionID, err)
+	}
+
+	if tran.AccountID != acc.ID {
+		return nil, fmt.Errorf("transaction[%v] %w", transactionID, model.ErrNotFound)
+	}
+
+	p, err := u.paymentRepo.Confirm(tran.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := toPayment(p, tran.Amount)
+
+	return &out, nil
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+)
+
+func TestPaymentUsecase(t *testing.T) {
+	t.Parallel()
+
+	newUsecase := func() (*paymentUsecase, *memory.Store) {
+		store := memory.NewStore()
+		store.AddUser(model.User{ID: 1, Name: "Alice"})
+		store.AddUser(model.User{ID: 2, Name: "Bob"})
+		store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB"})
+		store.AddAccount(model.Account{ID: 2, UserID: 2, Name: "Bob", Bank: "ACB"})
+		store.AddTransaction(model.Transaction{
+			ID: 1, UserID: 1, AccountID: 1, Amount: decimal.NewFromInt(1000), TransactionType: model.TransactionTypeDeposit, CreatedAt: "2021-01-02 09:00:00 +0700",
+		})
+
+		return NewPaymentUsecase(
+			memory.NewUserRepo(store),
+			memory.NewAccountRepo(store),
+			memory.NewTransactionRepo(store),
+			memory.NewPaymentRepo(store),
+		), store
+	}
+
+	payment := func(amount int64) CreatePayment {
+		return CreatePayment{
+			Amount:          decimal.NewFromInt(amount),
+			CreditorName:    "Bob",
+			CreditorAccount: "123456789",
+			CreditorBank:    "ACB",
+			Remittance:      "Invoice 1",
+		}
+	}
+
+	t.Run("creates pending withdrawals", func(t *testing.T) {
+		uc, store := newUsecase()
+
+		b, err := uc.CreateBatch(1, CreatePaymentBatch{
+			AccountID:     1,
+			DebtorAccount: "0011001234567",
+			Payments:      []CreatePayment{payment(400), payment(600)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "PB00000001", b.MessageID)
+		assert.Equal(t, "Alice", b.DebtorName)
+		assert.Equal(t, "VCB", b.Bank)
+		assert.Equal(t, time.Now().UTC().Format("2006-01-02"), b.ExecutionDate)
+		assert.Equal(t, "1000", b.Total.String())
+		require.Len(t, b.Payments, 2)
+		assert.Equal(t, model.PaymentStatusPending, b.Payments[0].Status)
+		assert.Equal(t, "TX2", b.Payments[0].EndToEndID)
+
+		tran, err := memory.NewTransactionRepo(store).FindByID(b.Payments[1].TransactionID)
+		require.NoError(t, err)
+		assert.Equal(t, model.TransactionTypeWithdraw, tran.TransactionType)
+		assert.Equal(t, "600", tran.Amount.String())
+
+		bs, err := uc.FindBatches(1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []PaymentBatch{*b}, bs)
+
+		got, err := uc.FindBatch(1, 1, b.ID)
+		require.NoError(t, err)
+		assert.Equal(t, b, got)
+
+		// the balance is spent
+		_, err = uc.CreateBatch(1, CreatePaymentBatch{AccountID: 1, DebtorAccount: "0011001234567", Payments: []CreatePayment{payment(1)}})
+		assert.True(t, errors.Is(err, model.ErrInsufficientFunds), "got %v", err)
+	})
+
+	t.Run("debtor account is the number of the account", func(t *testing.T) {
+		uc, store := newUsecase()
+
+		_, err := uc.CreateBatch(1, CreatePaymentBatch{AccountID: 1, Payments: []CreatePayment{payment(100)}})
+		var fieldErr *model.FieldError
+		require.True(t, errors.As(err, &fieldErr), "got %v", err)
+		assert.Equal(t, "/debtor_account", fieldErr.Field)
+
+		store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB", Number: "0071000123456"})
+
+		b, err := uc.CreateBatch(1, CreatePaymentBatch{AccountID: 1, Payments: []CreatePayment{payment(100)}})
+		require.NoError(t, err)
+		assert.Equal(t, "0071000123456", b.DebtorAccount)
+
+		_, err = uc.CreateBatch(1, CreatePaymentBatch{AccountID: 1, DebtorAccount: "0011001234567", Payments: []CreatePayment{payment(100)}})
+		require.True(t, errors.As(err, &fieldErr), "got %v", err)
+		assert.Equal(t, "/debtor_account", fieldErr.Field)
+	})
+
+	t.Run("creditor account of the format of its bank", func(t *testing.T) {
+		uc, _ := newUsecase()
+
+		p := payment(100)
+		p.CreditorBank = "VCB"
+
+		_, err := uc.CreateBatch(1, CreatePaymentBatch{AccountID: 1, DebtorAccount: "0011001234567", Payments: []CreatePayment{p}})
+		var fieldErrs model.FieldErrors
+		require.True(t, errors.As(err, &fieldErrs), "got %v", err)
+		assert.Equal(t, "/payments/0/creditor_account", fieldErrs[0].Field)
+		assert.EqualError(t, fieldErrs[0].Err, `VCB account number "123456789" is not 13 digits: invalid`)
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		uc, store := newUsecase()
+
+		_, err := uc.CreateBatch(1, CreatePaymentBatch{
+			AccountID:     1,
+			DebtorAccount: "0011001234567",
+			Payments:      []CreatePayment{payment(400), payment(601)},
+		})
+		assert.True(t, errors.Is(err, model.ErrInsufficientFunds), "got %v", err)
+
+		trans, err := memory.NewTransactionRepo(store).FindByUser(1)
+		require.NoError(t, err)
+		assert.Len(t, trans, 1)
+	})
+
+	t.Run("reports every invalid field", func(t *testing.T) {
+		uc, _ := newUsecase()
+
+		over := payment(0)
+		over.Amount = model.MaxPaymentAmount.Add(decimal.NewFromInt(1))
+		invalid := CreatePayment{
+			Amount:          decimal.RequireFromString("1.001"),
+			CreditorName:    strings.Repeat("a", 71),
+			CreditorAccount: "12-34",
+			CreditorBank:    "XYZ",
+			Remittance:      strings.Repeat("r", 141),
+		}
+
+		_, err := uc.CreateBatch(1, CreatePaymentBatch{
+			AccountID:     1,
+			DebtorAccount: "0011-001",
+			ExecutionDate: "2021-01-01",
+			Payments:      []CreatePayment{payment(0), over, invalid},
+		})
+
+		var fieldErrs model.FieldErrors
+		require.True(t, errors.As(err, &fieldErrs), "got %v", err)
+		fields := map[string]model.ErrorCode{}
+		for _, fe := range fieldErrs {
+			fields[fe.Field] = model.ErrorCodeOf(fe.Err)
+		}
+		assert.Equal(t, map[string]model.ErrorCode{
+			"/debtor_account":              model.CodeInvalid,
+			"/execution_date":              model.CodeInvalid,
+			"/payments/0/amount":           model.CodeInvalidAmount,
+			"/payments/1/amount":           model.CodeInvalidAmount,
+			"/payments/2/amount":           model.CodeInvalidAmount,
+			"/payments/2/creditor_name":    model.CodeInvalid,
+			"/payments/2/creditor_account": model.CodeInvalid,
+			"/payments/2/creditor_bank":    model.CodeInvalidBank,
+			"/payments/2/remittance":       model.CodeInvalid,
+		}, fields)
+
+		_, err = uc.CreateBatch(1, CreatePaymentBatch{AccountID: 1, DebtorAccount: "0011001234567"})
+		require.True(t, errors.As(err, &fieldErrs), "got %v", err)
+		assert.Equal(t, "/payments", fieldErrs[0].Field)
+	})
+
+	t.Run("confirm", func(t *testing.T) {
+		uc, _ := newUsecase()
+
+		b, err := uc.CreateBatch(1, CreatePaymentBatch{AccountID: 1, DebtorAccount: "0011001234567", Payments: []CreatePayment{payment(400)}})
+		require.NoError(t, err)
+
+		p, err := uc.ConfirmPayment(1, 1, b.Payments[0].TransactionID)
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusConfirmed, p.Status)
+		assert.NotEmpty(t, p.ConfirmedAt)
+		assert.Equal(t, "400", p.Amount.String())
+
+		// the deposit is not a payment
+		_, err = uc.ConfirmPayment(1, 1, 1)
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+
+	t.Run("account of another user", func(t *testing.T) {
+		uc, _ := newUsecase()
+
+		_, err := uc.CreateBatch(1, CreatePaymentBatch{AccountID: 2, DebtorAccount: "0011001234567", Payments: []CreatePayment{payment(1)}})
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+
+		_, err = uc.FindBatches(1, 2)
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+
+		_, err = uc.ConfirmPayment(1, 2, 1)
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+}
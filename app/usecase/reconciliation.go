@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+// StatementEntry is a line of a bank statement. Amount is signed: credits
+// are positive, debits negative. Date is the booking date, ValueDate is zero
+// for formats without one. Account is the number or IBAN of the account of
+// the statement, empty for formats without one.
+type StatementEntry struct {
+	Date        time.Time
+	ValueDate   time.Time
+	Amount      decimal.Decimal
+	Reference   string
+	Description string
+	Account     string
+}
+
+// ReconcileStatement is a bank statement of one account. A zero AccountID
+// is the account of the user whose IBAN or number the entries name.
+type ReconcileStatement struct {
+	AccountID int
+	Entries   []StatementEntry
+	// Create lists the 1-based lines of the missing entries to record as
+	// transactions. Lines whose reference was reconciled before are skipped,
+	// retried imports do not record them twice.
+	Create []int
+}
+
+type EntryStatus string
+
+var (
+	// EntryStatusMatched entries have a transaction in the ledger
+	EntryStatusMatched EntryStatus = "matched"
+	// EntryStatusMissing entries have none
+	EntryStatusMissing EntryStatus = "missing"
+	// EntryStatusDuplicate entries repeat an earlier entry of the statement
+	EntryStatusDuplicate EntryStatus = "duplicate"
+	// EntryStatusCreated entries were missing and are now recorded
+	EntryStatusCreated EntryStatus = "created"
+)
+
+// ReconciledEntry is a statement entry and the transaction it was matched
+// with, TransactionID is 0 for missing and duplicate entries
+type ReconciledEntry struct {
+	StatementEntry
+	Line          int
+	Status        EntryStatus
+	TransactionID int
+}
+
+// UnmatchedTransaction is a transaction of the statement period missing in
+// the statement. Duplicate is set when another transaction of the same day
+// and amount was matched, it was likely recorded twice.
+type UnmatchedTransaction struct {
+	Transaction
+	Duplicate bool
+}
+
+type ReconciliationReport struct {
+	AccountID int
+	// From and To are the first and last dates of the statement
+	From      time.Time
+	To        time.Time
+	Entries   []ReconciledEntry
+	Unmatched []UnmatchedTransaction
+	// Confirmed are the withdrawals whose pending payment the statement
+	// confirmed
+	Confirmed []int
+}
+
+// Count returns the number of entries of status s
+func (r ReconciliationReport) Count(s EntryStatus) int {
+	n := 0
+	for _, e := range r.Entries {
+		if e.Status == s {
+			n++
+		}
+	}
+
+	return n
+}
+
+type Reconciliation struct {
+	TransactionID int
+	AccountID     int
+	Status        model.ReconciliationStatus
+	Reference     string
+	StatementDate string
+	ValueDate     string
+	ReconciledAt  string
+}
+
+func toReconciliations(rs []model.Reconciliation) []Reconciliation {
+	out := make([]Reconciliation, len(rs))
+
+	for i, r := range rs {
+		out[i] = Reconciliation{
+			TransactionID: r.TransactionID,
+			AccountID:     r.AccountID,
+			Status:        r.Status,
+			Reference:     r.Reference,
+			StatementDate: r.StatementDate,
+			ValueDate:     r.ValueDate,
+			ReconciledAt:  r.ReconciledAt,
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,429 @@
+package usecase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/domain/repo"
+)
+
+// MatchWindow is how far apart the date of a statement entry and the
+// creation of its transaction may be, banks post some transfers days later
+const MatchWindow = 3 * 24 * time.Hour
+
+type ReconciliationUsecase interface {
+	// Reconcile matches the entries of a statement with the transactions of
+	// its account, given or picked by the IBAN or number the statement
+	// names, records the missing entries listed in s.Create, on their
+	// booking date, and stores the reconciliation of every matched or
+	// created transaction, all or none of them. The pending payments of the
+	// matched withdrawals are confirmed.
+	Reconcile(userID int, s ReconcileStatement) (*ReconciliationReport, error)
+	FindReconciliations(userID, accountID int) ([]Reconciliation, error)
+}
+
+type reconciliationUsecase struct {
+	userRepo           repo.UserRepo
+	accountRepo        repo.AccountRepo
+	transRepo          repo.TransactionRepo
+	reconciliationRepo repo.ReconciliationRepo
+	paymentRepo        repo.PaymentRepo
+}
+
+func NewReconciliationUsecase(userRepo repo.UserRepo, accountRepo repo.AccountRepo, transRepo repo.TransactionRepo, reconciliationRepo repo.ReconciliationRepo, paymentRepo repo.PaymentRepo) *reconciliationUsecase {
+	return &reconciliationUsecase{
+		userRepo,
+		accountRepo,
+		transRepo,
+		reconciliationRepo,
+		paymentRepo,
+	}
+}
+
+// ledgerTransaction is a transaction of the reconciled account
+type ledgerTransaction struct {
+	model.Transaction
+	day    time.Time
+	signed decimal.Decimal
+}
+
+// day is the date of t as a UTC midnight
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func abs(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+
+	return d
+}
+
+func (u *reconciliationUsecase) account(userID, accountID int) (model.Account, error) {
+	if _, err := u.userRepo.FindByID(userID); err != nil {
+		return model.Account{}, err
+	}
+
+	acc, err := u.accountRepo.FindByID(accountID)
+	if err != nil {
+		return model.Account{}, fmt.Errorf("account[%v] %w", accountID, err)
+	}
+
+	if acc.UserID != userID {
+		return model.Account{}, fmt.Errorf("account[%v] %w", accountID, model.ErrNotFound)
+	}
+
+	return acc, nil
+}
+
+// statementAccount returns the account s.AccountID of the user, else the
+// one the entries name by its IBAN or number
+func (u *reconciliationUsecase) statementAccount(userID int, s ReconcileStatement) (model.Account, error) {
+	if s.AccountID != 0 {
+		return u.account(userID, s.AccountID)
+	}
+
+	numbers := []string{}
+	seen := map[string]bool{}
+	for _, e := range s.Entries {
+		if e.Account != "" && !seen[e.Account] {
+			numbers = append(numbers, e.Account)
+			seen[e.Account] = true
+		}
+	}
+
+	switch {
+	case len(numbers) == 0:
+		return model.Account{}, fmt.Errorf("statement names no account, import it at its account: %w", model.ErrInvalid)
+	case len(numbers) > 1:
+		return model.Account{}, fmt.Errorf("statements of %d accounts, import them one by one at their account: %w", len(numbers), model.ErrInvalid)
+	}
+
+	if _, err := u.userRepo.FindByID(userID); err != nil {
+		return model.Account{}, err
+	}
+
+	accounts, err := u.accountRepo.FindByUser(userID)
+	if err != nil {
+		return model.Account{}, err
+	}
+
+	acc, ok := model.Accounts(accounts).ByNumber(numbers[0])
+	if !ok {
+		return model.Account{}, fmt.Errorf("account[%v] %w", numbers[0], model.ErrNotFound)
+	}
+
+	return acc, nil
+}
+
+// entriesOf keeps the entries of the statements of acc, picked by its number
+// or IBAN. Statements of an account without them have to be about a single
+// account.
+func entriesOf(acc model.Account, entries []StatementEntry) ([]StatementEntry, error) {
+	accounts := map[string]bool{}
+	for _, e := range entries {
+		if e.Account != "" {
+			accounts[e.Account] = true
+		}
+	}
+
+	if acc.Number == "" && acc.IBAN == "" {
+		if len(accounts) > 1 {
+			return nil, fmt.Errorf("statements of %d accounts, account[%v] has no number to pick its own, import them one by one: %w", len(accounts), acc.ID, model.ErrInvalid)
+		}
+
+		return entries, nil
+	}
+
+	out := []StatementEntry{}
+	for _, e := range entries {
+		if e.Account == "" || e.Account == acc.Number || e.Account == acc.IBAN {
+			out = append(out, e)
+		}
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("no statement of account[%v]: %w", acc.ID, model.ErrInvalid)
+	}
+
+	return out, nil
+}
+
+func (u *reconciliationUsecase) FindReconciliations(userID, accountID int) ([]Reconciliation, error) {
+	if _, err := u.account(userID, accountID); err != nil {
+		return nil, err
+	}
+
+	rs, err := u.reconciliationRepo.FindByAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toReconciliations(rs), nil
+}
+
+// Reconcile flags the entries repeating the reference, date and amount of
+// an earlier entry as duplicates. The others are matched with the
+// transaction reconciled with their reference before, else with the
+// transaction of the same signed amount created closest to their date,
+// within MatchWindow.
+func (u *reconciliationUsecase) Reconcile(userID int, s ReconcileStatement) (*ReconciliationReport, error) {
+	acc, err := u.statementAccount(userID, s)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.Entries) == 0 {
+		return nil, fmt.Errorf("statement has no entries: %w", model.ErrInvalid)
+	}
+
+	if s.Entries, err = entriesOf(acc, s.Entries); err != nil {
+		return nil, err
+	}
+
+	trans, err := u.transRepo.FindByUserAccount(userID, acc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ledger := make([]ledgerTransaction, len(trans))
+	byID := map[int]ledgerTransaction{}
+	for i, tran := range trans {
+		createdAt, err := model.ParseCreatedAt(tran.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("transaction[%v] created_at[%v]: %w", tran.ID, tran.CreatedAt, err)
+		}
+
+		ledger[i] = ledgerTransaction{tran, day(createdAt), model.SignedAmount(tran.TransactionType, tran.Amount)}
+		byID[tran.ID] = ledger[i]
+	}
+
+	recs, err := u.reconciliationRepo.FindByAccount(acc.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciled := map[int]model.Reconciliation{}
+	for _, r := range recs {
+		reconciled[r.TransactionID] = r
+	}
+
+	report := &ReconciliationReport{AccountID: acc.ID, Entries: make([]ReconciledEntry, len(s.Entries))}
+	seen := map[string]bool{}
+	for i, e := range s.Entries {
+		e.Date = day(e.Date)
+		entry := ReconciledEntry{StatementEntry: e, Line: i + 1, Status: EntryStatusMissing}
+
+		if report.From.IsZero() || e.Date.Before(report.From) {
+			report.From = e.Date
+		}
+		if e.Date.After(report.To) {
+			report.To = e.Date
+		}
+
+		if e.Reference != "" {
+			key := fmt.Sprintf("%s|%s|%s", e.Date.Format(model.StatementDateLayout), e.Amount.String(), e.Reference)
+			if seen[key] {
+				entry.Status = EntryStatusDuplicate
+			}
+			seen[key] = true
+		}
+
+		report.Entries[i] = entry
+	}
+
+	used := map[int]bool{}
+	match := func(entry *ReconciledEntry, tranID int) {
+		entry.Status = EntryStatusMatched
+		entry.TransactionID = tranID
+		used[tranID] = true
+	}
+
+	// a reference reconciled before identifies its transaction, creating the
+	// entry again is a retry of an earlier import
+	reconciledBefore := map[int]bool{}
+	for i := range report.Entries {
+		entry := &report.Entries[i]
+		if entry.Status != EntryStatusMissing || entry.Reference == "" {
+			continue
+		}
+
+		for _, r := range recs {
+			tran, ok := byID[r.TransactionID]
+			if ok && !used[tran.ID] && r.Reference == entry.Reference && tran.signed.Equal(entry.Amount) {
+				match(entry, tran.ID)
+				reconciledBefore[entry.Line] = true
+				break
+			}
+		}
+	}
+
+	for i := range report.Entries {
+		entry := &report.Entries[i]
+		if entry.Status != EntryStatusMissing {
+			continue
+		}
+
+		best, bestDistance := 0, time.Duration(0)
+		for _, tran := range ledger {
+			if used[tran.ID] || !tran.signed.Equal(entry.Amount) {
+				continue
+			}
+
+			// reconciled with another line of a statement
+			if r, ok := reconciled[tran.ID]; ok && r.Reference != "" && r.Reference != entry.Reference {
+				continue
+			}
+
+			distance := abs(tran.day.Sub(entry.Date))
+			if distance <= MatchWindow && (best == 0 || distance < bestDistance) {
+				best, bestDistance = tran.ID, distance
+			}
+		}
+
+		if best != 0 {
+			match(entry, best)
+		}
+	}
+
+	for _, line := range s.Create {
+		if reconciledBefore[line] {
+			continue
+		}
+
+		if line < 1 || line > len(report.Entries) || report.Entries[line-1].Status != EntryStatusMissing {
+			return nil, &model.FieldError{Field: "create", Err: fmt.Errorf("line %d is not a missing entry: %w", line, model.ErrInvalid)}
+		}
+
+		if report.Entries[line-1].Amount.IsZero() {
+			return nil, &model.FieldError{Field: "create", Err: fmt.Errorf("line %d has no amount: %w", line, model.ErrInvalid)}
+		}
+	}
+
+	saved := []*model.Reconciliation{}
+	for i := range report.Entries {
+		entry := &report.Entries[i]
+		if entry.Status != EntryStatusMatched {
+			continue
+		}
+
+		status := model.ReconciliationStatusMatched
+		if r, ok := reconciled[entry.TransactionID]; ok && r.Status == model.ReconciliationStatusCreated {
+			status = r.Status
+		}
+
+		saved = append(saved, reconciliationOf(entry, status))
+	}
+
+	created := []model.ReconciledTransaction{}
+	createdLines := []*ReconciledEntry{}
+	for _, line := range s.Create {
+		entry := &report.Entries[line-1]
+		if entry.Status != EntryStatusMissing {
+			continue
+		}
+
+		transactionType := model.TransactionTypeDeposit
+		if entry.Amount.IsNegative() {
+			transactionType = model.TransactionTypeWithdraw
+		}
+
+		// the transaction happened on the booking date of the line
+		tran := model.NewTransaction(userID, acc.ID, entry.Amount.Abs(), transactionType)
+		tran.CreatedAt = entry.Date.Format(model.CreatedAtLayout)
+
+		created = append(created, model.ReconciledTransaction{
+			Transaction:    tran,
+			Reconciliation: reconciliationOf(entry, model.ReconciliationStatusCreated),
+		})
+		createdLines = append(createdLines, entry)
+	}
+
+	if err := u.reconciliationRepo.SaveStatement(saved, created); err != nil {
+		return nil, fmt.Errorf("save statement of account[%v] %w", acc.ID, err)
+	}
+
+	for i, entry := range createdLines {
+		entry.Status = EntryStatusCreated
+		entry.TransactionID = created[i].Transaction.ID
+	}
+
+	if report.Confirmed, err = u.confirmPayments(acc.ID, used); err != nil {
+		return nil, err
+	}
+
+	report.Unmatched = []UnmatchedTransaction{}
+	for _, tran := range ledger {
+		if used[tran.ID] || tran.day.Before(report.From) || tran.day.After(report.To) {
+			continue
+		}
+
+		unmatched := UnmatchedTransaction{
+			Transaction: Transaction{
+				ID:              tran.ID,
+				AccountID:       tran.AccountID,
+				Amount:          tran.Amount,
+				Bank:            acc.Bank,
+				TransactionType: tran.TransactionType,
+				CreatedAt:       tran.CreatedAt,
+			},
+		}
+
+		for _, other := range ledger {
+			if used[other.ID] && other.day.Equal(tran.day) && other.signed.Equal(tran.signed) {
+				unmatched.Duplicate = true
+				break
+			}
+		}
+
+		report.Unmatched = append(report.Unmatched, unmatched)
+	}
+
+	return report, nil
+}
+
+// reconciliationOf is the reconciliation of the transaction of entry
+func reconciliationOf(entry *ReconciledEntry, status model.ReconciliationStatus) *model.Reconciliation {
+	r := &model.Reconciliation{
+		TransactionID: entry.TransactionID,
+		Status:        status,
+		Reference:     entry.Reference,
+		StatementDate: entry.Date.Format(model.StatementDateLayout),
+	}
+
+	if !entry.ValueDate.IsZero() {
+		r.ValueDate = entry.ValueDate.Format(model.StatementDateLayout)
+	}
+
+	return r
+}
+
+// confirmPayments confirms the pending payments of the matched transactions
+// and returns their transaction ids
+func (u *reconciliationUsecase) confirmPayments(accountID int, matched map[int]bool) ([]int, error) {
+	batches, err := u.paymentRepo.FindByAccount(accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	confirmed := []int{}
+	for _, b := range batches {
+		for _, p := range b.Payments {
+			if p.Status != model.PaymentStatusPending || !matched[p.TransactionID] {
+				continue
+			}
+
+			if _, err := u.paymentRepo.Confirm(p.TransactionID); err != nil {
+				return nil, err
+			}
+
+			confirmed = append(confirmed, p.TransactionID)
+		}
+	}
+
+	return confirmed, nil
+}
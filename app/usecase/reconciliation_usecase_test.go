@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+)
+
+func TestReconciliationUsecase_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	newUsecase := func() (*reconciliationUsecase, *memory.Store) {
+		store := memory.NewStore()
+		store.AddUser(model.User{ID: 1, Name: "Alice"})
+		store.AddUser(model.User{ID: 2, Name: "Bob"})
+		store.AddAccount(model.Account{ID: 1, UserID: 1, Bank: "VCB"})
+		store.AddAccount(model.Account{ID: 2, UserID: 2, Bank: "ACB"})
+
+		for _, tran := range []model.Transaction{
+			{ID: 1, Amount: decimal.NewFromInt(500), TransactionType: model.TransactionTypeDeposit, CreatedAt: "2021-01-02 09:00:00 +0700"},
+			{ID: 2, Amount: decimal.NewFromInt(100), TransactionType: model.TransactionTypeWithdraw, CreatedAt: "2021-01-05 09:00:00 +0700"},
+			{ID: 3, Amount: decimal.NewFromInt(100), TransactionType: model.TransactionTypeWithdraw, CreatedAt: "2021-01-09 09:00:00 +0700"},
+			{ID: 4, Amount: decimal.NewFromInt(70), TransactionType: model.TransactionTypeDeposit, CreatedAt: "2021-01-07 09:00:00 +0700"},
+			{ID: 5, Amount: decimal.NewFromInt(70), TransactionType: model.TransactionTypeDeposit, CreatedAt: "2021-01-07 10:00:00 +0700"},
+		} {
+			tran.UserID, tran.AccountID = 1, 1
+			store.AddTransaction(tran)
+		}
+
+		return NewReconciliationUsecase(
+			memory.NewUserRepo(store),
+			memory.NewAccountRepo(store),
+			memory.NewTransactionRepo(store),
+			memory.NewReconciliationRepo(store),
+			memory.NewPaymentRepo(store),
+		), store
+	}
+
+	date := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		require.NoError(t, err)
+		return d
+	}
+
+	entries := []StatementEntry{
+		{Date: date("2021-01-02"), Amount: decimal.NewFromInt(500), Reference: "FT001"},
+		// transaction 3 is closer than 2
+		{Date: date("2021-01-08"), Amount: decimal.NewFromInt(-100), Reference: "FT002"},
+		{Date: date("2021-01-07"), Amount: decimal.NewFromInt(70), Reference: "FT003"},
+		{Date: date("2021-01-07"), Amount: decimal.NewFromInt(70), Reference: "FT003"},
+		{Date: date("2021-01-08"), ValueDate: date("2021-01-09"), Amount: decimal.NewFromInt(-20), Reference: "FEE"},
+	}
+
+	statuses := func(report *ReconciliationReport) []string {
+		out := []string{}
+		for _, e := range report.Entries {
+			out = append(out, string(e.Status))
+		}
+
+		return out
+	}
+
+	t.Run("matches and flags", func(t *testing.T) {
+		uc, _ := newUsecase()
+
+		report, err := uc.Reconcile(1, ReconcileStatement{AccountID: 1, Entries: entries})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"matched", "matched", "matched", "duplicate", "missing"}, statuses(report))
+		assert.Equal(t, 1, report.Entries[0].TransactionID)
+		assert.Equal(t, 3, report.Entries[1].TransactionID)
+		assert.Equal(t, 4, report.Entries[2].TransactionID)
+		assert.Equal(t, date("2021-01-02"), report.From)
+		assert.Equal(t, date("2021-01-08"), report.To)
+
+		// 5 looks like a second record of 4, 2 is simply not on the statement
+		require.Len(t, report.Unmatched, 2)
+		assert.Equal(t, 2, report.Unmatched[0].ID)
+		assert.False(t, report.Unmatched[0].Duplicate)
+		assert.Equal(t, 5, report.Unmatched[1].ID)
+		assert.True(t, report.Unmatched[1].Duplicate)
+
+		recs, err := uc.FindReconciliations(1, 1)
+		require.NoError(t, err)
+		require.Len(t, recs, 3)
+		assert.Equal(t, Reconciliation{
+			TransactionID: 3,
+			AccountID:     1,
+			Status:        model.ReconciliationStatusMatched,
+			Reference:     "FT002",
+			StatementDate: "2021-01-08",
+			ReconciledAt:  recs[1].ReconciledAt,
+		}, recs[1])
+	})
+
+	t.Run("creates the confirmed missing entries", func(t *testing.T) {
+		uc, store := newUsecase()
+
+		report, err := uc.Reconcile(1, ReconcileStatement{AccountID: 1, Entries: entries, Create: []int{5}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"matched", "matched", "matched", "duplicate", "created"}, statuses(report))
+
+		created, err := memory.NewTransactionRepo(store).FindByID(report.Entries[4].TransactionID)
+		require.NoError(t, err)
+		assert.Equal(t, model.TransactionTypeWithdraw, created.TransactionType)
+		assert.Equal(t, "20", created.Amount.String())
+		assert.Equal(t, "2021-01-08 00:00:00 +0000", created.CreatedAt, "booked on the date of the line")
+
+		// importing the statement again matches the created transaction by
+		// its reference and keeps its status, the line is not created twice
+		report, err = uc.Reconcile(1, ReconcileStatement{AccountID: 1, Entries: entries, Create: []int{5}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"matched", "matched", "matched", "duplicate", "matched"}, statuses(report))
+		assert.Equal(t, created.ID, report.Entries[4].TransactionID)
+
+		recs, err := uc.FindReconciliations(1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, model.ReconciliationStatusCreated, recs[len(recs)-1].Status)
+		assert.Equal(t, "2021-01-08", recs[len(recs)-1].StatementDate)
+		assert.Equal(t, "2021-01-09", recs[len(recs)-1].ValueDate)
+	})
+
+	t.Run("only missing entries can be created", func(t *testing.T) {
+		uc, store := newUsecase()
+
+		for _, line := range []int{0, 1, 4, 6} {
+			_, err := uc.Reconcile(1, ReconcileStatement{AccountID: 1, Entries: entries, Create: []int{line}})
+			var fieldErr *model.FieldError
+			require.True(t, errors.As(err, &fieldErr), "line %d: got %v", line, err)
+			assert.Equal(t, "create", fieldErr.Field)
+		}
+
+		trans, err := memory.NewTransactionRepo(store).FindByUser(1)
+		require.NoError(t, err)
+		assert.Len(t, trans, 5)
+	})
+
+	t.Run("confirms pending payments", func(t *testing.T) {
+		uc, store := newUsecase()
+
+		payments := memory.NewPaymentRepo(store)
+		require.NoError(t, payments.CreateBatch(&model.PaymentBatch{
+			UserID: 1, AccountID: 1, DebtorAccount: "0011001234567", ExecutionDate: "2021-01-05",
+			Payments: []model.Payment{
+				{TransactionID: 2, CreditorName: "Bob", CreditorAccount: "123", CreditorBank: "ACB"},
+				{TransactionID: 3, CreditorName: "Bob", CreditorAccount: "123", CreditorBank: "ACB"},
+			},
+		}, nil))
+
+		report, err := uc.Reconcile(1, ReconcileStatement{AccountID: 1, Entries: entries})
+		require.NoError(t, err)
+		assert.Equal(t, []int{3}, report.Confirmed)
+
+		b, err := payments.FindBatch(1)
+		require.NoError(t, err)
+		assert.Equal(t, model.PaymentStatusPending, b.Payments[0].Status)
+		assert.Equal(t, model.PaymentStatusConfirmed, b.Payments[1].Status)
+
+		report, err = uc.Reconcile(1, ReconcileStatement{AccountID: 1, Entries: entries})
+		require.NoError(t, err)
+		assert.Empty(t, report.Confirmed)
+	})
+
+	t.Run("outside the match window", func(t *testing.T) {
+		uc, _ := newUsecase()
+
+		report, err := uc.Reconcile(1, ReconcileStatement{AccountID: 1, Entries: []StatementEntry{
+			{Date: date("2021-01-20"), Amount: decimal.NewFromInt(500)},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"missing"}, statuses(report))
+		assert.Empty(t, report.Unmatched)
+	})
+
+	t.Run("statements of several accounts", func(t *testing.T) {
+		uc, store := newUsecase()
+
+		of := func(account string, e StatementEntry) StatementEntry {
+			e.Account = account
+			return e
+		}
+		mixed := []StatementEntry{
+			of("0071000123456", entries[0]),
+			of("VN12VCB0011001234567", entries[1]),
+			of("GB82WEST12345698765432", entries[4]),
+		}
+
+		_, err := uc.Reconcile(1, ReconcileStatement{AccountID: 1, Entries: mixed})
+		assert.True(t, errors.Is(err, model.ErrInvalid), "got %v", err)
+
+		store.AddAccount(model.Account{ID: 1, UserID: 1, Bank: "VCB", Number: "0071000123456", IBAN: "GB82WEST12345698765432"})
+
+		report, err := uc.Reconcile(1, ReconcileStatement{AccountID: 1, Entries: mixed})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"matched", "missing"}, statuses(report))
+		assert.Equal(t, "FEE", report.Entries[1].Reference)
+
+		_, err = uc.Reconcile(1, ReconcileStatement{AccountID: 1, Entries: mixed[1:2]})
+		assert.True(t, errors.Is(err, model.ErrInvalid), "got %v", err)
+	})
+
+	t.Run("account the statement names", func(t *testing.T) {
+		uc, store := newUsecase()
+		store.AddAccount(model.Account{ID: 1, UserID: 1, Bank: "VCB", Number: "0071000123456", IBAN: "GB82WEST12345698765432"})
+		store.AddAccount(model.Account{ID: 2, UserID: 2, Bank: "ACB", Number: "190123"})
+
+		of := func(account string, e StatementEntry) StatementEntry {
+			e.Account = account
+			return e
+		}
+
+		report, err := uc.Reconcile(1, ReconcileStatement{Entries: []StatementEntry{of("GB82WEST12345698765432", entries[0])}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, report.AccountID)
+		assert.Equal(t, []string{"matched"}, statuses(report))
+
+		for _, s := range [][]StatementEntry{
+			entries[:1],
+			{of("0071000123456", entries[0]), of("GB82WEST12345698765432", entries[1])},
+		} {
+			_, err = uc.Reconcile(1, ReconcileStatement{Entries: s})
+			assert.True(t, errors.Is(err, model.ErrInvalid), "got %v", err)
+		}
+
+		// accounts of other users are not found
+		_, err = uc.Reconcile(1, ReconcileStatement{Entries: []StatementEntry{of("190123", entries[0])}})
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+
+	t.Run("account of another user", func(t *testing.T) {
+		uc, _ := newUsecase()
+
+		_, err := uc.Reconcile(1, ReconcileStatement{AccountID: 2, Entries: entries})
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+
+		_, err = uc.FindReconciliations(1, 2)
+		assert.True(t, errors.Is(err, model.ErrNotFound), "got %v", err)
+	})
+
+	t.Run("empty statement", func(t *testing.T) {
+		uc, _ := newUsecase()
+
+		_, err := uc.Reconcile(1, ReconcileStatement{AccountID: 1})
+		assert.True(t, errors.Is(err, model.ErrInvalid), "got %v", err)
+	})
+}
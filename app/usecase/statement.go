@@ -0,0 +1,34 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// StatementFilter selects the transactions of a statement. A zero From or To
+// leaves the period open on that side, To is exclusive.
+type StatementFilter struct {
+	AccountID *int
+	From      time.Time
+	To        time.Time
+}
+
+func (f StatementFilter) includes(t time.Time) bool {
+	return (f.From.IsZero() || !t.Before(f.From)) && (f.To.IsZero() || t.Before(f.To))
+}
+
+// StatementLine is a transaction of a statement
+type StatementLine struct {
+	Transaction
+	PostedAt time.Time
+	// Balance is the balance of the account after the transaction
+	Balance decimal.Decimal
+}
+
+// Balances are the balances of an account before and after the period of a
+// statement
+type Balances struct {
+	Opening decimal.Decimal
+	Closing decimal.Decimal
+}
@@ -17,6 +17,13 @@ type UpdateTransaction struct {
 	Amount decimal.Decimal
 }
 
+// Page is the keyset pagination of a listing ordered by id: at most Limit
+// items with an id greater than After. A zero Limit is every item.
+type Page struct {
+	After int
+	Limit int
+}
+
 type Transaction struct {
 	ID              int
 	AccountID       int
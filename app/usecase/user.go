@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"strings"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+// unmaskedDigits is how many digits of masked account numbers are shown
+const unmaskedDigits = 4
+
+type User struct {
+	ID   int
+	Name string
+}
+
+type Account struct {
+	ID     int
+	UserID int
+	Name   string
+	Bank   string
+	Number string
+	IBAN   string
+}
+
+func toAccount(acc model.Account) Account {
+	return Account{
+		ID:     acc.ID,
+		UserID: acc.UserID,
+		Name:   acc.Name,
+		Bank:   acc.Bank,
+		Number: acc.Number,
+		IBAN:   acc.IBAN,
+	}
+}
+
+func toAccounts(accs []model.Account) []Account {
+	out := make([]Account, len(accs))
+
+	for i, acc := range accs {
+		out[i] = toAccount(acc)
+	}
+
+	return out
+}
+
+// mask hides all but the last unmaskedDigits characters of s
+func mask(s string) string {
+	if len(s) <= unmaskedDigits {
+		return strings.Repeat("*", len(s))
+	}
+
+	return strings.Repeat("*", len(s)-unmaskedDigits) + s[len(s)-unmaskedDigits:]
+}
+
+// ResolveNumber returns the number of acc, see model.Account.ResolveNumber
+func (acc Account) ResolveNumber(given string) (string, error) {
+	return model.Account{ID: acc.ID, Number: acc.Number}.ResolveNumber(given)
+}
+
+// Masked hides all but the last unmaskedDigits characters of the number and
+// IBAN of acc, for the callers who are not authenticated as its owner
+func (acc Account) Masked() Account {
+	acc.Number = mask(acc.Number)
+	acc.IBAN = mask(acc.IBAN)
+
+	return acc
+}
@@ -1,6 +1,7 @@
 package usecase
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -11,10 +12,19 @@ import (
 )
 
 type UserUsecase interface {
-	FindTransactions(userID int, accountID *int) ([]Transaction, error)
-	CreateTransaction(userID int, t CreateTransaction) (*Transaction, error)
-	UpdateTransaction(userID, tranID int, t UpdateTransaction) (*Transaction, error)
-	DeleteTransaction(userID, tranID int) error
+	FindUser(ctx context.Context, userID int) (*User, error)
+	FindAccounts(ctx context.Context, userID int) ([]Account, error)
+	// FindAccountByNumber looks up the account numbered number at bank, of
+	// any user. Its number and IBAN are masked, userID is not authenticated.
+	FindAccountByNumber(ctx context.Context, userID int, bank, number string) (*Account, error)
+	FindTransactions(ctx context.Context, userID int, accountID *int) ([]Transaction, error)
+	// FindTransactionPage returns the page p of the transactions
+	// FindTransactions returns, and whether more follow
+	FindTransactionPage(ctx context.Context, userID int, accountID *int, p Page) ([]Transaction, bool, error)
+	EachStatementLine(ctx context.Context, userID int, f StatementFilter, fn func(StatementLine) error) (map[int]Balances, error)
+	CreateTransaction(ctx context.Context, userID int, t CreateTransaction) (*Transaction, error)
+	UpdateTransaction(ctx context.Context, userID, tranID int, t UpdateTransaction) (*Transaction, error)
+	DeleteTransaction(ctx context.Context, userID, tranID int) error
 }
 
 type userUsecase struct {
@@ -31,7 +41,76 @@ func NewUserUsecase(userRepo repo.UserRepo, accountRepo repo.AccountRepo, transR
 	}
 }
 
-func (u *userUsecase) FindTransactions(userID int, accountID *int) ([]Transaction, error) {
+// withContext returns a copy of u whose repos run their queries in ctx, to
+// trace them within the span of the request
+func (u *userUsecase) withContext(ctx context.Context) *userUsecase {
+	out := *u
+	if r, ok := repo.WithContext(ctx, u.userRepo).(repo.UserRepo); ok {
+		out.userRepo = r
+	}
+	if r, ok := repo.WithContext(ctx, u.accountRepo).(repo.AccountRepo); ok {
+		out.accountRepo = r
+	}
+	if r, ok := repo.WithContext(ctx, u.transRepo).(repo.TransactionRepo); ok {
+		out.transRepo = r
+	}
+
+	return &out
+}
+
+func (u *userUsecase) FindUser(ctx context.Context, userID int) (*User, error) {
+	u = u.withContext(ctx)
+
+	user, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &User{
+		ID:   user.ID,
+		Name: user.Name,
+	}, nil
+}
+
+func (u *userUsecase) FindAccounts(ctx context.Context, userID int) ([]Account, error) {
+	u = u.withContext(ctx)
+
+	_, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	accs, err := u.accountRepo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAccounts(accs), nil
+}
+
+func (u *userUsecase) FindAccountByNumber(ctx context.Context, userID int, bank, number string) (*Account, error) {
+	u = u.withContext(ctx)
+
+	if _, err := u.userRepo.FindByID(userID); err != nil {
+		return nil, err
+	}
+
+	if err := model.ValidateAccountNumber(bank, number); err != nil {
+		return nil, err
+	}
+
+	acc, err := u.accountRepo.FindByNumber(bank, number)
+	if err != nil {
+		return nil, fmt.Errorf("%s account %s %w", bank, mask(number), err)
+	}
+
+	out := toAccount(acc).Masked()
+	return &out, nil
+}
+
+func (u *userUsecase) FindTransactions(ctx context.Context, userID int, accountID *int) ([]Transaction, error) {
+	u = u.withContext(ctx)
+
 	_, err := u.userRepo.FindByID(userID)
 	if err != nil {
 		return nil, err
@@ -64,7 +143,126 @@ func (u *userUsecase) FindTransactions(userID int, accountID *int) ([]Transactio
 	return toTransactions(trans, accounts)
 }
 
-func (u *userUsecase) CreateTransaction(userID int, t CreateTransaction) (*Transaction, error) {
+func (u *userUsecase) FindTransactionPage(ctx context.Context, userID int, accountID *int, p Page) ([]Transaction, bool, error) {
+	u = u.withContext(ctx)
+
+	_, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// one more transaction than the page tells whether more follow
+	limit := p.Limit
+	if limit > 0 {
+		limit++
+	}
+
+	trans, err := u.transRepo.FindPage(userID, accountID, p.After, limit)
+	if err != nil {
+		return nil, false, err
+	}
+
+	more := p.Limit > 0 && len(trans) > p.Limit
+	if more {
+		trans = trans[:p.Limit]
+	}
+
+	if len(trans) == 0 {
+		return []Transaction{}, false, nil
+	}
+
+	accounts, err := u.accountRepo.FindByUser(userID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	out, err := toTransactions(trans, accounts)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return out, more, nil
+}
+
+// EachStatementLine calls fn, in created_at then id order, with the
+// transactions of the user, or of one of its accounts, created during the
+// period of f. The transactions are streamed from the repository, the
+// balances are computed from the whole history and returned by account id
+// once fn saw every line.
+func (u *userUsecase) EachStatementLine(ctx context.Context, userID int, f StatementFilter, fn func(StatementLine) error) (map[int]Balances, error) {
+	u = u.withContext(ctx)
+
+	_, err := u.userRepo.FindByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	accs, err := u.accountRepo.FindByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := map[int]Balances{}
+	for _, acc := range accs {
+		if f.AccountID == nil || *f.AccountID == acc.ID {
+			balances[acc.ID] = Balances{}
+		}
+	}
+
+	if f.AccountID != nil {
+		if _, ok := balances[*f.AccountID]; !ok {
+			return nil, fmt.Errorf("account[%v] %w", *f.AccountID, model.ErrNotFound)
+		}
+	}
+
+	err = u.transRepo.EachByUser(userID, f.AccountID, func(tran model.Transaction) error {
+		acc, ok := model.Accounts(accs).ByID(tran.AccountID)
+		if !ok {
+			return fmt.Errorf("account[%v] %w", tran.AccountID, model.ErrNotFound)
+		}
+
+		postedAt, err := model.ParseCreatedAt(tran.CreatedAt)
+		if err != nil {
+			return fmt.Errorf("transaction[%v] created_at[%v]: %w", tran.ID, tran.CreatedAt, err)
+		}
+
+		b := balances[acc.ID]
+		signed := model.SignedAmount(tran.TransactionType, tran.Amount)
+		if !f.From.IsZero() && postedAt.Before(f.From) {
+			b.Opening = b.Opening.Add(signed)
+		}
+		if f.includes(postedAt) || postedAt.Before(f.From) {
+			b.Closing = b.Closing.Add(signed)
+		}
+		balances[acc.ID] = b
+
+		if !f.includes(postedAt) {
+			return nil
+		}
+
+		return fn(StatementLine{
+			Transaction: Transaction{
+				ID:              tran.ID,
+				AccountID:       tran.AccountID,
+				Amount:          tran.Amount,
+				Bank:            acc.Bank,
+				TransactionType: tran.TransactionType,
+				CreatedAt:       tran.CreatedAt,
+			},
+			PostedAt: postedAt,
+			Balance:  b.Closing,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return balances, nil
+}
+
+func (u *userUsecase) CreateTransaction(ctx context.Context, userID int, t CreateTransaction) (*Transaction, error) {
+	u = u.withContext(ctx)
+
 	if err := model.ValidateTransactionType(t.TransactionType); err != nil {
 		return nil, err
 	}
@@ -107,7 +305,9 @@ func (u *userUsecase) CreateTransaction(userID int, t CreateTransaction) (*Trans
 	}, nil
 }
 
-func (u userUsecase) UpdateTransaction(userID, tranID int, t UpdateTransaction) (*Transaction, error) {
+func (u *userUsecase) UpdateTransaction(ctx context.Context, userID, tranID int, t UpdateTransaction) (*Transaction, error) {
+	u = u.withContext(ctx)
+
 	zero := decimal.NewFromInt(0)
 	if t.Amount.LessThanOrEqual(zero) {
 		return nil, fmt.Errorf("amount[%v]: %w", t.Amount.String(), model.ErrInvalid)
@@ -148,6 +348,6 @@ func (u userUsecase) UpdateTransaction(userID, tranID int, t UpdateTransaction)
 	}, nil
 }
 
-func (u *userUsecase) DeleteTransaction(userID, tranID int) error {
-	return u.transRepo.Delete(userID, tranID)
+func (u *userUsecase) DeleteTransaction(ctx context.Context, userID, tranID int) error {
+	return u.withContext(ctx).transRepo.Delete(userID, tranID)
 }
@@ -1,10 +1,12 @@
 package usecase
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"go-prj-skeleton/app/domain/model"
 	"go-prj-skeleton/app/domain/repo/mock"
@@ -12,6 +14,7 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUserUsecase_FindTransactions(t *testing.T) {
@@ -100,7 +103,7 @@ func TestUserUsecase_FindTransactions(t *testing.T) {
 		t.Run("valid user & empty account id", func(t *testing.T) {
 			t.Parallel()
 
-			trans, err := uc.FindTransactions(1, nil)
+			trans, err := uc.FindTransactions(context.Background(), 1, nil)
 			assert.NoError(t, err)
 
 			bytes, err := json.Marshal(trans)
@@ -130,7 +133,7 @@ func TestUserUsecase_FindTransactions(t *testing.T) {
 			t.Parallel()
 
 			accountID := int(1)
-			trans, err := uc.FindTransactions(1, &accountID)
+			trans, err := uc.FindTransactions(context.Background(), 1, &accountID)
 			assert.NoError(t, err)
 
 			bytes, err := json.Marshal(trans)
@@ -147,14 +150,14 @@ func TestUserUsecase_FindTransactions(t *testing.T) {
 		})
 
 		t.Run("valid user with no transactions", func(t *testing.T) {
-			trans, err := uc.FindTransactions(2, nil)
+			trans, err := uc.FindTransactions(context.Background(), 2, nil)
 			assert.NoError(t, err)
 			assert.Equal(t, 0, len(trans))
 		})
 
 		t.Run("valid user & account_id has no transaction", func(t *testing.T) {
 			accountID := int(2)
-			trans, err := uc.FindTransactions(1, &accountID)
+			trans, err := uc.FindTransactions(context.Background(), 1, &accountID)
 			assert.NoError(t, err)
 			assert.Equal(t, 0, len(trans))
 		})
@@ -203,14 +206,14 @@ func TestUserUsecase_FindTransactions(t *testing.T) {
 		uc := NewUserUsecase(userRepo, accountRepo, transRepo)
 
 		t.Run("user has transaction but contains invalid account id", func(t *testing.T) {
-			_, err := uc.FindTransactions(3, nil)
+			_, err := uc.FindTransactions(context.Background(), 3, nil)
 			if assert.Error(t, err) {
 				assert.EqualError(t, err, "account[4] not found")
 			}
 		})
 
 		t.Run("user not found", func(t *testing.T) {
-			_, err := uc.FindTransactions(4, nil)
+			_, err := uc.FindTransactions(context.Background(), 4, nil)
 			if assert.Error(t, err) {
 				assert.True(t, errors.Is(err, model.ErrNotFound))
 				assert.EqualError(t, err, "user id:4 not found")
@@ -279,18 +282,18 @@ func TestUserUsecase_FindTransactions(t *testing.T) {
 		uc := NewUserUsecase(userRepo, accountRepo, transRepo)
 
 		t.Run("find transaction by user", func(t *testing.T) {
-			_, err := uc.FindTransactions(1, nil)
+			_, err := uc.FindTransactions(context.Background(), 1, nil)
 			assert.EqualError(t, err, "find transactions by user got internal error")
 		})
 
 		t.Run("find transaction by user and account", func(t *testing.T) {
 			accountID := int(1)
-			_, err := uc.FindTransactions(1, &accountID)
+			_, err := uc.FindTransactions(context.Background(), 1, &accountID)
 			assert.EqualError(t, err, "find transactions by user and account got internal error")
 		})
 
 		t.Run("find accounts by user", func(t *testing.T) {
-			_, err := uc.FindTransactions(3, nil)
+			_, err := uc.FindTransactions(context.Background(), 3, nil)
 			assert.EqualError(t, err, "find accounts by user got internal error")
 		})
 	})
@@ -340,7 +343,7 @@ func TestUserUsecase_CreateTransaction(t *testing.T) {
 		}
 
 		uc := NewUserUsecase(userRepo, accountRepo, tranRepo)
-		createdTran, err := uc.CreateTransaction(1, CreateTransaction{
+		createdTran, err := uc.CreateTransaction(context.Background(), 1, CreateTransaction{
 			AccountID:       1,
 			Amount:          decimal.NewFromInt(1000),
 			TransactionType: model.TransactionTypeDeposit,
@@ -371,7 +374,7 @@ func TestUserUsecase_CreateTransaction(t *testing.T) {
 			}
 
 			uc := NewUserUsecase(nil, nil, nil)
-			_, err := uc.CreateTransaction(1, tran)
+			_, err := uc.CreateTransaction(context.Background(), 1, tran)
 			assert.EqualError(t, err, "TTT: invalid transaction type")
 		})
 
@@ -383,7 +386,7 @@ func TestUserUsecase_CreateTransaction(t *testing.T) {
 			}
 
 			uc := NewUserUsecase(nil, nil, nil)
-			_, err := uc.CreateTransaction(1, tran)
+			_, err := uc.CreateTransaction(context.Background(), 1, tran)
 			assert.EqualError(t, err, "amount[0]: invalid")
 		})
 
@@ -401,7 +404,7 @@ func TestUserUsecase_CreateTransaction(t *testing.T) {
 			}
 
 			uc := NewUserUsecase(userRepo, nil, nil)
-			_, err := uc.CreateTransaction(1, tran)
+			_, err := uc.CreateTransaction(context.Background(), 1, tran)
 			assert.True(t, errors.Is(err, model.ErrNotFound))
 			assert.EqualError(t, err, "not found")
 		})
@@ -430,7 +433,7 @@ func TestUserUsecase_CreateTransaction(t *testing.T) {
 			}
 
 			uc := NewUserUsecase(userRepo, accountRepo, nil)
-			_, err := uc.CreateTransaction(1, tran)
+			_, err := uc.CreateTransaction(context.Background(), 1, tran)
 			assert.True(t, errors.Is(err, model.ErrInvalid))
 			assert.EqualError(t, err, "account[1] invalid")
 		})
@@ -466,7 +469,7 @@ func TestUserUsecase_CreateTransaction(t *testing.T) {
 			}
 
 			uc := NewUserUsecase(userRepo, accountRepo, nil)
-			_, err := uc.CreateTransaction(1, tran)
+			_, err := uc.CreateTransaction(context.Background(), 1, tran)
 			assert.True(t, errors.Is(err, model.ErrInvalid))
 			assert.EqualError(t, err, "account[1] invalid")
 		})
@@ -508,7 +511,7 @@ func TestUserUsecase_CreateTransaction(t *testing.T) {
 			}
 
 			uc := NewUserUsecase(userRepo, accountRepo, tranRepo)
-			_, err := uc.CreateTransaction(1, tran)
+			_, err := uc.CreateTransaction(context.Background(), 1, tran)
 			assert.EqualError(t, err, "persit transaction: internal error")
 		})
 	})
@@ -568,7 +571,7 @@ func TestUserUsecase_UpdateTransaction(t *testing.T) {
 
 		uc := NewUserUsecase(userRepo, accountRepo, tranRepo)
 
-		tran, err := uc.UpdateTransaction(1, 2, UpdateTransaction{decimal.NewFromInt(2000)})
+		tran, err := uc.UpdateTransaction(context.Background(), 1, 2, UpdateTransaction{decimal.NewFromInt(2000)})
 		assert.NoError(t, err)
 
 		bytes, err := json.Marshal(tran)
@@ -586,7 +589,7 @@ func TestUserUsecase_UpdateTransaction(t *testing.T) {
 	t.Run("fail", func(t *testing.T) {
 		t.Run("zero amount", func(t *testing.T) {
 			uc := NewUserUsecase(nil, nil, nil)
-			_, err := uc.UpdateTransaction(1, 2, UpdateTransaction{decimal.NewFromInt(0)})
+			_, err := uc.UpdateTransaction(context.Background(), 1, 2, UpdateTransaction{decimal.NewFromInt(0)})
 			assert.True(t, errors.Is(err, model.ErrInvalid))
 			assert.EqualError(t, err, "amount[0]: invalid")
 		})
@@ -600,7 +603,7 @@ func TestUserUsecase_UpdateTransaction(t *testing.T) {
 
 			uc := NewUserUsecase(userRepo, nil, nil)
 
-			_, err := uc.UpdateTransaction(1, 2, UpdateTransaction{decimal.NewFromInt(2000)})
+			_, err := uc.UpdateTransaction(context.Background(), 1, 2, UpdateTransaction{decimal.NewFromInt(2000)})
 			assert.True(t, errors.Is(err, model.ErrNotFound))
 			assert.EqualError(t, err, "find user[1] not found")
 		})
@@ -626,7 +629,7 @@ func TestUserUsecase_UpdateTransaction(t *testing.T) {
 
 			uc := NewUserUsecase(userRepo, nil, tranRepo)
 
-			_, err := uc.UpdateTransaction(1, 2, UpdateTransaction{decimal.NewFromInt(2000)})
+			_, err := uc.UpdateTransaction(context.Background(), 1, 2, UpdateTransaction{decimal.NewFromInt(2000)})
 			assert.True(t, errors.Is(err, model.ErrNotFound))
 			assert.EqualError(t, err, "find transaction[2] not found")
 		})
@@ -671,7 +674,7 @@ func TestUserUsecase_UpdateTransaction(t *testing.T) {
 
 			uc := NewUserUsecase(userRepo, accountRepo, tranRepo)
 
-			_, err := uc.UpdateTransaction(1, 2, UpdateTransaction{decimal.NewFromInt(2000)})
+			_, err := uc.UpdateTransaction(context.Background(), 1, 2, UpdateTransaction{decimal.NewFromInt(2000)})
 			assert.EqualError(t, err, "internal error")
 		})
 
@@ -726,7 +729,7 @@ func TestUserUsecase_UpdateTransaction(t *testing.T) {
 
 			uc := NewUserUsecase(userRepo, accountRepo, tranRepo)
 
-			_, err := uc.UpdateTransaction(1, 2, UpdateTransaction{decimal.NewFromInt(2000)})
+			_, err := uc.UpdateTransaction(context.Background(), 1, 2, UpdateTransaction{decimal.NewFromInt(2000)})
 			assert.True(t, errors.Is(err, model.ErrInvalid))
 			assert.EqualError(t, err, "transaction[2] invalid")
 		})
@@ -782,8 +785,203 @@ func TestUserUsecase_UpdateTransaction(t *testing.T) {
 
 			uc := NewUserUsecase(userRepo, accountRepo, tranRepo)
 
-			_, err := uc.UpdateTransaction(1, 2, UpdateTransaction{decimal.NewFromInt(2000)})
+			_, err := uc.UpdateTransaction(context.Background(), 1, 2, UpdateTransaction{decimal.NewFromInt(2000)})
 			assert.EqualError(t, err, "update transaction[2] internal error")
 		})
 	})
 }
+
+func TestUserUsecase_FindAccounts(t *testing.T) {
+	t.Parallel()
+
+	userRepo := &mock.FakeUserRepo{
+		FindByIDHook: func(userID int) (model.User, error) {
+			if userID == 1 {
+				return model.User{ID: 1, Name: "Alice"}, nil
+			}
+
+			return model.User{}, fmt.Errorf("user id:%v %w", userID, model.ErrNotFound)
+		},
+	}
+
+	accountRepo := &mock.FakeAccountRepo{
+		FindByUserHook: func(userID int) ([]model.Account, error) {
+			return []model.Account{
+				{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB"},
+				{ID: 2, UserID: 1, Name: "Alice", Bank: "ACB"},
+			}, nil
+		},
+	}
+
+	uc := NewUserUsecase(userRepo, accountRepo, &mock.FakeTransactionRepo{})
+
+	t.Run("valid", func(t *testing.T) {
+		user, err := uc.FindUser(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, &User{ID: 1, Name: "Alice"}, user)
+
+		accs, err := uc.FindAccounts(context.Background(), 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []Account{
+			{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB"},
+			{ID: 2, UserID: 1, Name: "Alice", Bank: "ACB"},
+		}, accs)
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		_, err := uc.FindUser(context.Background(), 2)
+		assert.True(t, errors.Is(err, model.ErrNotFound))
+
+		_, err = uc.FindAccounts(context.Background(), 2)
+		assert.True(t, errors.Is(err, model.ErrNotFound))
+	})
+}
+
+func TestUserUsecase_FindAccountByNumber(t *testing.T) {
+	t.Parallel()
+
+	userRepo := &mock.FakeUserRepo{
+		FindByIDHook: func(userID int) (model.User, error) {
+			if userID == 1 || userID == 2 {
+				return model.User{ID: userID}, nil
+			}
+
+			return model.User{}, fmt.Errorf("user id:%v %w", userID, model.ErrNotFound)
+		},
+	}
+
+	accountRepo := &mock.FakeAccountRepo{
+		FindByNumberHook: func(bank, number string) (model.Account, error) {
+			if bank == "VCB" && number == "0071000123456" {
+				return model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB", Number: number, IBAN: "GB82WEST12345698765432"}, nil
+			}
+
+			return model.Account{}, model.ErrNotFound
+		},
+	}
+
+	uc := NewUserUsecase(userRepo, accountRepo, &mock.FakeTransactionRepo{})
+
+	t.Run("masked for every user", func(t *testing.T) {
+		for _, userID := range []int{1, 2} {
+			acc, err := uc.FindAccountByNumber(context.Background(), userID, "VCB", "0071000123456")
+			require.NoError(t, err)
+			assert.Equal(t, &Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB", Number: "*********3456", IBAN: "******************5432"}, acc)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := uc.FindAccountByNumber(context.Background(), 1, "VCB", "12345")
+		assert.True(t, errors.Is(err, model.ErrInvalid), err)
+
+		_, err = uc.FindAccountByNumber(context.Background(), 1, "XYZ", "0071000123456")
+		assert.True(t, errors.Is(err, model.ErrInvalidBank), err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := uc.FindAccountByNumber(context.Background(), 1, "VCB", "0071000999999")
+		assert.True(t, errors.Is(err, model.ErrNotFound), err)
+
+		_, err = uc.FindAccountByNumber(context.Background(), 3, "VCB", "0071000123456")
+		assert.True(t, errors.Is(err, model.ErrNotFound), err)
+	})
+}
+
+func TestUserUsecase_EachStatementLine(t *testing.T) {
+	t.Parallel()
+
+	userRepo := &mock.FakeUserRepo{
+		FindByIDHook: func(userID int) (model.User, error) {
+			if userID == 1 {
+				return model.User{ID: 1, Name: "Alice"}, nil
+			}
+
+			return model.User{}, fmt.Errorf("user id:%v %w", userID, model.ErrNotFound)
+		},
+	}
+
+	accountRepo := &mock.FakeAccountRepo{
+		FindByUserHook: func(userID int) ([]model.Account, error) {
+			return []model.Account{
+				{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB"},
+				{ID: 2, UserID: 1, Name: "Alice", Bank: "ACB"},
+			}, nil
+		},
+	}
+
+	trans := []model.Transaction{
+		{ID: 1, AccountID: 1, Amount: decimal.NewFromInt(100), TransactionType: model.TransactionTypeDeposit, CreatedAt: "2021-01-01 10:00:00 +0000"},
+		{ID: 2, AccountID: 2, Amount: decimal.NewFromInt(50), TransactionType: model.TransactionTypeDeposit, CreatedAt: "2021-01-15 10:00:00 +0000"},
+		{ID: 3, AccountID: 1, Amount: decimal.NewFromInt(30), TransactionType: model.TransactionTypeWithdraw, CreatedAt: "2021-02-01 10:00:00 +0000"},
+		{ID: 4, AccountID: 1, Amount: decimal.NewFromInt(20), TransactionType: model.TransactionTypeDeposit, CreatedAt: "2021-02-20 10:00:00 +0000"},
+		{ID: 5, AccountID: 1, Amount: decimal.NewFromInt(5), TransactionType: model.TransactionTypeWithdraw, CreatedAt: "2021-03-01 10:00:00 +0000"},
+	}
+
+	transRepo := &mock.FakeTransactionRepo{
+		EachByUserHook: func(userID int, accountID *int, fn func(model.Transaction) error) error {
+			for _, tran := range trans {
+				if accountID == nil || *accountID == tran.AccountID {
+					if err := fn(tran); err != nil {
+						return err
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+
+	uc := NewUserUsecase(userRepo, accountRepo, transRepo)
+
+	collect := func(f StatementFilter) ([]string, map[int]Balances, error) {
+		lines := []string{}
+		balances, err := uc.EachStatementLine(context.Background(), 1, f, func(l StatementLine) error {
+			lines = append(lines, fmt.Sprintf("%v:%v", l.ID, l.Balance))
+			return nil
+		})
+
+		return lines, balances, err
+	}
+
+	t.Run("every account", func(t *testing.T) {
+		lines, balances, err := collect(StatementFilter{})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"1:100", "2:50", "3:70", "4:90", "5:85"}, lines)
+		assert.Equal(t, "85", balances[1].Closing.String())
+		assert.Equal(t, "50", balances[2].Closing.String())
+		assert.True(t, balances[1].Opening.IsZero())
+	})
+
+	t.Run("period of one account", func(t *testing.T) {
+		accountID := 1
+		lines, balances, err := collect(StatementFilter{
+			AccountID: &accountID,
+			From:      time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			To:        time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"3:70", "4:90"}, lines)
+		assert.Len(t, balances, 1)
+		assert.Equal(t, "100", balances[1].Opening.String())
+		assert.Equal(t, "90", balances[1].Closing.String())
+	})
+
+	t.Run("empty period", func(t *testing.T) {
+		lines, balances, err := collect(StatementFilter{From: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)})
+		assert.NoError(t, err)
+		assert.Empty(t, lines)
+		assert.Equal(t, "85", balances[1].Opening.String())
+		assert.Equal(t, "85", balances[1].Closing.String())
+	})
+
+	t.Run("account of another user", func(t *testing.T) {
+		accountID := 3
+		_, _, err := collect(StatementFilter{AccountID: &accountID})
+		assert.True(t, errors.Is(err, model.ErrNotFound))
+	})
+
+	t.Run("user not found", func(t *testing.T) {
+		_, err := uc.EachStatementLine(context.Background(), 2, StatementFilter{}, nil)
+		assert.True(t, errors.Is(err, model.ErrNotFound))
+	})
+}
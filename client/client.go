@@ -0,0 +1,279 @@
+// Package client is the Go client of the REST API served by cmd/srv.
+//
+// Errors returned for problem responses are *Error values matching the
+// domain errors of package model, e.g. errors.Is(err, model.ErrNotFound).
+// Requests failing with a network error or a 429, 500, 502, 503 or 504
+// status are retried; non safe requests carry an Idempotency-Key reused by
+// their retries, so a retry never applies a change twice.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"go-prj-skeleton/app/requestid"
+)
+
+const (
+	// DefaultMaxRetries is how many times a failed request is retried
+	DefaultMaxRetries = 3
+
+	// DefaultBackoff is the delay before the first retry, it doubles for
+	// every following one
+	DefaultBackoff = 100 * time.Millisecond
+
+	maxBackoff = 5 * time.Second
+)
+
+// Client calls the API at a base URL. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	token      string
+	maxRetries int
+	backoff    time.Duration
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sends the requests with hc instead of http.DefaultClient
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithToken sends "Authorization: Bearer <token>" with every request, the
+// admin endpoints require it
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithRetries sets how many times a failed request is retried and the delay
+// before the first retry. Zero maxRetries disables retries.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// New returns a client of the API served at baseURL, e.g.
+// "http://localhost:8080".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("parse base url: %w", err)
+	}
+
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("base url %q must be absolute", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		maxRetries: DefaultMaxRetries,
+		backoff:    DefaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+type idempotencyKeyCtxKey struct{}
+
+// WithIdempotencyKey returns a copy of ctx making the non safe request sent
+// with it use key as Idempotency-Key instead of a generated one. Reuse the
+// key when retrying the call yourself, e.g. after a restart.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+// rawBody is a request body sent as is rather than JSON encoded
+type rawBody struct {
+	contentType string
+	data        []byte
+}
+
+// do sends a request to path, relative to the base URL, with in encoded as
+// the JSON body when not nil, or sent as is when a rawBody, and decodes the
+// JSON response into out when not nil. It returns the response headers.
+func (c *Client) do(ctx context.Context, method, path string, in, out interface{}) (http.Header, error) {
+	var body []byte
+	contentType := "application/json"
+	switch in := in.(type) {
+	case nil:
+	case rawBody:
+		body, contentType = in.data, in.contentType
+	default:
+		var err error
+		if body, err = json.Marshal(in); err != nil {
+			return nil, fmt.Errorf("encode request: %w", err)
+		}
+	}
+
+	u, err := c.baseURL.Parse(c.baseURL.Path + path)
+	if err != nil {
+		return nil, fmt.Errorf("parse path %q: %w", path, err)
+	}
+
+	key := ""
+	if method != http.MethodGet && method != http.MethodHead {
+		key, _ = ctx.Value(idempotencyKeyCtxKey{}).(string)
+		if key == "" {
+			key = requestid.New()
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, u.String(), key, contentType, body)
+		if err == nil && !retryable(resp.StatusCode) {
+			defer resp.Body.Close()
+			return resp.Header, decode(resp, out)
+		}
+
+		var retryAfter time.Duration
+		if err == nil {
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+			if attempt >= c.maxRetries {
+				defer resp.Body.Close()
+				return resp.Header, decode(resp, out)
+			}
+
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		if err != nil && (attempt >= c.maxRetries || ctx.Err() != nil) {
+			return nil, err
+		}
+
+		if err := c.wait(ctx, attempt, retryAfter); err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, u, key, contentType string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json, application/problem+json")
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	if key != "" {
+		req.Header.Set("Idempotency-Key", key)
+	}
+
+	return c.httpClient.Do(req)
+}
+
+// wait sleeps before retry attempt+1, using retryAfter when the server
+// asked for it
+func (c *Client) wait(ctx context.Context, attempt int, retryAfter time.Duration) error {
+	delay := retryAfter
+	if delay == 0 {
+		delay = c.backoff << uint(attempt)
+		if delay > maxBackoff || delay <= 0 {
+			delay = maxBackoff
+		}
+
+		// full jitter keeps clients failing together from retrying together
+		delay = time.Duration(rand.Int63n(int64(delay)) + 1)
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func retryable(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+func parseRetryAfter(s string) time.Duration {
+	if s == "" {
+		return 0
+	}
+
+	if secs, err := strconv.Atoi(s); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+
+	if t, err := http.ParseTime(s); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+
+	return 0
+}
+
+// decode returns the *Error of a non 2xx response, or decodes its JSON body
+// into out. A *[]byte or io.Writer out gets the raw body.
+func decode(resp *http.Response, out interface{}) error {
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newError(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if raw, ok := out.(*[]byte); ok {
+		b, err := io.ReadAll(resp.Body)
+		*raw = b
+		return err
+	}
+
+	if w, ok := out.(io.Writer); ok {
+		_, err := io.Copy(w, resp.Body)
+		return err
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,426 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+	"go-prj-skeleton/app/interface/restful"
+	"go-prj-skeleton/app/registry"
+	"go-prj-skeleton/app/setting"
+)
+
+const adminToken = "secret"
+
+var settingsOnce sync.Once
+
+// newTestServer serves restful.Handlers over the memory backend, user 1
+// owns accounts 1 and 2. wrap, when not nil, wraps the handlers.
+func newTestServer(t *testing.T, wrap func(http.Handler) http.Handler) *httptest.Server {
+	settingsOnce.Do(func() {
+		setting.EnvSettingsInit(nil)
+		setting.ProjectEnvSettings.StorageBackend = setting.StorageBackendMemory
+		setting.ProjectEnvSettings.AdminToken = adminToken
+	})
+
+	ctn, err := registry.NewContainer()
+	require.NoError(t, err)
+	t.Cleanup(func() { ctn.Clean() })
+
+	store := ctn.Resolve("memory-store").(*memory.Store)
+	store.AddUser(model.User{ID: 1, Name: "Alice"})
+	store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB"})
+	store.AddAccount(model.Account{ID: 2, UserID: 1, Name: "Alice", Bank: "ACB"})
+
+	var h http.Handler = restful.Handlers(ctn)
+	if wrap != nil {
+		h = wrap(h)
+	}
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func newTestClient(t *testing.T, srv *httptest.Server, opts ...Option) *Client {
+	opts = append([]Option{WithRetries(3, time.Millisecond)}, opts...)
+	c, err := New(srv.URL, opts...)
+	require.NoError(t, err)
+
+	return c
+}
+
+func deposit(accountID int, amount int64) CreateTransaction {
+	return CreateTransaction{
+		AccountID:       accountID,
+		Amount:          decimal.NewFromInt(amount),
+		TransactionType: model.TransactionTypeDeposit,
+	}
+}
+
+func TestClient_Transactions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := newTestClient(t, newTestServer(t, nil))
+
+	created, err := c.CreateTransaction(ctx, 1, deposit(1, 100))
+	require.NoError(t, err)
+	assert.Equal(t, 1, created.AccountID)
+	assert.Equal(t, "VCB", created.Bank)
+	assert.True(t, decimal.NewFromInt(100).Equal(created.Amount))
+	assert.False(t, created.CreatedAt.IsZero())
+
+	updated, err := c.UpdateTransaction(ctx, 1, created.ID, decimal.RequireFromString("20.5"))
+	require.NoError(t, err)
+	assert.Equal(t, "20.5", updated.Amount.String())
+
+	require.NoError(t, c.DeleteTransaction(ctx, 1, created.ID))
+
+	it := c.ListTransactions(ctx, 1, ListOptions{})
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+
+	info, err := c.Info(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "HRM API", info.JSONAPI.Name)
+
+	spec, err := c.OpenAPI(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, string(spec), `"openapi"`)
+}
+
+func TestClient_ListTransactions(t *testing.T) {
+	t.Parallel()
+
+	var gets int32
+	srv := newTestServer(t, func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodGet {
+				atomic.AddInt32(&gets, 1)
+			}
+			h.ServeHTTP(w, r)
+		})
+	})
+
+	ctx := context.Background()
+	c := newTestClient(t, srv)
+	for i := 0; i < 5; i++ {
+		_, err := c.CreateTransaction(ctx, 1, deposit(1, int64(i+1)))
+		require.NoError(t, err)
+	}
+	_, err := c.CreateTransaction(ctx, 1, deposit(2, 1))
+	require.NoError(t, err)
+
+	amounts := []int64{}
+	it := c.ListTransactions(ctx, 1, ListOptions{AccountID: 1, PageSize: 2})
+	for it.Next() {
+		assert.Equal(t, 1, it.Transaction().AccountID)
+		amounts = append(amounts, it.Transaction().Amount.IntPart())
+	}
+	require.NoError(t, it.Err())
+
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, amounts)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&gets))
+
+	it = c.ListTransactions(ctx, 2, ListOptions{})
+	assert.False(t, it.Next())
+	assert.True(t, errors.Is(it.Err(), model.ErrNotFound))
+}
+
+func TestClient_ExportTransactions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := newTestClient(t, newTestServer(t, nil))
+
+	_, err := c.CreateTransaction(ctx, 1, deposit(1, 100))
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, c.ExportTransactions(ctx, 1, ExportOptions{}, out))
+	assert.True(t, strings.HasPrefix(out.String(), "id,account_id,bank,transaction_type,amount,balance,created_at\n1,1,VCB,deposit,100.00,100.00,"), out.String())
+
+	out.Reset()
+	require.NoError(t, c.ExportTransactions(ctx, 1, ExportOptions{Format: "qif", AccountID: 1, From: time.Now().AddDate(1, 0, 0)}, out))
+	assert.Contains(t, out.String(), "T100.00\nPOpening Balance\n")
+
+	err = c.ExportTransactions(ctx, 1, ExportOptions{Format: "ofx"}, out)
+	assert.True(t, errors.Is(err, model.ErrInvalid))
+}
+
+func TestClient_ImportStatement(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := newTestClient(t, newTestServer(t, nil))
+
+	created, err := c.CreateTransaction(ctx, 1, deposit(1, 100))
+	require.NoError(t, err)
+
+	today := time.Now().UTC().Format("20060102")
+	statement := []byte("<OFX><STMTTRN><DTPOSTED>" + today + "<TRNAMT>100.00<FITID>A1</STMTTRN>" +
+		"<STMTTRN><DTPOSTED>" + today + "<TRNAMT>-20.00<FITID>A2</STMTTRN></OFX>")
+
+	report, err := c.ImportStatement(ctx, 1, 1, ImportOptions{Format: "ofx"}, statement)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Matched)
+	assert.Equal(t, 1, report.Missing)
+	assert.Equal(t, created.ID, report.Entries[0].TransactionID)
+
+	report, err = c.ImportStatement(ctx, 1, 1, ImportOptions{Format: "ofx", Create: []int{2}}, statement)
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Empty(t, report.Unmatched)
+
+	rs, err := c.ListReconciliations(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, rs, 2)
+	assert.Equal(t, model.ReconciliationStatusCreated, rs[1].Status)
+
+	_, err = c.ImportStatement(ctx, 1, 1, ImportOptions{Format: "csv", Bank: "XYZ"}, statement)
+	assert.True(t, errors.Is(err, model.ErrInvalid), "got %v", err)
+
+	// OFX statements do not name their account
+	_, err = c.ImportStatement(ctx, 1, 0, ImportOptions{Format: "ofx"}, statement)
+	assert.True(t, errors.Is(err, model.ErrInvalid), "got %v", err)
+}
+
+func TestClient_PaymentBatches(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := newTestClient(t, newTestServer(t, nil))
+
+	_, err := c.CreateTransaction(ctx, 1, deposit(1, 1000))
+	require.NoError(t, err)
+
+	b, err := c.CreatePaymentBatch(ctx, 1, 1, CreatePaymentBatch{
+		DebtorAccount: "0071000123456",
+		Payments: []CreatePayment{
+			{Amount: decimal.NewFromInt(300), CreditorName: "Bob", CreditorAccount: "190123", CreditorBank: "ACB"},
+			{Amount: decimal.NewFromInt(200), CreditorName: "Carol", CreditorAccount: "601704060012345", CreditorBank: "VIB"},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, b.Pending)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, c.ExportPaymentBatch(ctx, 1, 1, b.ID, out))
+	assert.Contains(t, out.String(), "<MsgId>"+b.MessageID+"</MsgId>")
+
+	// the statement listing the first payment confirms it
+	today := time.Now().UTC().Format("20060102")
+	statement := []byte("<OFX><STMTTRN><DTPOSTED>" + today + "<TRNAMT>-300.00<FITID>P1</STMTTRN></OFX>")
+	report, err := c.ImportStatement(ctx, 1, 1, ImportOptions{Format: "ofx"}, statement)
+	require.NoError(t, err)
+	assert.Equal(t, []int{b.Payments[0].TransactionID}, report.Confirmed)
+
+	p, err := c.ConfirmPayment(ctx, 1, 1, b.Payments[1].TransactionID)
+	require.NoError(t, err)
+	assert.Equal(t, model.PaymentStatusConfirmed, p.Status)
+
+	bs, err := c.ListPaymentBatches(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, bs, 1)
+	assert.Equal(t, 2, bs[0].Confirmed)
+
+	_, err = c.CreatePaymentBatch(ctx, 1, 1, CreatePaymentBatch{
+		DebtorAccount: "0071000123456",
+		Payments:      []CreatePayment{{Amount: decimal.NewFromInt(600), CreditorName: "Bob", CreditorAccount: "190123", CreditorBank: "ACB"}},
+	})
+	assert.True(t, errors.Is(err, model.ErrInsufficientFunds), "got %v", err)
+}
+
+func TestClient_Errors(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := newTestClient(t, newTestServer(t, nil))
+
+	_, err := c.UpdateTransaction(ctx, 1, 404, decimal.NewFromInt(1))
+	assert.True(t, errors.Is(err, model.ErrNotFound))
+	assert.False(t, errors.Is(err, model.ErrInvalid))
+
+	_, err = c.CreateTransaction(ctx, 1, CreateTransaction{AccountID: 1, Amount: decimal.NewFromInt(-1), TransactionType: "refund"})
+	assert.True(t, errors.Is(err, model.ErrInvalid))
+
+	apiErr := &Error{}
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	assert.NotEmpty(t, apiErr.RequestID)
+
+	fields := []string{}
+	for _, fe := range apiErr.Fields {
+		fields = append(fields, fe.Field)
+	}
+	assert.ElementsMatch(t, []string{"/amount", "/transaction_type"}, fields)
+
+	t.Run("legacy error body", func(t *testing.T) {
+		legacy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"find transaction[1] not found"}`))
+		}))
+		defer legacy.Close()
+
+		err := newTestClient(t, legacy).DeleteTransaction(ctx, 1, 1)
+		assert.True(t, errors.Is(err, model.ErrNotFound))
+		assert.Contains(t, err.Error(), "find transaction[1] not found")
+	})
+}
+
+func TestClient_Retry(t *testing.T) {
+	t.Parallel()
+
+	// the first POST is applied but its response is lost
+	var posts int32
+	keys := []string{}
+	srv := newTestServer(t, func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			keys = append(keys, r.Header.Get("Idempotency-Key"))
+			if atomic.AddInt32(&posts, 1) == 1 {
+				h.ServeHTTP(httptest.NewRecorder(), r)
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+
+			h.ServeHTTP(w, r)
+		})
+	})
+
+	ctx := context.Background()
+	c := newTestClient(t, srv)
+
+	created, err := c.CreateTransaction(ctx, 1, deposit(1, 100))
+	require.NoError(t, err)
+
+	require.Len(t, keys, 2)
+	assert.NotEmpty(t, keys[0])
+	assert.Equal(t, keys[0], keys[1])
+
+	ids := []int{}
+	it := c.ListTransactions(ctx, 1, ListOptions{})
+	for it.Next() {
+		ids = append(ids, it.Transaction().ID)
+	}
+	require.NoError(t, it.Err())
+	assert.Equal(t, []int{created.ID}, ids)
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		var calls int32
+		down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer down.Close()
+
+		_, err := newTestClient(t, down, WithRetries(2, time.Millisecond)).Info(ctx)
+		apiErr := &Error{}
+		require.True(t, errors.As(err, &apiErr))
+		assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+	})
+}
+
+func TestClient_Admin(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv := newTestServer(t, nil)
+
+	_, err := newTestClient(t, srv).CheckIntegrity(ctx)
+	assert.True(t, errors.Is(err, model.ErrUnauthorized))
+
+	c := newTestClient(t, srv, WithToken(adminToken))
+	report, err := c.CheckIntegrity(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.Total)
+
+	report, err = c.RepairIntegrity(ctx, true)
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+}
+
+func TestClient_Customers(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	srv := newTestServer(t, nil)
+
+	_, err := newTestClient(t, srv).CreateUser(ctx, "Bob")
+	assert.True(t, errors.Is(err, model.ErrUnauthorized))
+
+	c := newTestClient(t, srv, WithToken(adminToken))
+	u, err := c.CreateUser(ctx, "Bob")
+	require.NoError(t, err)
+	assert.Equal(t, &User{ID: 2, Name: "Bob"}, u)
+
+	_, err = c.CreateUser(ctx, "Alice")
+	assert.True(t, errors.Is(err, model.ErrConflict), "got %v", err)
+
+	u, err = c.RenameUser(ctx, u.ID, "Robert")
+	require.NoError(t, err)
+	assert.Equal(t, "Robert", u.Name)
+
+	acc, err := c.CreateAccount(ctx, u.ID, CreateAccount{Bank: "ACB", Number: "190123"})
+	require.NoError(t, err)
+	assert.Equal(t, &Account{ID: 3, UserID: 2, Name: "Robert", Bank: "ACB", Number: "190123"}, acc)
+
+	_, err = c.CreateAccount(ctx, 1, CreateAccount{Bank: "ACB", Number: "190123"})
+	assert.True(t, errors.Is(err, model.ErrConflict), "got %v", err)
+
+	acc, err = c.UpdateAccount(ctx, u.ID, acc.ID, UpdateAccount{Name: "Robert Savings", Number: "190124"})
+	require.NoError(t, err)
+	assert.Equal(t, &Account{ID: 3, UserID: 2, Name: "Robert Savings", Bank: "ACB", Number: "190124"}, acc)
+
+	banks, err := newTestClient(t, srv).Banks(ctx)
+	require.NoError(t, err)
+	assert.Len(t, banks, len(model.Banks))
+}
+
+func TestClient_GraphQL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := newTestClient(t, newTestServer(t, nil))
+
+	_, err := c.CreateTransaction(ctx, 1, deposit(2, 100))
+	require.NoError(t, err)
+
+	out := struct {
+		User struct {
+			Accounts []struct {
+				Bank         string
+				Transactions []struct{ Amount string }
+			}
+		}
+	}{}
+	require.NoError(t, c.GraphQL(ctx, `query($id: Int!) { user(id: $id) { accounts { bank transactions { amount } } } }`,
+		map[string]interface{}{"id": 1}, &out))
+	require.Len(t, out.User.Accounts, 2)
+	assert.Equal(t, "ACB", out.User.Accounts[1].Bank)
+	assert.Equal(t, "100.00", out.User.Accounts[1].Transactions[0].Amount)
+
+	err = c.GraphQL(ctx, `{ user(id: 2) { name } }`, nil, nil)
+	assert.True(t, errors.Is(err, model.ErrNotFound))
+}
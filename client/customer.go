@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type User struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type Account struct {
+	ID     int    `json:"id"`
+	UserID int    `json:"user_id"`
+	Name   string `json:"name"`
+	Bank   string `json:"bank"`
+	Number string `json:"number,omitempty"`
+	IBAN   string `json:"iban,omitempty"`
+}
+
+type Bank struct {
+	Code string `json:"code"`
+	BIC  string `json:"bic"`
+	BIN  string `json:"bin"`
+}
+
+type CreateAccount struct {
+	// Name is the holder of the account, the name of its user when empty
+	Name   string `json:"name,omitempty"`
+	Bank   string `json:"bank"`
+	Number string `json:"number,omitempty"`
+	IBAN   string `json:"iban,omitempty"`
+}
+
+// UpdateAccount replaces the name, number and IBAN of an account, an empty
+// number or IBAN is removed
+type UpdateAccount struct {
+	Name   string `json:"name"`
+	Number string `json:"number,omitempty"`
+	IBAN   string `json:"iban,omitempty"`
+}
+
+type saveUser struct {
+	Name string `json:"name"`
+}
+
+// CreateUser creates the user named name, it needs WithToken. A taken name
+// matches model.ErrConflict.
+func (c *Client) CreateUser(ctx context.Context, name string) (*User, error) {
+	out := &User{}
+	if _, err := c.do(ctx, http.MethodPost, "/admin/users", saveUser{name}, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// RenameUser changes the name of a user, it needs WithToken
+func (c *Client) RenameUser(ctx context.Context, userID int, name string) (*User, error) {
+	out := &User{}
+	if _, err := c.do(ctx, http.MethodPut, fmt.Sprintf("/admin/users/%v", userID), saveUser{name}, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// CreateAccount opens an account of a user, it needs WithToken. A number
+// already used at the bank matches model.ErrConflict.
+func (c *Client) CreateAccount(ctx context.Context, userID int, a CreateAccount) (*Account, error) {
+	out := &Account{}
+	if _, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/admin/users/%v/accounts", userID), a, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// UpdateAccount replaces the name, number and IBAN of an account, it needs
+// WithToken
+func (c *Client) UpdateAccount(ctx context.Context, userID, accountID int, a UpdateAccount) (*Account, error) {
+	out := &Account{}
+	path := fmt.Sprintf("/admin/users/%v/accounts/%v", userID, accountID)
+	if _, err := c.do(ctx, http.MethodPut, path, a, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Banks lists the banks the service accepts accounts of
+func (c *Client) Banks(ctx context.Context) ([]Bank, error) {
+	out := []Bank{}
+	if _, err := c.do(ctx, http.MethodGet, "/api/banks", nil, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
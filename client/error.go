@@ -0,0 +1,163 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+// maxErrorBytes caps how much of an error response is read
+const maxErrorBytes = 64 << 10
+
+// sentinels are the domain errors matched by an Error of a code
+var sentinels = map[model.ErrorCode]error{
+	model.CodeInvalid:                model.ErrInvalid,
+	model.CodeInvalidAmount:          model.ErrInvalidAmount,
+	model.CodeInvalidBank:            model.ErrInvalidBank,
+	model.CodeInvalidTransactionType: model.ErrTransactionTypeInvalid,
+	model.CodeNotFound:               model.ErrNotFound,
+	model.CodeMethodNotAllowed:       model.ErrMethodNotAllowed,
+	model.CodeConflict:               model.ErrConflict,
+	model.CodeUnauthorized:           model.ErrUnauthorized,
+	model.CodePayloadTooLarge:        model.ErrPayloadTooLarge,
+	model.CodeInsufficientFunds:      model.ErrInsufficientFunds,
+	model.CodeRateLimited:            model.ErrRateLimited,
+}
+
+// FieldError is one invalid field of a request, Field is a JSON pointer for
+// body fields or the name of a path or query parameter
+type FieldError struct {
+	Field  string          `json:"field"`
+	Code   model.ErrorCode `json:"code"`
+	Detail string          `json:"detail"`
+}
+
+// Error is an error response of the API
+type Error struct {
+	StatusCode int             `json:"status"`
+	Code       model.ErrorCode `json:"code"`
+	Title      string          `json:"title"`
+	Detail     string          `json:"detail"`
+	Instance   string          `json:"instance"`
+	RequestID  string          `json:"request_id"`
+	Fields     []FieldError    `json:"errors"`
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("api: %v %s", e.StatusCode, e.Code)
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+
+	if e.RequestID != "" {
+		msg += " (request_id " + e.RequestID + ")"
+	}
+
+	return msg
+}
+
+// Is matches the domain error of the code of e. Every invalid_* code also
+// matches model.ErrInvalid.
+func (e *Error) Is(target error) bool {
+	if sentinel, ok := sentinels[e.Code]; ok && sentinel == target {
+		return true
+	}
+
+	return target == model.ErrInvalid && strings.HasPrefix(string(e.Code), string(model.CodeInvalid))
+}
+
+// newError decodes the problem document of resp, or the {"error": "..."}
+// body of servers predating problem responses
+func newError(resp *http.Response) error {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBytes))
+	if err != nil {
+		return fmt.Errorf("read error response: %w", err)
+	}
+
+	e := &Error{}
+	if json.Unmarshal(body, e) == nil && e.Code != "" {
+		e.StatusCode = resp.StatusCode
+		return e
+	}
+
+	legacy := struct {
+		Error string `json:"error"`
+	}{}
+	json.Unmarshal(body, &legacy)
+
+	e = &Error{
+		StatusCode: resp.StatusCode,
+		Code:       codeOfStatus(resp.StatusCode),
+		Title:      http.StatusText(resp.StatusCode),
+		Detail:     legacy.Error,
+		RequestID:  resp.Header.Get("X-Request-ID"),
+	}
+
+	return e
+}
+
+func codeOfStatus(status int) model.ErrorCode {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return model.CodeInvalid
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return model.CodeUnauthorized
+	case http.StatusNotFound:
+		return model.CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return model.CodeMethodNotAllowed
+	case http.StatusConflict:
+		return model.CodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return model.CodePayloadTooLarge
+	case http.StatusTooManyRequests:
+		return model.CodeRateLimited
+	}
+
+	return model.CodeInternal
+}
+
+// GraphQLError is one error of a GraphQL response. Like Error it matches
+// the domain error of its code.
+type GraphQLError struct {
+	Message    string        `json:"message"`
+	Path       []interface{} `json:"path"`
+	Extensions struct {
+		Code      model.ErrorCode `json:"code"`
+		RequestID string          `json:"request_id"`
+		Fields    []FieldError    `json:"errors"`
+	} `json:"extensions"`
+}
+
+func (e *GraphQLError) Error() string {
+	return "graphql: " + e.Message
+}
+
+func (e *GraphQLError) Is(target error) bool {
+	return (&Error{Code: e.Extensions.Code}).Is(target)
+}
+
+// GraphQLErrors are the errors of a GraphQL response, errors.Is and
+// errors.As look at the first one
+type GraphQLErrors []*GraphQLError
+
+func (s GraphQLErrors) Error() string {
+	msgs := make([]string, len(s))
+	for i := range s {
+		msgs[i] = s[i].Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (s GraphQLErrors) Unwrap() error {
+	if len(s) == 0 {
+		return nil
+	}
+
+	return s[0]
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+// CreatePayment is a payment of a batch, CreditorBank is "VCB", "ACB" or
+// "VIB"
+type CreatePayment struct {
+	Amount          decimal.Decimal `json:"amount"`
+	CreditorName    string          `json:"creditor_name"`
+	CreditorAccount string          `json:"creditor_account"`
+	CreditorBank    string          `json:"creditor_bank"`
+	Remittance      string          `json:"remittance,omitempty"`
+}
+
+// CreatePaymentBatch pays withdrawals of an account, DebtorAccount is the
+// number of the account at its bank, required only for accounts without one.
+// ExecutionDate is a YYYY-MM-DD date, today when empty.
+type CreatePaymentBatch struct {
+	DebtorAccount string          `json:"debtor_account,omitempty"`
+	ExecutionDate string          `json:"execution_date,omitempty"`
+	Payments      []CreatePayment `json:"payments"`
+}
+
+type Payment struct {
+	TransactionID   int                 `json:"transaction_id"`
+	Amount          decimal.Decimal     `json:"amount"`
+	CreditorName    string              `json:"creditor_name"`
+	CreditorAccount string              `json:"creditor_account"`
+	CreditorBank    string              `json:"creditor_bank"`
+	Remittance      string              `json:"remittance"`
+	EndToEndID      string              `json:"end_to_end_id"`
+	Status          model.PaymentStatus `json:"status"`
+	ConfirmedAt     string              `json:"confirmed_at"`
+}
+
+type PaymentBatch struct {
+	ID            int             `json:"id"`
+	MessageID     string          `json:"message_id"`
+	AccountID     int             `json:"account_id"`
+	DebtorAccount string          `json:"debtor_account"`
+	ExecutionDate string          `json:"execution_date"`
+	CreatedAt     string          `json:"created_at"`
+	Total         decimal.Decimal `json:"total"`
+	Pending       int             `json:"pending"`
+	Confirmed     int             `json:"confirmed"`
+	Payments      []Payment       `json:"payments"`
+}
+
+// CreatePaymentBatch records the payments as pending withdrawals of the
+// account. Errors wrap model.ErrInsufficientFunds when their total is over
+// the balance.
+func (c *Client) CreatePaymentBatch(ctx context.Context, userID, accountID int, b CreatePaymentBatch) (*PaymentBatch, error) {
+	out := &PaymentBatch{}
+	path := fmt.Sprintf("/api/users/%v/accounts/%v/payment-batches", userID, accountID)
+	if _, err := c.do(ctx, http.MethodPost, path, b, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// ListPaymentBatches returns the payment batches of the account
+func (c *Client) ListPaymentBatches(ctx context.Context, userID, accountID int) ([]PaymentBatch, error) {
+	out := []PaymentBatch{}
+	if _, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/users/%v/accounts/%v/payment-batches", userID, accountID), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// ExportPaymentBatch writes the pain.001.001.03 file of the batch to w
+func (c *Client) ExportPaymentBatch(ctx context.Context, userID, accountID, batchID int, w io.Writer) error {
+	path := fmt.Sprintf("/api/users/%v/accounts/%v/payment-batches/%v/pain001", userID, accountID, batchID)
+	_, err := c.do(ctx, http.MethodGet, path, nil, w)
+	return err
+}
+
+// ConfirmPayment marks the payment of the withdrawal paid
+func (c *Client) ConfirmPayment(ctx context.Context, userID, accountID, tranID int) (*Payment, error) {
+	out := &Payment{}
+	path := fmt.Sprintf("/api/users/%v/accounts/%v/payments/%v/confirm", userID, accountID, tranID)
+	if _, err := c.do(ctx, http.MethodPost, path, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,128 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+// ImportOptions describes the statement sent to ImportStatement
+type ImportOptions struct {
+	// Format is "csv", "ofx", "mt940" or "camt053"
+	Format string
+	// Bank selects the CSV layout, the bank of the account when empty
+	Bank string
+	// Create lists the 1-based lines of the missing entries to record as
+	// transactions
+	Create []int
+}
+
+// StatementEntry is an entry of a reconciled statement
+type StatementEntry struct {
+	Line int `json:"line"`
+	// Date is the YYYY-MM-DD booking date, ValueDate is empty for formats
+	// without value dates
+	Date        string          `json:"date"`
+	ValueDate   string          `json:"value_date"`
+	Amount      decimal.Decimal `json:"amount"`
+	Reference   string          `json:"reference"`
+	Description string          `json:"description"`
+	// Status is "matched", "missing", "duplicate" or "created"
+	Status        string `json:"status"`
+	TransactionID int    `json:"transaction_id"`
+}
+
+// UnmatchedTransaction is a transaction missing in a statement, Duplicate
+// is set when it was likely recorded twice
+type UnmatchedTransaction struct {
+	Transaction
+	Duplicate bool
+}
+
+func (t *UnmatchedTransaction) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		Duplicate bool `json:"duplicate"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	if err := t.Transaction.UnmarshalJSON(b); err != nil {
+		return err
+	}
+	t.Duplicate = raw.Duplicate
+
+	return nil
+}
+
+type ReconciliationReport struct {
+	AccountID int `json:"account_id"`
+	// From and To are the first and last YYYY-MM-DD dates of the statement
+	From      string `json:"from"`
+	To        string `json:"to"`
+	Matched   int    `json:"matched"`
+	Missing   int    `json:"missing"`
+	Duplicate int    `json:"duplicate"`
+	Created   int    `json:"created"`
+	// Confirmed lists the withdrawals whose pending payment was confirmed
+	Confirmed []int                  `json:"confirmed"`
+	Entries   []StatementEntry       `json:"entries"`
+	Unmatched []UnmatchedTransaction `json:"unmatched"`
+}
+
+type Reconciliation struct {
+	TransactionID int                        `json:"transaction_id"`
+	AccountID     int                        `json:"account_id"`
+	Status        model.ReconciliationStatus `json:"status"`
+	Reference     string                     `json:"reference"`
+	StatementDate string                     `json:"statement_date"`
+	ValueDate     string                     `json:"value_date,omitempty"`
+	ReconciledAt  string                     `json:"reconciled_at"`
+}
+
+// ImportStatement reconciles the statement file with the transactions of
+// the account. A zero accountID is the account of the user the statement
+// names by IBAN or number, as camt.053 statements do.
+func (c *Client) ImportStatement(ctx context.Context, userID, accountID int, opts ImportOptions, statement []byte) (*ReconciliationReport, error) {
+	query := url.Values{}
+	query.Set("format", opts.Format)
+	if opts.Bank != "" {
+		query.Set("bank", opts.Bank)
+	}
+	if len(opts.Create) > 0 {
+		lines := make([]string, len(opts.Create))
+		for i, line := range opts.Create {
+			lines[i] = strconv.Itoa(line)
+		}
+		query.Set("create", strings.Join(lines, ","))
+	}
+
+	out := &ReconciliationReport{}
+	path := fmt.Sprintf("/api/users/%v/accounts/%v/statements?%s", userID, accountID, query.Encode())
+	if accountID == 0 {
+		path = fmt.Sprintf("/api/users/%v/statements?%s", userID, query.Encode())
+	}
+	if _, err := c.do(ctx, http.MethodPost, path, rawBody{"application/octet-stream", statement}, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// ListReconciliations returns the reconciled transactions of the account
+func (c *Client) ListReconciliations(ctx context.Context, userID, accountID int) ([]Reconciliation, error) {
+	out := []Reconciliation{}
+	if _, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/users/%v/accounts/%v/reconciliations", userID, accountID), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+type Info struct {
+	JSONAPI struct {
+		Version string `json:"version"`
+		Name    string `json:"name"`
+	} `json:"jsonapi"`
+}
+
+// Info returns the name and version of the service
+func (c *Client) Info(ctx context.Context) (*Info, error) {
+	out := &Info{}
+	if _, err := c.do(ctx, http.MethodGet, "/", nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// OpenAPI returns the OpenAPI 3 document of the API
+func (c *Client) OpenAPI(ctx context.Context) ([]byte, error) {
+	var out []byte
+	if _, err := c.do(ctx, http.MethodGet, "/api/openapi.json", nil, &out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// GraphQL runs query with variables and decodes its data into out. Errors
+// of the response are returned as GraphQLErrors, next to whatever data
+// could be resolved.
+func (c *Client) GraphQL(ctx context.Context, query string, variables map[string]interface{}, out interface{}) error {
+	in := struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables,omitempty"`
+	}{query, variables}
+
+	resp := struct {
+		Data   json.RawMessage `json:"data"`
+		Errors GraphQLErrors   `json:"errors"`
+	}{}
+	if _, err := c.do(ctx, http.MethodPost, "/api/graphql", in, &resp); err != nil {
+		return err
+	}
+
+	if out != nil && len(resp.Data) > 0 && string(resp.Data) != "null" {
+		if err := json.Unmarshal(resp.Data, out); err != nil {
+			return err
+		}
+	}
+
+	if len(resp.Errors) > 0 {
+		return resp.Errors
+	}
+
+	return nil
+}
+
+type IntegrityViolation struct {
+	Rule     model.Rule     `json:"rule"`
+	Severity model.Severity `json:"severity"`
+	Entity   string         `json:"entity"`
+	EntityID int            `json:"entity_id"`
+	Message  string         `json:"message"`
+	Fixable  bool           `json:"fixable"`
+	Repaired bool           `json:"repaired"`
+}
+
+type IntegrityReport struct {
+	DryRun     bool                   `json:"dry_run"`
+	Total      int                    `json:"total"`
+	Repaired   int                    `json:"repaired"`
+	BySeverity map[model.Severity]int `json:"by_severity"`
+	Violations []IntegrityViolation   `json:"violations"`
+}
+
+// CheckIntegrity reports the data integrity violations, it needs WithToken
+func (c *Client) CheckIntegrity(ctx context.Context) (*IntegrityReport, error) {
+	out := &IntegrityReport{}
+	if _, err := c.do(ctx, http.MethodGet, "/admin/integrity", nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// RepairIntegrity repairs the fixable violations, or only reports what
+// would be repaired when dryRun is set. It needs WithToken.
+func (c *Client) RepairIntegrity(ctx context.Context, dryRun bool) (*IntegrityReport, error) {
+	path := "/admin/integrity/repair?dry_run=false"
+	if dryRun {
+		path = "/admin/integrity/repair?dry_run=true"
+	}
+
+	out := &IntegrityReport{}
+	if _, err := c.do(ctx, http.MethodPost, path, nil, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
@@ -0,0 +1,240 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+// DefaultPageSize is the number of transactions fetched per request by
+// ListTransactions
+const DefaultPageSize = 100
+
+type Transaction struct {
+	ID              int
+	AccountID       int
+	Amount          decimal.Decimal
+	Bank            string
+	TransactionType model.TransactionType
+	CreatedAt       time.Time
+}
+
+func (t *Transaction) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		ID              int                   `json:"id"`
+		AccountID       int                   `json:"account_id"`
+		Amount          decimal.Decimal       `json:"amount"`
+		Bank            string                `json:"bank"`
+		TransactionType model.TransactionType `json:"transaction_type"`
+		CreatedAt       string                `json:"created_at"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	createdAt, err := model.ParseCreatedAt(raw.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("transaction[%v] created_at: %w", raw.ID, err)
+	}
+
+	*t = Transaction{
+		ID:              raw.ID,
+		AccountID:       raw.AccountID,
+		Amount:          raw.Amount,
+		Bank:            raw.Bank,
+		TransactionType: raw.TransactionType,
+		CreatedAt:       createdAt,
+	}
+
+	return nil
+}
+
+type CreateTransaction struct {
+	AccountID       int                   `json:"account_id"`
+	Amount          decimal.Decimal       `json:"amount"`
+	TransactionType model.TransactionType `json:"transaction_type"`
+}
+
+type updateTransaction struct {
+	Amount decimal.Decimal `json:"amount"`
+}
+
+// ListOptions filters and pages ListTransactions
+type ListOptions struct {
+	// AccountID only lists the transactions of this account when not zero
+	AccountID int
+
+	// PageSize is the number of transactions fetched per request,
+	// DefaultPageSize when zero
+	PageSize int
+}
+
+// ListTransactions iterates over the transactions of userID ordered by id,
+// fetching them one page at a time:
+//
+//	it := c.ListTransactions(ctx, userID, client.ListOptions{})
+//	for it.Next() {
+//		t := it.Transaction()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+func (c *Client) ListTransactions(ctx context.Context, userID int, opts ListOptions) *TransactionIterator {
+	limit := opts.PageSize
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+
+	query := url.Values{}
+	query.Set("limit", strconv.Itoa(limit))
+	if opts.AccountID != 0 {
+		query.Set("account_id", strconv.Itoa(opts.AccountID))
+	}
+
+	return &TransactionIterator{
+		client: c,
+		ctx:    ctx,
+		next:   fmt.Sprintf("/api/users/%v/transactions?%s", userID, query.Encode()),
+	}
+}
+
+func (c *Client) CreateTransaction(ctx context.Context, userID int, t CreateTransaction) (*Transaction, error) {
+	out := &Transaction{}
+	if _, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/api/users/%v/transactions", userID), t, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// UpdateTransaction changes the amount of a transaction
+func (c *Client) UpdateTransaction(ctx context.Context, userID, tranID int, amount decimal.Decimal) (*Transaction, error) {
+	out := &Transaction{}
+	path := fmt.Sprintf("/api/users/%v/transactions/%v", userID, tranID)
+	if _, err := c.do(ctx, http.MethodPut, path, updateTransaction{amount}, out); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// DeleteTransaction deletes a transaction, deleting a transaction of
+// another user or a missing one is not an error
+func (c *Client) DeleteTransaction(ctx context.Context, userID, tranID int) error {
+	_, err := c.do(ctx, http.MethodDelete, fmt.Sprintf("/api/users/%v/transactions/%v", userID, tranID), nil, nil)
+	return err
+}
+
+// ExportOptions selects what ExportTransactions writes
+type ExportOptions struct {
+	// Format is "csv", "ofx" or "qif", csv when empty. ofx and qif need
+	// AccountID.
+	Format string
+
+	// AccountID only exports the statement of this account when not zero
+	AccountID int
+
+	// From and To, when not zero, bound the UTC dates of the exported
+	// transactions, both inclusive
+	From time.Time
+	To   time.Time
+}
+
+// ExportTransactions writes the file exporting the transactions of userID
+// to w as it is received
+func (c *Client) ExportTransactions(ctx context.Context, userID int, opts ExportOptions, w io.Writer) error {
+	query := url.Values{}
+	if opts.Format != "" {
+		query.Set("format", opts.Format)
+	}
+	if opts.AccountID != 0 {
+		query.Set("account_id", strconv.Itoa(opts.AccountID))
+	}
+	if !opts.From.IsZero() {
+		query.Set("from", opts.From.Format("2006-01-02"))
+	}
+	if !opts.To.IsZero() {
+		query.Set("to", opts.To.Format("2006-01-02"))
+	}
+
+	_, err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/users/%v/transactions/export?%s", userID, query.Encode()), nil, w)
+	return err
+}
+
+// TransactionIterator iterates over the pages of a transaction listing,
+// see Client.ListTransactions
+type TransactionIterator struct {
+	client *Client
+	ctx    context.Context
+
+	next    string
+	page    []Transaction
+	current Transaction
+	err     error
+}
+
+// Next advances to the next transaction, fetching the next page when
+// needed. It returns false when the listing is over or failed, see Err.
+func (it *TransactionIterator) Next() bool {
+	for len(it.page) == 0 {
+		if it.err != nil || it.next == "" {
+			return false
+		}
+
+		it.fetch()
+	}
+
+	it.current, it.page = it.page[0], it.page[1:]
+
+	return true
+}
+
+// Transaction returns the transaction Next advanced to
+func (it *TransactionIterator) Transaction() Transaction {
+	return it.current
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *TransactionIterator) Err() error {
+	return it.err
+}
+
+func (it *TransactionIterator) fetch() {
+	page := []Transaction{}
+	header, err := it.client.do(it.ctx, http.MethodGet, it.next, nil, &page)
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.page = page
+	it.next = nextLink(header.Get("Link"))
+}
+
+// nextLink returns the target of the rel="next" link of a Link header
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+
+		for _, param := range parts[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			}
+		}
+	}
+
+	return ""
+}
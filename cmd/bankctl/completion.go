@@ -0,0 +1,150 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go-prj-skeleton/app/domain/model"
+)
+
+// completeCommand is the hidden command the completion scripts call with the
+// words typed so far, the last one being completed. It prints one
+// suggestion per line.
+const completeCommand = "__complete"
+
+const bashCompletion = `# bankctl bash completion, load it with: source <(bankctl completion bash)
+_bankctl() {
+	local IFS=$'\n'
+	COMPREPLY=($(bankctl __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null))
+}
+complete -o default -F _bankctl bankctl
+`
+
+const zshCompletion = `# bankctl zsh completion, load it with: source <(bankctl completion zsh)
+autoload -U +X bashcompinit && bashcompinit
+` + bashCompletion
+
+func completionScript(shell string) func(fs *flag.FlagSet) func(e *env, args []string) error {
+	script := bashCompletion
+	if shell == "zsh" {
+		script = zshCompletion
+	}
+
+	return func(fs *flag.FlagSet) func(e *env, args []string) error {
+		return func(e *env, args []string) error {
+			_, err := fmt.Fprint(e.stdout, script)
+			return err
+		}
+	}
+}
+
+var globalFlagNames = []string{"-endpoint", "-profile", "-token"}
+
+// complete prints the suggestions for the last of args
+func complete(e *env, args []string) error {
+	if len(args) == 0 {
+		args = []string{""}
+	}
+	word := args[len(args)-1]
+	words := args[:len(args)-1]
+
+	// skip the global flags and their values
+	for len(words) > 0 && strings.HasPrefix(words[0], "-") {
+		if len(words) == 1 {
+			return suggest(e, word, values("", words[0]))
+		}
+		words = words[2:]
+	}
+
+	var candidates []string
+	switch len(words) {
+	case 0:
+		if strings.HasPrefix(word, "-") {
+			candidates = globalFlagNames
+			break
+		}
+
+		for name := range commands {
+			candidates = append(candidates, name)
+		}
+		sort.Strings(candidates)
+
+	case 1:
+		candidates = names(commands[words[0]])
+
+	default:
+		cmd, ok := commands[words[0]][words[1]]
+		if !ok {
+			return nil
+		}
+
+		fs := flag.NewFlagSet("", flag.ContinueOnError)
+		cmd.setup(fs)
+
+		if prev := words[len(words)-1]; len(words) > 2 && strings.HasPrefix(prev, "-") && !isBoolFlag(fs, prev) {
+			candidates = values(words[0], prev)
+			break
+		}
+
+		if words[0] == "config" && (words[1] == "use" || words[1] == "remove") && !strings.HasPrefix(word, "-") {
+			candidates = values(words[0], "-profile")
+			break
+		}
+
+		fs.VisitAll(func(f *flag.Flag) {
+			candidates = append(candidates, "-"+f.Name)
+		})
+	}
+
+	return suggest(e, word, candidates)
+}
+
+// values returns the known values of flag for the commands of group, which
+// is empty for the global flags
+func values(group, flag string) []string {
+	switch strings.TrimLeft(flag, "-") {
+	case "o":
+		return formats
+	case "format":
+		if group == "statements" {
+			return []string{"csv", "ofx", "mt940", "camt053"}
+		}
+		return []string{"csv", "ofx", "qif"}
+	case "type":
+		return []string{string(model.TransactionTypeWithdraw), string(model.TransactionTypeDeposit)}
+	case "bank":
+		return model.Banks
+	case "profile":
+		cfg, err := loadConfig()
+		if err != nil {
+			return nil
+		}
+		return cfg.names()
+	}
+
+	return nil
+}
+
+func isBoolFlag(fs *flag.FlagSet, name string) bool {
+	f := fs.Lookup(strings.TrimLeft(name, "-"))
+	if f == nil {
+		return false
+	}
+
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
+
+func suggest(e *env, word string, candidates []string) error {
+	for _, c := range candidates {
+		if strings.HasPrefix(c, word) {
+			if _, err := fmt.Fprintln(e.stdout, c); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// defaultEndpoint is used when no profile sets one
+const defaultEndpoint = "http://localhost:8080"
+
+// Profile holds the endpoint and credentials of one deployment
+type Profile struct {
+	Endpoint string `json:"endpoint"`
+	Token    string `json:"token,omitempty"`
+}
+
+// Config is the content of the config file, see configPath
+type Config struct {
+	Current  string             `json:"current"`
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// configPath returns $BANKCTL_CONFIG, or bankctl/config.json in the user
+// config directory, e.g. ~/.config/bankctl/config.json
+func configPath() (string, error) {
+	if p := os.Getenv("BANKCTL_CONFIG"); p != "" {
+		return p, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "bankctl", "config.json"), nil
+}
+
+// loadConfig reads the config file, a missing file is an empty config
+func loadConfig() (*Config, error) {
+	cfg := &Config{Profiles: map[string]Profile{}}
+
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, cfg); err != nil {
+		return nil, fmt.Errorf("config %s: %w", path, err)
+	}
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]Profile{}
+	}
+
+	return cfg, nil
+}
+
+// save writes the config file, readable by the user only as it holds tokens
+func (cfg *Config) save() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	b, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0o600)
+}
+
+func (cfg *Config) names() []string {
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// resolve returns the profile to use: the one named by the -profile flag or
+// $BANKCTL_PROFILE, else the current one. The -endpoint and -token flags,
+// then $BANKCTL_ENDPOINT and $BANKCTL_TOKEN, override its fields.
+func (cfg *Config) resolve(g globalFlags) (Profile, error) {
+	name := firstNonEmpty(g.profile, os.Getenv("BANKCTL_PROFILE"), cfg.Current)
+
+	p := Profile{}
+	if name != "" {
+		var ok bool
+		if p, ok = cfg.Profiles[name]; !ok {
+			return Profile{}, fmt.Errorf("unknown profile %q, see `bankctl config list`", name)
+		}
+	}
+
+	p.Endpoint = firstNonEmpty(g.endpoint, os.Getenv("BANKCTL_ENDPOINT"), p.Endpoint, defaultEndpoint)
+	p.Token = firstNonEmpty(g.token, os.Getenv("BANKCTL_TOKEN"), p.Token)
+
+	return p, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
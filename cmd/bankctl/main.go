@@ -0,0 +1,202 @@
+// Command bankctl inspects and fixes the data of the service through its
+// REST API, see `bankctl help`.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"go-prj-skeleton/client"
+)
+
+const usage = `usage: bankctl [-profile name] [-endpoint url] [-token token] <command> <subcommand> [flags]
+
+commands:
+  transactions list -user ID [-account ID] [-type T] [-bank B] [-min N] [-max N] [-since DATE] [-until DATE] [-limit N]
+  transactions create -user ID -account ID -amount N -type withdraw|deposit
+  transactions update -user ID -id ID -amount N
+  transactions reverse -user ID -id ID   record the opposite transaction of the same amount
+  transactions delete -user ID -id ID
+  transactions export -user ID [-account ID] [-format csv|ofx|qif] [-since DATE] [-until DATE] [-out FILE]
+  statements import -user ID [-account ID] -file FILE -format csv|ofx|mt940|camt053 [-bank B] [-create LINES]
+  statements reconciliations -user ID -account ID
+  payments create -user ID -account ID -file FILE   submit the payment batch of a JSON file
+  payments list -user ID -account ID
+  payments export -user ID -account ID -batch ID [-out FILE]   download the pain.001 file of a batch
+  payments confirm -user ID -account ID -transaction ID
+  users show -id ID
+  users create -name NAME
+  users rename -id ID -name NAME
+  accounts list -user ID
+  accounts create -user ID -bank B [-number N] [-iban IBAN] [-name NAME]
+  accounts update -user ID -id ID -name NAME [-number N] [-iban IBAN]   an empty number or IBAN is removed
+  banks list
+  config set-profile NAME [-endpoint URL] [-token TOKEN] [-use]
+  config use NAME
+  config list
+  config remove NAME
+  completion bash|zsh                    print the shell completion script
+
+Commands printing data take -o table|json|csv (default table). The endpoint and token come from the
+profile selected by -profile, $BANKCTL_PROFILE or "config use", and can be overridden by -endpoint/-token
+or $BANKCTL_ENDPOINT/$BANKCTL_TOKEN.
+`
+
+type globalFlags struct {
+	profile  string
+	endpoint string
+	token    string
+}
+
+// env is what commands run with
+type env struct {
+	ctx    context.Context
+	global globalFlags
+	stdout io.Writer
+}
+
+// client returns an API client for the resolved profile
+func (e *env) client() (*client.Client, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := cfg.resolve(e.global)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []client.Option{}
+	if p.Token != "" {
+		opts = append(opts, client.WithToken(p.Token))
+	}
+
+	return client.New(p.Endpoint, opts...)
+}
+
+// command is one subcommand. setup declares its flags on fs and returns the
+// function running it with the remaining positional arguments.
+type command struct {
+	setup func(fs *flag.FlagSet) func(e *env, args []string) error
+}
+
+var commands = map[string]map[string]command{
+	"transactions": {
+		"list":    {listTransactions},
+		"create":  {createTransaction},
+		"update":  {updateTransaction},
+		"reverse": {reverseTransaction},
+		"delete":  {deleteTransaction},
+		"export":  {exportTransactions},
+	},
+	"statements": {
+		"import":          {importStatement},
+		"reconciliations": {listReconciliations},
+	},
+	"payments": {
+		"create":  {createPaymentBatch},
+		"list":    {listPaymentBatches},
+		"export":  {exportPaymentBatch},
+		"confirm": {confirmPayment},
+	},
+	"users": {
+		"show":   {showUser},
+		"create": {createUser},
+		"rename": {renameUser},
+	},
+	"accounts": {
+		"list":   {listAccounts},
+		"create": {createAccount},
+		"update": {updateAccount},
+	},
+	"banks": {
+		"list": {listBanks},
+	},
+	"config": {
+		"set-profile": {setProfile},
+		"use":         {useProfile},
+		"list":        {listProfiles},
+		"remove":      {removeProfile},
+	},
+	"completion": {
+		"bash": {completionScript("bash")},
+		"zsh":  {completionScript("zsh")},
+	},
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func globalFlagSet(g *globalFlags) *flag.FlagSet {
+	flags := flag.NewFlagSet("bankctl", flag.ContinueOnError)
+	flags.StringVar(&g.profile, "profile", "", "profile of the config file to use")
+	flags.StringVar(&g.endpoint, "endpoint", "", "base URL of the API, overrides the profile")
+	flags.StringVar(&g.token, "token", "", "bearer token, overrides the profile")
+	flags.Usage = func() {
+		fmt.Fprint(flags.Output(), usage)
+	}
+
+	return flags
+}
+
+func run(ctx context.Context, args []string, stdout io.Writer) error {
+	e := &env{ctx: ctx, stdout: stdout}
+
+	flags := globalFlagSet(&e.global)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	args = flags.Args()
+
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Fprint(stdout, usage)
+		return nil
+	}
+
+	if args[0] == completeCommand {
+		return complete(e, args[1:])
+	}
+
+	subs, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown command %q\n\n%s", args[0], usage)
+	}
+
+	if len(args) < 2 {
+		return fmt.Errorf("%s needs a subcommand: %s", args[0], strings.Join(names(subs), ", "))
+	}
+
+	cmd, ok := subs[args[1]]
+	if !ok {
+		return fmt.Errorf("unknown subcommand %q of %s: %s", args[1], args[0], strings.Join(names(subs), ", "))
+	}
+
+	fs := flag.NewFlagSet(args[0]+" "+args[1], flag.ContinueOnError)
+	fs.SetOutput(stdout)
+	runCmd := cmd.setup(fs)
+	if err := fs.Parse(args[2:]); err != nil {
+		return err
+	}
+
+	return runCmd(e, fs.Args())
+}
+
+func names(subs map[string]command) []string {
+	out := make([]string, 0, len(subs))
+	for name := range subs {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+
+	return out
+}
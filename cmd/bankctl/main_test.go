@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/app/interface/persistence/memory"
+	"go-prj-skeleton/app/interface/restful"
+	"go-prj-skeleton/app/registry"
+	"go-prj-skeleton/app/setting"
+)
+
+// newTestServer serves restful.Handlers over the memory backend, user 1
+// owns accounts 1 and 2, and points bankctl at it through a profile.
+func newTestServer(t *testing.T) {
+	setting.EnvSettingsInit(nil)
+	setting.ProjectEnvSettings.StorageBackend = setting.StorageBackendMemory
+	setting.ProjectEnvSettings.AdminToken = "0123456789"
+
+	ctn, err := registry.NewContainer()
+	require.NoError(t, err)
+	t.Cleanup(func() { ctn.Clean() })
+
+	store := ctn.Resolve("memory-store").(*memory.Store)
+	store.AddUser(model.User{ID: 1, Name: "Alice"})
+	store.AddAccount(model.Account{ID: 1, UserID: 1, Name: "Alice", Bank: "VCB", Number: "0071000123456"})
+	store.AddAccount(model.Account{ID: 2, UserID: 1, Name: "Alice", Bank: "ACB"})
+
+	srv := httptest.NewServer(restful.Handlers(ctn))
+	t.Cleanup(srv.Close)
+
+	t.Setenv("BANKCTL_CONFIG", filepath.Join(t.TempDir(), "config.json"))
+	t.Setenv("BANKCTL_PROFILE", "")
+	t.Setenv("BANKCTL_ENDPOINT", "")
+	t.Setenv("BANKCTL_TOKEN", "")
+
+	runOK(t, "config", "set-profile", "test", "-endpoint", srv.URL, "-token", "0123456789")
+}
+
+func runOK(t *testing.T, args ...string) string {
+	out := &bytes.Buffer{}
+	require.NoError(t, run(context.Background(), args, out), strings.Join(args, " "))
+
+	return out.String()
+}
+
+func TestBankctl_Transactions(t *testing.T) {
+	newTestServer(t)
+
+	runOK(t, "transactions", "create", "-user", "1", "-account", "1", "-amount", "100", "-type", "deposit")
+	runOK(t, "transactions", "create", "-user", "1", "-account", "2", "-amount", "20.5", "-type", "withdraw")
+	runOK(t, "transactions", "create", "-user", "1", "-account", "1", "-amount", "7", "-type", "withdraw")
+
+	out := runOK(t, "transactions", "list", "-user", "1")
+	assert.Equal(t, 4, strings.Count(out, "\n"), out)
+	assert.True(t, strings.HasPrefix(out, "ID "), out)
+
+	out = runOK(t, "transactions", "list", "-user", "1", "-type", "withdraw", "-min", "10", "-o", "csv")
+	assert.Regexp(t, `^id,account,bank,type,amount,created_at\n2,2,ACB,withdraw,20.50,`, out)
+	assert.Equal(t, 2, strings.Count(out, "\n"), out)
+
+	runOK(t, "transactions", "update", "-user", "1", "-id", "1", "-amount", "50")
+	runOK(t, "transactions", "reverse", "-user", "1", "-id", "1")
+	// reversing twice is a replay of the first reversal
+	runOK(t, "transactions", "reverse", "-user", "1", "-id", "1")
+	runOK(t, "transactions", "delete", "-user", "1", "-id", "3")
+
+	trans := []transactionJSON{}
+	require.NoError(t, json.Unmarshal([]byte(runOK(t, "transactions", "list", "-user", "1", "-account", "1", "-o", "json")), &trans))
+	require.Len(t, trans, 2)
+	assert.Equal(t, model.TransactionTypeDeposit, trans[0].TransactionType)
+	assert.Equal(t, "50", trans[0].Amount.String())
+	assert.Equal(t, model.TransactionTypeWithdraw, trans[1].TransactionType)
+	assert.Equal(t, "50", trans[1].Amount.String())
+
+	out = runOK(t, "transactions", "export", "-user", "1", "-account", "1", "-format", "qif")
+	assert.True(t, strings.HasPrefix(out, "!Type:Bank\n"), out)
+	assert.Equal(t, 3, strings.Count(out, "^"), out)
+
+	err := run(context.Background(), []string{"transactions", "reverse", "-user", "1", "-id", "404"}, &bytes.Buffer{})
+	assert.True(t, errors.Is(err, model.ErrNotFound))
+
+	err = run(context.Background(), []string{"transactions", "list", "-user", "1", "-since", "yesterday"}, &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestBankctl_Statements(t *testing.T) {
+	newTestServer(t)
+
+	runOK(t, "transactions", "create", "-user", "1", "-account", "2", "-amount", "1500", "-type", "deposit")
+
+	today := time.Now().UTC().Format("02/01/2006")
+	file := filepath.Join(t.TempDir(), "statement.csv")
+	require.NoError(t, ioutil.WriteFile(file, []byte("Ngày hiệu lực;Số GD;Ghi nợ;Ghi có;Nội dung\n"+
+		today+";5012;;1.500,00;Luong\n"+
+		today+";5013;20,00;;Phi\n"), 0600))
+
+	out := runOK(t, "statements", "import", "-user", "1", "-account", "2", "-file", file, "-format", "csv", "-o", "csv")
+	assert.Regexp(t, `^line,date,amount,reference,status,transaction,description\n1,\S+,1500.00,5012,matched,1,Luong\n2,\S+,-20.00,5013,missing,,Phi\n$`, out)
+
+	out = runOK(t, "statements", "import", "-user", "1", "-account", "2", "-file", file, "-format", "csv", "-create", "2", "-o", "csv")
+	assert.Contains(t, out, "-20.00,5013,created,2,Phi")
+
+	out = runOK(t, "statements", "reconciliations", "-user", "1", "-account", "2", "-o", "csv")
+	assert.Regexp(t, `\n1,matched,5012,\S+,[^\n]+\n2,created,5013,`, out)
+
+	err := run(context.Background(), []string{"statements", "import", "-user", "1", "-account", "2", "-file", file, "-format", "csv", "-create", "x"}, &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestBankctl_Payments(t *testing.T) {
+	newTestServer(t)
+
+	runOK(t, "transactions", "create", "-user", "1", "-account", "1", "-amount", "1000", "-type", "deposit")
+
+	file := filepath.Join(t.TempDir(), "batch.json")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`{"debtor_account": "0071000123456", "payments": [`+
+		`{"amount": "300", "creditor_name": "Bob", "creditor_account": "190123", "creditor_bank": "ACB", "remittance": "Invoice 12"}]}`), 0600))
+
+	out := runOK(t, "payments", "create", "-user", "1", "-account", "1", "-file", file, "-o", "csv")
+	assert.Equal(t, "transaction,end_to_end_id,amount,creditor,account,bank,status,confirmed_at\n2,TX2,300.00,Bob,190123,ACB,pending,\n", out)
+
+	out = runOK(t, "payments", "list", "-user", "1", "-account", "1", "-o", "csv")
+	assert.Regexp(t, `\n1,PB00000001,\S+,300.00,1,0,`, out)
+
+	pain := filepath.Join(t.TempDir(), "PB00000001.xml")
+	runOK(t, "payments", "export", "-user", "1", "-account", "1", "-batch", "1", "-out", pain)
+	data, err := ioutil.ReadFile(pain)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<EndToEndId>TX2</EndToEndId>")
+
+	out = runOK(t, "payments", "confirm", "-user", "1", "-account", "1", "-transaction", "2", "-o", "csv")
+	assert.Contains(t, out, ",confirmed,")
+
+	err = run(context.Background(), []string{"payments", "export", "-user", "1", "-account", "1", "-batch", "404", "-out", pain}, &bytes.Buffer{})
+	assert.True(t, errors.Is(err, model.ErrNotFound))
+	assert.NoFileExists(t, pain)
+}
+
+func TestBankctl_UsersAccountsBanks(t *testing.T) {
+	newTestServer(t)
+
+	assert.Contains(t, runOK(t, "users", "show", "-id", "1"), "Alice")
+
+	out := runOK(t, "accounts", "list", "-user", "1", "-o", "csv")
+	assert.Equal(t, "id,name,bank,number\n1,Alice,VCB,*********3456\n2,Alice,ACB,\n", out)
+
+	assert.Equal(t, "bank,bic,bin\nVCB,BFTVVNVX,970436\nACB,ASCBVNVX,970416\nVIB,VNIBVNVX,970441\n", runOK(t, "banks", "list", "-o", "csv"))
+
+	out = runOK(t, "users", "create", "-name", "Bob", "-o", "csv")
+	assert.Equal(t, "id,name\n2,Bob\n", out)
+	runOK(t, "users", "rename", "-id", "2", "-name", "Robert")
+
+	out = runOK(t, "accounts", "create", "-user", "2", "-bank", "VIB", "-number", "601704060012345", "-o", "csv")
+	assert.Equal(t, "id,name,bank,number,iban\n3,Robert,VIB,601704060012345,\n", out)
+	runOK(t, "accounts", "update", "-user", "2", "-id", "3", "-name", "Robert Savings")
+	assert.Contains(t, runOK(t, "accounts", "list", "-user", "2", "-o", "csv"), "3,Robert Savings,VIB,\n")
+
+	err := run(context.Background(), []string{"accounts", "create", "-user", "2", "-bank", "VCB", "-number", "0071000123456"}, &bytes.Buffer{})
+	assert.True(t, errors.Is(err, model.ErrConflict), "got %v", err)
+
+	err = run(context.Background(), []string{"users", "show", "-id", "404"}, &bytes.Buffer{})
+	assert.True(t, errors.Is(err, model.ErrNotFound))
+}
+
+func TestBankctl_Config(t *testing.T) {
+	newTestServer(t)
+
+	runOK(t, "config", "set-profile", "prod", "-endpoint", "https://bank.example.com")
+
+	out := runOK(t, "config", "list", "-o", "csv")
+	assert.Contains(t, out, "*,test,")
+	assert.Contains(t, out, ",****6789\n")
+	assert.NotContains(t, out, "0123456789")
+
+	runOK(t, "config", "use", "prod")
+	assert.Regexp(t, `\n\*\s+prod `, runOK(t, "config", "list"))
+
+	cfg, err := loadConfig()
+	require.NoError(t, err)
+	p, err := cfg.resolve(globalFlags{profile: "test", token: "override"})
+	require.NoError(t, err)
+	assert.Equal(t, "override", p.Token)
+
+	runOK(t, "config", "remove", "prod")
+	assert.Error(t, run(context.Background(), []string{"config", "use", "prod"}, &bytes.Buffer{}))
+	assert.Error(t, run(context.Background(), []string{"-profile", "prod", "users", "show", "-id", "1"}, &bytes.Buffer{}))
+}
+
+func TestBankctl_Complete(t *testing.T) {
+	newTestServer(t)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"tr"}, "transactions\n"},
+		{[]string{"-"}, "-endpoint\n-profile\n-token\n"},
+		{[]string{"-profile", "t"}, "test\n"},
+		{[]string{"-profile", "test", "config", "u"}, "use\n"},
+		{[]string{"transactions", "list", "-s"}, "-since\n"},
+		{[]string{"transactions", "list", "-type", ""}, "withdraw\ndeposit\n"},
+		{[]string{"transactions", "list", "-o", "j"}, "json\n"},
+		{[]string{"statements", "import", "-format", "m"}, "mt940\n"},
+		{[]string{"config", "use", ""}, "test\n"},
+	}
+
+	for _, tt := range tests {
+		out := runOK(t, append([]string{completeCommand}, tt.args...)...)
+		assert.Equal(t, tt.want, out, tt.args)
+	}
+
+	assert.Contains(t, runOK(t, "completion", "zsh"), "bashcompinit")
+}
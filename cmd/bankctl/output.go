@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+var formats = []string{"table", "json", "csv"}
+
+// table is the rows printed by a command, v is printed instead for JSON
+type table struct {
+	header []string
+	rows   [][]string
+	v      interface{}
+}
+
+func outputFlag(fs *flag.FlagSet) *string {
+	return fs.String("o", "table", "output format: "+strings.Join(formats, ", "))
+}
+
+func write(w io.Writer, format string, t table) error {
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, strings.Join(t.header, "\t"))
+		for _, row := range t.rows {
+			fmt.Fprintln(tw, strings.Join(row, "\t"))
+		}
+
+		return tw.Flush()
+
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(t.v)
+
+	case "csv":
+		cw := csv.NewWriter(w)
+		lower := make([]string, len(t.header))
+		for i, h := range t.header {
+			lower[i] = strings.ToLower(h)
+		}
+
+		cw.Write(lower)
+		cw.WriteAll(t.rows)
+
+		return cw.Error()
+	}
+
+	return fmt.Errorf("unknown output format %q, use one of %s", format, strings.Join(formats, ", "))
+}
+
+// writeFile runs download with the file at path, with stdout when path is
+// empty. The file is removed when download fails.
+func writeFile(e *env, path string, download func(w io.Writer) error) error {
+	if path == "" {
+		return download(e.stdout)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := download(f); err != nil {
+		f.Close()
+		os.Remove(path)
+		return err
+	}
+
+	return f.Close()
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+
+	"go-prj-skeleton/client"
+)
+
+func paymentsTable(ps []client.Payment, v interface{}) table {
+	t := table{
+		header: []string{"TRANSACTION", "END_TO_END_ID", "AMOUNT", "CREDITOR", "ACCOUNT", "BANK", "STATUS", "CONFIRMED_AT"},
+		v:      v,
+	}
+
+	for _, p := range ps {
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(p.TransactionID),
+			p.EndToEndID,
+			p.Amount.StringFixed(2),
+			p.CreditorName,
+			p.CreditorAccount,
+			p.CreditorBank,
+			string(p.Status),
+			p.ConfirmedAt,
+		})
+	}
+
+	return t
+}
+
+// createPaymentBatch submits the batch of a JSON file, in the body format of
+// the API
+func createPaymentBatch(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	accountID := fs.Int("account", 0, "id of the paying account (required)")
+	file := fs.String("file", "", `JSON file of the batch: {"debtor_account", "execution_date", "payments": [{"amount", "creditor_name", "creditor_account", "creditor_bank", "remittance"}]} (required)`)
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 || *accountID == 0 || *file == "" {
+			return fmt.Errorf("-user, -account and -file are required")
+		}
+
+		data, err := ioutil.ReadFile(*file)
+		if err != nil {
+			return err
+		}
+
+		in := client.CreatePaymentBatch{}
+		if err := json.Unmarshal(data, &in); err != nil {
+			return fmt.Errorf("%s: %w", *file, err)
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		b, err := c.CreatePaymentBatch(e.ctx, *userID, *accountID, in)
+		if err != nil {
+			return err
+		}
+
+		return write(e.stdout, *output, paymentsTable(b.Payments, b))
+	}
+}
+
+func listPaymentBatches(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	accountID := fs.Int("account", 0, "id of the account (required)")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 || *accountID == 0 {
+			return fmt.Errorf("-user and -account are required")
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		bs, err := c.ListPaymentBatches(e.ctx, *userID, *accountID)
+		if err != nil {
+			return err
+		}
+
+		t := table{header: []string{"ID", "MESSAGE_ID", "EXECUTION_DATE", "TOTAL", "PENDING", "CONFIRMED", "CREATED_AT"}, v: bs}
+		for _, b := range bs {
+			t.rows = append(t.rows, []string{
+				strconv.Itoa(b.ID),
+				b.MessageID,
+				b.ExecutionDate,
+				b.Total.StringFixed(2),
+				strconv.Itoa(b.Pending),
+				strconv.Itoa(b.Confirmed),
+				b.CreatedAt,
+			})
+		}
+
+		return write(e.stdout, *output, t)
+	}
+}
+
+// exportPaymentBatch downloads the pain.001 file to upload to the bank
+func exportPaymentBatch(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	accountID := fs.Int("account", 0, "id of the account (required)")
+	batchID := fs.Int("batch", 0, "id of the payment batch (required)")
+	out := fs.String("out", "", "file to write, stdout when empty")
+
+	return func(e *env, args []string) error {
+		if *userID == 0 || *accountID == 0 || *batchID == 0 {
+			return fmt.Errorf("-user, -account and -batch are required")
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		return writeFile(e, *out, func(w io.Writer) error {
+			return c.ExportPaymentBatch(e.ctx, *userID, *accountID, *batchID, w)
+		})
+	}
+}
+
+func confirmPayment(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	accountID := fs.Int("account", 0, "id of the account (required)")
+	tranID := fs.Int("transaction", 0, "id of the withdrawal of the payment (required)")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 || *accountID == 0 || *tranID == 0 {
+			return fmt.Errorf("-user, -account and -transaction are required")
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		p, err := c.ConfirmPayment(e.ctx, *userID, *accountID, *tranID)
+		if err != nil {
+			return err
+		}
+
+		return write(e.stdout, *output, paymentsTable([]client.Payment{*p}, p))
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+)
+
+func profileName(args []string) (string, error) {
+	if len(args) != 1 || args[0] == "" {
+		return "", fmt.Errorf("expected one profile name, got %q", args)
+	}
+
+	return args[0], nil
+}
+
+// setProfile creates or updates a profile, unset flags keep their value.
+// Its flags go before or after the name, e.g.
+// `config set-profile prod -endpoint https://...`.
+func setProfile(fs *flag.FlagSet) func(e *env, args []string) error {
+	endpoint := fs.String("endpoint", "", "base URL of the API")
+	token := fs.String("token", "", "bearer token")
+	use := fs.Bool("use", false, "make it the current profile")
+
+	return func(e *env, args []string) error {
+		if len(args) == 0 {
+			return fmt.Errorf("expected a profile name")
+		}
+
+		// flag stops at the name, parse what follows it
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		name, err := profileName(append(args[:1:1], fs.Args()...))
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		p := cfg.Profiles[name]
+		p.Endpoint = firstNonEmpty(*endpoint, p.Endpoint)
+		p.Token = firstNonEmpty(*token, p.Token)
+		cfg.Profiles[name] = p
+
+		if *use || cfg.Current == "" {
+			cfg.Current = name
+		}
+
+		return cfg.save()
+	}
+}
+
+func useProfile(fs *flag.FlagSet) func(e *env, args []string) error {
+	return func(e *env, args []string) error {
+		name, err := profileName(args)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		if _, ok := cfg.Profiles[name]; !ok {
+			return fmt.Errorf("unknown profile %q, see `bankctl config list`", name)
+		}
+		cfg.Current = name
+
+		return cfg.save()
+	}
+}
+
+// listProfiles prints the profiles, tokens are masked
+func listProfiles(fs *flag.FlagSet) func(e *env, args []string) error {
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		type profileJSON struct {
+			Name     string `json:"name"`
+			Current  bool   `json:"current"`
+			Endpoint string `json:"endpoint"`
+			Token    string `json:"token,omitempty"`
+		}
+
+		t := table{header: []string{"CURRENT", "NAME", "ENDPOINT", "TOKEN"}}
+		out := []profileJSON{}
+		for _, name := range cfg.names() {
+			p := cfg.Profiles[name]
+			current := ""
+			if name == cfg.Current {
+				current = "*"
+			}
+
+			out = append(out, profileJSON{name, name == cfg.Current, p.Endpoint, maskToken(p.Token)})
+			t.rows = append(t.rows, []string{current, name, p.Endpoint, maskToken(p.Token)})
+		}
+		t.v = out
+
+		return write(e.stdout, *output, t)
+	}
+}
+
+func removeProfile(fs *flag.FlagSet) func(e *env, args []string) error {
+	return func(e *env, args []string) error {
+		name, err := profileName(args)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		if _, ok := cfg.Profiles[name]; !ok {
+			return fmt.Errorf("unknown profile %q, see `bankctl config list`", name)
+		}
+
+		delete(cfg.Profiles, name)
+		if cfg.Current == name {
+			cfg.Current = ""
+		}
+
+		return cfg.save()
+	}
+}
+
+// maskToken keeps the last 4 characters of long tokens only
+func maskToken(token string) string {
+	if token == "" {
+		return ""
+	}
+
+	if len(token) <= 8 {
+		return "****"
+	}
+
+	return "****" + token[len(token)-4:]
+}
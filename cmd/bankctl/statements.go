@@ -0,0 +1,144 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/client"
+)
+
+func reconciliationTable(r *client.ReconciliationReport) table {
+	t := table{
+		header: []string{"LINE", "DATE", "AMOUNT", "REFERENCE", "STATUS", "TRANSACTION", "DESCRIPTION"},
+		v:      r,
+	}
+
+	for _, e := range r.Entries {
+		tranID := ""
+		if e.TransactionID != 0 {
+			tranID = strconv.Itoa(e.TransactionID)
+		}
+
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(e.Line),
+			e.Date,
+			e.Amount.StringFixed(2),
+			e.Reference,
+			e.Status,
+			tranID,
+			e.Description,
+		})
+	}
+
+	// transactions missing in the statement follow its lines
+	for _, u := range r.Unmatched {
+		status := "unmatched"
+		if u.Duplicate {
+			status = "unmatched duplicate"
+		}
+
+		t.rows = append(t.rows, []string{
+			"",
+			u.CreatedAt.UTC().Format(dateLayout),
+			model.SignedAmount(u.TransactionType, u.Amount).StringFixed(2),
+			"",
+			status,
+			strconv.Itoa(u.ID),
+			"",
+		})
+	}
+
+	return t
+}
+
+// parseLines parses comma separated line numbers
+func parseLines(s string) ([]int, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	lines := []int{}
+	for _, part := range strings.Split(s, ",") {
+		line, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			return nil, fmt.Errorf("-create: %q is not a line number", part)
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// importStatement reconciles a statement file, run it without -create to
+// review the missing entries first
+func importStatement(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	accountID := fs.Int("account", 0, "id of the account of the statement, by default the one it names by IBAN or number (camt053)")
+	file := fs.String("file", "", "statement file (required)")
+	format := fs.String("format", "", "file format: csv, ofx, mt940 or camt053 (required)")
+	bank := fs.String("bank", "", "bank whose CSV layout the file has, the bank of the account by default")
+	create := fs.String("create", "", "comma separated lines of missing entries to record as transactions")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 || *file == "" || *format == "" {
+			return fmt.Errorf("-user, -file and -format are required")
+		}
+
+		lines, err := parseLines(*create)
+		if err != nil {
+			return err
+		}
+
+		statement, err := ioutil.ReadFile(*file)
+		if err != nil {
+			return err
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		report, err := c.ImportStatement(e.ctx, *userID, *accountID, client.ImportOptions{Format: *format, Bank: *bank, Create: lines}, statement)
+		if err != nil {
+			return err
+		}
+
+		return write(e.stdout, *output, reconciliationTable(report))
+	}
+}
+
+func listReconciliations(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	accountID := fs.Int("account", 0, "id of the account (required)")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 || *accountID == 0 {
+			return fmt.Errorf("-user and -account are required")
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		rs, err := c.ListReconciliations(e.ctx, *userID, *accountID)
+		if err != nil {
+			return err
+		}
+
+		t := table{header: []string{"TRANSACTION", "STATUS", "REFERENCE", "STATEMENT_DATE", "VALUE_DATE", "RECONCILED_AT"}, v: rs}
+		for _, r := range rs {
+			t.rows = append(t.rows, []string{strconv.Itoa(r.TransactionID), string(r.Status), r.Reference, r.StatementDate, r.ValueDate, r.ReconciledAt})
+		}
+
+		return write(e.stdout, *output, t)
+	}
+}
@@ -0,0 +1,336 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"go-prj-skeleton/app/domain/model"
+	"go-prj-skeleton/client"
+)
+
+const dateLayout = "2006-01-02"
+
+// transactionJSON is the JSON form of a transaction, the same as the API's
+type transactionJSON struct {
+	ID              int                   `json:"id"`
+	AccountID       int                   `json:"account_id"`
+	Amount          decimal.Decimal       `json:"amount"`
+	Bank            string                `json:"bank"`
+	TransactionType model.TransactionType `json:"transaction_type"`
+	CreatedAt       string                `json:"created_at"`
+}
+
+func transactionsTable(trans []client.Transaction) table {
+	t := table{
+		header: []string{"ID", "ACCOUNT", "BANK", "TYPE", "AMOUNT", "CREATED_AT"},
+	}
+
+	out := make([]transactionJSON, len(trans))
+	for i, tran := range trans {
+		createdAt := tran.CreatedAt.Format(model.CreatedAtLayout)
+		out[i] = transactionJSON{tran.ID, tran.AccountID, tran.Amount, tran.Bank, tran.TransactionType, createdAt}
+		t.rows = append(t.rows, []string{
+			strconv.Itoa(tran.ID),
+			strconv.Itoa(tran.AccountID),
+			tran.Bank,
+			string(tran.TransactionType),
+			tran.Amount.StringFixed(2),
+			createdAt,
+		})
+	}
+	t.v = out
+
+	return t
+}
+
+// filter keeps the transactions matching every set field
+type filter struct {
+	transactionType string
+	bank            string
+	min, max        string
+	since, until    string
+}
+
+func (f filter) compile() (func(client.Transaction) bool, error) {
+	checks := []func(client.Transaction) bool{}
+
+	if f.transactionType != "" {
+		tt := model.TransactionType(f.transactionType)
+		if err := model.ValidateTransactionType(tt); err != nil {
+			return nil, fmt.Errorf("-type: %w", err)
+		}
+		checks = append(checks, func(t client.Transaction) bool { return t.TransactionType == tt })
+	}
+
+	if f.bank != "" {
+		if err := model.ValidateBank(f.bank); err != nil {
+			return nil, fmt.Errorf("-bank: %w", err)
+		}
+		checks = append(checks, func(t client.Transaction) bool { return t.Bank == f.bank })
+	}
+
+	if f.min != "" {
+		min, err := decimal.NewFromString(f.min)
+		if err != nil {
+			return nil, fmt.Errorf("-min: %w", err)
+		}
+		checks = append(checks, func(t client.Transaction) bool { return t.Amount.GreaterThanOrEqual(min) })
+	}
+
+	if f.max != "" {
+		max, err := decimal.NewFromString(f.max)
+		if err != nil {
+			return nil, fmt.Errorf("-max: %w", err)
+		}
+		checks = append(checks, func(t client.Transaction) bool { return t.Amount.LessThanOrEqual(max) })
+	}
+
+	if f.since != "" {
+		since, err := time.ParseInLocation(dateLayout, f.since, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("-since: %w", err)
+		}
+		checks = append(checks, func(t client.Transaction) bool { return !t.CreatedAt.Before(since) })
+	}
+
+	if f.until != "" {
+		until, err := time.ParseInLocation(dateLayout, f.until, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("-until: %w", err)
+		}
+		// until is inclusive
+		end := until.AddDate(0, 0, 1)
+		checks = append(checks, func(t client.Transaction) bool { return t.CreatedAt.Before(end) })
+	}
+
+	return func(t client.Transaction) bool {
+		for _, check := range checks {
+			if !check(t) {
+				return false
+			}
+		}
+
+		return true
+	}, nil
+}
+
+func listTransactions(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	accountID := fs.Int("account", 0, "only list the transactions of this account")
+	limit := fs.Int("limit", 0, "stop after this many transactions, 0 for all")
+	f := filter{}
+	fs.StringVar(&f.transactionType, "type", "", "only list withdraw or deposit transactions")
+	fs.StringVar(&f.bank, "bank", "", "only list the transactions of this bank")
+	fs.StringVar(&f.min, "min", "", "minimum amount")
+	fs.StringVar(&f.max, "max", "", "maximum amount")
+	fs.StringVar(&f.since, "since", "", "only list the transactions created on or after this date, YYYY-MM-DD")
+	fs.StringVar(&f.until, "until", "", "only list the transactions created on or before this date, YYYY-MM-DD")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 {
+			return fmt.Errorf("-user is required")
+		}
+
+		match, err := f.compile()
+		if err != nil {
+			return err
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		trans := []client.Transaction{}
+		it := c.ListTransactions(e.ctx, *userID, client.ListOptions{AccountID: *accountID})
+		for it.Next() && (*limit == 0 || len(trans) < *limit) {
+			if match(it.Transaction()) {
+				trans = append(trans, it.Transaction())
+			}
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+
+		return write(e.stdout, *output, transactionsTable(trans))
+	}
+}
+
+func createTransaction(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	accountID := fs.Int("account", 0, "id of the account (required)")
+	amount := fs.String("amount", "", "amount, e.g. 100.50 (required)")
+	transactionType := fs.String("type", "", "withdraw or deposit (required)")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 || *accountID == 0 || *amount == "" || *transactionType == "" {
+			return fmt.Errorf("-user, -account, -amount and -type are required")
+		}
+
+		d, err := decimal.NewFromString(*amount)
+		if err != nil {
+			return fmt.Errorf("-amount: %w", err)
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		created, err := c.CreateTransaction(e.ctx, *userID, client.CreateTransaction{
+			AccountID:       *accountID,
+			Amount:          d,
+			TransactionType: model.TransactionType(*transactionType),
+		})
+		if err != nil {
+			return err
+		}
+
+		return write(e.stdout, *output, transactionsTable([]client.Transaction{*created}))
+	}
+}
+
+func updateTransaction(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	tranID := fs.Int("id", 0, "id of the transaction (required)")
+	amount := fs.String("amount", "", "new amount (required)")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 || *tranID == 0 || *amount == "" {
+			return fmt.Errorf("-user, -id and -amount are required")
+		}
+
+		d, err := decimal.NewFromString(*amount)
+		if err != nil {
+			return fmt.Errorf("-amount: %w", err)
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		updated, err := c.UpdateTransaction(e.ctx, *userID, *tranID, d)
+		if err != nil {
+			return err
+		}
+
+		return write(e.stdout, *output, transactionsTable([]client.Transaction{*updated}))
+	}
+}
+
+// reverseTransaction records the opposite transaction, the original stays
+// in the ledger. Its idempotency key is derived from the reversed
+// transaction, so running it twice reverses once as long as the server
+// replays the key: within a day, on the same replica.
+func reverseTransaction(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	tranID := fs.Int("id", 0, "id of the transaction to reverse (required)")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 || *tranID == 0 {
+			return fmt.Errorf("-user and -id are required")
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		var original *client.Transaction
+		it := c.ListTransactions(e.ctx, *userID, client.ListOptions{})
+		for original == nil && it.Next() {
+			if t := it.Transaction(); t.ID == *tranID {
+				original = &t
+			}
+		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+
+		if original == nil {
+			return fmt.Errorf("transaction[%v] of user[%v] %w", *tranID, *userID, model.ErrNotFound)
+		}
+
+		opposite := model.TransactionTypeDeposit
+		if original.TransactionType == model.TransactionTypeDeposit {
+			opposite = model.TransactionTypeWithdraw
+		}
+
+		ctx := client.WithIdempotencyKey(e.ctx, fmt.Sprintf("bankctl-reverse-%v-%v", *userID, *tranID))
+		reversal, err := c.CreateTransaction(ctx, *userID, client.CreateTransaction{
+			AccountID:       original.AccountID,
+			Amount:          original.Amount,
+			TransactionType: opposite,
+		})
+		if err != nil {
+			return err
+		}
+
+		return write(e.stdout, *output, transactionsTable([]client.Transaction{*reversal}))
+	}
+}
+
+func deleteTransaction(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	tranID := fs.Int("id", 0, "id of the transaction (required)")
+
+	return func(e *env, args []string) error {
+		if *userID == 0 || *tranID == 0 {
+			return fmt.Errorf("-user and -id are required")
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		return c.DeleteTransaction(e.ctx, *userID, *tranID)
+	}
+}
+
+func exportTransactions(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	accountID := fs.Int("account", 0, "only export the statement of this account, required by ofx and qif")
+	format := fs.String("format", "csv", "file format: csv, ofx or qif")
+	since := fs.String("since", "", "only export the transactions created on or after this UTC date, YYYY-MM-DD")
+	until := fs.String("until", "", "only export the transactions created on or before this UTC date, YYYY-MM-DD")
+	out := fs.String("out", "", "file to write, stdout when empty")
+
+	return func(e *env, args []string) error {
+		if *userID == 0 {
+			return fmt.Errorf("-user is required")
+		}
+
+		opts := client.ExportOptions{Format: *format, AccountID: *accountID}
+		var err error
+		if *since != "" {
+			if opts.From, err = time.Parse(dateLayout, *since); err != nil {
+				return fmt.Errorf("-since: %w", err)
+			}
+		}
+		if *until != "" {
+			if opts.To, err = time.Parse(dateLayout, *until); err != nil {
+				return fmt.Errorf("-until: %w", err)
+			}
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		return writeFile(e, *out, func(w io.Writer) error {
+			return c.ExportTransactions(e.ctx, *userID, opts, w)
+		})
+	}
+}
@@ -0,0 +1,236 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+
+	"go-prj-skeleton/client"
+)
+
+// Users and accounts are read through GraphQL and written through the
+// admin endpoints, which need the admin token.
+
+type userJSON struct {
+	ID       int           `json:"id"`
+	Name     string        `json:"name"`
+	Accounts []accountJSON `json:"accounts"`
+}
+
+type accountJSON struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Bank   string `json:"bank"`
+	Number string `json:"number,omitempty"`
+}
+
+const userQuery = `query($id: Int!) { user(id: $id) { id name accounts { id name bank number } } }`
+
+func fetchUser(e *env, userID int) (*userJSON, error) {
+	c, err := e.client()
+	if err != nil {
+		return nil, err
+	}
+
+	out := struct{ User *userJSON }{}
+	if err := c.GraphQL(e.ctx, userQuery, map[string]interface{}{"id": userID}, &out); err != nil {
+		return nil, err
+	}
+
+	return out.User, nil
+}
+
+func showUser(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("id", 0, "id of the user (required)")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 {
+			return fmt.Errorf("-id is required")
+		}
+
+		u, err := fetchUser(e, *userID)
+		if err != nil {
+			return err
+		}
+
+		return write(e.stdout, *output, table{
+			header: []string{"ID", "NAME", "ACCOUNTS"},
+			rows:   [][]string{{strconv.Itoa(u.ID), u.Name, strconv.Itoa(len(u.Accounts))}},
+			v:      u,
+		})
+	}
+}
+
+func listAccounts(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 {
+			return fmt.Errorf("-user is required")
+		}
+
+		u, err := fetchUser(e, *userID)
+		if err != nil {
+			return err
+		}
+
+		// the GraphQL API masks the numbers
+		t := table{header: []string{"ID", "NAME", "BANK", "NUMBER"}, v: u.Accounts}
+		for _, a := range u.Accounts {
+			t.rows = append(t.rows, []string{strconv.Itoa(a.ID), a.Name, a.Bank, a.Number})
+		}
+
+		return write(e.stdout, *output, t)
+	}
+}
+
+func createUser(fs *flag.FlagSet) func(e *env, args []string) error {
+	name := fs.String("name", "", "name of the user, unique among them (required)")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *name == "" {
+			return fmt.Errorf("-name is required")
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		u, err := c.CreateUser(e.ctx, *name)
+		if err != nil {
+			return err
+		}
+
+		return write(e.stdout, *output, usersTable(*u))
+	}
+}
+
+func renameUser(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("id", 0, "id of the user (required)")
+	name := fs.String("name", "", "new name of the user (required)")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 || *name == "" {
+			return fmt.Errorf("-id and -name are required")
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		u, err := c.RenameUser(e.ctx, *userID, *name)
+		if err != nil {
+			return err
+		}
+
+		return write(e.stdout, *output, usersTable(*u))
+	}
+}
+
+func usersTable(u client.User) table {
+	return table{
+		header: []string{"ID", "NAME"},
+		rows:   [][]string{{strconv.Itoa(u.ID), u.Name}},
+		v:      u,
+	}
+}
+
+func createAccount(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	bank := fs.String("bank", "", "bank of the account (required)")
+	number := fs.String("number", "", "number of the account at the bank")
+	iban := fs.String("iban", "", "IBAN of the account")
+	name := fs.String("name", "", "holder of the account, the name of the user by default")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 || *bank == "" {
+			return fmt.Errorf("-user and -bank are required")
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		acc, err := c.CreateAccount(e.ctx, *userID, client.CreateAccount{
+			Name:   *name,
+			Bank:   *bank,
+			Number: *number,
+			IBAN:   *iban,
+		})
+		if err != nil {
+			return err
+		}
+
+		return write(e.stdout, *output, accountsTable(*acc))
+	}
+}
+
+func updateAccount(fs *flag.FlagSet) func(e *env, args []string) error {
+	userID := fs.Int("user", 0, "id of the user (required)")
+	accountID := fs.Int("id", 0, "id of the account (required)")
+	name := fs.String("name", "", "holder of the account (required)")
+	number := fs.String("number", "", "number of the account at the bank, removed when empty")
+	iban := fs.String("iban", "", "IBAN of the account, removed when empty")
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		if *userID == 0 || *accountID == 0 || *name == "" {
+			return fmt.Errorf("-user, -id and -name are required")
+		}
+
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		acc, err := c.UpdateAccount(e.ctx, *userID, *accountID, client.UpdateAccount{
+			Name:   *name,
+			Number: *number,
+			IBAN:   *iban,
+		})
+		if err != nil {
+			return err
+		}
+
+		return write(e.stdout, *output, accountsTable(*acc))
+	}
+}
+
+func accountsTable(acc client.Account) table {
+	return table{
+		header: []string{"ID", "NAME", "BANK", "NUMBER", "IBAN"},
+		rows:   [][]string{{strconv.Itoa(acc.ID), acc.Name, acc.Bank, acc.Number, acc.IBAN}},
+		v:      acc,
+	}
+}
+
+func listBanks(fs *flag.FlagSet) func(e *env, args []string) error {
+	output := outputFlag(fs)
+
+	return func(e *env, args []string) error {
+		c, err := e.client()
+		if err != nil {
+			return err
+		}
+
+		banks, err := c.Banks(e.ctx)
+		if err != nil {
+			return err
+		}
+
+		t := table{header: []string{"BANK", "BIC", "BIN"}, v: banks}
+		for _, b := range banks {
+			t.rows = append(t.rows, []string{b.Code, b.BIC, b.BIN})
+		}
+
+		return write(e.stdout, *output, t)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"go-prj-skeleton/app/interface/restful/handler"
+	"go-prj-skeleton/app/jsonutil"
+	"go-prj-skeleton/app/registry"
+	"go-prj-skeleton/app/usecase"
+)
+
+func check(args []string) error {
+	flags := flag.NewFlagSet("check", flag.ContinueOnError)
+	repair := flags.Bool("repair", false, "repair the fixable violations instead of only reporting them")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	startUpDB()
+	defer shutdownDB()
+
+	ctn, err := registry.NewContainer()
+	if err != nil {
+		return fmt.Errorf("failed to build container: %w", err)
+	}
+	defer ctn.Clean()
+
+	report, err := ctn.Resolve("integrity-usecase").(usecase.IntegrityUsecase).Check(!*repair)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(jsonutil.Marshal(handler.NewIntegrityReport(*report))))
+
+	if n := report.Unrepaired(); n > 0 {
+		return fmt.Errorf("%v integrity violations left", n)
+	}
+
+	return nil
+}
@@ -1,22 +1,88 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"net/http"
 	"os"
 
-	log "github.com/sirupsen/logrus"
-
-	"go-prj-skeleton/app/interface/restful"
+	"go-prj-skeleton/app/health"
+	"go-prj-skeleton/app/logger"
+	"go-prj-skeleton/app/mysqlutil"
 	"go-prj-skeleton/app/pgutil"
-	"go-prj-skeleton/app/registry"
 	"go-prj-skeleton/app/setting"
 )
 
+const usage = `usage: srv <command> [arguments]
+
+commands:
+  serve               start the HTTP and gRPC servers (default)
+  migrate up          apply all pending migrations
+  migrate down [N]    roll back the last N migrations (default 1)
+  migrate status      print the schema version and pending migrations
+  migrate force V     set the schema version to V without running migrations (-1 for none)
+  check [-repair]     report data integrity violations as JSON, -repair fixes the fixable ones
+`
+
 func main() {
+	args := os.Args[1:]
+	if len(args) == 0 {
+		args = []string{"serve"}
+	}
+
 	initEnvSettings()
 
-	pgutil.StartUp(pgutil.Configuration{
+	var err error
+	switch args[0] {
+	case "serve":
+		err = serve(args[1:])
+	case "migrate":
+		err = migrate(args[1:])
+	case "check":
+		err = check(args[1:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return
+	default:
+		err = fmt.Errorf("unknown command %q\n\n%s", args[0], usage)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func initEnvSettings() {
+	//initialize env settings and read from env
+	setting.EnvSettingsInit([]string{
+		"SETTING_STORAGE_BACKEND",
+		"SETTING_AUTO_MIGRATE",
+		"SETTING_GRPC_PORT",
+		"SETTING_METRICS_PORT",
+		"SETTING_SHUTDOWN_TIMEOUT",
+		"SETTING_PANIC_STACK",
+		"SETTING_RATE_LIMITS",
+		"SETTING_RATE_LIMIT_BACKEND",
+		"SETTING_TRACE_EXPORTER",
+		"SETTING_OTLP_ENDPOINT",
+		"SETTING_LOG_LEVEL",
+		"SETTING_POSTGRE_HOST",
+		"SETTING_POSTGRE_PORT",
+		"SETTING_POSTGRE_DATABASE_NAME",
+		"SETTING_POSTGRE_USER",
+		"SETTING_MYSQL_HOST",
+		"SETTING_MYSQL_PORT",
+		"SETTING_MYSQL_DATABASE_NAME",
+		"SETTING_MYSQL_USER",
+	})
+
+	if err := logger.SetLevel(setting.ProjectEnvSettings.LogLevel); err != nil {
+		logger.L().WithError(err).Error("SETTING_LOG_LEVEL")
+	}
+}
+
+func pgConfiguration() pgutil.Configuration {
+	return pgutil.Configuration{
 		URL:             os.Getenv("DATABASE_URL"), //make work with heroku
 		Host:            setting.ProjectEnvSettings.PostgreHost,
 		Port:            setting.ProjectEnvSettings.PostgrePort,
@@ -24,34 +90,61 @@ func main() {
 		User:            setting.ProjectEnvSettings.PostgreUser,
 		Password:        setting.ProjectEnvSettings.PostgrePassword,
 		ApplicationName: "HRS",
-	})
+	}
+}
 
-	// make it work on heroku
-	port := os.Getenv("PORT")
-	if len(port) == 0 {
-		port = "8080"
+func mysqlConfiguration() mysqlutil.Configuration {
+	return mysqlutil.Configuration{
+		URL:            os.Getenv("MYSQL_URL"),
+		Host:           setting.ProjectEnvSettings.MySQLHost,
+		Port:           setting.ProjectEnvSettings.MySQLPort,
+		Database:       setting.ProjectEnvSettings.MySQLDatabaseName,
+		User:           setting.ProjectEnvSettings.MySQLUser,
+		Password:       setting.ProjectEnvSettings.MySQLPassword,
+		MaxConnections: setting.ProjectEnvSettings.MySQLMaxConnections,
 	}
+}
 
-	ctn, err := registry.NewContainer()
-	if err != nil {
-		log.Fatalf("failed to build container: %v", err)
+func startUpDB() {
+	switch setting.ProjectEnvSettings.StorageBackend {
+	case setting.StorageBackendMemory:
+	case setting.StorageBackendMySQL:
+		mysqlutil.StartUp(mysqlConfiguration())
+	default:
+		pgutil.StartUp(pgConfiguration())
 	}
+}
 
-	server := http.Server{
-		Addr:    ":" + port,
-		Handler: restful.Handlers(ctn),
+// dbChecks checks the database answers and its schema is at the version of
+// the last migration. The memory backend has nothing to check.
+func dbChecks() ([]health.Check, func(), error) {
+	var ping func(context.Context) error
+	switch setting.ProjectEnvSettings.StorageBackend {
+	case setting.StorageBackendMemory:
+		return nil, func() {}, nil
+	case setting.StorageBackendMySQL:
+		ping = mysqlutil.Ping
+	default:
+		ping = pgutil.Ping
 	}
-	if err := server.ListenAndServe(); err != nil {
-		fmt.Printf("start sever fail: %s", err.Error())
+
+	migrator, closeDB, err := newMigrator()
+	if err != nil {
+		return nil, nil, err
 	}
+
+	return []health.Check{
+		{Name: "database", Func: ping},
+		{Name: "migrations", Func: migrator.Check},
+	}, closeDB, nil
 }
 
-func initEnvSettings() {
-	//initialize env settings and read from env
-	setting.EnvSettingsInit([]string{
-		"SETTING_POSTGRE_HOST",
-		"SETTING_POSTGRE_PORT",
-		"SETTING_POSTGRE_DATABASE_NAME",
-		"SETTING_POSTGRE_USER",
-	})
+func shutdownDB() {
+	switch setting.ProjectEnvSettings.StorageBackend {
+	case setting.StorageBackendMemory:
+	case setting.StorageBackendMySQL:
+		mysqlutil.Shutdown()
+	default:
+		pgutil.Shutdown()
+	}
 }
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+
+	"go-prj-skeleton/app/migration"
+	"go-prj-skeleton/app/mysqlutil"
+	"go-prj-skeleton/app/pgutil"
+	"go-prj-skeleton/app/setting"
+	"go-prj-skeleton/db"
+)
+
+func migrate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("migrate needs a subcommand\n\n%s", usage)
+	}
+
+	migrator, closeDB, err := newMigrator()
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("applied %v migrations\n", applied)
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+		}
+
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("reverted %v migrations\n", reverted)
+
+	case "status":
+		status, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("version: %v\ndirty: %v\n", status.Version, status.Dirty)
+		for _, m := range status.Pending {
+			fmt.Printf("pending: %v_%s\n", m.Version, m.Name)
+		}
+
+	case "force":
+		if len(args) < 2 {
+			return fmt.Errorf("migrate force needs a version\n\n%s", usage)
+		}
+
+		version, err := strconv.Atoi(args[1])
+		if err != nil || version < migration.NilVersion {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+
+		if err := migrator.Force(ctx, version); err != nil {
+			return err
+		}
+
+		fmt.Printf("forced version %v\n", version)
+
+	default:
+		return fmt.Errorf("unknown migrate subcommand %q\n\n%s", args[0], usage)
+	}
+
+	return nil
+}
+
+// newMigrator connects to the configured storage backend with a dedicated
+// pool; the returned func closes it.
+func newMigrator() (*migration.Migrator, func(), error) {
+	var (
+		driver, dsn string
+		dialect     migration.Dialect
+		migrations  []migration.Migration
+		err         error
+	)
+
+	switch setting.ProjectEnvSettings.StorageBackend {
+	case setting.StorageBackendMemory:
+		return nil, nil, fmt.Errorf("the %s storage backend has no schema to migrate", setting.StorageBackendMemory)
+
+	case setting.StorageBackendMySQL:
+		cfg, err := mysql.ParseDSN(mysqlutil.DSN(mysqlConfiguration()))
+		if err != nil {
+			return nil, nil, err
+		}
+		cfg.MultiStatements = true
+
+		driver, dsn, dialect = "mysql", cfg.FormatDSN(), migration.MySQL
+		migrations, err = migration.Load(db.MySQLMigrations())
+		if err != nil {
+			return nil, nil, err
+		}
+
+	default:
+		driver, dsn, dialect = "postgres", pgutil.DSN(pgConfiguration()), migration.Postgres
+		migrations, err = migration.Load(db.PostgresMigrations())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	conn, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return migration.NewMigrator(conn, dialect, migrations), func() { conn.Close() }, nil
+}
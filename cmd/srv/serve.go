@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"google.golang.org/grpc"
+
+	"go-prj-skeleton/app/health"
+	"go-prj-skeleton/app/interface/restful"
+	"go-prj-skeleton/app/interface/rpc"
+	"go-prj-skeleton/app/lifecycle"
+	"go-prj-skeleton/app/logger"
+	"go-prj-skeleton/app/metrics"
+	"go-prj-skeleton/app/pgutil"
+	"go-prj-skeleton/app/registry"
+	"go-prj-skeleton/app/setting"
+	"go-prj-skeleton/app/tracing"
+)
+
+func serve(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("serve takes no arguments\n\n%s", usage)
+	}
+
+	s := setting.ProjectEnvSettings
+	if s.AutoMigrate {
+		migrator, closeDB, err := newMigrator()
+		if err != nil {
+			return err
+		}
+
+		applied, err := migrator.Up(context.Background())
+		closeDB()
+		if err != nil {
+			return fmt.Errorf("auto migrate: %w", err)
+		}
+
+		logger.L().Infof("auto migrate: applied %v migrations", applied)
+	}
+
+	// the hooks start in the order they are registered, each one after those
+	// it depends on, and stop in reverse order
+	app := lifecycle.New(s.ShutdownTimeout)
+
+	var shutdownTracing func(context.Context) error
+	app.Register(lifecycle.Hook{
+		Name: "tracing",
+		OnStart: func(ctx context.Context) (err error) {
+			shutdownTracing, err = tracing.Init(ctx, tracing.Config{
+				Exporter:     s.TraceExporter,
+				OTLPEndpoint: s.OTLPEndpoint,
+				OTLPInsecure: s.OTLPInsecure,
+				File:         s.TraceFile,
+			})
+			return err
+		},
+		// the spans of the last requests are flushed
+		OnStop: func(ctx context.Context) error { return shutdownTracing(ctx) },
+	})
+
+	app.Register(lifecycle.Hook{
+		Name: "database",
+		OnStart: func(context.Context) error {
+			startUpDB()
+			if s.StorageBackend == setting.StorageBackendPostgres {
+				metrics.Registry.MustRegister(metrics.NewPoolCollector(pgutil.PoolStats))
+			}
+			return nil
+		},
+		OnStop: func(context.Context) error {
+			shutdownDB()
+			return nil
+		},
+	})
+
+	var ctn *registry.Container
+	app.Register(lifecycle.Hook{
+		Name: "container",
+		OnStart: func(context.Context) (err error) {
+			if ctn, err = registry.NewContainer(); err != nil {
+				return err
+			}
+
+			// a misconfiguration fails the start rather than the requests
+			if err := ctn.Check(); err != nil {
+				ctn.Clean()
+				return err
+			}
+
+			return nil
+		},
+		OnStop: func(context.Context) error { return ctn.Clean() },
+	})
+
+	var (
+		checks      []health.Check
+		closeChecks func()
+	)
+	app.Register(lifecycle.Hook{
+		Name: "health checks",
+		OnStart: func(context.Context) (err error) {
+			checks, closeChecks, err = dbChecks()
+			return err
+		},
+		OnStop: func(context.Context) error {
+			closeChecks()
+			return nil
+		},
+	})
+
+	// the metrics are served apart from the API, on a port kept private, and
+	// stop after it to be scraped while the requests drain
+	if s.MetricsPort != "" {
+		app.Register(httpServer("metrics server", ":"+s.MetricsPort, func() http.Handler {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", metrics.Handler())
+			return mux
+		}))
+	}
+
+	// make it work on heroku
+	port := os.Getenv("PORT")
+	if len(port) == 0 {
+		port = "8080"
+	}
+
+	app.Register(httpServer("HTTP server", ":"+port, func() http.Handler {
+		return restful.Handlers(ctn, checks...)
+	}))
+
+	if s.GRPCPort != "" {
+		app.Register(grpcServer(":"+s.GRPCPort, func() *grpc.Server {
+			return rpc.Server(ctn)
+		}))
+	}
+
+	return app.Run(context.Background())
+}
+
+// httpServer serves the handler returned by handler on addr. The port is
+// listened on at start, so a busy one fails it. Stopping it stops accepting
+// connections and drains the in-flight requests.
+func httpServer(name, addr string, handler func() http.Handler) lifecycle.Hook {
+	server := &http.Server{Addr: addr}
+	var lis net.Listener
+
+	return lifecycle.Hook{
+		Name: name,
+		OnStart: func(context.Context) (err error) {
+			server.Handler = handler()
+			lis, err = net.Listen("tcp", addr)
+			return err
+		},
+		Serve: func() error {
+			logger.L().Infof("%s listening on %s", name, lis.Addr())
+			if err := server.Serve(lis); err != http.ErrServerClosed {
+				return err
+			}
+			return nil
+		},
+		OnStop: server.Shutdown,
+	}
+}
+
+// grpcServer serves the server returned by server on addr. Stopping it
+// waits for the pending RPCs until the shutdown timeout, then cancels them.
+func grpcServer(addr string, server func() *grpc.Server) lifecycle.Hook {
+	var (
+		srv *grpc.Server
+		lis net.Listener
+	)
+
+	return lifecycle.Hook{
+		Name: "gRPC server",
+		OnStart: func(context.Context) (err error) {
+			srv = server()
+			lis, err = net.Listen("tcp", addr)
+			return err
+		},
+		Serve: func() error {
+			logger.L().Infof("gRPC server listening on %s", lis.Addr())
+			return srv.Serve(lis)
+		},
+		OnStop: func(ctx context.Context) error {
+			stopped := make(chan struct{})
+			go func() {
+				srv.GracefulStop()
+				close(stopped)
+			}()
+
+			select {
+			case <-stopped:
+				return nil
+			case <-ctx.Done():
+				srv.Stop()
+				return ctx.Err()
+			}
+		},
+	}
+}
@@ -0,0 +1,33 @@
+// Package db embeds the SQL migrations so they ship inside the service binary.
+package db
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed migrations/*.sql
+var postgres embed.FS
+
+//go:embed mysql/migrations/*.sql
+var mysql embed.FS
+
+// PostgresMigrations returns the PostgreSQL migration files.
+func PostgresMigrations() fs.FS {
+	sub, err := fs.Sub(postgres, "migrations")
+	if err != nil {
+		panic(err)
+	}
+
+	return sub
+}
+
+// MySQLMigrations returns the MySQL migration files.
+func MySQLMigrations() fs.FS {
+	sub, err := fs.Sub(mysql, "mysql/migrations")
+	if err != nil {
+		panic(err)
+	}
+
+	return sub
+}
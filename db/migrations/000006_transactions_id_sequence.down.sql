@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE transactions ALTER COLUMN id DROP DEFAULT;
+
+DROP SEQUENCE IF EXISTS transactions_id_seq;
+
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+
+CREATE SEQUENCE IF NOT EXISTS transactions_id_seq AS INTEGER OWNED BY transactions.id;
+
+SELECT setval('transactions_id_seq', COALESCE((SELECT MAX(id) FROM transactions), 0) + 1, false);
+
+ALTER TABLE transactions ALTER COLUMN id SET DEFAULT nextval('transactions_id_seq');
+
+COMMIT;
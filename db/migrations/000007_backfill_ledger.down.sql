@@ -0,0 +1,14 @@
+BEGIN;
+
+-- the quarantined rows go back as they were; the normalized banks,
+-- transaction types and users are kept
+INSERT INTO accounts (id, user_id, name, bank)
+SELECT id, user_id, name, bank FROM quarantined_accounts;
+
+INSERT INTO transactions (id, user_id, account_id, amount, transaction_type, created_at)
+SELECT id, user_id, account_id, amount, transaction_type, created_at FROM quarantined_transactions;
+
+DROP TABLE IF EXISTS quarantined_transactions;
+DROP TABLE IF EXISTS quarantined_accounts;
+
+COMMIT;
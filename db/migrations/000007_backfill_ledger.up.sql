@@ -0,0 +1,69 @@
+BEGIN;
+
+-- rows 000008 can't convert are moved here with the reason, for someone to
+-- repair and insert back by hand
+CREATE TABLE IF NOT EXISTS quarantined_accounts(
+	LIKE accounts,
+	reason VARCHAR (64) NOT NULL,
+	quarantined_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS quarantined_transactions(
+	LIKE transactions,
+	reason VARCHAR (64) NOT NULL,
+	quarantined_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- the account owner is the source of truth for a transaction's user
+UPDATE transactions t
+SET user_id = a.user_id
+FROM accounts a
+WHERE a.id = t.account_id AND t.user_id <> a.user_id;
+
+UPDATE accounts
+SET bank = UPPER(TRIM(bank))
+WHERE bank <> UPPER(TRIM(bank));
+
+UPDATE transactions
+SET transaction_type = LOWER(TRIM(transaction_type))
+WHERE transaction_type <> LOWER(TRIM(transaction_type));
+
+-- the conversion of 000008, NULL for anything it would fail on
+CREATE FUNCTION pg_temp.parse_created_at(value TEXT) RETURNS TIMESTAMPTZ AS $$
+BEGIN
+	RETURN (
+		SUBSTRING(value FROM '^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(?:\.\d{1,6})?') ||
+		SUBSTRING(value FROM ' ([+-]\d{4})')
+	)::TIMESTAMPTZ;
+EXCEPTION WHEN OTHERS THEN
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- amounts must stay positive once rounded to NUMERIC(19, 2), which also
+-- rules out NULL, NaN and infinities
+INSERT INTO quarantined_transactions (id, user_id, account_id, amount, transaction_type, created_at, reason)
+SELECT id, user_id, account_id, amount, transaction_type, created_at, reason
+FROM (
+	SELECT t.*, CASE
+		WHEN a.id IS NULL THEN 'unknown account'
+		WHEN a.bank IS NULL OR a.bank NOT IN ('VCB', 'ACB', 'VIB') THEN 'account has an unknown bank'
+		WHEN t.amount IS NULL OR NOT (t.amount >= 0.005 AND t.amount < 1e17) THEN 'invalid amount'
+		WHEN t.transaction_type IS NULL OR t.transaction_type NOT IN ('withdraw', 'deposit') THEN 'invalid transaction type'
+		WHEN pg_temp.parse_created_at(t.created_at) IS NULL THEN 'invalid created_at'
+	END AS reason
+	FROM transactions t
+	LEFT JOIN accounts a ON a.id = t.account_id
+) checked
+WHERE reason IS NOT NULL;
+
+DELETE FROM transactions WHERE id IN (SELECT id FROM quarantined_transactions);
+
+INSERT INTO quarantined_accounts (id, user_id, name, bank, reason)
+SELECT id, user_id, name, bank, 'unknown bank'
+FROM accounts
+WHERE bank IS NULL OR bank NOT IN ('VCB', 'ACB', 'VIB');
+
+DELETE FROM accounts WHERE id IN (SELECT id FROM quarantined_accounts);
+
+COMMIT;
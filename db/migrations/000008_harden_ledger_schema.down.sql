@@ -0,0 +1,33 @@
+BEGIN;
+
+DROP INDEX IF EXISTS transactions_account_id_idx;
+DROP INDEX IF EXISTS transactions_user_id_idx;
+DROP INDEX IF EXISTS accounts_user_id_idx;
+
+ALTER TABLE transactions
+	DROP CONSTRAINT IF EXISTS transactions_account_id_user_id_fkey;
+
+ALTER TABLE accounts
+	DROP CONSTRAINT IF EXISTS accounts_id_user_id_key;
+
+ALTER TABLE accounts
+	DROP CONSTRAINT IF EXISTS accounts_bank_check,
+	ALTER COLUMN bank DROP NOT NULL,
+	ALTER COLUMN bank TYPE VARCHAR (300);
+
+ALTER TABLE transactions
+	DROP CONSTRAINT IF EXISTS transactions_transaction_type_check,
+	ALTER COLUMN transaction_type DROP NOT NULL,
+	ALTER COLUMN transaction_type TYPE VARCHAR (300);
+
+ALTER TABLE transactions
+	ALTER COLUMN created_at DROP NOT NULL,
+	ALTER COLUMN created_at DROP DEFAULT,
+	ALTER COLUMN created_at TYPE VARCHAR (300) USING TO_CHAR(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD HH24:MI:SS') || ' +0000';
+
+ALTER TABLE transactions
+	DROP CONSTRAINT IF EXISTS transactions_amount_check,
+	ALTER COLUMN amount DROP NOT NULL,
+	ALTER COLUMN amount TYPE FLOAT(2) USING amount::FLOAT(2);
+
+COMMIT;
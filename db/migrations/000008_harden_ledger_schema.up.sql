@@ -0,0 +1,40 @@
+BEGIN;
+
+-- amounts are exact and positive
+ALTER TABLE transactions
+	ALTER COLUMN amount TYPE NUMERIC(19, 2) USING amount::NUMERIC(19, 2),
+	ALTER COLUMN amount SET NOT NULL,
+	ADD CONSTRAINT transactions_amount_check CHECK (amount > 0);
+
+-- created_at was written as "2006-01-02 15:04:05.999999999 -0700 MST" or
+-- "2006-01-02 15:04:05 -0700"; 000007 quarantined the rows in any other format
+ALTER TABLE transactions
+	ALTER COLUMN created_at TYPE TIMESTAMPTZ USING (
+		SUBSTRING(created_at FROM '^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(?:\.\d{1,6})?') ||
+		SUBSTRING(created_at FROM ' ([+-]\d{4})')
+	)::TIMESTAMPTZ,
+	ALTER COLUMN created_at SET DEFAULT NOW(),
+	ALTER COLUMN created_at SET NOT NULL;
+
+ALTER TABLE transactions
+	ALTER COLUMN transaction_type TYPE VARCHAR (16),
+	ALTER COLUMN transaction_type SET NOT NULL,
+	ADD CONSTRAINT transactions_transaction_type_check CHECK (transaction_type IN ('withdraw', 'deposit'));
+
+ALTER TABLE accounts
+	ALTER COLUMN bank TYPE VARCHAR (16),
+	ALTER COLUMN bank SET NOT NULL,
+	ADD CONSTRAINT accounts_bank_check CHECK (bank IN ('VCB', 'ACB', 'VIB'));
+
+-- a transaction's user must own its account
+ALTER TABLE accounts
+	ADD CONSTRAINT accounts_id_user_id_key UNIQUE (id, user_id);
+
+ALTER TABLE transactions
+	ADD CONSTRAINT transactions_account_id_user_id_fkey FOREIGN KEY (account_id, user_id) REFERENCES accounts (id, user_id);
+
+CREATE INDEX IF NOT EXISTS accounts_user_id_idx ON accounts (user_id);
+CREATE INDEX IF NOT EXISTS transactions_user_id_idx ON transactions (user_id, id);
+CREATE INDEX IF NOT EXISTS transactions_account_id_idx ON transactions (account_id, id);
+
+COMMIT;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS transaction_reconciliations;
\ No newline at end of file
@@ -0,0 +1,15 @@
+BEGIN;
+
+-- the bank statement line each transaction was reconciled with
+CREATE TABLE IF NOT EXISTS transaction_reconciliations(
+	transaction_id INTEGER PRIMARY KEY REFERENCES transactions (id) ON DELETE CASCADE,
+	account_id INTEGER NOT NULL REFERENCES accounts (id),
+	status VARCHAR (16) NOT NULL CHECK (status IN ('matched', 'created')),
+	reference VARCHAR (128) NOT NULL DEFAULT '',
+	statement_date DATE NOT NULL,
+	reconciled_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS transaction_reconciliations_account_id_idx ON transaction_reconciliations (account_id, transaction_id);
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS payment_batches;
+
+COMMIT;
@@ -0,0 +1,28 @@
+BEGIN;
+
+-- withdrawals paid by the bank from a payment initiation file
+CREATE TABLE IF NOT EXISTS payment_batches(
+	id SERIAL PRIMARY KEY,
+	user_id INTEGER NOT NULL REFERENCES users (id),
+	account_id INTEGER NOT NULL REFERENCES accounts (id),
+	debtor_account VARCHAR (34) NOT NULL,
+	execution_date DATE NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS payment_batches_account_id_idx ON payment_batches (account_id, id);
+
+CREATE TABLE IF NOT EXISTS payments(
+	transaction_id INTEGER PRIMARY KEY REFERENCES transactions (id) ON DELETE CASCADE,
+	batch_id INTEGER NOT NULL REFERENCES payment_batches (id) ON DELETE CASCADE,
+	creditor_name VARCHAR (70) NOT NULL,
+	creditor_account VARCHAR (34) NOT NULL,
+	creditor_bank VARCHAR (300) NOT NULL,
+	remittance VARCHAR (140) NOT NULL DEFAULT '',
+	status VARCHAR (16) NOT NULL CHECK (status IN ('pending', 'confirmed')),
+	confirmed_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS payments_batch_id_idx ON payments (batch_id, transaction_id);
+
+COMMIT;
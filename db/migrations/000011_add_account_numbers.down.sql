@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE accounts
+	DROP CONSTRAINT IF EXISTS accounts_bank_number_key,
+	DROP CONSTRAINT IF EXISTS accounts_iban_check,
+	DROP CONSTRAINT IF EXISTS accounts_number_check,
+	DROP COLUMN IF EXISTS iban,
+	DROP COLUMN IF EXISTS number;
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+-- the number of an account at its bank and its optional IBAN, NULL for the
+-- accounts recorded before numbers were; the format of a number depends on
+-- the bank and is validated by the service
+ALTER TABLE accounts
+	ADD COLUMN IF NOT EXISTS number VARCHAR (19),
+	ADD COLUMN IF NOT EXISTS iban VARCHAR (34),
+	ADD CONSTRAINT accounts_number_check CHECK (number ~ '^[0-9]+$'),
+	ADD CONSTRAINT accounts_iban_check CHECK (iban ~ '^[A-Z]{2}[0-9]{2}[0-9A-Z]{11,30}$'),
+	ADD CONSTRAINT accounts_bank_number_key UNIQUE (bank, number);
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+-- the token buckets of the rate limits shared by the replicas, tat is when
+-- the next request of the bucket was expected: the bucket is full once it is
+-- past, and its row may be deleted
+CREATE TABLE IF NOT EXISTS rate_limit_buckets(
+	key VARCHAR (128) PRIMARY KEY,
+	tat TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS rate_limit_buckets_tat_idx ON rate_limit_buckets (tat);
+
+COMMIT;
@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE accounts ALTER COLUMN id DROP DEFAULT;
+
+DROP SEQUENCE IF EXISTS accounts_id_seq;
+
+ALTER TABLE users ALTER COLUMN id DROP DEFAULT;
+
+DROP SEQUENCE IF EXISTS users_id_seq;
+
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE SEQUENCE IF NOT EXISTS users_id_seq AS INTEGER OWNED BY users.id;
+
+SELECT setval('users_id_seq', COALESCE((SELECT MAX(id) FROM users), 0) + 1, false);
+
+ALTER TABLE users ALTER COLUMN id SET DEFAULT nextval('users_id_seq');
+
+CREATE SEQUENCE IF NOT EXISTS accounts_id_seq AS INTEGER OWNED BY accounts.id;
+
+SELECT setval('accounts_id_seq', COALESCE((SELECT MAX(id) FROM accounts), 0) + 1, false);
+
+ALTER TABLE accounts ALTER COLUMN id SET DEFAULT nextval('accounts_id_seq');
+
+COMMIT;
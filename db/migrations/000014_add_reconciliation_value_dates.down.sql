@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE transaction_reconciliations DROP COLUMN IF EXISTS value_date;
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+-- the value date of the statement line, NULL for formats without one
+ALTER TABLE transaction_reconciliations ADD COLUMN IF NOT EXISTS value_date DATE NULL;
+
+COMMIT;
@@ -0,0 +1,4 @@
+CREATE TABLE IF NOT EXISTS users(
+  id INTEGER PRIMARY KEY,
+  name VARCHAR (300) UNIQUE NOT NULL
+) ENGINE=InnoDB;
@@ -0,0 +1 @@
+DELETE FROM users WHERE name IN ('Alice', 'Cong');
\ No newline at end of file
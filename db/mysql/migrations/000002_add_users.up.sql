@@ -0,0 +1,5 @@
+INSERT INTO users(id, name) 
+VALUES (1, 'Alice');
+
+INSERT INTO users(id, name) 
+VALUES (2, 'Cong');
\ No newline at end of file
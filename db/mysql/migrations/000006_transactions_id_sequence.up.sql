@@ -0,0 +1 @@
+ALTER TABLE transactions MODIFY id INTEGER NOT NULL AUTO_INCREMENT;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS transaction_reconciliations(
+	transaction_id INTEGER PRIMARY KEY,
+	account_id INTEGER NOT NULL,
+	status VARCHAR (16) NOT NULL,
+	reference VARCHAR (128) NOT NULL DEFAULT '',
+	statement_date VARCHAR (10) NOT NULL,
+	reconciled_at VARCHAR (300) NOT NULL,
+	INDEX transaction_reconciliations_account_id_idx (account_id, transaction_id),
+	FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE,
+	FOREIGN KEY (account_id) REFERENCES accounts (id)
+) ENGINE=InnoDB;
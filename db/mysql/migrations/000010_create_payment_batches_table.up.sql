@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS payment_batches(
+	id INTEGER NOT NULL AUTO_INCREMENT PRIMARY KEY,
+	user_id INTEGER NOT NULL,
+	account_id INTEGER NOT NULL,
+	debtor_account VARCHAR (34) NOT NULL,
+	execution_date VARCHAR (10) NOT NULL,
+	created_at VARCHAR (300) NOT NULL,
+	INDEX payment_batches_account_id_idx (account_id, id),
+	FOREIGN KEY (user_id) REFERENCES users (id),
+	FOREIGN KEY (account_id) REFERENCES accounts (id)
+) ENGINE=InnoDB;
+
+CREATE TABLE IF NOT EXISTS payments(
+	transaction_id INTEGER PRIMARY KEY,
+	batch_id INTEGER NOT NULL,
+	creditor_name VARCHAR (70) NOT NULL,
+	creditor_account VARCHAR (34) NOT NULL,
+	creditor_bank VARCHAR (300) NOT NULL,
+	remittance VARCHAR (140) NOT NULL DEFAULT '',
+	status VARCHAR (16) NOT NULL,
+	confirmed_at VARCHAR (300) NOT NULL DEFAULT '',
+	INDEX payments_batch_id_idx (batch_id, transaction_id),
+	FOREIGN KEY (transaction_id) REFERENCES transactions (id) ON DELETE CASCADE,
+	FOREIGN KEY (batch_id) REFERENCES payment_batches (id) ON DELETE CASCADE
+) ENGINE=InnoDB;
@@ -0,0 +1,4 @@
+ALTER TABLE accounts
+	DROP INDEX accounts_bank_number_key,
+	DROP COLUMN iban,
+	DROP COLUMN number;
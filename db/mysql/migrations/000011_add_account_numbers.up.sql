@@ -0,0 +1,4 @@
+ALTER TABLE accounts
+	ADD COLUMN number VARCHAR (19) NULL,
+	ADD COLUMN iban VARCHAR (34) NULL,
+	ADD UNIQUE INDEX accounts_bank_number_key (bank, number);
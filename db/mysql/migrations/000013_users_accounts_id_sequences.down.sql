@@ -0,0 +1,6 @@
+SET FOREIGN_KEY_CHECKS = 0;
+
+ALTER TABLE accounts MODIFY id INTEGER NOT NULL;
+ALTER TABLE users MODIFY id INTEGER NOT NULL;
+
+SET FOREIGN_KEY_CHECKS = 1;
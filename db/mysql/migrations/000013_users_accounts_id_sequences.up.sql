@@ -0,0 +1,9 @@
+-- accounts, transactions, reconciliations and payment batches reference the
+-- ids: MySQL refuses to change referenced columns while foreign keys are
+-- checked
+SET FOREIGN_KEY_CHECKS = 0;
+
+ALTER TABLE users MODIFY id INTEGER NOT NULL AUTO_INCREMENT;
+ALTER TABLE accounts MODIFY id INTEGER NOT NULL AUTO_INCREMENT;
+
+SET FOREIGN_KEY_CHECKS = 1;
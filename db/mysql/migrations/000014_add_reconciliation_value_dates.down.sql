@@ -0,0 +1,2 @@
+ALTER TABLE transaction_reconciliations
+	DROP COLUMN value_date;
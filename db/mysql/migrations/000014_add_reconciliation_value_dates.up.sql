@@ -0,0 +1,2 @@
+ALTER TABLE transaction_reconciliations
+	ADD COLUMN value_date VARCHAR (10) NULL AFTER statement_date;
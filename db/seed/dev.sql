@@ -0,0 +1,8 @@
+-- Development data, loaded by make seed-dev once the database is migrated: the
+-- numbers of the accounts of Alice inserted by 000003.
+BEGIN;
+
+UPDATE accounts SET number = '0071000123456' WHERE id = 1 AND bank = 'VCB' AND number IS NULL;
+UPDATE accounts SET number = '601704060012345' WHERE id = 2 AND bank = 'VIB' AND number IS NULL;
+
+COMMIT;